@@ -0,0 +1,112 @@
+// Command migrate manages the database schema:
+//
+//	go run ./cmd/migrate [-dsn URL] status    # versi yang sudah/belum diterapkan
+//	go run ./cmd/migrate [-dsn URL] up        # terapkan semua migration yang tertunda
+//	go run ./cmd/migrate [-dsn URL] down [n]  # rollback n migration terakhir (default 1)
+//
+// The connection comes from -dsn or the DATABASE_URL environment variable.
+// Unlike the server there is no default database and .env is not read:
+// without either the command exits with status 2, so it never touches a
+// database by accident. The DSN is never printed because it carries
+// credentials.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate [-dsn URL] status|up|down [n]")
+	os.Exit(2)
+}
+
+func main() {
+	dsn := flag.String("dsn", "", "Postgres connection URL (default $DATABASE_URL)")
+	flag.Usage = usage
+	flag.Parse()
+	args := flag.Args()
+	if len(args) < 1 {
+		usage()
+	}
+	if *dsn == "" {
+		*dsn = os.Getenv("DATABASE_URL")
+	}
+	if *dsn == "" {
+		fmt.Fprintln(os.Stderr, "migrate: set DATABASE_URL or pass -dsn")
+		os.Exit(2)
+	}
+
+	db, err := sql.Open("postgres", *dsn)
+	if err != nil {
+		log.Fatal("open database: ", err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+	if err := db.PingContext(ctx); err != nil {
+		log.Fatal("connect to database: ", err)
+	}
+
+	m, err := repository.NewMigrator(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch args[0] {
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+		for _, s := range statuses {
+			at := "-"
+			if s.AppliedAt != nil {
+				at = s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, s.State, at)
+		}
+		w.Flush()
+
+	case "up":
+		applied, err := m.Up(ctx)
+		for _, mig := range applied {
+			fmt.Printf("applied %04d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				usage()
+			}
+			steps = n
+		}
+		reverted, err := m.Down(ctx, steps)
+		for _, mig := range reverted {
+			fmt.Printf("reverted %04d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+	default:
+		usage()
+	}
+}
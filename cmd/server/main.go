@@ -8,8 +8,12 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
+	"github.com/roksva123/go-kinerja-backend/internal/api"
 	"github.com/roksva123/go-kinerja-backend/internal/api/handlers"
 	"github.com/roksva123/go-kinerja-backend/internal/config"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/graph"
+	"github.com/roksva123/go-kinerja-backend/internal/notify"
 	"github.com/roksva123/go-kinerja-backend/internal/repository"
 	"github.com/roksva123/go-kinerja-backend/internal/service"
 	"golang.org/x/crypto/bcrypt"
@@ -24,6 +28,13 @@ func main() {
 		log.Fatal("failed load config:", err)
 	}
 
+	// TIME ZONE
+	loc, err := dates.Location(cfg.TimeZone)
+	if err != nil {
+		log.Fatal("invalid TIMEZONE:", err)
+	}
+	dates.SetDefault(loc)
+
 	// INIT DB
 	repo := repository.NewPostgresRepo()
 
@@ -47,11 +58,49 @@ func main() {
     cfg.ClickUpToken,
     cfg.ClickUpTeamID,
 	)
+	clickSvc.BaseURL = cfg.ClickUpBaseURL
 	workloadSvc := service.NewWorkloadService(repo, clickSvc)
+	rebalanceSvc := service.NewRebalanceService(repo, clickSvc, cfg.WorkloadNormalMax)
+	kpiSvc := service.NewKPIService(repo)
+	appraisalSvc := service.NewAppraisalService(repo, kpiSvc)
+	okrSvc := service.NewOKRService(repo)
+	projectSvc := service.NewProjectService(repo)
+	notifySvc := service.NewNotificationService(repo, notify.NewRegistry(
+		&notify.SMTPNotifier{Host: cfg.SMTPHost, Port: cfg.SMTPPort, Username: cfg.SMTPUsername, Password: cfg.SMTPPassword, From: cfg.SMTPFrom},
+		&notify.WebhookNotifier{Secret: cfg.WebhookSigningKey},
+		&notify.ChatNotifier{},
+	))
+	budgetSvc := service.NewBudgetService(repo, notifySvc)
+	sprintSvc := service.NewSprintService(repo)
+	estimateSvc := service.NewEstimateService(repo)
+	alertSvc := service.NewAlertService(repo, notifySvc, cfg.WorkloadNormalMax)
+	clickSvc.OnSyncComplete("budgets", budgetSvc.EvaluateAll)
+	clickSvc.OnSyncComplete("alerts", alertSvc.EvaluateAll)
+	reportSvc := service.NewReportService(repo, kpiSvc, notifySvc)
+	go notifySvc.Run(context.Background(), time.Minute)
+	go reportSvc.Run(context.Background(), 5*time.Minute)
 	clickupHandler := handlers.NewClickUpHandler(clickSvc)
 	workloadHandler := handlers.NewWorkloadHandler(workloadSvc, clickSvc)
 	syncHandler := handlers.NewSyncHandler(clickSvc, repo)
 	authHandler := handlers.NewAuthHandler(repo, cfg.JWTSecret)
+	rebalanceHandler := handlers.NewRebalanceHandler(rebalanceSvc)
+	kpiHandler := handlers.NewKPIHandler(kpiSvc)
+	appraisalHandler := handlers.NewAppraisalHandler(appraisalSvc)
+	okrHandler := handlers.NewOKRHandler(okrSvc)
+	projectHandler := handlers.NewProjectHandler(projectSvc)
+	budgetHandler := handlers.NewBudgetHandler(budgetSvc)
+	sprintHandler := handlers.NewSprintHandler(sprintSvc)
+	estimateHandler := handlers.NewEstimateHandler(estimateSvc)
+	alertHandler := handlers.NewAlertHandler(alertSvc)
+	notificationHandler := handlers.NewNotificationHandler(notifySvc)
+	reportHandler := handlers.NewReportHandler(reportSvc)
+	resourceSvc := service.NewResourceService(repo)
+	resourceHandler := handlers.NewResourceHandler(resourceSvc)
+	graphExecutor, err := graph.New(resourceSvc, kpiSvc)
+	if err != nil {
+		log.Fatal("invalid graphql schema:", err)
+	}
+	graphqlHandler := handlers.NewGraphQLHandler(graphExecutor)
 
 
 	// ROUTER
@@ -64,50 +113,25 @@ func main() {
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
-
-	r.Static("/images", "public/images")
-	api := r.Group("/api/v1")
-
-	// CLICKUP ROUTES
-	clickup := api.Group("/clickup")
-	{
-		clickup.POST("/sync/team", clickupHandler.SyncTeam)
-		clickup.POST("/sync/members", clickupHandler.SyncMembers)
-		clickup.POST("/sync/tasks", clickupHandler.SyncTasks)
-		clickup.POST("/sync/all", clickupHandler.SyncAll)
-
-		clickup.GET("/spaces", clickupHandler.GetSpaces)
-		clickup.GET("/members", clickupHandler.GetMembers)
-		clickup.GET("/tasks", clickupHandler.GetTasks)
-		clickup.GET("/fullsync", clickupHandler.FullSync)
-		clickup.GET("/fullsync/filter", clickupHandler.GetFullSyncFiltered) 
-		clickup.GET("/data", clickupHandler.GetFullData)
-	}
-
-	sync := api.Group("/sync")
-	{
-		sync.POST("/spaces-folders-lists", syncHandler.SyncSpacesFoldersAndListsHandler)
-		sync.GET("/lists", syncHandler.GetListsHandler)
-		sync.GET("/folders", syncHandler.GetFoldersHandler)
-		sync.POST("/all", syncHandler.TriggerSyncAll) 
-		sync.GET("/history", syncHandler.GetSyncHistory) 
-		sync.GET("/all/stream", syncHandler.StreamSyncAll) // Endpoint baru untuk streaming
-	}
-
-	work := api.Group("/workload")
-	{
-		work.POST("/sync", workloadHandler.SyncAll)
-		work.GET("/workload", workloadHandler.GetWorkload)
-		work.GET("/tasks-by-range", workloadHandler.GetTasksByRange)
-		work.GET("/summary", workloadHandler.GetTasksSummary)
-		work.GET("", workloadHandler.GetWorkload)
-	}	
-
-	// AUTH ROUTES
-	auth := api.Group("/auth")
-	{
-		auth.POST("/login", authHandler.Login)
-	}
+	api.Register(r, api.Handlers{
+		ClickUp:      clickupHandler,
+		Workload:     workloadHandler,
+		Sync:         syncHandler,
+		Auth:         authHandler,
+		Rebalance:    rebalanceHandler,
+		KPI:          kpiHandler,
+		Appraisal:    appraisalHandler,
+		OKR:          okrHandler,
+		Project:      projectHandler,
+		Budget:       budgetHandler,
+		Sprint:       sprintHandler,
+		Estimate:     estimateHandler,
+		Alert:        alertHandler,
+		Notification: notificationHandler,
+		Report:       reportHandler,
+		Resource:     resourceHandler,
+		GraphQL:      graphqlHandler,
+	}, cfg.JWTSecret)
 
 	// START SERVER
 	log.Println("Server running on port:", cfg.Port)
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type AlertHandler struct {
+	alertSvc *service.AlertService
+}
+
+func NewAlertHandler(alertSvc *service.AlertService) *AlertHandler {
+	return &AlertHandler{alertSvc: alertSvc}
+}
+
+// GetAlerts GET /api/v1/alerts?status=open&rule=overdue&user_id=123
+func (h *AlertHandler) GetAlerts(c *gin.Context) {
+	userID, ok := optionalUserID(c)
+	if !ok {
+		return
+	}
+	alerts, err := h.alertSvc.GetAlerts(c.Request.Context(), model.AlertFilter{
+		Status:   c.Query("status"),
+		RuleCode: c.Query("rule"),
+		UserID:   userID,
+	})
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(alerts), "alerts": alerts})
+}
+
+// EvaluateAlerts menjalankan rule alert tanpa menunggu sync berikutnya.
+// POST /api/v1/alerts/evaluate
+func (h *AlertHandler) EvaluateAlerts(c *gin.Context) {
+	res, err := h.alertSvc.Evaluate(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetRules GET /api/v1/alerts/rules
+func (h *AlertHandler) GetRules(c *gin.Context) {
+	rules, err := h.alertSvc.GetRules(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(rules), "rules": rules})
+}
+
+// UpdateRule mengubah threshold atau menonaktifkan rule.
+// PUT /api/v1/alerts/rules/:code  {"threshold": 2, "active": true}
+func (h *AlertHandler) UpdateRule(c *gin.Context) {
+	var req model.AlertRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+	rule, err := h.alertSvc.UpdateRule(c.Request.Context(), c.Param("code"), req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rule)
+}
+
+// GetAlert GET /api/v1/alerts/:id
+func (h *AlertHandler) GetAlert(c *gin.Context) {
+	id, ok := alertID(c)
+	if !ok {
+		return
+	}
+	a, err := h.alertSvc.GetAlert(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// AcknowledgeAlert POST /api/v1/alerts/:id/acknowledge
+func (h *AlertHandler) AcknowledgeAlert(c *gin.Context) {
+	id, ok := alertID(c)
+	if !ok {
+		return
+	}
+	a, err := h.alertSvc.Acknowledge(c.Request.Context(), id, alertActor(c))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+// ResolveAlert POST /api/v1/alerts/:id/resolve
+func (h *AlertHandler) ResolveAlert(c *gin.Context) {
+	id, ok := alertID(c)
+	if !ok {
+		return
+	}
+	a, err := h.alertSvc.Resolve(c.Request.Context(), id, alertActor(c))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, a)
+}
+
+func alertID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid alert id"))
+		return 0, false
+	}
+	return id, true
+}
+
+// alertActor mengambil subject JWT bila ada; route alert tidak wajib login.
+func alertActor(c *gin.Context) string {
+	if v, ok := c.Get("claims"); ok {
+		if claims, ok := v.(jwt.MapClaims); ok {
+			if sub, ok := claims["sub"]; ok && sub != nil {
+				return fmt.Sprint(sub)
+			}
+		}
+	}
+	return "anonymous"
+}
@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type AppraisalHandler struct {
+	appraisalSvc *service.AppraisalService
+}
+
+func NewAppraisalHandler(appraisalSvc *service.AppraisalService) *AppraisalHandler {
+	return &AppraisalHandler{appraisalSvc: appraisalSvc}
+}
+
+// CreatePeriod POST /api/v1/appraisals  {"name": "H2 2026", "period_start": "2026-07-01", "period_end": "2026-12-31"}
+func (h *AppraisalHandler) CreatePeriod(c *gin.Context) {
+	var req model.AppraisalPeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	actor, ok := subject(c)
+	if !ok {
+		return
+	}
+	p, err := h.appraisalSvc.CreatePeriod(c.Request.Context(), req, actor)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, p)
+}
+
+// GetPeriods GET /api/v1/appraisals
+func (h *AppraisalHandler) GetPeriods(c *gin.Context) {
+	periods, err := h.appraisalSvc.GetPeriods(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(periods), "periods": periods})
+}
+
+// GetPeriod GET /api/v1/appraisals/:id
+func (h *AppraisalHandler) GetPeriod(c *gin.Context) {
+	id, ok := appraisalID(c)
+	if !ok {
+		return
+	}
+	p, err := h.appraisalSvc.GetPeriod(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// Compute menghitung ulang scorecard periode yang belum dikunci.
+// POST /api/v1/appraisals/:id/compute
+func (h *AppraisalHandler) Compute(c *gin.Context) {
+	id, ok := appraisalID(c)
+	if !ok {
+		return
+	}
+	actor, ok := subject(c)
+	if !ok {
+		return
+	}
+	result, err := h.appraisalSvc.Compute(c.Request.Context(), id, actor)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// Lock membekukan scorecard dan task periode.
+// POST /api/v1/appraisals/:id/lock
+func (h *AppraisalHandler) Lock(c *gin.Context) {
+	id, ok := appraisalID(c)
+	if !ok {
+		return
+	}
+	actor, ok := subject(c)
+	if !ok {
+		return
+	}
+	p, err := h.appraisalSvc.Lock(c.Request.Context(), id, actor)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// Reopen membuka kembali periode terkunci, wajib menyertakan alasan.
+// POST /api/v1/appraisals/:id/reopen  {"reason": "..."}
+func (h *AppraisalHandler) Reopen(c *gin.Context) {
+	id, ok := appraisalID(c)
+	if !ok {
+		return
+	}
+	actor, ok := subject(c)
+	if !ok {
+		return
+	}
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	p, err := h.appraisalSvc.Reopen(c.Request.Context(), id, actor, req.Reason)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// GetScorecards GET /api/v1/appraisals/:id/scorecards?user_id=123; anggota
+// hanya melihat scorecard miliknya (lihat scopedUserID).
+func (h *AppraisalHandler) GetScorecards(c *gin.Context) {
+	id, ok := appraisalID(c)
+	if !ok {
+		return
+	}
+	userID, ok := scopedUserID(c)
+	if !ok {
+		return
+	}
+	result, err := h.appraisalSvc.GetScorecards(c.Request.Context(), id, userID)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetTasks GET /api/v1/appraisals/:id/tasks?user_id=123
+func (h *AppraisalHandler) GetTasks(c *gin.Context) {
+	id, ok := appraisalID(c)
+	if !ok {
+		return
+	}
+	userID, ok := scopedUserID(c)
+	if !ok {
+		return
+	}
+	tasks, frozen, err := h.appraisalSvc.GetTasks(c.Request.Context(), id, userID)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"frozen": frozen, "count": len(tasks), "tasks": tasks})
+}
+
+// GetAuditLog GET /api/v1/appraisals/:id/audit, manager dan admin saja.
+func (h *AppraisalHandler) GetAuditLog(c *gin.Context) {
+	id, ok := appraisalID(c)
+	if !ok {
+		return
+	}
+	entries, err := h.appraisalSvc.GetAuditLog(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(entries), "entries": entries})
+}
+
+// SaveReview menyimpan review kualitatif manajer untuk satu anggota; hanya
+// manager dan admin.
+// PUT /api/v1/appraisals/:id/reviews/:user_id  {"rating": 4, "strengths": "...", "improvements": "...", "final_grade": "B"}
+func (h *AppraisalHandler) SaveReview(c *gin.Context) {
+	id, ok := appraisalID(c)
+	if !ok {
+		return
+	}
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid user_id"))
+		return
+	}
+	caller, ok := tokenCaller(c)
+	if !ok {
+		return
+	}
+	var req model.AppraisalReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	rv, err := h.appraisalSvc.SaveReview(c.Request.Context(), id, userID, req, caller)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rv)
+}
+
+// Acknowledge dipakai anggota untuk menyetujui review atau menambahkan rebuttal;
+// hanya anggota yang di-review.
+// POST /api/v1/appraisals/:id/reviews/:user_id/acknowledge  {"rebuttal": "..."}
+func (h *AppraisalHandler) Acknowledge(c *gin.Context) {
+	id, ok := appraisalID(c)
+	if !ok {
+		return
+	}
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid user_id"))
+		return
+	}
+	caller, ok := tokenCaller(c)
+	if !ok {
+		return
+	}
+	var req model.AppraisalAcknowledgeRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			fail(c, apperr.Validation("invalid request: "+err.Error()))
+			return
+		}
+	}
+
+	rv, err := h.appraisalSvc.Acknowledge(c.Request.Context(), id, userID, caller, req.Rebuttal)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, rv)
+}
+
+// GetReviews GET /api/v1/appraisals/:id/reviews?user_id=123
+func (h *AppraisalHandler) GetReviews(c *gin.Context) {
+	id, ok := appraisalID(c)
+	if !ok {
+		return
+	}
+	userID, ok := scopedUserID(c)
+	if !ok {
+		return
+	}
+	reviews, err := h.appraisalSvc.GetReviews(c.Request.Context(), id, userID)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(reviews), "reviews": reviews})
+}
+
+func appraisalID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid appraisal period id"))
+		return 0, false
+	}
+	return id, true
+}
+
+func optionalUserID(c *gin.Context) (*int64, bool) {
+	v := c.Query("user_id")
+	if v == "" {
+		return nil, true
+	}
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid user_id"))
+		return nil, false
+	}
+	return &id, true
+}
+
+// scopedUserID reads ?user_id like optionalUserID and narrows it to the
+// caller with service.ScopeUserID: admins and managers may ask for anyone,
+// members only for themselves.
+func scopedUserID(c *gin.Context) (*int64, bool) {
+	userID, ok := optionalUserID(c)
+	if !ok {
+		return nil, false
+	}
+	caller, ok := tokenCaller(c)
+	if !ok {
+		return nil, false
+	}
+	userID, err := service.ScopeUserID(caller, userID)
+	if err != nil {
+		fail(c, err)
+		return nil, false
+	}
+	return userID, true
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"database/sql"
 	"net/http"
 	"time"
 
@@ -9,16 +10,20 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/i18n"
 	"github.com/roksva123/go-kinerja-backend/internal/model"
 	"github.com/roksva123/go-kinerja-backend/internal/repository"
 )
 
+var errInvalidCredentials = apperr.New(apperr.CodeUnauthorized, "username or password is incorrect")
+
 type AuthHandler struct {
-	Repo      *repository.PostgresRepo
+	Repo      repository.AdminStore
 	JWTSecret string
 }
 
-func NewAuthHandler(repo *repository.PostgresRepo, jwtSecret string) *AuthHandler {
+func NewAuthHandler(repo repository.AdminStore, jwtSecret string) *AuthHandler {
 	return &AuthHandler{Repo: repo, JWTSecret: jwtSecret}
 }
 
@@ -28,45 +33,153 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// Validate JSON
 	if err := c.ShouldBindJSON(&req); err != nil {
-		response.ApiMessage = "Invalid request: " + err.Error()
-		c.JSON(http.StatusBadRequest, response)
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
 		return
 	}
 
 	// Fetch admin by username
 	admin, err := h.Repo.GetAdminByUsername(context.Background(), req.Username)
 	if err != nil {
-		response.ApiMessage = "Username or password is incorrect"
-		c.JSON(http.StatusUnauthorized, response)
+		fail(c, errInvalidCredentials)
 		return
 	}
 
 	// Compare password using bcrypt
 	if bcrypt.CompareHashAndPassword([]byte(admin.PasswordHash), []byte(req.Password)) != nil {
-		response.ApiMessage = "Username or password is incorrect"
-		c.JSON(http.StatusUnauthorized, response)
+		fail(c, errInvalidCredentials)
 		return
 	}
 
-	// Create JWT token
-	claims := jwt.MapClaims{
-		"sub": admin.ID,
-		"iat": time.Now().Unix(),
-		"exp": time.Now().Add(12 * time.Hour).Unix(),
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(h.JWTSecret))
+	tokenString, err := h.token(admin)
 	if err != nil {
-		response.ApiMessage = "Failed to generate token"
-		c.JSON(http.StatusInternalServerError, response)
+		fail(c, err)
 		return
 	}
 
-	response.ApiMessage = "Login Successful"
+	response.ApiMessage = i18n.T(i18n.Negotiate(c.GetHeader("Accept-Language"), c.Query("lang"), admin.Language), "msg.login_successful")
 	response.Data = model.LoginResponse{
 		Token: tokenString,
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// token signs a JWT for admin. The "lang" claim carries the saved language
+// preference, which middleware.Language applies to later requests; "role"
+// is checked by middleware.RequireRole and "user_id" names the member the
+// account belongs to.
+func (h *AuthHandler) token(admin *model.Admin) (string, error) {
+	claims := jwt.MapClaims{
+		"sub":  admin.ID,
+		"lang": admin.Language,
+		"role": admin.Role,
+		"iat":  time.Now().Unix(),
+		"exp":  time.Now().Add(12 * time.Hour).Unix(),
+	}
+	if admin.UserID != nil {
+		claims["user_id"] = *admin.UserID
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(h.JWTSecret))
+}
+
+// SetLanguage menyimpan preferensi bahasa admin dan mengembalikan token
+// baru yang membawa preferensi tersebut.
+// PUT /api/v1/auth/language {"language": "id"}
+func (h *AuthHandler) SetLanguage(c *gin.Context) {
+	var req model.LanguageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+	lang := i18n.Normalize(req.Language)
+	if lang == "" {
+		fail(c, apperr.Validation("language must be en or id"))
+		return
+	}
+
+	sub, ok := subject(c)
+	if !ok {
+		return
+	}
+
+	admin, err := h.Repo.UpdateAdminLanguage(c.Request.Context(), sub, lang)
+	if err == sql.ErrNoRows {
+		fail(c, apperr.New(apperr.CodeUnauthorized, "admin no longer exists"))
+		return
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	tokenString, err := h.token(admin)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":  i18n.T(lang, "msg.language_updated"),
+		"language": lang,
+		"token":    tokenString,
+	})
+}
+
+// SaveAccount membuat atau memperbarui akun dengan role dan anggota ClickUp
+// yang terhubung; hanya admin.
+// PUT /api/v1/auth/accounts/:username {"password": "...", "role": "member", "user_id": 123}
+func (h *AuthHandler) SaveAccount(c *gin.Context) {
+	var req model.AccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+	if !model.ValidRole(req.Role) {
+		fail(c, apperr.Validation("role must be admin, manager or member"))
+		return
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	admin := &model.Admin{Username: c.Param("username"), PasswordHash: string(hash), Role: req.Role, UserID: req.UserID}
+	err = h.Repo.SaveAdmin(c.Request.Context(), admin)
+	if err == sql.ErrNoRows {
+		fail(c, apperr.Validation("user_id is not a synced ClickUp member"))
+		return
+	}
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, model.Account{ID: admin.ID, Username: admin.Username, Role: admin.Role, UserID: admin.UserID, Language: admin.Language})
+}
+
+// subject returns the "sub" claim set by JWTAuthMiddleware. Without one the
+// request is rejected, so callers can always attribute the action.
+func subject(c *gin.Context) (string, bool) {
+	caller, ok := tokenCaller(c)
+	return caller.Subject, ok
+}
+
+// tokenCaller reads subject, role and member from the token claims; like
+// subject it rejects a token without "sub".
+func tokenCaller(c *gin.Context) (model.Caller, bool) {
+	var caller model.Caller
+	if v, ok := c.Get("claims"); ok {
+		if claims, ok := v.(jwt.MapClaims); ok {
+			caller.Subject, _ = claims["sub"].(string)
+			caller.Role, _ = claims["role"].(string)
+			// angka JSON di-decode sebagai float64
+			if id, ok := claims["user_id"].(float64); ok {
+				userID := int64(id)
+				caller.UserID = &userID
+			}
+		}
+	}
+	if caller.Subject == "" {
+		fail(c, apperr.New(apperr.CodeUnauthorized, "token has no subject"))
+		return caller, false
+	}
+	return caller, true
+}
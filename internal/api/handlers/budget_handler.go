@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type BudgetHandler struct {
+	budgetSvc *service.BudgetService
+}
+
+func NewBudgetHandler(budgetSvc *service.BudgetService) *BudgetHandler {
+	return &BudgetHandler{budgetSvc: budgetSvc}
+}
+
+// SetBudget membuat atau mengganti budget jam sebuah folder/list.
+// POST /api/v1/budgets  {"level": "folder", "node_id": "123", "budget_hours": 400, "thresholds": [75, 90, 100]}
+func (h *BudgetHandler) SetBudget(c *gin.Context) {
+	var req model.BudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	st, err := h.budgetSvc.SetBudget(c.Request.Context(), req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, st)
+}
+
+// GetBudgets burn semua budget. GET /api/v1/budgets
+func (h *BudgetHandler) GetBudgets(c *gin.Context) {
+	budgets, err := h.budgetSvc.GetBudgets(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(budgets), "budgets": budgets})
+}
+
+// GetBudget GET /api/v1/budgets/:id
+func (h *BudgetHandler) GetBudget(c *gin.Context) {
+	id, ok := budgetID(c)
+	if !ok {
+		return
+	}
+	st, err := h.budgetSvc.GetBudget(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, st)
+}
+
+// UpdateBudget PUT /api/v1/budgets/:id
+func (h *BudgetHandler) UpdateBudget(c *gin.Context) {
+	id, ok := budgetID(c)
+	if !ok {
+		return
+	}
+	var req model.BudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	st, err := h.budgetSvc.UpdateBudget(c.Request.Context(), id, req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, st)
+}
+
+// DeleteBudget DELETE /api/v1/budgets/:id
+func (h *BudgetHandler) DeleteBudget(c *gin.Context) {
+	id, ok := budgetID(c)
+	if !ok {
+		return
+	}
+	if err := h.budgetSvc.DeleteBudget(c.Request.Context(), id); err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "budget_deleted")})
+}
+
+func budgetID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid budget id"))
+		return 0, false
+	}
+	return id, true
+}
@@ -3,9 +3,9 @@ package handlers
 import (
 	"context"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/export"
 	"github.com/roksva123/go-kinerja-backend/internal/model"
 	"github.com/roksva123/go-kinerja-backend/internal/service"
 )
@@ -22,60 +22,72 @@ func NewClickUpHandler(click *service.ClickUpService) *ClickUpHandler {
 
 func (h *ClickUpHandler) SyncTeam(c *gin.Context) {
 	if err := h.Click.SyncTeam(context.Background()); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "teams synced"})
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "teams_synced")})
 }
 
 func (h *ClickUpHandler) SyncMembers(c *gin.Context) {
 	if err := h.Click.SyncMembers(context.Background()); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "members synced"})
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "members_synced")})
 }
 
 func (h *ClickUpHandler) SyncTasks(c *gin.Context) {
 	n, err := h.Click.SyncTasks(context.Background())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "tasks synced", "count": n})
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "tasks_synced"), "count": n})
 }
 
 func (h *ClickUpHandler) SyncAll(c *gin.Context) {
 	err := h.Click.AllSync(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "full sync completed successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "full_sync_done")})
 }
 
+// GetTasks GET /api/v1/clickup/tasks?status=open&assignee=123&project=Kinerja&q=login&sort=-due_date&limit=50
 func (h *ClickUpHandler) GetTasks(c *gin.Context) {
-	tasks, err := h.Click.GetTasks(c.Request.Context())
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
+	tasks, page, err := h.Click.GetTasksPage(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"tasks": tasks})
+	setPageHeaders(c, page)
+	c.JSON(http.StatusOK, gin.H{"count": len(tasks), "tasks": tasks, "page": page})
 }
 
+// GetMembers GET /api/v1/clickup/members?role=backend&status=aktif&q=budi&sort=name
 func (h *ClickUpHandler) GetMembers(c *gin.Context) {
-	users, err := h.Click.GetMembers(c.Request.Context())
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
+	users, page, err := h.Click.GetMembersPage(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"users": users})
+	setPageHeaders(c, page)
+	c.JSON(http.StatusOK, gin.H{"count": len(users), "users": users, "page": page})
 }
 
 func (h *ClickUpHandler) GetSpaces(c *gin.Context) {
 	spaces, err := h.Click.GetSpaces(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"spaces": spaces})
@@ -89,26 +101,18 @@ func (h *ClickUpHandler) GetFullSyncFiltered(c *gin.Context) {
 	filter.Role = c.Query("role")
 	filter.Range = c.Query("range")
 
-	startStr := c.Query("start_date")
-	endStr := c.Query("end_date")
-
-	if startStr != "" && endStr != "" {
-		layout := "2006-01-02"
-
-		if sd, err := time.Parse(layout, startStr); err == nil {
-			ms := sd.UnixNano() / 1e6
-			filter.StartDate = &ms
-		}
-		if ed, err := time.Parse(layout, endStr); err == nil {
-			ed = ed.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
-			ms := ed.UnixNano() / 1e6
-			filter.EndDate = &ms
-		}
+	start, end, ok := dateRangeQuery(c, "start_date", "end_date", false)
+	if !ok {
+		return
+	}
+	if !start.IsZero() {
+		startMs, endMs := start.UnixMilli(), end.UnixMilli()
+		filter.StartDate, filter.EndDate = &startMs, &endMs
 	}
 
 	out, err := h.Click.FullSyncFiltered(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		fail(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"tasks": out})
@@ -120,53 +124,42 @@ func (h *ClickUpHandler) FullSync(c *gin.Context) {
 	filter.Role = c.Query("role")
 	filter.Username = c.Query("username")
 
-	startStr := c.Query("start_date")
-	endStr := c.Query("end_date")
-
-	if startStr != "" && endStr != "" {
-		layout := "2006-01-02"
-
-		if sd, err := time.Parse(layout, startStr); err == nil {
-			ms := sd.UnixNano() / 1e6
-			filter.StartDate = &ms
-		}
-		if ed, err := time.Parse(layout, endStr); err == nil {
-			ed = ed.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
-			ms := ed.UnixNano() / 1e6
-			filter.EndDate = &ms
-		}
+	start, end, ok := dateRangeQuery(c, "start_date", "end_date", false)
+	if !ok {
+		return
+	}
+	if !start.IsZero() {
+		startMs, endMs := start.UnixMilli(), end.UnixMilli()
+		filter.StartDate, filter.EndDate = &startMs, &endMs
 	}
 
 	out, err := h.Click.FullSyncFlow(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"data": out})
 }
 
+// GetFullData GET /api/v1/clickup/data?start_date=2026-10-01&end_date=2026-10-31&role=backend&format=csv|xlsx
 func (h *ClickUpHandler) GetFullData(c *gin.Context) {
+	format, ok := exportFormat(c)
+	if !ok {
+		return
+	}
 	var filter model.FullSyncFilter
 
 	filter.Role = c.Query("role")
 	filter.Username = c.Query("username")
 
-	startStr := c.Query("start_date")
-	endStr := c.Query("end_date")
-
-	if startStr != "" && endStr != "" {
-		layout := "2006-01-02"
-
-		if sd, err := time.Parse(layout, startStr); err == nil {
-			ms := sd.UnixNano() / 1e6
-			filter.StartDate = &ms
-		}
-		if ed, err := time.Parse(layout, endStr); err == nil {
-			ed = ed.Add(23*time.Hour + 59*time.Minute + 59*time.Second)
-			ms := ed.UnixNano() / 1e6
-			filter.EndDate = &ms
-		}
+	start, end, ok := dateRangeQuery(c, "start_date", "end_date", false)
+	if !ok {
+		return
+	}
+	if !start.IsZero() {
+		startMs, endMs := start.UnixMilli(), end.UnixMilli()
+		filter.StartDate, filter.EndDate = &startMs, &endMs
 	}
 
 	out, err := h.Click.Repo.GetFullDataFiltered(
@@ -177,7 +170,11 @@ func (h *ClickUpHandler) GetFullData(c *gin.Context) {
 		filter.Username,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
+		return
+	}
+	if format != export.FormatJSON {
+		writeExport(c, format, "clickup-data", len(out), fullDataSheet(out))
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"data": out})
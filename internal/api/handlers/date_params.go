@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+)
+
+// requestLocation returns the ?tz= zone or the workspace default.
+// ok=false berarti error sudah diteruskan lewat fail.
+func requestLocation(c *gin.Context) (*time.Location, bool) {
+	loc, err := dates.Location(c.Query("tz"))
+	if err != nil {
+		fail(c, err)
+		return nil, false
+	}
+	return loc, true
+}
+
+// dateRangeQuery reads a start/end pair in any format package dates accepts.
+// A bare end date covers its whole day. With required=false both may be
+// omitted, in which case the zero times are returned.
+func dateRangeQuery(c *gin.Context, startKey, endKey string, required bool) (start, end time.Time, ok bool) {
+	loc, ok := requestLocation(c)
+	if !ok {
+		return start, end, false
+	}
+	startStr, endStr := c.Query(startKey), c.Query(endKey)
+	if startStr == "" || endStr == "" {
+		if required || startStr != endStr {
+			fail(c, apperr.Validation(startKey+" and "+endKey+" are required"))
+			return start, end, false
+		}
+		return start, end, true
+	}
+
+	start, err := dates.ParseStart(startStr, loc)
+	if err != nil {
+		fail(c, apperr.Validation("invalid "+startKey+": "+err.Error()))
+		return start, end, false
+	}
+	end, err = dates.ParseEnd(endStr, loc)
+	if err != nil {
+		fail(c, apperr.Validation("invalid "+endKey+": "+err.Error()))
+		return start, end, false
+	}
+	if end.Before(start) {
+		fail(c, apperr.Validation(endKey+" must not be before "+startKey))
+		return start, end, false
+	}
+	return start, end, true
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+)
+
+// fail hands err to the error middleware, which renders the standard error
+// envelope. Errors without an apperr code become internal_error.
+func fail(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+// GetErrorCatalogue GET /api/v1/errors
+// Daftar kode error beserta status HTTP dan pesannya.
+func GetErrorCatalogue(c *gin.Context) {
+	codes := apperr.Catalogue()
+	c.JSON(http.StatusOK, gin.H{"count": len(codes), "codes": codes})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type EstimateHandler struct {
+	estimateSvc *service.EstimateService
+}
+
+func NewEstimateHandler(estimateSvc *service.EstimateService) *EstimateHandler {
+	return &EstimateHandler{estimateSvc: estimateSvc}
+}
+
+// GetAccuracy laporan estimasi vs aktual task yang sudah ditutup.
+// GET /api/v1/estimates/accuracy?start_date=2026-07-01&end_date=2026-09-30&group_by=member|role|project
+func (h *EstimateHandler) GetAccuracy(c *gin.Context) {
+	report, err := h.estimateSvc.GetAccuracy(c.Request.Context(), c.Query("start_date"), c.Query("end_date"), c.Query("group_by"))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// SetManualEstimate mengganti estimasi sebuah task.
+// PUT /api/v1/estimates/tasks/:id  {"hours": 6}
+func (h *EstimateHandler) SetManualEstimate(c *gin.Context) {
+	var req model.ManualEstimateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+	if err := h.estimateSvc.SetManualEstimate(c.Request.Context(), c.Param("id"), req.Hours); err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"task_id": c.Param("id"), "time_estimate_hours": req.Hours, "estimate_source": model.EstimateSourceManual})
+}
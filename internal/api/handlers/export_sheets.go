@@ -0,0 +1,231 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/api/middleware"
+	"github.com/roksva123/go-kinerja-backend/internal/export"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// exportFormat membaca ?format= atau header Accept. ok=false berarti
+// response error sudah dikirim.
+func exportFormat(c *gin.Context) (export.Format, bool) {
+	f, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+	if err != nil {
+		fail(c, err)
+		return "", false
+	}
+	return f, true
+}
+
+// writeExport streams sheets as a file download; rows is the size of the
+// detail sheet, checked against export.MaxRows first. Once the first byte is
+// sent the status can't change, so a failure midway is only logged.
+func writeExport(c *gin.Context, f export.Format, filename string, rows int, sheets ...export.Sheet) {
+	if err := export.CheckRows(rows); err != nil {
+		fail(c, err)
+		return
+	}
+	lang := middleware.Lang(c)
+	c.Header("Content-Type", export.ContentType(f))
+	c.Header("Content-Disposition", `attachment; filename="`+filename+"."+string(f)+`"`)
+	c.Status(http.StatusOK)
+	if err := export.Write(c.Writer, f, lang, sheets...); err != nil {
+		log.Printf("WARNING: export %s.%s failed: %v\n", filename, f, err)
+	}
+}
+
+var sheetTasks = export.Column{EN: "Tasks", ID: "Task"}
+
+var workloadMemberColumns = []export.Column{
+	{EN: "Name", ID: "Nama"},
+	{EN: "Username", ID: "Username"},
+	{EN: "Email", ID: "Email"},
+	{EN: "Role", ID: "Role"},
+	{EN: "Tasks", ID: "Jumlah Task"},
+	{EN: "Hours logged", ID: "Jam Tercatat"},
+	{EN: "Expected hours", ID: "Jam Seharusnya"},
+	{EN: "Standard hours", ID: "Jam Standar"},
+}
+
+var memberTaskColumns = []export.Column{
+	{EN: "Name", ID: "Nama"},
+	{EN: "Username", ID: "Username"},
+	{EN: "Role", ID: "Role"},
+	{EN: "Task ID", ID: "ID Task"},
+	{EN: "Task", ID: "Task"},
+	{EN: "Status", ID: "Status"},
+	{EN: "Project", ID: "Proyek"},
+	{EN: "Start date", ID: "Tanggal Mulai"},
+	{EN: "Due date", ID: "Tenggat"},
+	{EN: "Date done", ID: "Tanggal Selesai"},
+	{EN: "Estimate (hours)", ID: "Estimasi (jam)"},
+	{EN: "Spent (hours)", ID: "Terpakai (jam)"},
+	{EN: "Efficiency %", ID: "Efisiensi %"},
+}
+
+// workloadSheets: detail task per member (satu baris per task) lalu ringkasan
+// per member. Member tanpa task tetap muncul di detail dengan kolom task kosong.
+func workloadSheets(users []model.WorkloadUser) []export.Sheet {
+	tasks := export.Sheet{
+		Name:    sheetTasks,
+		Columns: memberTaskColumns,
+		Rows: func(emit func(...interface{}) error) error {
+			for _, u := range users {
+				if len(u.Tasks) == 0 {
+					if err := emit(u.Name, u.Username, u.Role); err != nil {
+						return err
+					}
+					continue
+				}
+				for _, t := range u.Tasks {
+					if err := emit(u.Name, u.Username, u.Role, t.ID, t.Name, t.StatusName, t.ProjectName,
+						t.StartDate, t.DueDate, t.DateDone, t.TimeEstimateHours, t.TimeSpentHours, t.TimeEfficiencyPercentage); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+	members := export.Sheet{
+		Name:    export.Column{EN: "Members", ID: "Member"},
+		Columns: workloadMemberColumns,
+		Rows: func(emit func(...interface{}) error) error {
+			for _, u := range users {
+				if err := emit(u.Name, u.Username, u.Email, u.Role, u.TaskCount, u.TotalHours, u.ExpectedHours, u.StandardHours); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	return []export.Sheet{tasks, members}
+}
+
+var tasksByRangeTaskColumns = append(append([]export.Column{}, memberTaskColumns...),
+	export.Column{EN: "Remaining", ID: "Sisa Waktu"},
+	export.Column{EN: "Actual duration", ID: "Durasi Aktual"},
+	export.Column{EN: "Schedule status", ID: "Status Jadwal"},
+)
+
+var tasksByRangeAssigneeColumns = []export.Column{
+	{EN: "Name", ID: "Nama"},
+	{EN: "Username", ID: "Username"},
+	{EN: "Role", ID: "Role"},
+	{EN: "Tasks", ID: "Jumlah Task"},
+	{EN: "Spent hours", ID: "Jam Terpakai"},
+	{EN: "Expected hours", ID: "Jam Seharusnya"},
+	{EN: "Upcoming hours", ID: "Jam Mendatang"},
+	{EN: "On-time completion %", ID: "Selesai Tepat Waktu %"},
+}
+
+// workloadRows counts the detail rows of workloadSheets.
+func workloadRows(users []model.WorkloadUser) int {
+	n := 0
+	for _, u := range users {
+		n += max(len(u.Tasks), 1)
+	}
+	return n
+}
+
+// tasksByRangeRows counts the detail rows of tasksByRangeSheets.
+func tasksByRangeRows(assignees []AssigneeWithTasks) int {
+	n := 0
+	for _, a := range assignees {
+		n += len(a.Tasks)
+	}
+	return n
+}
+
+func tasksByRangeSheets(assignees []AssigneeWithTasks) []export.Sheet {
+	tasks := export.Sheet{
+		Name:    sheetTasks,
+		Columns: tasksByRangeTaskColumns,
+		Rows: func(emit func(...interface{}) error) error {
+			for _, a := range assignees {
+				for _, t := range a.Tasks {
+					if err := emit(a.Name, a.Username, a.Role, t.ID, t.Name, t.StatusName, t.ProjectName,
+						t.StartDate, t.DueDate, t.DateDone, t.TimeEstimateHours, t.TimeSpentHours, t.TimeEfficiencyPercentage,
+						t.RemainingTimeFormatted, t.ActualDurationFormatted, t.ScheduleStatusLabel); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	}
+	summary := export.Sheet{
+		Name:    export.Column{EN: "Assignees", ID: "Assignee"},
+		Columns: tasksByRangeAssigneeColumns,
+		Rows: func(emit func(...interface{}) error) error {
+			for _, a := range assignees {
+				if err := emit(a.Name, a.Username, a.Role, a.TotalTasks, a.TotalSpentHours, a.ExpectedHours,
+					a.TotalUpcomingHours, a.OnTimeCompletionPercentage); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	return []export.Sheet{tasks, summary}
+}
+
+func taskSummarySheet(summaries []model.TaskSummary) export.Sheet {
+	return export.Sheet{
+		Name: export.Column{EN: "Summary", ID: "Ringkasan"},
+		Columns: []export.Column{
+			{EN: "Name", ID: "Nama"},
+			{EN: "Email", ID: "Email"},
+			{EN: "Role", ID: "Role"},
+			{EN: "Tasks", ID: "Jumlah Task"},
+			{EN: "Work hours", ID: "Jam Kerja"},
+			{EN: "Spent hours", ID: "Jam Terpakai"},
+			{EN: "Upcoming hours", ID: "Jam Mendatang"},
+		},
+		Rows: func(emit func(...interface{}) error) error {
+			for _, s := range summaries {
+				if err := emit(s.Name, s.Email, s.Role, s.TotalTasks, s.TotalWorkHours, s.TotalSpentHours, s.TotalUpcomingHours); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func fullDataSheet(rows []model.TaskWithMember) export.Sheet {
+	return export.Sheet{
+		Name: sheetTasks,
+		Columns: []export.Column{
+			{EN: "Task ID", ID: "ID Task"},
+			{EN: "Task", ID: "Task"},
+			{EN: "Status", ID: "Status"},
+			{EN: "Status type", ID: "Tipe Status"},
+			{EN: "Start date", ID: "Tanggal Mulai"},
+			{EN: "Due date", ID: "Tenggat"},
+			{EN: "Date created", ID: "Tanggal Dibuat"},
+			{EN: "Date done", ID: "Tanggal Selesai"},
+			{EN: "Date closed", ID: "Tanggal Ditutup"},
+			{EN: "Estimate (hours)", ID: "Estimasi (jam)"},
+			{EN: "Spent (hours)", ID: "Terpakai (jam)"},
+			{EN: "Username", ID: "Username"},
+			{EN: "Email", ID: "Email"},
+			{EN: "Role", ID: "Role"},
+			{EN: "Team", ID: "Tim"},
+		},
+		Rows: func(emit func(...interface{}) error) error {
+			for _, t := range rows {
+				if err := emit(t.TaskID, t.TaskName, t.TaskStatus, t.TaskStatusType, t.StartDate, t.DueDate,
+					t.DateCreated, t.DateDone, t.DateClosed, t.TimeEstimateHours, t.TimeSpentHours,
+					t.Username, t.Email, t.Role, t.TeamName); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
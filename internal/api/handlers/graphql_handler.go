@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/api/middleware"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/graph"
+)
+
+type GraphQLHandler struct {
+	executor *graph.Executor
+}
+
+func NewGraphQLHandler(executor *graph.Executor) *GraphQLHandler {
+	return &GraphQLHandler{executor: executor}
+}
+
+// Query POST /api/graphql {"query": "...", "variables": {...}} atau
+// GET /api/graphql?query=...&variables=... . Error GraphQL dikirim di field
+// errors dengan status 200; hanya body yang rusak memakai envelope error.
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req graph.Request
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if v := c.Query("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				fail(c, apperr.Validation("invalid variables: "+err.Error()))
+				return
+			}
+		}
+		if req.Query == "" {
+			fail(c, apperr.Validation("query is required"))
+			return
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	view, ok := resourceView(c)
+	if !ok {
+		return
+	}
+	caller, ok := tokenCaller(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, h.executor.Execute(c.Request.Context(), req, view, caller, middleware.Lang(c)))
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/api/middleware"
+	"github.com/roksva123/go-kinerja-backend/internal/i18n"
+)
+
+// message translates a "msg.<key>" entry for the request language.
+func message(c *gin.Context, key string) string {
+	return i18n.T(middleware.Lang(c), "msg."+key)
+}
+
+// scheduleLabel translates a schedule status (Early, On Time, Late,
+// Severely Late); the status itself stays in English for clients to branch on.
+func scheduleLabel(lang string, status *string) *string {
+	if status == nil {
+		return nil
+	}
+	s := i18n.T(lang, "schedule."+*status)
+	return &s
+}
+
+// statusLabels maps each normalized status key to its display label.
+func statusLabels(lang string, counts map[string]int) map[string]string {
+	out := make(map[string]string, len(counts))
+	for status := range counts {
+		out[status] = i18n.StatusLabel(lang, status)
+	}
+	return out
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type KPIHandler struct {
+	kpiSvc *service.KPIService
+}
+
+func NewKPIHandler(kpiSvc *service.KPIService) *KPIHandler {
+	return &KPIHandler{kpiSvc: kpiSvc}
+}
+
+// GetIndicators GET /api/v1/kpi/indicators
+func (h *KPIHandler) GetIndicators(c *gin.Context) {
+	indicators, err := h.kpiSvc.GetIndicators(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"indicators": indicators})
+}
+
+// UpdateIndicator mengubah field indikator yang dikirim; field yang tidak
+// dikirim tetap.
+// PUT /api/v1/kpi/indicators/:code
+func (h *KPIHandler) UpdateIndicator(c *gin.Context) {
+	var req model.KPIIndicatorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	ind, err := h.kpiSvc.UpdateIndicator(c.Request.Context(), c.Param("code"), req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ind)
+}
+
+// GetRoleWeights GET /api/v1/kpi/weights
+func (h *KPIHandler) GetRoleWeights(c *gin.Context) {
+	weights, err := h.kpiSvc.GetRoleWeights(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"weights": weights})
+}
+
+// SetRoleWeights mengganti seluruh bobot indikator untuk satu role.
+// PUT /api/v1/kpi/weights/:role  {"weights": {"on_time_rate": 0.4, ...}}
+func (h *KPIHandler) SetRoleWeights(c *gin.Context) {
+	var req struct {
+		Weights map[string]float64 `json:"weights" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	role := c.Param("role")
+	if err := h.kpiSvc.SetRoleWeights(c.Request.Context(), role, req.Weights); err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"role": role, "weights": req.Weights})
+}
+
+// ComputeScorecards menghitung dan menyimpan scorecard semua anggota aktif.
+// POST /api/v1/kpi/scorecards/compute?period=2026-09 atau period=2026-Q3
+func (h *KPIHandler) ComputeScorecards(c *gin.Context) {
+	scorecards, err := h.kpiSvc.ComputePeriod(c.Request.Context(), c.Query("period"))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(scorecards), "scorecards": scorecards})
+}
+
+// GetScorecards GET /api/v1/kpi/scorecards?period=2026-09&user_id=123;
+// anggota hanya melihat scorecard miliknya.
+func (h *KPIHandler) GetScorecards(c *gin.Context) {
+	userID, ok := scopedUserID(c)
+	if !ok {
+		return
+	}
+
+	scorecards, err := h.kpiSvc.GetScorecards(c.Request.Context(), c.Query("period"), userID)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(scorecards), "scorecards": scorecards})
+}
+
+// GetScorecard GET /api/v1/kpi/scorecards/:id
+func (h *KPIHandler) GetScorecard(c *gin.Context) {
+	sc, ok := h.scorecard(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, sc)
+}
+
+// GetIndicatorTasks menampilkan task di balik nilai satu indikator (drill-down).
+// GET /api/v1/kpi/scorecards/:id/indicators/:code/tasks
+func (h *KPIHandler) GetIndicatorTasks(c *gin.Context) {
+	sc, ok := h.scorecard(c)
+	if !ok {
+		return
+	}
+
+	tasks, err := h.kpiSvc.GetIndicatorTasks(c.Request.Context(), sc.ID, c.Param("code"))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(tasks), "tasks": tasks})
+}
+
+// scorecard loads the scorecard named by :id and checks the caller may read
+// it; members only get their own.
+func (h *KPIHandler) scorecard(c *gin.Context) (*model.KPIScorecard, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid scorecard id"))
+		return nil, false
+	}
+	caller, ok := tokenCaller(c)
+	if !ok {
+		return nil, false
+	}
+
+	sc, err := h.kpiSvc.GetScorecard(c.Request.Context(), id)
+	if err == nil {
+		_, err = service.ScopeUserID(caller, &sc.UserID)
+	}
+	if err != nil {
+		fail(c, err)
+		return nil, false
+	}
+	return sc, true
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+)
+
+// listParams membaca pagination/sort/filter bersama (lihat package query).
+// ok=false berarti error sudah diteruskan lewat fail.
+func listParams(c *gin.Context) (query.Params, bool) {
+	p, err := query.Parse(c.Request.URL.Query())
+	if err != nil {
+		fail(c, err)
+		return p, false
+	}
+	return p, true
+}
+
+// setPageHeaders exposes the page metadata as headers, so endpoints that
+// return a bare JSON array stay compatible.
+func setPageHeaders(c *gin.Context, page query.Page) {
+	c.Header("X-Total-Count", strconv.Itoa(page.Total))
+	if page.NextCursor != "" {
+		c.Header("X-Next-Cursor", page.NextCursor)
+	}
+}
+
+func idString(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+var workloadListSpec = query.MemorySpec[model.WorkloadUser]{
+	Sort: map[string]func(a, b model.WorkloadUser) int{
+		"name":           func(a, b model.WorkloadUser) int { return query.CompareString(a.Name, b.Name) },
+		"role":           func(a, b model.WorkloadUser) int { return query.CompareString(a.Role, b.Role) },
+		"total_hours":    func(a, b model.WorkloadUser) int { return query.CompareFloat(a.TotalHours, b.TotalHours) },
+		"expected_hours": func(a, b model.WorkloadUser) int { return query.CompareFloat(a.ExpectedHours, b.ExpectedHours) },
+		"task_count":     func(a, b model.WorkloadUser) int { return query.CompareInt(a.TaskCount, b.TaskCount) },
+	},
+	DefaultSort: []query.SortField{{Field: "name"}},
+	Filters: map[string]func(model.WorkloadUser) []string{
+		query.FilterRole:     func(u model.WorkloadUser) []string { return []string{u.Role} },
+		query.FilterAssignee: func(u model.WorkloadUser) []string { return []string{idString(u.UserID), u.Username, u.Name, u.Email} },
+	},
+	Search: func(u model.WorkloadUser) []string { return []string{u.Name, u.Username, u.Email} },
+}
+
+var taskSummaryListSpec = query.MemorySpec[model.TaskSummary]{
+	Sort: map[string]func(a, b model.TaskSummary) int{
+		"name":              func(a, b model.TaskSummary) int { return query.CompareString(a.Name, b.Name) },
+		"role":              func(a, b model.TaskSummary) int { return query.CompareString(a.Role, b.Role) },
+		"total_tasks":       func(a, b model.TaskSummary) int { return query.CompareInt(a.TotalTasks, b.TotalTasks) },
+		"total_work_hours":  func(a, b model.TaskSummary) int { return query.CompareFloat(a.TotalWorkHours, b.TotalWorkHours) },
+		"total_spent_hours": func(a, b model.TaskSummary) int { return query.CompareFloat(a.TotalSpentHours, b.TotalSpentHours) },
+		"total_upcoming_hours": func(a, b model.TaskSummary) int {
+			return query.CompareFloat(a.TotalUpcomingHours, b.TotalUpcomingHours)
+		},
+	},
+	DefaultSort: []query.SortField{{Field: "name"}},
+	Filters: map[string]func(model.TaskSummary) []string{
+		query.FilterRole:     func(s model.TaskSummary) []string { return []string{s.Role} },
+		query.FilterAssignee: func(s model.TaskSummary) []string { return []string{idString(s.UserID), s.Name, s.Email} },
+	},
+	Search: func(s model.TaskSummary) []string { return []string{s.Name, s.Email} },
+}
+
+var assigneeListSpec = query.MemorySpec[AssigneeWithTasks]{
+	Sort: map[string]func(a, b AssigneeWithTasks) int{
+		"name":              func(a, b AssigneeWithTasks) int { return query.CompareString(a.Name, b.Name) },
+		"role":              func(a, b AssigneeWithTasks) int { return query.CompareString(a.Role, b.Role) },
+		"total_tasks":       func(a, b AssigneeWithTasks) int { return query.CompareInt(a.TotalTasks, b.TotalTasks) },
+		"total_spent_hours": func(a, b AssigneeWithTasks) int { return query.CompareFloat(a.TotalSpentHours, b.TotalSpentHours) },
+		"expected_hours":    func(a, b AssigneeWithTasks) int { return query.CompareFloat(a.ExpectedHours, b.ExpectedHours) },
+		"total_upcoming_hours": func(a, b AssigneeWithTasks) int {
+			return query.CompareFloat(a.TotalUpcomingHours, b.TotalUpcomingHours)
+		},
+	},
+	DefaultSort: []query.SortField{{Field: "name"}},
+	Filters: map[string]func(AssigneeWithTasks) []string{
+		query.FilterRole:     func(a AssigneeWithTasks) []string { return []string{a.Role} },
+		query.FilterAssignee: func(a AssigneeWithTasks) []string { return []string{idString(a.ClickupID), a.Username, a.Name} },
+	},
+	Search: func(a AssigneeWithTasks) []string { return []string{a.Name, a.Username} },
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type NotificationHandler struct {
+	notifySvc *service.NotificationService
+}
+
+func NewNotificationHandler(notifySvc *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notifySvc: notifySvc}
+}
+
+// CreateSubscription mendaftarkan user atau role ke sebuah channel.
+// POST /api/v1/notifications/subscriptions
+// {"subscriber_type": "role", "role": "backend", "channel": "email", "events": ["alert"], "quiet_start": "22:00", "quiet_end": "07:00"}
+func (h *NotificationHandler) CreateSubscription(c *gin.Context) {
+	var req model.NotificationSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+	sub, err := h.notifySvc.CreateSubscription(c.Request.Context(), req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, sub)
+}
+
+// GetSubscriptions GET /api/v1/notifications/subscriptions
+func (h *NotificationHandler) GetSubscriptions(c *gin.Context) {
+	subs, err := h.notifySvc.GetSubscriptions(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(subs), "subscriptions": subs})
+}
+
+// GetSubscription GET /api/v1/notifications/subscriptions/:id
+func (h *NotificationHandler) GetSubscription(c *gin.Context) {
+	id, ok := notificationID(c, "subscription")
+	if !ok {
+		return
+	}
+	sub, err := h.notifySvc.GetSubscription(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+// UpdateSubscription PUT /api/v1/notifications/subscriptions/:id
+func (h *NotificationHandler) UpdateSubscription(c *gin.Context) {
+	id, ok := notificationID(c, "subscription")
+	if !ok {
+		return
+	}
+	var req model.NotificationSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+	sub, err := h.notifySvc.UpdateSubscription(c.Request.Context(), id, req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, sub)
+}
+
+// DeleteSubscription DELETE /api/v1/notifications/subscriptions/:id
+func (h *NotificationHandler) DeleteSubscription(c *gin.Context) {
+	id, ok := notificationID(c, "subscription")
+	if !ok {
+		return
+	}
+	if err := h.notifySvc.DeleteSubscription(c.Request.Context(), id); err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "subscription_deleted")})
+}
+
+// GetDeliveries log pengiriman notifikasi terbaru.
+// GET /api/v1/notifications/deliveries?status=failed&limit=100
+func (h *NotificationHandler) GetDeliveries(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	deliveries, err := h.notifySvc.GetDeliveries(c.Request.Context(), c.Query("status"), limit)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(deliveries), "deliveries": deliveries})
+}
+
+// RetryDelivery POST /api/v1/notifications/deliveries/:id/retry
+func (h *NotificationHandler) RetryDelivery(c *gin.Context) {
+	id, ok := notificationID(c, "delivery")
+	if !ok {
+		return
+	}
+	d, err := h.notifySvc.RetryDelivery(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, d)
+}
+
+// SendTest mengirim pesan uji langsung ke sebuah target.
+// POST /api/v1/notifications/test  {"channel": "chat", "target": "https://hooks.slack.com/services/..."}
+func (h *NotificationHandler) SendTest(c *gin.Context) {
+	var req model.NotificationTestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+	if err := h.notifySvc.SendTest(c.Request.Context(), req.Channel, req.Target); err != nil {
+		if errors.Is(err, service.ErrInvalidSubscription) {
+			fail(c, err)
+			return
+		}
+		fail(c, apperr.Wrap(apperr.CodeUpstreamError, err))
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "test_notification_sent")})
+}
+
+func notificationID(c *gin.Context, kind string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid "+kind+" id"))
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type OKRHandler struct {
+	okrSvc *service.OKRService
+}
+
+func NewOKRHandler(okrSvc *service.OKRService) *OKRHandler {
+	return &OKRHandler{okrSvc: okrSvc}
+}
+
+// CreateObjective POST /api/v1/okrs/objectives
+// {"title": "...", "period": "2026-Q3", "owner_type": "member", "owner_user_id": 123}
+func (h *OKRHandler) CreateObjective(c *gin.Context) {
+	var req model.ObjectiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	o, err := h.okrSvc.CreateObjective(c.Request.Context(), req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, o)
+}
+
+// GetObjectives GET /api/v1/okrs/objectives?period=2026-Q3&user_id=123&role=backend
+func (h *OKRHandler) GetObjectives(c *gin.Context) {
+	userID, ok := optionalUserID(c)
+	if !ok {
+		return
+	}
+
+	objectives, err := h.okrSvc.GetObjectives(c.Request.Context(), c.Query("period"), userID, c.Query("role"))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(objectives), "objectives": objectives})
+}
+
+// GetObjective GET /api/v1/okrs/objectives/:id
+func (h *OKRHandler) GetObjective(c *gin.Context) {
+	id, ok := okrID(c)
+	if !ok {
+		return
+	}
+	o, err := h.okrSvc.GetObjective(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, o)
+}
+
+// UpdateObjective PUT /api/v1/okrs/objectives/:id
+func (h *OKRHandler) UpdateObjective(c *gin.Context) {
+	id, ok := okrID(c)
+	if !ok {
+		return
+	}
+	var req model.ObjectiveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	o, err := h.okrSvc.UpdateObjective(c.Request.Context(), id, req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, o)
+}
+
+// DeleteObjective DELETE /api/v1/okrs/objectives/:id
+func (h *OKRHandler) DeleteObjective(c *gin.Context) {
+	id, ok := okrID(c)
+	if !ok {
+		return
+	}
+	if err := h.okrSvc.DeleteObjective(c.Request.Context(), id); err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "objective_deleted")})
+}
+
+// CreateKeyResult menambah key result beserta link ClickUp-nya.
+// POST /api/v1/okrs/objectives/:id/key-results
+// {"title": "...", "metric": "tasks_completed", "links": [{"link_type": "list", "link_value": "901234"}]}
+func (h *OKRHandler) CreateKeyResult(c *gin.Context) {
+	id, ok := okrID(c)
+	if !ok {
+		return
+	}
+	var req model.KeyResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	kr, err := h.okrSvc.CreateKeyResult(c.Request.Context(), id, req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, kr)
+}
+
+// GetKeyResult GET /api/v1/okrs/key-results/:id
+func (h *OKRHandler) GetKeyResult(c *gin.Context) {
+	id, ok := okrID(c)
+	if !ok {
+		return
+	}
+	kr, err := h.okrSvc.GetKeyResult(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, kr)
+}
+
+// UpdateKeyResult mengganti key result dan seluruh link-nya.
+// PUT /api/v1/okrs/key-results/:id
+func (h *OKRHandler) UpdateKeyResult(c *gin.Context) {
+	id, ok := okrID(c)
+	if !ok {
+		return
+	}
+	var req model.KeyResultRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	kr, err := h.okrSvc.UpdateKeyResult(c.Request.Context(), id, req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, kr)
+}
+
+// DeleteKeyResult DELETE /api/v1/okrs/key-results/:id
+func (h *OKRHandler) DeleteKeyResult(c *gin.Context) {
+	id, ok := okrID(c)
+	if !ok {
+		return
+	}
+	if err := h.okrSvc.DeleteKeyResult(c.Request.Context(), id); err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "key_result_deleted")})
+}
+
+// GetKeyResultTasks menampilkan task ter-link yang dihitung untuk progress.
+// GET /api/v1/okrs/key-results/:id/tasks
+func (h *OKRHandler) GetKeyResultTasks(c *gin.Context) {
+	id, ok := okrID(c)
+	if !ok {
+		return
+	}
+	tasks, err := h.okrSvc.GetKeyResultTasks(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(tasks), "tasks": tasks})
+}
+
+func okrID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid id"))
+		return 0, false
+	}
+	return id, true
+}
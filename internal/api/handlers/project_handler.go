@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/api/middleware"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type ProjectHandler struct {
+	projectSvc *service.ProjectService
+}
+
+func NewProjectHandler(projectSvc *service.ProjectService) *ProjectHandler {
+	return &ProjectHandler{projectSvc: projectSvc}
+}
+
+// GetProjects ringkasan status, jam, overdue dan health per node.
+// GET /api/v1/projects?level=folder&include_archived=true
+func (h *ProjectHandler) GetProjects(c *gin.Context) {
+	level := c.DefaultQuery("level", model.ProjectLevelFolder)
+	includeArchived := c.Query("include_archived") == "true"
+
+	projects, err := h.projectSvc.GetProjects(c.Request.Context(), level, includeArchived)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	lang := middleware.Lang(c)
+	for i := range projects {
+		projects[i].StatusLabels = statusLabels(lang, projects[i].StatusCounts)
+	}
+	c.JSON(http.StatusOK, gin.H{"level": level, "count": len(projects), "projects": projects})
+}
+
+// GetProject health view satu space, folder atau list.
+// GET /api/v1/projects/:level/:id?weeks=12
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	weeks := 0
+	if v := c.Query("weeks"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			fail(c, apperr.Validation("invalid weeks"))
+			return
+		}
+		weeks = n
+	}
+
+	detail, err := h.projectSvc.GetProject(c.Request.Context(), c.Param("level"), c.Param("id"), weeks)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	detail.StatusLabels = statusLabels(middleware.Lang(c), detail.StatusCounts)
+	c.JSON(http.StatusOK, detail)
+}
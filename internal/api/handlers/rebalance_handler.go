@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type RebalanceHandler struct {
+	rebalanceSvc *service.RebalanceService
+}
+
+func NewRebalanceHandler(rebalanceSvc *service.RebalanceService) *RebalanceHandler {
+	return &RebalanceHandler{rebalanceSvc: rebalanceSvc}
+}
+
+// GenerateSuggestions menghitung ulang usulan pemindahan task untuk anggota yang overload.
+// POST /api/v1/workload/rebalance?days=14
+func (h *RebalanceHandler) GenerateSuggestions(c *gin.Context) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "14"))
+	if err != nil || days <= 0 {
+		fail(c, apperr.Validation("invalid days parameter"))
+		return
+	}
+
+	resp, err := h.rebalanceSvc.Suggest(c.Request.Context(), days)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetSuggestions GET /api/v1/workload/rebalance?status=pending
+func (h *RebalanceHandler) GetSuggestions(c *gin.Context) {
+	suggestions, err := h.rebalanceSvc.GetSuggestions(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(suggestions), "suggestions": suggestions})
+}
+
+// AcceptSuggestion menerapkan usulan ke ClickUp lalu ke database lokal.
+// POST /api/v1/workload/rebalance/:id/accept
+func (h *RebalanceHandler) AcceptSuggestion(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid suggestion id"))
+		return
+	}
+
+	sug, err := h.rebalanceSvc.Accept(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, sug)
+}
+
+// DismissSuggestion POST /api/v1/workload/rebalance/:id/dismiss
+func (h *RebalanceHandler) DismissSuggestion(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid suggestion id"))
+		return
+	}
+
+	sug, err := h.rebalanceSvc.Dismiss(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, sug)
+}
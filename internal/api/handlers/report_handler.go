@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/api/middleware"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type ReportHandler struct {
+	reportSvc *service.ReportService
+}
+
+func NewReportHandler(reportSvc *service.ReportService) *ReportHandler {
+	return &ReportHandler{reportSvc: reportSvc}
+}
+
+// CreateDefinition menjadwalkan laporan mingguan/bulanan.
+// POST /api/v1/reports/definitions
+// {"name": "Weekly backend workload", "report_type": "workload", "period": "weekly", "audience": {"role": "pm"}, "filters": {"role": "backend"}, "format": "html+csv"}
+func (h *ReportHandler) CreateDefinition(c *gin.Context) {
+	var req model.ReportDefinitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+	d, err := h.reportSvc.CreateDefinition(c.Request.Context(), req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, d)
+}
+
+// GetDefinitions GET /api/v1/reports/definitions
+func (h *ReportHandler) GetDefinitions(c *gin.Context) {
+	defs, err := h.reportSvc.GetDefinitions(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(defs), "definitions": defs})
+}
+
+// GetDefinition GET /api/v1/reports/definitions/:id
+func (h *ReportHandler) GetDefinition(c *gin.Context) {
+	id, ok := reportID(c)
+	if !ok {
+		return
+	}
+	d, err := h.reportSvc.GetDefinition(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, d)
+}
+
+// UpdateDefinition PUT /api/v1/reports/definitions/:id
+func (h *ReportHandler) UpdateDefinition(c *gin.Context) {
+	id, ok := reportID(c)
+	if !ok {
+		return
+	}
+	var req model.ReportDefinitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+	d, err := h.reportSvc.UpdateDefinition(c.Request.Context(), id, req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, d)
+}
+
+// DeleteDefinition DELETE /api/v1/reports/definitions/:id
+func (h *ReportHandler) DeleteDefinition(c *gin.Context) {
+	id, ok := reportID(c)
+	if !ok {
+		return
+	}
+	if err := h.reportSvc.DeleteDefinition(c.Request.Context(), id); err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "report_deleted")})
+}
+
+// RunDefinition mengirim laporan periode terakhir sekarang juga.
+// POST /api/v1/reports/definitions/:id/run
+func (h *ReportHandler) RunDefinition(c *gin.Context) {
+	id, ok := reportID(c)
+	if !ok {
+		return
+	}
+	run, err := h.reportSvc.RunNow(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, run)
+}
+
+// PreviewDefinition menampilkan isi laporan tanpa mengirimnya.
+// GET /api/v1/reports/definitions/:id/preview?format=html|csv|json
+func (h *ReportHandler) PreviewDefinition(c *gin.Context) {
+	id, ok := reportID(c)
+	if !ok {
+		return
+	}
+	d, doc, err := h.reportSvc.Preview(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+
+	switch c.DefaultQuery("format", "json") {
+	case "html":
+		html, err := service.RenderReportHTML(*doc)
+		if err != nil {
+			fail(c, err)
+			return
+		}
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
+	case "csv":
+		data, err := service.RenderReportCSV(*doc)
+		if err != nil {
+			fail(c, err)
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="`+service.ReportFileName(*d, *doc)+`.csv"`)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+	default:
+		c.JSON(http.StatusOK, doc)
+	}
+}
+
+// GetRuns riwayat pengiriman sebuah laporan.
+// GET /api/v1/reports/definitions/:id/runs
+func (h *ReportHandler) GetRuns(c *gin.Context) {
+	id, ok := reportID(c)
+	if !ok {
+		return
+	}
+	runs, err := h.reportSvc.GetRuns(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(runs), "runs": runs})
+}
+
+// GetMemberReport laporan kinerja per member dalam PDF untuk appraisal.
+// GET /api/v1/reports/members/123.pdf?period=2026-09
+// period memakai label KPI ("2026-09" atau "2026-Q3"), default bulan lalu.
+// Anggota hanya boleh mengunduh laporannya sendiri.
+func (h *ReportHandler) GetMemberReport(c *gin.Context) {
+	file := c.Param("file")
+	id, err := strconv.ParseInt(strings.TrimSuffix(file, ".pdf"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid member id"))
+		return
+	}
+	caller, ok := tokenCaller(c)
+	if !ok {
+		return
+	}
+	if _, err := service.ScopeUserID(caller, &id); err != nil {
+		fail(c, err)
+		return
+	}
+	label := c.Query("period")
+	if label == "" {
+		now := time.Now().In(dates.Default())
+		label = time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, now.Location()).Format("2006-01")
+	}
+
+	rep, err := h.reportSvc.BuildMemberReport(c.Request.Context(), id, label)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	data, err := service.RenderMemberReportPDF(*rep, middleware.Lang(c))
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="member-%d-%s.pdf"`, id, label))
+	c.Data(http.StatusOK, "application/pdf", data)
+}
+
+func reportID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid report id"))
+		return 0, false
+	}
+	return id, true
+}
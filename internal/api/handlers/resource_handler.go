@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+	"github.com/roksva123/go-kinerja-backend/internal/resource"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+// ResourceHandler serves API v2. Lists answer {"data": [...], "page": {...}},
+// single resources {"data": {...}}.
+type ResourceHandler struct {
+	resourceSvc *service.ResourceService
+}
+
+func NewResourceHandler(resourceSvc *service.ResourceService) *ResourceHandler {
+	return &ResourceHandler{resourceSvc: resourceSvc}
+}
+
+// resourceView membaca ?include= dan ?tz=. ok=false berarti error sudah
+// diteruskan lewat fail.
+func resourceView(c *gin.Context, includes ...string) (resource.View, bool) {
+	loc, ok := requestLocation(c)
+	if !ok {
+		return resource.View{}, false
+	}
+	inc, err := resource.ParseInclude(c.Query("include"), includes...)
+	if err != nil {
+		fail(c, err)
+		return resource.View{}, false
+	}
+	return resource.View{Include: inc, Location: loc}, true
+}
+
+func writePage(c *gin.Context, data interface{}, page query.Page) {
+	setPageHeaders(c, page)
+	c.JSON(http.StatusOK, gin.H{"data": data, "page": page})
+}
+
+// ListTasks GET /api/v2/tasks?status=done&assignee=123&due_at_from=2026-10-01&include=assignees,project
+func (h *ResourceHandler) ListTasks(c *gin.Context) {
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
+	view, ok := resourceView(c, resource.IncludeAssignees, resource.IncludeProject)
+	if !ok {
+		return
+	}
+	tasks, page, err := h.resourceSvc.ListTasks(c.Request.Context(), params, view)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	writePage(c, tasks, page)
+}
+
+func (h *ResourceHandler) GetTask(c *gin.Context) {
+	view, ok := resourceView(c, resource.IncludeAssignees, resource.IncludeProject)
+	if !ok {
+		return
+	}
+	task, err := h.resourceSvc.GetTask(c.Request.Context(), c.Param("id"), view)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": task})
+}
+
+func (h *ResourceHandler) ListMembers(c *gin.Context) {
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
+	view, ok := resourceView(c)
+	if !ok {
+		return
+	}
+	members, page, err := h.resourceSvc.ListMembers(c.Request.Context(), params, view)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	writePage(c, members, page)
+}
+
+func (h *ResourceHandler) GetMember(c *gin.Context) {
+	view, ok := resourceView(c)
+	if !ok {
+		return
+	}
+	member, err := h.resourceSvc.GetMember(c.Request.Context(), c.Param("id"), view)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": member})
+}
+
+// ListProjects GET /api/v2/projects?level=folder&include_archived=true
+func (h *ResourceHandler) ListProjects(c *gin.Context) {
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
+	view, ok := resourceView(c)
+	if !ok {
+		return
+	}
+	projects, page, err := h.resourceSvc.ListProjects(c.Request.Context(), c.Query("level"), c.Query("include_archived") == "true", params, view)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	writePage(c, projects, page)
+}
+
+func (h *ResourceHandler) GetProject(c *gin.Context) {
+	view, ok := resourceView(c)
+	if !ok {
+		return
+	}
+	project, err := h.resourceSvc.GetProject(c.Request.Context(), c.Param("id"), view)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": project})
+}
+
+// ListWorkload GET /api/v2/workload?start=2026-10-01&end=2026-10-31&include=member
+func (h *ResourceHandler) ListWorkload(c *gin.Context) {
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
+	start, end, ok := dateRangeQuery(c, "start", "end", true)
+	if !ok {
+		return
+	}
+	view, ok := resourceView(c, resource.IncludeMember)
+	if !ok {
+		return
+	}
+	workload, page, err := h.resourceSvc.GetWorkload(c.Request.Context(), start, end, params, view)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	writePage(c, workload, page)
+}
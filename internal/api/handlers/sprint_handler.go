@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type SprintHandler struct {
+	sprintSvc *service.SprintService
+}
+
+func NewSprintHandler(sprintSvc *service.SprintService) *SprintHandler {
+	return &SprintHandler{sprintSvc: sprintSvc}
+}
+
+// CreateSprint mendefinisikan sprint manual dari sebuah list.
+// POST /api/v1/sprints  {"name": "Sprint 12", "list_id": "901", "start_date": "2026-10-05", "end_date": "2026-10-16"}
+func (h *SprintHandler) CreateSprint(c *gin.Context) {
+	var req model.SprintRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	sp, err := h.sprintSvc.CreateSprint(c.Request.Context(), req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, sp)
+}
+
+// DetectSprints mendeteksi sprint dari list bertanggal di folder sprint ClickUp.
+// POST /api/v1/sprints/detect
+func (h *SprintHandler) DetectSprints(c *gin.Context) {
+	n, err := h.sprintSvc.DetectSprints(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"detected": n})
+}
+
+// GetSprints GET /api/v1/sprints
+func (h *SprintHandler) GetSprints(c *gin.Context) {
+	sprints, err := h.sprintSvc.GetSprints(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"count": len(sprints), "sprints": sprints})
+}
+
+// GetVelocity GET /api/v1/sprints/velocity
+func (h *SprintHandler) GetVelocity(c *gin.Context) {
+	v, err := h.sprintSvc.GetVelocity(c.Request.Context())
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v)
+}
+
+// GetSprint GET /api/v1/sprints/:id
+func (h *SprintHandler) GetSprint(c *gin.Context) {
+	id, ok := sprintID(c)
+	if !ok {
+		return
+	}
+	sp, err := h.sprintSvc.GetSprint(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, sp)
+}
+
+// UpdateSprint PUT /api/v1/sprints/:id
+func (h *SprintHandler) UpdateSprint(c *gin.Context) {
+	id, ok := sprintID(c)
+	if !ok {
+		return
+	}
+	var req model.SprintRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		fail(c, apperr.Validation("invalid request: "+err.Error()))
+		return
+	}
+
+	sp, err := h.sprintSvc.UpdateSprint(c.Request.Context(), id, req)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, sp)
+}
+
+// DeleteSprint DELETE /api/v1/sprints/:id
+func (h *SprintHandler) DeleteSprint(c *gin.Context) {
+	id, ok := sprintID(c)
+	if !ok {
+		return
+	}
+	if err := h.sprintSvc.DeleteSprint(c.Request.Context(), id); err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "sprint_deleted")})
+}
+
+// GetReport burndown, burnup dan scope change sprint.
+// GET /api/v1/sprints/:id/report
+func (h *SprintHandler) GetReport(c *gin.Context) {
+	id, ok := sprintID(c)
+	if !ok {
+		return
+	}
+	report, err := h.sprintSvc.GetReport(c.Request.Context(), id)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func sprintID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		fail(c, apperr.Validation("invalid sprint id"))
+		return 0, false
+	}
+	return id, true
+}
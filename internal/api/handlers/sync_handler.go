@@ -6,11 +6,11 @@ import (
 	"io"
 	"log"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
 	"github.com/roksva123/go-kinerja-backend/internal/repository"
 )
 
@@ -26,16 +26,18 @@ type IClickUpService interface {
 	SyncSpacesAndFolders(ctx context.Context) error
 	GetLists(ctx context.Context) ([]model.List, error)
 	GetFolders(ctx context.Context) ([]model.Folder, error)
+	GetListsPage(ctx context.Context, p query.Params) ([]model.List, query.Page, error)
+	GetFoldersPage(ctx context.Context, p query.Params) ([]model.Folder, query.Page, error)
 	AllSync(ctx context.Context) error
 	AllSyncWithProgress(ctx context.Context, progressChan chan<- string) error
 }
 
 type SyncHandler struct {
 	ClickUpService IClickUpService
-	Repo           *repository.PostgresRepo
+	Repo           repository.SyncHistoryStore
 }
 
-func NewSyncHandler(s IClickUpService, r *repository.PostgresRepo) *SyncHandler {
+func NewSyncHandler(s IClickUpService, r repository.SyncHistoryStore) *SyncHandler {
 	return &SyncHandler{
 		ClickUpService: s,
 		Repo:           r,
@@ -47,56 +49,68 @@ func (h *SyncHandler) SyncSpacesFoldersAndListsHandler(c *gin.Context) {
 	err := h.ClickUpService.SyncSpacesAndFolders(c.Request.Context())
 	if err != nil {
 		log.Printf("ERROR from SyncSpacesAndFolders service: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Sync for spaces, folders, and lists completed successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": message(c, "structure_synced")})
 	log.Println("--- API TRIGGER: Sync finished successfully ---")
 }
 
+// GetListsHandler GET /api/v1/sync/lists?project=Kinerja&q=sprint&sort=name&limit=50
+// Total dan cursor berikutnya ada di header X-Total-Count / X-Next-Cursor.
 func (h *SyncHandler) GetListsHandler(c *gin.Context) {
-	lists, err := h.ClickUpService.GetLists(c.Request.Context())
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
+	lists, page, err := h.ClickUpService.GetListsPage(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
 
+	setPageHeaders(c, page)
 	c.JSON(http.StatusOK, lists)
 }
 
+// GetFoldersHandler GET /api/v1/sync/folders?q=kinerja&sort=space,name
 func (h *SyncHandler) GetFoldersHandler(c *gin.Context) {
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
 	log.Println("--- API TRIGGER: Syncing Spaces, Folders, and Lists before getting folders ---")
 	err := h.ClickUpService.SyncSpacesAndFolders(c.Request.Context())
 	if err != nil {
 		log.Printf("ERROR from SyncSpacesAndFolders service during GetFolders: %v", err)
 
 	}
-	folders, err := h.ClickUpService.GetFolders(c.Request.Context())
+	folders, page, err := h.ClickUpService.GetFoldersPage(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
 
+	setPageHeaders(c, page)
 	c.JSON(http.StatusOK, folders)
 }
 
 // GetSyncHistory mengambil riwayat sinkronisasi dari database.
-// GET /api/v1/sync/history
+// GET /api/v1/sync/history?status=failed&sync_time_from=2026-10-01&limit=20
 func (h *SyncHandler) GetSyncHistory(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "20")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+	params, ok := listParams(c)
+	if !ok {
 		return
 	}
 
-	history, err := h.Repo.GetSyncHistory(c.Request.Context(), limit)
+	history, page, err := h.Repo.GetSyncHistoryPage(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get sync history"})
+		fail(c, err)
 		return
 	}
 
+	setPageHeaders(c, page)
 	c.JSON(http.StatusOK, history)
 }
 
@@ -143,7 +157,7 @@ func (h *SyncHandler) TriggerSyncAll(c *gin.Context) {
 	}()
 
 	// Langsung berikan respons ke client bahwa proses telah dimulai
-	c.JSON(http.StatusAccepted, gin.H{"message": "Full sync process has been started in the background."})
+	c.JSON(http.StatusAccepted, gin.H{"message": message(c, "full_sync_started")})
 }
 
 // StreamSyncAll memulai sinkronisasi dan mengalirkan progresnya menggunakan Server-Sent Events (SSE).
@@ -8,6 +8,10 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/api/middleware"
+	"github.com/roksva123/go-kinerja-backend/internal/export"
+	"github.com/roksva123/go-kinerja-backend/internal/i18n"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
 	"github.com/roksva123/go-kinerja-backend/internal/service"
 	// "github.com/roksva123/go-kinerja-backend/internal/model"
 )
@@ -24,72 +28,89 @@ func NewWorkloadHandler(workloadSvc *service.WorkloadService, clickupSvc *servic
 	}
 }
 
+// GetTasksSummary GET /api/v1/workload/summary?start_date=2026-10-01&end_date=2026-10-31&tz=Asia/Jakarta&format=csv|xlsx
 func (h *WorkloadHandler) GetTasksSummary(c *gin.Context) {
-	startDateStr := c.Query("start_date")
-	endDateStr := c.Query("end_date")
-	name := c.Query("name")
-	email := c.Query("email")
-
-	if startDateStr == "" || endDateStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date and end_date are required"})
+	format, ok := exportFormat(c)
+	if !ok {
 		return
 	}
-
-	layout := "02-01-2006"
-	startDate, err := time.Parse(layout, startDateStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format. Use DD-MM-YYYY"})
+	params, ok := listParams(c)
+	if !ok {
 		return
 	}
-
-	endDate, err := time.Parse(layout, endDateStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format. Use DD-MM-YYYY"})
+	startDate, endDate, ok := dateRangeQuery(c, "start_date", "end_date", true)
+	if !ok {
 		return
 	}
+	name := c.Query("name")
+	email := c.Query("email")
 
 	summary, err := h.workloadSvc.GetTasksSummary(c.Request.Context(), startDate, endDate, name, email)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
 
+	if format != export.FormatJSON {
+		params.Limit, params.Offset = query.NoLimit, 0
+	}
+	summary, page, err := query.Apply(summary, params, taskSummaryListSpec)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	if format != export.FormatJSON {
+		writeExport(c, format, "tasks-summary-"+startDate.Format("2006-01-02")+"_"+endDate.Format("2006-01-02"), len(summary), taskSummarySheet(summary))
+		return
+	}
+	setPageHeaders(c, page)
 	c.JSON(http.StatusOK, summary)
 }
 
+// GetWorkload GET /api/v1/workload/workload?start=2026-10-01&end=2026-10-31&tz=Asia/Jakarta&format=csv|xlsx
 func (h *WorkloadHandler) GetWorkload(c *gin.Context) {
-	startStr := c.Query("start")
-	endStr := c.Query("end")
+	format, ok := exportFormat(c)
+	if !ok {
+		return
+	}
+	params, ok := listParams(c)
+	if !ok {
+		return
+	}
+	start, end, ok := dateRangeQuery(c, "start", "end", true)
+	if !ok {
+		return
+	}
 	username := c.Query("username")
 
-
-	layout := "2006-01-02" 
-	start, err := time.Parse(layout, startStr)
+	users, err := h.workloadSvc.GetWorkload(c.Request.Context(), start, end, username)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start date format, use DD-MM-YYYY", "details": err.Error()})
+		fail(c, err)
 		return
 	}
-	end, err := time.Parse(layout, endStr)
+	if format != export.FormatJSON {
+		params.Limit, params.Offset = query.NoLimit, 0
+	}
+	users, page, err := query.Apply(users, params, workloadListSpec)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end date format, use DD-MM-YYYY", "details": err.Error()})
+		fail(c, err)
 		return
 	}
-
-	users, err := h.workloadSvc.GetWorkload(c.Request.Context(), start, end, username)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if format != export.FormatJSON {
+		writeExport(c, format, "workload-"+start.Format("2006-01-02")+"_"+end.Format("2006-01-02"), workloadRows(users), workloadSheets(users)...)
 		return
 	}
+	setPageHeaders(c, page)
 	c.JSON(http.StatusOK, users)
 }
 
 func (h *WorkloadHandler) SyncAll(c *gin.Context) {
 	err := h.workloadSvc.SyncAll(c.Request.Context())
 	if err != nil {
-		c.JSON(500, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
-	c.JSON(200, gin.H{"message": "workload synced"})
+	c.JSON(200, gin.H{"message": message(c, "workload_synced")})
 }
 
 const responseDateFormat = "02-01-2006"
@@ -112,37 +133,21 @@ func formatEfficiency(val *float64) *string {
 	return &s
 }
 
-// formatRemainingHours formats hours into a "D hari, H jam" string.
-func formatRemainingHours(hours *float64) *string {
+// formatRemainingHours formats hours as calendar days, e.g. "1 hari, 3 jam".
+func formatRemainingHours(lang string, hours *float64) *string {
 	if hours == nil {
 		return nil
 	}
-	h := *hours
-	sign := ""
-	if h < 0 {
-		sign = "-"
-		h = -h
-	}
-	days := int(h / 24)
-	remainingHours := int(math.Mod(h, 24))
-	s := fmt.Sprintf("%s%d hari, %d jam", sign, days, remainingHours)
+	s := i18n.Duration(lang, *hours, i18n.CalendarDayHours)
 	return &s
 }
 
-// formatWorkHours 
-func formatWorkHours(hours *float64) *string {
+// formatWorkHours formats hours as 8-hour working days.
+func formatWorkHours(lang string, hours *float64) *string {
 	if hours == nil {
 		return nil
 	}
-	h := *hours
-	sign := ""
-	if h < 0 {
-		sign = "-"
-		h = -h
-	}
-	days := int(h / 8)
-	remainingHours := int(math.Mod(h, 8))
-	s := fmt.Sprintf("%s%d hari, %d jam", sign, days, remainingHours)
+	s := i18n.Duration(lang, *hours, i18n.WorkDayHours)
 	return &s
 }
 
@@ -150,29 +155,35 @@ func (h *WorkloadHandler) AllSync(c *gin.Context) {
 	h.SyncAll(c)
 }
 
+// GetTasksByRange GET /api/v1/workload/tasks-by-range?start_date=2026-10-01&end_date=2026-10-31&tz=Asia/Jakarta&format=csv|xlsx
 func (h *WorkloadHandler) GetTasksByRange(c *gin.Context) {
-	startStr := c.Query("start_date")
-	endStr := c.Query("end_date")
-
-	layout := "02-01-2006"
-	startDate, err := time.Parse(layout, startStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid start_date format, use DD-MM-YYYY"})
+	format, ok := exportFormat(c)
+	if !ok {
 		return
 	}
-	endDate, err := time.Parse(layout, endStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid end_date format, use DD-MM-YYYY"})
+	startDate, endDate, ok := dateRangeQuery(c, "start_date", "end_date", true)
+	if !ok {
 		return
 	}
 
+	// sort=asc|desc adalah parameter lama endpoint ini, bukan field sort.
 	sortOrder := c.DefaultQuery("sort", "desc")
+	values := c.Request.URL.Query()
+	if s := strings.ToLower(values.Get("sort")); s == "asc" || s == "desc" {
+		values.Del("sort")
+	}
+	params, err := query.Parse(values)
+	if err != nil {
+		fail(c, err)
+		return
+	}
 
 	originalResponse, err := h.workloadSvc.GetTasksByRangeGrouped(c.Request.Context(), startDate, endDate, sortOrder)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		fail(c, err)
 		return
 	}
+	lang := middleware.Lang(c)
 
 	responseAssignees := make([]AssigneeWithTasks, len(originalResponse.Assignees))
 	for i, originalAssignee := range originalResponse.Assignees {
@@ -256,9 +267,11 @@ func (h *WorkloadHandler) GetTasksByRange(c *gin.Context) {
 				DateDone:          formatTimePtr(originalTask.DateDone),
 				DateClosed:        formatTimePtr(originalTask.DateClosed),
 				TimeEfficiencyPercentage:   timeEfficiency,
-				RemainingTimeFormatted:   formatWorkHours(remainingHours), 
-				ActualDurationFormatted:  formatWorkHours(actualDuration), 
+				RemainingTimeFormatted:   formatWorkHours(lang, remainingHours), 
+				ActualDurationFormatted:  formatWorkHours(lang, actualDuration), 
 				ScheduleStatus:             scheduleStatus,
+				ScheduleStatusLabel:        scheduleLabel(lang, scheduleStatus),
+				StatusLabel:                i18n.StatusLabel(lang, service.NormalizeStatus(originalTask.StatusName)),
 			}
 		}
 
@@ -297,9 +310,24 @@ func (h *WorkloadHandler) GetTasksByRange(c *gin.Context) {
 		}
 	}
 
+	if format != export.FormatJSON {
+		params.Limit, params.Offset = query.NoLimit, 0
+	}
+	responseAssignees, page, err := query.Apply(responseAssignees, params, assigneeListSpec)
+	if err != nil {
+		fail(c, err)
+		return
+	}
+	if format != export.FormatJSON {
+		writeExport(c, format, "tasks-"+startDate.Format("2006-01-02")+"_"+endDate.Format("2006-01-02"), tasksByRangeRows(responseAssignees), tasksByRangeSheets(responseAssignees)...)
+		return
+	}
+
+	setPageHeaders(c, page)
 	response := gin.H{
-		"count":     originalResponse.Count,
+		"count":     len(responseAssignees),
 		"assignees": responseAssignees,
+		"page":      page,
 	}
 
 	c.JSON(http.StatusOK, response)
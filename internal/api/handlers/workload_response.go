@@ -21,6 +21,8 @@ type TaskInResponse struct {
 	RemainingTimeFormatted   *string  `json:"remaining_time,omitempty"`
 	ActualDurationFormatted  *string  `json:"actual_duration,omitempty"`
 	ScheduleStatus           *string  `json:"schedule_status,omitempty"`
+	ScheduleStatusLabel      *string  `json:"schedule_status_label,omitempty"`
+	StatusLabel              string   `json:"status_label"`
 }
 
 type AssigneeWithTasks struct {
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+)
+
+// Errors renders the last error a handler passed to c.Error as the standard
+// envelope (see package apperr). Internal errors are logged, not exposed.
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		e := apperr.From(c.Errors.Last().Err)
+		if e.Code == apperr.CodeInternal {
+			log.Printf("ERROR %s %s: %v", c.Request.Method, c.Request.URL.Path, e.Err)
+		}
+		status := e.Status
+		if status == 0 {
+			status = apperr.New(e.Code, "").Status
+		}
+		c.JSON(status, e.Render(Lang(c)))
+	}
+}
+
+// NoRoute answers unknown routes with the not_found envelope.
+func NoRoute(c *gin.Context) {
+	_ = c.Error(apperr.NotFound("no route for " + c.Request.Method + " " + c.Request.URL.Path))
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+)
+
+var errBudgetMissing = apperr.NotFound("budget not found")
+
+func TestErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		err     error
+		lang    string
+		status  int
+		code    apperr.Code
+		message string
+		details interface{}
+	}{
+		{"validation", apperr.Validation("limit must be positive"), "", http.StatusBadRequest, apperr.CodeValidationFailed,
+			"The request is invalid.", "limit must be positive"},
+		{"wrapped sentinel keeps its code", fmt.Errorf("%w: id 7", errBudgetMissing), "", http.StatusNotFound, apperr.CodeNotFound,
+			"The resource was not found.", "budget not found: id 7"},
+		{"explicit details", apperr.Validation("bad").WithDetails(map[string]interface{}{"field": "limit"}), "", http.StatusBadRequest,
+			apperr.CodeValidationFailed, "The request is invalid.", map[string]interface{}{"field": "limit"}},
+		{"status filled from the code", &apperr.Error{Code: apperr.CodeConflict, Message: "sprint closed"}, "", http.StatusConflict,
+			apperr.CodeConflict, "The resource state does not allow this action.", "sprint closed"},
+		{"plain errors stay internal", errors.New("pq: connection refused"), "", http.StatusInternalServerError, apperr.CodeInternal,
+			"An unexpected error occurred.", nil},
+		{"localized message", apperr.New(apperr.CodeForbidden, "admins only"), "id", http.StatusForbidden, apperr.CodeForbidden,
+			"Anda tidak diizinkan melakukan ini.", "admins only"},
+		{"upstream", apperr.Wrap(apperr.CodeClickUpUnavailable, errors.New("timeout")), "", http.StatusServiceUnavailable,
+			apperr.CodeClickUpUnavailable, "ClickUp is unavailable, try again later.", "clickup_unavailable: timeout"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(Language(""), Errors())
+			r.GET("/x", func(c *gin.Context) { _ = c.Error(tt.err) })
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/x?lang="+tt.lang, nil))
+
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			var body struct {
+				Error struct {
+					Code    apperr.Code `json:"code"`
+					Message string      `json:"message"`
+					Details interface{} `json:"details"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %s: %v", w.Body, err)
+			}
+			if body.Error.Code != tt.code || body.Error.Message != tt.message {
+				t.Errorf("error = %+v, want %s %q", body.Error, tt.code, tt.message)
+			}
+			if fmt.Sprint(body.Error.Details) != fmt.Sprint(tt.details) {
+				t.Errorf("details = %#v, want %#v", body.Error.Details, tt.details)
+			}
+		})
+	}
+}
+
+// A handler that already answered keeps its response even if it also
+// recorded an error.
+func TestErrorsLeavesWrittenResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Errors())
+	r.GET("/x", func(c *gin.Context) {
+		c.String(http.StatusAccepted, "queued")
+		_ = c.Error(errors.New("late failure"))
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/x", nil))
+	if w.Code != http.StatusAccepted || w.Body.String() != "queued" {
+		t.Errorf("got %d %q, want the handler's response", w.Code, w.Body)
+	}
+}
+
+func TestNoRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Errors())
+	r.NoRoute(NoRoute)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/nope", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", w.Code)
+	}
+	var body apperr.Body
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Error.Code != apperr.CodeNotFound || body.Error.Details != "no route for DELETE /api/nope" {
+		t.Errorf("error = %+v", body.Error)
+	}
+}
@@ -1,17 +1,16 @@
 package middleware
 
 import (
-    "net/http"
-
     "github.com/gin-gonic/gin"
     "github.com/golang-jwt/jwt/v5"
+    "github.com/roksva123/go-kinerja-backend/internal/apperr"
 )
 
 func Auth(jwtKey string) gin.HandlerFunc {
     return func(c *gin.Context) {
         tokenStr := c.GetHeader("Authorization")
         if tokenStr == "" {
-            c.JSON(http.StatusUnauthorized, gin.H{"error": "missing token"})
+            _ = c.Error(apperr.New(apperr.CodeUnauthorized, "missing token"))
             c.Abort()
             return
         }
@@ -21,7 +20,7 @@ func Auth(jwtKey string) gin.HandlerFunc {
         })
 
         if err != nil || !token.Valid {
-            c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+            _ = c.Error(apperr.New(apperr.CodeUnauthorized, "invalid token"))
             c.Abort()
             return
         }
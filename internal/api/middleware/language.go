@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/roksva123/go-kinerja-backend/internal/i18n"
+)
+
+const langKey = "lang"
+
+// Language resolves the response language once per request: ?lang= first,
+// then the "lang" claim of a valid bearer token (the admin's saved
+// preference), then Accept-Language. Handlers read it with Lang.
+func Language(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(langKey, i18n.Negotiate(c.GetHeader("Accept-Language"), c.Query("lang"), tokenLang(c, secret)))
+		c.Next()
+	}
+}
+
+// Lang returns the language chosen by Language, or negotiates it from the
+// request when the middleware did not run.
+func Lang(c *gin.Context) string {
+	if v, ok := c.Get(langKey); ok {
+		if lang, ok := v.(string); ok {
+			return lang
+		}
+	}
+	return i18n.Negotiate(c.GetHeader("Accept-Language"), c.Query("lang"))
+}
+
+func tokenLang(c *gin.Context, secret string) string {
+	raw := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+	if raw == "" || secret == "" {
+		return ""
+	}
+	token, err := jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	if err != nil || !token.Valid {
+		return ""
+	}
+	if claims, ok := token.Claims.(jwt.MapClaims); ok {
+		if lang, ok := claims["lang"].(string); ok {
+			return lang
+		}
+	}
+	return ""
+}
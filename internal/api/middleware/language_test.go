@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestLanguage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sign := func(secret, lang string) string {
+		s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "admin", "lang": lang}).SignedString([]byte(secret))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + s
+	}
+	tests := []struct {
+		name, query, auth, accept, want string
+	}{
+		{"default", "", "", "", "en"},
+		{"accept-language", "", "", "id-ID,en;q=0.5", "id"},
+		{"claim over accept-language", "", sign("secret", "id"), "en", "id"},
+		{"query over claim", "?lang=en", sign("secret", "id"), "id", "en"},
+		{"query over accept-language", "?lang=id", "", "en", "id"},
+		{"unsupported query falls through", "?lang=fr", sign("secret", "id"), "en", "id"},
+		// a token signed with another key must not choose the language
+		{"forged claim ignored", "", sign("other", "id"), "en", "en"},
+		{"malformed token ignored", "", "Bearer nope", "id", "id"},
+	}
+	for _, tt := range tests {
+		r := gin.New()
+		r.Use(Language("secret"))
+		r.GET("/x", func(c *gin.Context) { c.String(http.StatusOK, Lang(c)) })
+
+		req := httptest.NewRequest(http.MethodGet, "/x"+tt.query, nil)
+		if tt.auth != "" {
+			req.Header.Set("Authorization", tt.auth)
+		}
+		if tt.accept != "" {
+			req.Header.Set("Accept-Language", tt.accept)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if got := w.Body.String(); got != tt.want {
+			t.Errorf("%s: lang = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Without the middleware, Lang still honours ?lang= and Accept-Language.
+func TestLangWithoutMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/x", func(c *gin.Context) { c.String(http.StatusOK, Lang(c)) })
+
+	req := httptest.NewRequest(http.MethodGet, "/x", nil)
+	req.Header.Set("Accept-Language", "id")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Body.String() != "id" {
+		t.Errorf("lang = %q, want id", w.Body)
+	}
+}
@@ -0,0 +1,230 @@
+// Package api wires the HTTP routes. The route table lives here rather than
+// in cmd/server so tests can build it and compare it with the OpenAPI spec.
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/roksva123/go-kinerja-backend/internal/api/handlers"
+	"github.com/roksva123/go-kinerja-backend/internal/api/middleware"
+	jwtmw "github.com/roksva123/go-kinerja-backend/internal/middleware"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/openapi"
+)
+
+// Handlers groups every handler the router needs.
+type Handlers struct {
+	ClickUp      *handlers.ClickUpHandler
+	Workload     *handlers.WorkloadHandler
+	Sync         *handlers.SyncHandler
+	Auth         *handlers.AuthHandler
+	Rebalance    *handlers.RebalanceHandler
+	KPI          *handlers.KPIHandler
+	Appraisal    *handlers.AppraisalHandler
+	OKR          *handlers.OKRHandler
+	Project      *handlers.ProjectHandler
+	Budget       *handlers.BudgetHandler
+	Sprint       *handlers.SprintHandler
+	Estimate     *handlers.EstimateHandler
+	Alert        *handlers.AlertHandler
+	Notification *handlers.NotificationHandler
+	Report       *handlers.ReportHandler
+	Resource     *handlers.ResourceHandler
+	GraphQL      *handlers.GraphQLHandler
+}
+
+// Register installs the API middleware and routes on r.
+func Register(r *gin.Engine, h Handlers, jwtSecret string) {
+	r.Use(middleware.Errors())
+	r.Use(middleware.Language(jwtSecret))
+	r.NoRoute(middleware.NoRoute)
+
+	r.Static("/images", "public/images")
+	r.GET("/api/openapi.json", openapi.ServeJSON)
+	r.GET("/api/docs", openapi.ServeUI)
+	r.GET("/api/graphql", jwtmw.JWTAuthMiddleware(jwtSecret), h.GraphQL.Query)
+	r.POST("/api/graphql", jwtmw.JWTAuthMiddleware(jwtSecret), h.GraphQL.Query)
+	v1 := r.Group("/api/v1")
+	v1.GET("/errors", handlers.GetErrorCatalogue)
+
+	// CLICKUP ROUTES
+	clickup := v1.Group("/clickup")
+	{
+		clickup.POST("/sync/team", h.ClickUp.SyncTeam)
+		clickup.POST("/sync/members", h.ClickUp.SyncMembers)
+		clickup.POST("/sync/tasks", h.ClickUp.SyncTasks)
+		clickup.POST("/sync/all", h.ClickUp.SyncAll)
+
+		clickup.GET("/spaces", h.ClickUp.GetSpaces)
+		clickup.GET("/members", h.ClickUp.GetMembers)
+		clickup.GET("/tasks", h.ClickUp.GetTasks)
+		clickup.GET("/fullsync", h.ClickUp.FullSync)
+		clickup.GET("/fullsync/filter", h.ClickUp.GetFullSyncFiltered)
+		clickup.GET("/data", h.ClickUp.GetFullData)
+	}
+
+	sync := v1.Group("/sync")
+	{
+		sync.POST("/spaces-folders-lists", h.Sync.SyncSpacesFoldersAndListsHandler)
+		sync.GET("/lists", h.Sync.GetListsHandler)
+		sync.GET("/folders", h.Sync.GetFoldersHandler)
+		sync.POST("/all", h.Sync.TriggerSyncAll)
+		sync.GET("/history", h.Sync.GetSyncHistory)
+		sync.GET("/all/stream", h.Sync.StreamSyncAll) // Endpoint baru untuk streaming
+	}
+
+	work := v1.Group("/workload")
+	{
+		work.POST("/sync", h.Workload.SyncAll)
+		work.GET("/workload", h.Workload.GetWorkload)
+		work.GET("/tasks-by-range", h.Workload.GetTasksByRange)
+		work.GET("/summary", h.Workload.GetTasksSummary)
+		work.GET("", h.Workload.GetWorkload)
+
+		// Menerima saran me-reassign task di ClickUp, jadi hanya manager
+		// dan admin yang boleh membuat dan memutuskan saran.
+		rebalance := []gin.HandlerFunc{jwtmw.JWTAuthMiddleware(jwtSecret), jwtmw.RequireRole(model.RoleAdmin, model.RoleManager)}
+		work.POST("/rebalance", append(rebalance, h.Rebalance.GenerateSuggestions)...)
+		work.GET("/rebalance", h.Rebalance.GetSuggestions)
+		work.POST("/rebalance/:id/accept", append(rebalance, h.Rebalance.AcceptSuggestion)...)
+		work.POST("/rebalance/:id/dismiss", append(rebalance, h.Rebalance.DismissSuggestion)...)
+	}
+
+	kpi := v1.Group("/kpi", jwtmw.JWTAuthMiddleware(jwtSecret))
+	{
+		kpi.GET("/indicators", h.KPI.GetIndicators)
+		kpi.PUT("/indicators/:code", jwtmw.RequireRole(model.RoleAdmin), h.KPI.UpdateIndicator)
+		kpi.GET("/weights", h.KPI.GetRoleWeights)
+		kpi.PUT("/weights/:role", jwtmw.RequireRole(model.RoleAdmin), h.KPI.SetRoleWeights)
+		kpi.POST("/scorecards/compute", jwtmw.RequireRole(model.RoleAdmin), h.KPI.ComputeScorecards)
+		kpi.GET("/scorecards", h.KPI.GetScorecards)
+		kpi.GET("/scorecards/:id", h.KPI.GetScorecard)
+		kpi.GET("/scorecards/:id/indicators/:code/tasks", h.KPI.GetIndicatorTasks)
+	}
+
+	appraisals := v1.Group("/appraisals", jwtmw.JWTAuthMiddleware(jwtSecret))
+	{
+		appraisals.POST("", jwtmw.RequireRole(model.RoleAdmin), h.Appraisal.CreatePeriod)
+		appraisals.GET("", h.Appraisal.GetPeriods)
+		appraisals.GET("/:id", h.Appraisal.GetPeriod)
+		appraisals.POST("/:id/compute", jwtmw.RequireRole(model.RoleAdmin), h.Appraisal.Compute)
+		appraisals.POST("/:id/lock", jwtmw.RequireRole(model.RoleAdmin), h.Appraisal.Lock)
+		appraisals.POST("/:id/reopen", jwtmw.RequireRole(model.RoleAdmin), h.Appraisal.Reopen)
+		appraisals.GET("/:id/scorecards", h.Appraisal.GetScorecards)
+		appraisals.GET("/:id/tasks", h.Appraisal.GetTasks)
+		appraisals.GET("/:id/audit", jwtmw.RequireRole(model.RoleAdmin, model.RoleManager), h.Appraisal.GetAuditLog)
+		appraisals.GET("/:id/reviews", h.Appraisal.GetReviews)
+		appraisals.PUT("/:id/reviews/:user_id", h.Appraisal.SaveReview)
+		appraisals.POST("/:id/reviews/:user_id/acknowledge", h.Appraisal.Acknowledge)
+	}
+
+	okrs := v1.Group("/okrs")
+	okrWrites := okrs.Group("", jwtmw.JWTAuthMiddleware(jwtSecret), jwtmw.RequireRole(model.RoleAdmin))
+	{
+		okrWrites.POST("/objectives", h.OKR.CreateObjective)
+		okrs.GET("/objectives", h.OKR.GetObjectives)
+		okrs.GET("/objectives/:id", h.OKR.GetObjective)
+		okrWrites.PUT("/objectives/:id", h.OKR.UpdateObjective)
+		okrWrites.DELETE("/objectives/:id", h.OKR.DeleteObjective)
+		okrWrites.POST("/objectives/:id/key-results", h.OKR.CreateKeyResult)
+		okrs.GET("/key-results/:id", h.OKR.GetKeyResult)
+		okrWrites.PUT("/key-results/:id", h.OKR.UpdateKeyResult)
+		okrWrites.DELETE("/key-results/:id", h.OKR.DeleteKeyResult)
+		okrs.GET("/key-results/:id/tasks", h.OKR.GetKeyResultTasks)
+	}
+
+	projects := v1.Group("/projects")
+	{
+		projects.GET("", h.Project.GetProjects)
+		projects.GET("/:level/:id", h.Project.GetProject)
+	}
+
+	budgets := v1.Group("/budgets", jwtmw.JWTAuthMiddleware(jwtSecret), jwtmw.RequireRole(model.RoleAdmin))
+	{
+		budgets.POST("", h.Budget.SetBudget)
+		budgets.GET("", h.Budget.GetBudgets)
+		budgets.GET("/:id", h.Budget.GetBudget)
+		budgets.PUT("/:id", h.Budget.UpdateBudget)
+		budgets.DELETE("/:id", h.Budget.DeleteBudget)
+	}
+
+	sprints := v1.Group("/sprints")
+	sprintWrites := sprints.Group("", jwtmw.JWTAuthMiddleware(jwtSecret), jwtmw.RequireRole(model.RoleAdmin))
+	{
+		sprintWrites.POST("", h.Sprint.CreateSprint)
+		sprints.GET("", h.Sprint.GetSprints)
+		sprintWrites.POST("/detect", h.Sprint.DetectSprints)
+		sprints.GET("/velocity", h.Sprint.GetVelocity)
+		sprints.GET("/:id", h.Sprint.GetSprint)
+		sprintWrites.PUT("/:id", h.Sprint.UpdateSprint)
+		sprintWrites.DELETE("/:id", h.Sprint.DeleteSprint)
+		sprints.GET("/:id/report", h.Sprint.GetReport)
+	}
+
+	estimates := v1.Group("/estimates")
+	estimateWrites := estimates.Group("", jwtmw.JWTAuthMiddleware(jwtSecret), jwtmw.RequireRole(model.RoleAdmin))
+	{
+		estimates.GET("/accuracy", h.Estimate.GetAccuracy)
+		estimateWrites.PUT("/tasks/:id", h.Estimate.SetManualEstimate)
+	}
+
+	alerts := v1.Group("/alerts")
+	alertWrites := alerts.Group("", jwtmw.JWTAuthMiddleware(jwtSecret), jwtmw.RequireRole(model.RoleAdmin))
+	{
+		alerts.GET("", h.Alert.GetAlerts)
+		alerts.POST("/evaluate", h.Alert.EvaluateAlerts)
+		alerts.GET("/rules", h.Alert.GetRules)
+		alertWrites.PUT("/rules/:code", h.Alert.UpdateRule)
+		alerts.GET("/:id", h.Alert.GetAlert)
+		alerts.POST("/:id/acknowledge", h.Alert.AcknowledgeAlert)
+		alerts.POST("/:id/resolve", h.Alert.ResolveAlert)
+	}
+
+	notifications := v1.Group("/notifications", jwtmw.JWTAuthMiddleware(jwtSecret), jwtmw.RequireRole(model.RoleAdmin))
+	{
+		notifications.POST("/subscriptions", h.Notification.CreateSubscription)
+		notifications.GET("/subscriptions", h.Notification.GetSubscriptions)
+		notifications.GET("/subscriptions/:id", h.Notification.GetSubscription)
+		notifications.PUT("/subscriptions/:id", h.Notification.UpdateSubscription)
+		notifications.DELETE("/subscriptions/:id", h.Notification.DeleteSubscription)
+		notifications.GET("/deliveries", h.Notification.GetDeliveries)
+		notifications.POST("/deliveries/:id/retry", h.Notification.RetryDelivery)
+		notifications.POST("/test", h.Notification.SendTest)
+	}
+
+	// Definisi laporan memuat data seluruh tim; PDF per anggota dibatasi di
+	// handler ke anggota itu sendiri.
+	reports := v1.Group("/reports", jwtmw.JWTAuthMiddleware(jwtSecret))
+	definitions := reports.Group("/definitions", jwtmw.RequireRole(model.RoleAdmin, model.RoleManager))
+	{
+		definitions.POST("", h.Report.CreateDefinition)
+		definitions.GET("", h.Report.GetDefinitions)
+		definitions.GET("/:id", h.Report.GetDefinition)
+		definitions.PUT("/:id", h.Report.UpdateDefinition)
+		definitions.DELETE("/:id", h.Report.DeleteDefinition)
+		definitions.POST("/:id/run", h.Report.RunDefinition)
+		definitions.GET("/:id/preview", h.Report.PreviewDefinition)
+		definitions.GET("/:id/runs", h.Report.GetRuns)
+		reports.GET("/members/:file", h.Report.GetMemberReport)
+	}
+
+	// AUTH ROUTES
+	auth := v1.Group("/auth")
+	{
+		auth.POST("/login", h.Auth.Login)
+		auth.PUT("/language", jwtmw.JWTAuthMiddleware(jwtSecret), h.Auth.SetLanguage)
+		auth.PUT("/accounts/:username", jwtmw.JWTAuthMiddleware(jwtSecret), jwtmw.RequireRole(model.RoleAdmin), h.Auth.SaveAccount)
+	}
+
+	// API v2: resource kanonik (lihat package resource). v1 tetap dilayani
+	// sampai semua klien pindah.
+	v2 := r.Group("/api/v2")
+	{
+		v2.GET("/tasks", h.Resource.ListTasks)
+		v2.GET("/tasks/:id", h.Resource.GetTask)
+		v2.GET("/members", h.Resource.ListMembers)
+		v2.GET("/members/:id", h.Resource.GetMember)
+		v2.GET("/projects", h.Resource.ListProjects)
+		v2.GET("/projects/:id", h.Resource.GetProject)
+		v2.GET("/workload", h.Resource.ListWorkload)
+	}
+}
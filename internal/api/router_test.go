@@ -0,0 +1,226 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/roksva123/go-kinerja-backend/internal/openapi"
+)
+
+// TestRoutesDocumented fails when a route is registered without an entry in
+// openapi.Operations, or documented without being registered.
+func TestRoutesDocumented(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	Register(r, Handlers{}, "secret")
+
+	var registered []string
+	for _, rt := range r.Routes() {
+		if !strings.HasPrefix(rt.Path, "/api/") {
+			continue // static files
+		}
+		registered = append(registered, rt.Method+" "+rt.Path)
+	}
+	sort.Strings(registered)
+
+	documented := map[string]bool{}
+	for _, route := range openapi.Routes() {
+		documented[route] = true
+	}
+	for _, route := range registered {
+		if !documented[route] {
+			t.Errorf("route %s is not in openapi.Operations", route)
+		}
+		delete(documented, route)
+	}
+	for route := range documented {
+		t.Errorf("openapi.Operations documents %s, which is not registered", route)
+	}
+}
+
+// guardCase is a request rejected by the middleware before any handler
+// runs; Register gets nil handlers, so reaching one would panic.
+type guardCase struct {
+	name, method, path, auth string
+	want                     int
+}
+
+func checkGuards(t *testing.T, tests []guardCase) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	Register(r, Handlers{}, "secret")
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.auth != "" {
+			req.Header.Set("Authorization", tt.auth)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+}
+
+// bearer signs claims with the secret checkGuards registers the routes with.
+func bearer(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+	s, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return "Bearer " + s
+}
+
+// TestRebalanceRoutesNeedAuth: generating and deciding suggestions
+// reassigns ClickUp tasks, so members may not.
+func TestRebalanceRoutesNeedAuth(t *testing.T) {
+	member := bearer(t, jwt.MapClaims{"sub": "a1", "role": "member"})
+	checkGuards(t, []guardCase{
+		{"no token", http.MethodPost, "/api/v1/workload/rebalance", "", http.StatusUnauthorized},
+		{"member generates", http.MethodPost, "/api/v1/workload/rebalance", member, http.StatusForbidden},
+		{"member accepts", http.MethodPost, "/api/v1/workload/rebalance/1/accept", member, http.StatusForbidden},
+		{"member dismisses", http.MethodPost, "/api/v1/workload/rebalance/1/dismiss", member, http.StatusForbidden},
+	})
+}
+
+// TestAppraisalRoutesNeedAuth checks the guards in front of the appraisal
+// handlers.
+func TestAppraisalRoutesNeedAuth(t *testing.T) {
+	checkGuards(t, []guardCase{
+		{"no token", http.MethodGet, "/api/v1/appraisals", "", http.StatusUnauthorized},
+		{"bad signature", http.MethodPost, "/api/v1/appraisals/1/compute", "Bearer x.y.z", http.StatusUnauthorized},
+		{"manager locks", http.MethodPost, "/api/v1/appraisals/1/lock", bearer(t, jwt.MapClaims{"sub": "a1", "role": "manager"}), http.StatusForbidden},
+		{"member reopens", http.MethodPost, "/api/v1/appraisals/1/reopen", bearer(t, jwt.MapClaims{"sub": "a1", "role": "member"}), http.StatusForbidden},
+		{"token without role", http.MethodPost, "/api/v1/appraisals/1/lock", bearer(t, jwt.MapClaims{"sub": "a1"}), http.StatusForbidden},
+		{"member creates", http.MethodPost, "/api/v1/appraisals", bearer(t, jwt.MapClaims{"sub": "a1", "role": "member"}), http.StatusForbidden},
+		{"manager computes", http.MethodPost, "/api/v1/appraisals/1/compute", bearer(t, jwt.MapClaims{"sub": "a1", "role": "manager"}), http.StatusForbidden},
+		{"member computes", http.MethodPost, "/api/v1/appraisals/1/compute", bearer(t, jwt.MapClaims{"sub": "a1", "role": "member"}), http.StatusForbidden},
+		{"member reads audit", http.MethodGet, "/api/v1/appraisals/1/audit", bearer(t, jwt.MapClaims{"sub": "a1", "role": "member", "user_id": 7}), http.StatusForbidden},
+		{"member reads other scorecards", http.MethodGet, "/api/v1/appraisals/1/scorecards?user_id=8", bearer(t, jwt.MapClaims{"sub": "a1", "role": "member", "user_id": 7}), http.StatusForbidden},
+		{"unlinked member reads tasks", http.MethodGet, "/api/v1/appraisals/1/tasks", bearer(t, jwt.MapClaims{"sub": "a1", "role": "member"}), http.StatusForbidden},
+		{"member reads other reviews", http.MethodGet, "/api/v1/appraisals/1/reviews?user_id=8", bearer(t, jwt.MapClaims{"sub": "a1", "role": "member", "user_id": 7}), http.StatusForbidden},
+	})
+}
+
+// TestKPIRoutesNeedAuth: every KPI route needs a token; configuration
+// changes and compute are admin only.
+func TestKPIRoutesNeedAuth(t *testing.T) {
+	manager := bearer(t, jwt.MapClaims{"sub": "a1", "role": "manager"})
+	checkGuards(t, []guardCase{
+		{"no token", http.MethodGet, "/api/v1/kpi/indicators", "", http.StatusUnauthorized},
+		{"no token scorecards", http.MethodGet, "/api/v1/kpi/scorecards", "", http.StatusUnauthorized},
+		{"manager updates indicator", http.MethodPut, "/api/v1/kpi/indicators/on_time_rate", manager, http.StatusForbidden},
+		{"manager sets weights", http.MethodPut, "/api/v1/kpi/weights/backend", manager, http.StatusForbidden},
+		{"manager computes", http.MethodPost, "/api/v1/kpi/scorecards/compute?period=2026-09", manager, http.StatusForbidden},
+		{"member reads other scorecards", http.MethodGet, "/api/v1/kpi/scorecards?user_id=8", bearer(t, jwt.MapClaims{"sub": "a1", "role": "member", "user_id": 7}), http.StatusForbidden},
+	})
+}
+
+// TestOKRWritesNeedAdmin: objectives and key results are read by everyone
+// but only admins change them.
+func TestOKRWritesNeedAdmin(t *testing.T) {
+	manager := bearer(t, jwt.MapClaims{"sub": "a1", "role": "manager"})
+	checkGuards(t, []guardCase{
+		{"no token", http.MethodPost, "/api/v1/okrs/objectives", "", http.StatusUnauthorized},
+		{"manager creates objective", http.MethodPost, "/api/v1/okrs/objectives", manager, http.StatusForbidden},
+		{"manager updates objective", http.MethodPut, "/api/v1/okrs/objectives/1", manager, http.StatusForbidden},
+		{"manager deletes objective", http.MethodDelete, "/api/v1/okrs/objectives/1", manager, http.StatusForbidden},
+		{"manager adds key result", http.MethodPost, "/api/v1/okrs/objectives/1/key-results", manager, http.StatusForbidden},
+		{"manager updates key result", http.MethodPut, "/api/v1/okrs/key-results/1", manager, http.StatusForbidden},
+		{"manager deletes key result", http.MethodDelete, "/api/v1/okrs/key-results/1", manager, http.StatusForbidden},
+	})
+}
+
+// TestBudgetRoutesNeedAdmin: the whole budgets group, reads included, is
+// admin only.
+func TestBudgetRoutesNeedAdmin(t *testing.T) {
+	manager := bearer(t, jwt.MapClaims{"sub": "a1", "role": "manager"})
+	checkGuards(t, []guardCase{
+		{"no token", http.MethodGet, "/api/v1/budgets", "", http.StatusUnauthorized},
+		{"manager lists", http.MethodGet, "/api/v1/budgets", manager, http.StatusForbidden},
+		{"manager sets", http.MethodPost, "/api/v1/budgets", manager, http.StatusForbidden},
+		{"manager updates", http.MethodPut, "/api/v1/budgets/1", manager, http.StatusForbidden},
+		{"manager deletes", http.MethodDelete, "/api/v1/budgets/1", manager, http.StatusForbidden},
+	})
+}
+
+// TestSprintWritesNeedAdmin: sprints are read by everyone but only admins
+// create, detect, change or delete them.
+func TestSprintWritesNeedAdmin(t *testing.T) {
+	manager := bearer(t, jwt.MapClaims{"sub": "a1", "role": "manager"})
+	checkGuards(t, []guardCase{
+		{"no token", http.MethodPost, "/api/v1/sprints", "", http.StatusUnauthorized},
+		{"manager creates", http.MethodPost, "/api/v1/sprints", manager, http.StatusForbidden},
+		{"manager detects", http.MethodPost, "/api/v1/sprints/detect", manager, http.StatusForbidden},
+		{"manager updates", http.MethodPut, "/api/v1/sprints/1", manager, http.StatusForbidden},
+		{"manager deletes", http.MethodDelete, "/api/v1/sprints/1", manager, http.StatusForbidden},
+	})
+}
+
+// TestManualEstimateNeedsAdmin: a manual estimate overrides the ClickUp
+// one, so only admins may set it.
+func TestManualEstimateNeedsAdmin(t *testing.T) {
+	checkGuards(t, []guardCase{
+		{"no token", http.MethodPut, "/api/v1/estimates/tasks/t1", "", http.StatusUnauthorized},
+		{"manager sets", http.MethodPut, "/api/v1/estimates/tasks/t1", bearer(t, jwt.MapClaims{"sub": "a1", "role": "manager"}), http.StatusForbidden},
+	})
+}
+
+// TestAlertRuleUpdateNeedsAdmin: thresholds apply to everyone, so only
+// admins change them.
+func TestAlertRuleUpdateNeedsAdmin(t *testing.T) {
+	checkGuards(t, []guardCase{
+		{"no token", http.MethodPut, "/api/v1/alerts/rules/overdue_tasks", "", http.StatusUnauthorized},
+		{"manager updates", http.MethodPut, "/api/v1/alerts/rules/overdue_tasks", bearer(t, jwt.MapClaims{"sub": "a1", "role": "manager"}), http.StatusForbidden},
+	})
+}
+
+// TestNotificationRoutesNeedAdmin: subscriptions choose where the service
+// sends data, and /test posts to any target, so the group is admin only.
+func TestNotificationRoutesNeedAdmin(t *testing.T) {
+	manager := bearer(t, jwt.MapClaims{"sub": "a1", "role": "manager"})
+	checkGuards(t, []guardCase{
+		{"no token", http.MethodPost, "/api/v1/notifications/test", "", http.StatusUnauthorized},
+		{"manager tests", http.MethodPost, "/api/v1/notifications/test", manager, http.StatusForbidden},
+		{"manager subscribes", http.MethodPost, "/api/v1/notifications/subscriptions", manager, http.StatusForbidden},
+		{"manager lists deliveries", http.MethodGet, "/api/v1/notifications/deliveries", manager, http.StatusForbidden},
+	})
+}
+
+// TestReportRoutesNeedAuth: definitions cover the whole team; a member may
+// only download their own PDF.
+func TestReportRoutesNeedAuth(t *testing.T) {
+	member := bearer(t, jwt.MapClaims{"sub": "a1", "role": "member", "user_id": 7})
+	checkGuards(t, []guardCase{
+		{"no token", http.MethodGet, "/api/v1/reports/definitions", "", http.StatusUnauthorized},
+		{"no token pdf", http.MethodGet, "/api/v1/reports/members/7.pdf", "", http.StatusUnauthorized},
+		{"member lists definitions", http.MethodGet, "/api/v1/reports/definitions", member, http.StatusForbidden},
+		{"member runs definition", http.MethodPost, "/api/v1/reports/definitions/1/run", member, http.StatusForbidden},
+		{"member downloads other pdf", http.MethodGet, "/api/v1/reports/members/8.pdf", member, http.StatusForbidden},
+		{"unlinked member downloads pdf", http.MethodGet, "/api/v1/reports/members/7.pdf", bearer(t, jwt.MapClaims{"sub": "a1", "role": "member"}), http.StatusForbidden},
+	})
+}
+
+func TestGraphQLNeedsToken(t *testing.T) {
+	checkGuards(t, []guardCase{
+		{"no token get", http.MethodGet, "/api/graphql?query=%7Bmembers%7Bid%7D%7D", "", http.StatusUnauthorized},
+		{"no token post", http.MethodPost, "/api/graphql", "", http.StatusUnauthorized},
+	})
+}
+
+func TestSaveAccountNeedsAdmin(t *testing.T) {
+	checkGuards(t, []guardCase{
+		{"no token", http.MethodPut, "/api/v1/auth/accounts/budi", "", http.StatusUnauthorized},
+		{"manager saves", http.MethodPut, "/api/v1/auth/accounts/budi", bearer(t, jwt.MapClaims{"sub": "a1", "role": "manager"}), http.StatusForbidden},
+	})
+}
@@ -0,0 +1,177 @@
+// Package apperr is the single error type returned by the API. Handlers pass
+// errors to c.Error and the error middleware renders them as
+//
+//	{"error": {"code": "not_found", "message": "...", "details": ...}}
+//
+// Codes are stable and meant for clients to branch on; message is localized
+// for the request language (see package i18n) and details carries the specifics.
+//
+//	code                 status  meaning
+//	validation_failed    400     bad parameter, body or query syntax
+//	unauthorized         401     missing, invalid or expired token
+//	forbidden            403     authenticated but not allowed
+//	not_found            404     the resource or route does not exist
+//	conflict             409     the resource state does not allow the action
+//	rate_limited         429     too many requests, to us or to ClickUp
+//	internal_error       500     unexpected failure, see server log
+//	clickup_error        502     ClickUp rejected the request
+//	clickup_unavailable  503     ClickUp could not be reached
+//	upstream_error       502     another external service (SMTP, webhook) failed
+package apperr
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/roksva123/go-kinerja-backend/internal/i18n"
+)
+
+type Code string
+
+const (
+	CodeValidationFailed   Code = "validation_failed"
+	CodeUnauthorized       Code = "unauthorized"
+	CodeForbidden          Code = "forbidden"
+	CodeNotFound           Code = "not_found"
+	CodeConflict           Code = "conflict"
+	CodeRateLimited        Code = "rate_limited"
+	CodeInternal           Code = "internal_error"
+	CodeClickUpError       Code = "clickup_error"
+	CodeClickUpUnavailable Code = "clickup_unavailable"
+	CodeUpstreamError      Code = "upstream_error"
+)
+
+// Entry documents one code of the catalogue.
+type Entry struct {
+	Code    Code   `json:"code"`
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+	// MessageID is the Indonesian message.
+	MessageID string `json:"message_id"`
+}
+
+var statuses = []struct {
+	Code   Code
+	Status int
+}{
+	{CodeValidationFailed, http.StatusBadRequest},
+	{CodeUnauthorized, http.StatusUnauthorized},
+	{CodeForbidden, http.StatusForbidden},
+	{CodeNotFound, http.StatusNotFound},
+	{CodeConflict, http.StatusConflict},
+	{CodeRateLimited, http.StatusTooManyRequests},
+	{CodeInternal, http.StatusInternalServerError},
+	{CodeClickUpError, http.StatusBadGateway},
+	{CodeClickUpUnavailable, http.StatusServiceUnavailable},
+	{CodeUpstreamError, http.StatusBadGateway},
+}
+
+// catalogue takes its messages from package i18n (keys "error.<code>").
+var catalogue = func() []Entry {
+	out := make([]Entry, len(statuses))
+	for i, s := range statuses {
+		key := "error." + string(s.Code)
+		out[i] = Entry{Code: s.Code, Status: s.Status, Message: i18n.T(i18n.EN, key), MessageID: i18n.T(i18n.ID, key)}
+	}
+	return out
+}()
+
+// Catalogue returns every code with its status and messages.
+func Catalogue() []Entry {
+	return append([]Entry(nil), catalogue...)
+}
+
+var byCode = func() map[Code]Entry {
+	m := make(map[Code]Entry, len(catalogue))
+	for _, e := range catalogue {
+		m[e.Code] = e
+	}
+	return m
+}()
+
+func lookup(code Code) Entry {
+	if e, ok := byCode[code]; ok {
+		return e
+	}
+	return byCode[CodeInternal]
+}
+
+// Error is an API error. Message is the English description used by
+// Error(); the response message comes from the catalogue.
+type Error struct {
+	Code    Code
+	Status  int
+	Message string
+	Details interface{}
+	Err     error
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = string(e.Code)
+	}
+	if e.Err != nil {
+		return msg + ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// New returns an error with the catalogue status for code. Services use it
+// for their sentinel errors so errors.Is keeps working when they wrap them.
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Status: lookup(code).Status, Message: message}
+}
+
+// Wrap attaches code to err, keeping err in the chain.
+func Wrap(code Code, err error) *Error {
+	e := New(code, "")
+	e.Err = err
+	return e
+}
+
+// Validation is shorthand for a validation_failed error.
+func Validation(message string) *Error { return New(CodeValidationFailed, message) }
+
+// NotFound is shorthand for a not_found error.
+func NotFound(message string) *Error { return New(CodeNotFound, message) }
+
+// WithDetails returns a copy of e carrying details.
+func (e *Error) WithDetails(details interface{}) *Error {
+	cp := *e
+	cp.Details = details
+	return &cp
+}
+
+// From converts any error into an *Error. Errors that wrap a sentinel
+// *Error take its code, with the full error text as details; anything else
+// becomes internal_error without details.
+func From(err error) *Error {
+	var e *Error
+	if !errors.As(err, &e) {
+		return Wrap(CodeInternal, err)
+	}
+	if e.Details != nil || e.Code == CodeInternal {
+		return e
+	}
+	return e.WithDetails(err.Error())
+}
+
+// Body is the JSON error envelope.
+type Body struct {
+	Error BodyError `json:"error"`
+}
+
+type BodyError struct {
+	Code    Code        `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+// Render builds the envelope for lang (i18n.EN or i18n.ID).
+func (e *Error) Render(lang string) Body {
+	code := lookup(e.Code).Code
+	return Body{Error: BodyError{Code: e.Code, Message: i18n.T(lang, "error."+string(code)), Details: e.Details}}
+}
@@ -0,0 +1,461 @@
+// Package clickupfake is an httptest server that answers the parts of the
+// ClickUp API v2 used by the sync, from fixture JSON instead of a live
+// workspace.
+//
+// The default fixture (fixtures/workspace.json) holds two teams, spaces with
+// folders and folderless lists, list custom fields, five tasks (one subtask
+// and one closed) and time entries. Point ClickUpService.BaseURL at
+// Server.URL:
+//
+//	fake := clickupfake.New(t)
+//	svc := service.NewClickUpService(repo, "", clickupfake.Token, clickupfake.TeamID)
+//	svc.BaseURL = fake.URL
+package clickupfake
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Token and TeamID match the default fixture.
+const (
+	Token  = "pk_test_fake"
+	TeamID = "9001"
+)
+
+// DefaultPageSize is the page size of ClickUp's Get Filtered Team Tasks.
+const DefaultPageSize = 100
+
+//go:embed fixtures/workspace.json
+var workspaceJSON []byte
+
+// Workspace is the fixture served by the fake. Tasks, custom fields and time
+// entries are kept as recorded; the fake only reads the fields it filters on.
+type Workspace struct {
+	Teams        []Team                       `json:"teams"`
+	Spaces       []Space                      `json:"spaces"`
+	CustomFields map[string][]json.RawMessage `json:"custom_fields"`
+	Tasks        []json.RawMessage            `json:"tasks"`
+	TimeEntries  []json.RawMessage            `json:"time_entries"`
+}
+
+type Team struct {
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	Color   string            `json:"color"`
+	Members []json.RawMessage `json:"members"`
+}
+
+// Space lists its folders and folderless lists; TeamID is fixture-only.
+type Space struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	TeamID   string   `json:"team_id"`
+	Archived bool     `json:"archived"`
+	Folders  []Folder `json:"folders"`
+	Lists    []List   `json:"lists"`
+}
+
+type Folder struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	OrderIndex int    `json:"orderindex"`
+	Archived   bool   `json:"archived"`
+	Lists      []List `json:"lists"`
+}
+
+type List struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	OrderIndex int     `json:"orderindex"`
+	Archived   bool    `json:"archived"`
+	StartDate  *string `json:"start_date"`
+	DueDate    *string `json:"due_date"`
+}
+
+// DefaultWorkspace decodes a fresh copy of the embedded fixture, so tests
+// may change it before starting a server.
+func DefaultWorkspace() Workspace {
+	var ws Workspace
+	if err := json.Unmarshal(workspaceJSON, &ws); err != nil {
+		panic(fmt.Sprintf("clickupfake: invalid fixture: %v", err))
+	}
+	return ws
+}
+
+// Request is one call received by the fake.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// Server is a running fake. URL is the API root, the equivalent of
+// https://api.clickup.com/api/v2.
+type Server struct {
+	URL string
+	// Token is the expected Authorization header; empty accepts any.
+	Token string
+	// PageSize limits the tasks per page of /team/{id}/task.
+	PageSize int
+
+	srv *httptest.Server
+
+	mu          sync.Mutex
+	ws          Workspace
+	rateLimited int
+	requests    []Request
+}
+
+// New starts a fake serving DefaultWorkspace. It is closed when the test
+// finishes.
+func New(tb testing.TB) *Server {
+	return NewWithWorkspace(tb, DefaultWorkspace())
+}
+
+func NewWithWorkspace(tb testing.TB, ws Workspace) *Server {
+	tb.Helper()
+	s := &Server{Token: Token, PageSize: DefaultPageSize, ws: ws}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v2/team", s.getTeams)
+	mux.HandleFunc("GET /api/v2/team/{team_id}/space", s.getSpaces)
+	mux.HandleFunc("GET /api/v2/space/{space_id}/folder", s.getFolders)
+	mux.HandleFunc("GET /api/v2/space/{space_id}/list", s.getFolderlessLists)
+	mux.HandleFunc("GET /api/v2/folder/{folder_id}/list", s.getLists)
+	mux.HandleFunc("GET /api/v2/list/{list_id}/field", s.getCustomFields)
+	mux.HandleFunc("GET /api/v2/team/{team_id}/task", s.getTasks)
+	mux.HandleFunc("GET /api/v2/task/{task_id}", s.taskByID)
+	mux.HandleFunc("PUT /api/v2/task/{task_id}", s.taskByID)
+	mux.HandleFunc("GET /api/v2/team/{team_id}/time_entries", s.getTimeEntries)
+
+	s.srv = httptest.NewServer(s.intercept(mux))
+	s.URL = s.srv.URL + "/api/v2"
+	tb.Cleanup(s.srv.Close)
+	return s
+}
+
+// RateLimit answers the next n requests with 429, as ClickUp does once a
+// token exceeds its requests per minute.
+func (s *Server) RateLimit(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimited = n
+}
+
+// Requests returns the calls received so far, rate-limited ones included.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// intercept records the request, then applies the rate limit and token check
+// before routing.
+func (s *Server) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body})
+		limited := s.rateLimited > 0
+		if limited {
+			s.rateLimited--
+		}
+		s.mu.Unlock()
+
+		if limited {
+			w.Header().Set("X-RateLimit-Limit", "100")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+			writeError(w, http.StatusTooManyRequests, "Rate limit reached", "APP_002")
+			return
+		}
+		if s.Token != "" && r.Header.Get("Authorization") != s.Token {
+			writeError(w, http.StatusUnauthorized, "Token invalid", "OAUTH_025")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, msg, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"err": msg, "ECODE": code})
+}
+
+// team reports whether the workspace holds the team in the path; ClickUp
+// answers other team ids with 401.
+func (s *Server) team(w http.ResponseWriter, r *http.Request) bool {
+	for _, t := range s.ws.Teams {
+		if t.ID == r.PathValue("team_id") {
+			return true
+		}
+	}
+	writeError(w, http.StatusUnauthorized, "Team not authorized", "OAUTH_027")
+	return false
+}
+
+// archived is ClickUp's archived query parameter: archived items are only
+// returned when it is true.
+func archived(r *http.Request) bool {
+	return r.URL.Query().Get("archived") == "true"
+}
+
+func (s *Server) getTeams(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, map[string]interface{}{"teams": s.ws.Teams})
+}
+
+func (s *Server) getSpaces(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.team(w, r) {
+		return
+	}
+	spaces := []map[string]interface{}{}
+	for _, sp := range s.ws.Spaces {
+		if sp.TeamID == r.PathValue("team_id") && sp.Archived == archived(r) {
+			spaces = append(spaces, map[string]interface{}{"id": sp.ID, "name": sp.Name, "private": false, "archived": sp.Archived})
+		}
+	}
+	writeJSON(w, map[string]interface{}{"spaces": spaces})
+}
+
+func (s *Server) space(w http.ResponseWriter, id string) (Space, bool) {
+	for _, sp := range s.ws.Spaces {
+		if sp.ID == id {
+			return sp, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "Space not found", "ACCESS_091")
+	return Space{}, false
+}
+
+func listJSON(l List, folder *Folder, sp Space) map[string]interface{} {
+	out := map[string]interface{}{
+		"id":         l.ID,
+		"name":       l.Name,
+		"orderindex": l.OrderIndex,
+		"archived":   l.Archived,
+		"start_date": l.StartDate,
+		"due_date":   l.DueDate,
+		"space":      map[string]interface{}{"id": sp.ID, "name": sp.Name, "access": true},
+	}
+	if folder != nil {
+		out["folder"] = map[string]interface{}{"id": folder.ID, "name": folder.Name, "hidden": false, "access": true}
+	}
+	return out
+}
+
+func (s *Server) getFolders(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sp, ok := s.space(w, r.PathValue("space_id"))
+	if !ok {
+		return
+	}
+	folders := []map[string]interface{}{}
+	for _, f := range sp.Folders {
+		if f.Archived != archived(r) {
+			continue
+		}
+		lists := []map[string]interface{}{}
+		for _, l := range f.Lists {
+			lists = append(lists, listJSON(l, nil, sp))
+		}
+		folders = append(folders, map[string]interface{}{
+			"id":         f.ID,
+			"name":       f.Name,
+			"orderindex": f.OrderIndex,
+			"archived":   f.Archived,
+			"task_count": strconv.Itoa(s.taskCount(f.Lists)),
+			"space":      map[string]interface{}{"id": sp.ID, "name": sp.Name},
+			"lists":      lists,
+		})
+	}
+	writeJSON(w, map[string]interface{}{"folders": folders})
+}
+
+func (s *Server) getFolderlessLists(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sp, ok := s.space(w, r.PathValue("space_id"))
+	if !ok {
+		return
+	}
+	writeJSON(w, map[string]interface{}{"lists": filterLists(sp.Lists, nil, sp, archived(r))})
+}
+
+func (s *Server) getLists(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sp := range s.ws.Spaces {
+		for _, f := range sp.Folders {
+			if f.ID == r.PathValue("folder_id") {
+				writeJSON(w, map[string]interface{}{"lists": filterLists(f.Lists, &f, sp, archived(r))})
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "Folder not found", "ACCESS_092")
+}
+
+func filterLists(lists []List, folder *Folder, sp Space, archived bool) []map[string]interface{} {
+	out := []map[string]interface{}{}
+	for _, l := range lists {
+		if l.Archived == archived {
+			out = append(out, listJSON(l, folder, sp))
+		}
+	}
+	return out
+}
+
+func (s *Server) getCustomFields(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fields := s.ws.CustomFields[r.PathValue("list_id")]
+	if fields == nil {
+		fields = []json.RawMessage{}
+	}
+	writeJSON(w, map[string]interface{}{"fields": fields})
+}
+
+// taskHeader is the part of a recorded task the fake filters on.
+type taskHeader struct {
+	ID     string  `json:"id"`
+	Parent *string `json:"parent"`
+	Status struct {
+		Type string `json:"type"`
+	} `json:"status"`
+	List struct {
+		ID string `json:"id"`
+	} `json:"list"`
+}
+
+func header(raw json.RawMessage) taskHeader {
+	var h taskHeader
+	json.Unmarshal(raw, &h)
+	return h
+}
+
+func (s *Server) taskCount(lists []List) int {
+	n := 0
+	for _, raw := range s.ws.Tasks {
+		h := header(raw)
+		for _, l := range lists {
+			if h.List.ID == l.ID {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// getTasks pages the tasks like Get Filtered Team Tasks: subtasks and closed
+// tasks are left out unless subtasks=true and include_closed=true, and an
+// empty page marks the end.
+func (s *Server) getTasks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.team(w, r) {
+		return
+	}
+	q := r.URL.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	if page < 0 {
+		writeError(w, http.StatusBadRequest, "Page must be a positive number", "ITEM_158")
+		return
+	}
+
+	var matched []json.RawMessage
+	for _, raw := range s.ws.Tasks {
+		h := header(raw)
+		if h.Parent != nil && q.Get("subtasks") != "true" {
+			continue
+		}
+		if h.Status.Type == "closed" && q.Get("include_closed") != "true" {
+			continue
+		}
+		matched = append(matched, raw)
+	}
+
+	size := s.PageSize
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	from := min(page*size, len(matched))
+	to := min(from+size, len(matched))
+	tasks := append([]json.RawMessage{}, matched[from:to]...)
+	writeJSON(w, map[string]interface{}{"tasks": tasks, "last_page": to == len(matched)})
+}
+
+// taskByID answers Get Task and Update Task; updates are only recorded.
+func (s *Server) taskByID(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, raw := range s.ws.Tasks {
+		if header(raw).ID == r.PathValue("task_id") {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(raw)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "Task not found, deleted", "ITEM_013")
+}
+
+// getTimeEntries filters by start time (start_date/end_date in ms, both
+// inclusive) and by the comma-separated assignee ids.
+func (s *Server) getTimeEntries(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.team(w, r) {
+		return
+	}
+	q := r.URL.Query()
+	from, _ := strconv.ParseInt(q.Get("start_date"), 10, 64)
+	to, _ := strconv.ParseInt(q.Get("end_date"), 10, 64)
+	assignees := map[string]bool{}
+	for _, id := range strings.Split(q.Get("assignee"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			assignees[id] = true
+		}
+	}
+
+	entries := []json.RawMessage{}
+	for _, raw := range s.ws.TimeEntries {
+		var e struct {
+			Start string `json:"start"`
+			User  struct {
+				ID int64 `json:"id"`
+			} `json:"user"`
+		}
+		json.Unmarshal(raw, &e)
+		start, _ := strconv.ParseInt(e.Start, 10, 64)
+		if (from > 0 && start < from) || (to > 0 && start > to) {
+			continue
+		}
+		if len(assignees) > 0 && !assignees[strconv.FormatInt(e.User.ID, 10)] {
+			continue
+		}
+		entries = append(entries, raw)
+	}
+	writeJSON(w, map[string]interface{}{"data": entries})
+}
@@ -0,0 +1,131 @@
+package clickupfake
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func get(t *testing.T, s *Server, path, token string, out interface{}) int {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, s.URL+path, nil)
+	req.Header.Set("Authorization", token)
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if out != nil && res.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res.StatusCode
+}
+
+func taskIDs(t *testing.T, s *Server, query string) (ids []string, lastPage bool) {
+	t.Helper()
+	var out struct {
+		Tasks []struct {
+			ID string `json:"id"`
+		} `json:"tasks"`
+		LastPage bool `json:"last_page"`
+	}
+	if status := get(t, s, "/team/"+TeamID+"/task?"+query, Token, &out); status != http.StatusOK {
+		t.Fatalf("%s: status %d", query, status)
+	}
+	for _, tk := range out.Tasks {
+		ids = append(ids, tk.ID)
+	}
+	return ids, out.LastPage
+}
+
+func TestTaskPagesAndFilters(t *testing.T) {
+	s := New(t)
+	if ids, _ := taskIDs(t, s, "page=0"); len(ids) != 3 {
+		t.Errorf("default filters = %v, want subtask and closed task left out", ids)
+	}
+
+	s.PageSize = 2
+	var all []string
+	for page := 0; ; page++ {
+		ids, last := taskIDs(t, s, "subtasks=true&include_closed=true&page="+strconv.Itoa(page))
+		if len(ids) == 0 {
+			break
+		}
+		all = append(all, ids...)
+		if last != (page == 2) {
+			t.Errorf("page %d last_page = %v", page, last)
+		}
+	}
+	if len(all) != 5 || all[0] != "t1" || all[4] != "t5" {
+		t.Errorf("paged tasks = %v, want t1..t5", all)
+	}
+}
+
+func TestSpaceTreeSkipsArchived(t *testing.T) {
+	s := New(t)
+	var spaces struct {
+		Spaces []struct{ ID string } `json:"spaces"`
+	}
+	get(t, s, "/team/"+TeamID+"/space?archived=false", Token, &spaces)
+	if len(spaces.Spaces) != 2 {
+		t.Errorf("spaces = %+v, want s1 and s2", spaces.Spaces)
+	}
+
+	var folders struct {
+		Folders []struct {
+			ID        string `json:"id"`
+			TaskCount string `json:"task_count"`
+			Lists     []struct{ ID string }
+		} `json:"folders"`
+	}
+	get(t, s, "/space/s1/folder", Token, &folders)
+	if len(folders.Folders) != 1 || folders.Folders[0].TaskCount != "4" || len(folders.Folders[0].Lists) != 2 {
+		t.Errorf("folders = %+v", folders.Folders)
+	}
+
+	var fields struct {
+		Fields []struct{ Name string } `json:"fields"`
+	}
+	get(t, s, "/list/l1/field", Token, &fields)
+	if len(fields.Fields) != 2 || fields.Fields[0].Name != "Tanggal Mulai" {
+		t.Errorf("custom fields = %+v", fields.Fields)
+	}
+}
+
+func TestTimeEntriesFilter(t *testing.T) {
+	s := New(t)
+	var out struct {
+		Data []struct{ ID string } `json:"data"`
+	}
+	// Oktober 2026, hanya user 102
+	get(t, s, "/team/"+TeamID+"/time_entries?start_date=1790812800000&end_date=1793491199999&assignee=102", Token, &out)
+	if len(out.Data) != 2 || out.Data[0].ID != "te2" || out.Data[1].ID != "te3" {
+		t.Errorf("time entries = %+v", out.Data)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := New(t)
+	if status := get(t, s, "/team", "wrong", nil); status != http.StatusUnauthorized {
+		t.Errorf("bad token: status %d", status)
+	}
+	if status := get(t, s, "/team/1/task", Token, nil); status != http.StatusUnauthorized {
+		t.Errorf("unknown team: status %d", status)
+	}
+
+	s.RateLimit(2)
+	for i := 0; i < 2; i++ {
+		if status := get(t, s, "/team", Token, nil); status != http.StatusTooManyRequests {
+			t.Errorf("request %d: status %d, want 429", i, status)
+		}
+	}
+	if status := get(t, s, "/team", Token, nil); status != http.StatusOK {
+		t.Errorf("after rate limit: status %d", status)
+	}
+	if n := len(s.Requests()); n != 5 {
+		t.Errorf("recorded %d requests, want 5", n)
+	}
+}
@@ -10,6 +10,8 @@ type Config struct {
 	// APP
 	AppEnv string
 	Port   string
+	// TimeZone is the workspace default for date parameters and day boundaries.
+	TimeZone string
 
 	// Database
 	DBHost string
@@ -25,6 +27,8 @@ type Config struct {
 	ClickUpSpaceID string
 	ClickUpFolderID string
 	ClickUpListID string
+	// ClickUpBaseURL is the API root; point it at a fake server for local tests.
+	ClickUpBaseURL string
 
 	// Admin login
 	AdminUsername string
@@ -37,6 +41,14 @@ type Config struct {
 	WorkloadNormalMin float64
 	WorkloadNormalMax float64
 	WorkloadOverload  float64
+
+	// Notifications
+	SMTPHost          string
+	SMTPPort          string
+	SMTPUsername      string
+	SMTPPassword      string
+	SMTPFrom          string
+	WebhookSigningKey string
 }
 
 func Load() (*Config, error) {
@@ -44,6 +56,7 @@ func Load() (*Config, error) {
 		// App
 		AppEnv: getEnv("APP_ENV", "development"),
 		Port:   getEnv("PORT", "8001"),
+		TimeZone: getEnv("TIMEZONE", "Asia/Jakarta"),
 
 		// DB
 		DBHost: getEnv("DB_HOST", "db.fsufakerljrkzrlrjiwm.supabase.co"),
@@ -62,6 +75,7 @@ func Load() (*Config, error) {
 		ClickUpAPIKey: getEnv("CLICKUP_API_KEY", "pk_101582122_8YV9NZHLPHQ75C9TWGM4RHB0U9MZJ2C2"),
 		ClickUpFolderID: getEnv("CLICKUP_FOLDER_ID", "90189201519"),
 		ClickUpListID: getEnv("CLICKUP_LIST_ID", "901812499939"),
+		ClickUpBaseURL: getEnv("CLICKUP_BASE_URL", "https://api.clickup.com/api/v2"),
 
 		// Admin login
 		AdminUsername: getEnv("ADMIN_USERNAME", "admin"),
@@ -72,6 +86,14 @@ func Load() (*Config, error) {
 		WorkloadNormalMin: getEnvFloat("WORKLOAD_NORMAL_MIN", 36),
 		WorkloadNormalMax: getEnvFloat("WORKLOAD_NORMAL_MAX", 45),
 		WorkloadOverload:  getEnvFloat("WORKLOAD_OVERLOAD", 60),
+
+		// Notifications
+		SMTPHost:          getEnv("SMTP_HOST", ""),
+		SMTPPort:          getEnv("SMTP_PORT", "587"),
+		SMTPUsername:      getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:      getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:          getEnv("SMTP_FROM", "kinerja@localhost"),
+		WebhookSigningKey: getEnv("WEBHOOK_SIGNING_KEY", ""),
 	}
 
 	return cfg, nil
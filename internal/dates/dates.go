@@ -0,0 +1,144 @@
+// Package dates is the single place where API dates are parsed and where day
+// boundaries and working days are computed. Date-only values are read as
+// midnight in the request time zone (?tz=) or the workspace default.
+//
+//	2026-10-31                 ISO date
+//	31-10-2026                 legacy DD-MM-YYYY
+//	2026-10-31T09:30:00+07:00  RFC 3339, keeps its own offset
+//	2026-10-31T09:30:00        local time in the zone
+package dates
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	// Asia/Jakarta must resolve even on images without zoneinfo.
+	_ "time/tzdata"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+)
+
+// DefaultZone is the workspace time zone used when TIMEZONE is not set.
+const DefaultZone = "Asia/Jakarta"
+
+var ErrInvalidDate = apperr.New(apperr.CodeValidationFailed, "invalid date")
+
+var (
+	mu         sync.RWMutex
+	defaultLoc = mustLoad(DefaultZone)
+)
+
+func mustLoad(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// Default returns the workspace time zone.
+func Default() *time.Location {
+	mu.RLock()
+	defer mu.RUnlock()
+	return defaultLoc
+}
+
+// SetDefault changes the workspace time zone, usually once at startup.
+func SetDefault(loc *time.Location) {
+	if loc == nil {
+		return
+	}
+	mu.Lock()
+	defaultLoc = loc
+	mu.Unlock()
+}
+
+// Location resolves an IANA zone name; an empty name means the default.
+func Location(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Default(), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown time zone %q", ErrInvalidDate, name)
+	}
+	return loc, nil
+}
+
+var dateLayouts = []string{"2006-01-02", "02-01-2006"}
+
+var dateTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02T15:04"}
+
+// Parse reads s in any supported format. The second result reports whether
+// s carried only a date, so callers can widen an end bound to the whole day.
+func Parse(s string, loc *time.Location) (time.Time, bool, error) {
+	if loc == nil {
+		loc = Default()
+	}
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, true, nil
+		}
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t.In(loc), false, nil
+	}
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, false, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("%w: %q must be YYYY-MM-DD, DD-MM-YYYY or RFC 3339", ErrInvalidDate, s)
+}
+
+// ParseStart parses the lower bound of a range.
+func ParseStart(s string, loc *time.Location) (time.Time, error) {
+	t, _, err := Parse(s, loc)
+	return t, err
+}
+
+// ParseEnd parses the upper bound of a range; a bare date covers its whole day.
+func ParseEnd(s string, loc *time.Location) (time.Time, error) {
+	t, dateOnly, err := Parse(s, loc)
+	if err != nil {
+		return t, err
+	}
+	if dateOnly {
+		t = EndOfDay(t, loc)
+	}
+	return t, nil
+}
+
+// StartOfDay returns midnight of t's calendar day in loc.
+func StartOfDay(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = Default()
+	}
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// EndOfDay returns the last instant of t's calendar day in loc.
+func EndOfDay(t time.Time, loc *time.Location) time.Time {
+	return StartOfDay(t, loc).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+// WorkingDays counts Monday–Friday calendar days from start to end inclusive,
+// using the day each instant falls on in loc.
+func WorkingDays(start, end time.Time, loc *time.Location) int {
+	if end.Before(start) {
+		return 0
+	}
+	last := StartOfDay(end, loc)
+	days := 0
+	for d := StartOfDay(start, loc); !d.After(last); d = d.AddDate(0, 0, 1) {
+		if wd := d.Weekday(); wd != time.Saturday && wd != time.Sunday {
+			days++
+		}
+	}
+	return days
+}
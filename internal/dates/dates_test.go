@@ -0,0 +1,108 @@
+package dates
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	jkt, err := Location("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		in       string
+		want     time.Time
+		dateOnly bool
+	}{
+		{"2026-10-31", time.Date(2026, 10, 31, 0, 0, 0, 0, jkt), true},
+		{" 31-10-2026 ", time.Date(2026, 10, 31, 0, 0, 0, 0, jkt), true},
+		// RFC 3339 menyimpan offset-nya sendiri, bukan tz request
+		{"2026-10-31T09:30:00+02:00", time.Date(2026, 10, 31, 7, 30, 0, 0, time.UTC), false},
+		{"2026-10-31T23:30:00Z", time.Date(2026, 11, 1, 6, 30, 0, 0, jkt), false},
+		{"2026-10-31T09:30:00", time.Date(2026, 10, 31, 9, 30, 0, 0, jkt), false},
+		{"2026-10-31 09:30:00", time.Date(2026, 10, 31, 9, 30, 0, 0, jkt), false},
+	}
+	for _, tt := range tests {
+		got, dateOnly, err := Parse(tt.in, jkt)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) || dateOnly != tt.dateOnly {
+			t.Errorf("Parse(%q) = %v, %v; want %v, %v", tt.in, got, dateOnly, tt.want, tt.dateOnly)
+		}
+	}
+
+	for _, in := range []string{"", "31/10/2026", "2026-13-01", "kemarin"} {
+		if _, _, err := Parse(in, jkt); !errors.Is(err, ErrInvalidDate) {
+			t.Errorf("Parse(%q) err = %v, want ErrInvalidDate", in, err)
+		}
+	}
+}
+
+func TestLocation(t *testing.T) {
+	if loc, err := Location(""); err != nil || loc != Default() {
+		t.Errorf("Location(\"\") = %v, %v; want the default zone", loc, err)
+	}
+	if _, err := Location("Mars/Olympus"); !errors.Is(err, ErrInvalidDate) {
+		t.Errorf("unknown zone err = %v, want ErrInvalidDate", err)
+	}
+}
+
+func TestParseEnd(t *testing.T) {
+	jkt, _ := Location("Asia/Jakarta")
+
+	// tanggal saja mencakup seluruh hari
+	got, err := ParseEnd("2026-10-31", jkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 10, 31, 23, 59, 59, int(time.Second-1), jkt); !got.Equal(want) {
+		t.Errorf("ParseEnd(date) = %v, want %v", got, want)
+	}
+
+	// jam yang eksplisit dipakai apa adanya
+	got, err = ParseEnd("2026-10-31T12:00:00", jkt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2026, 10, 31, 12, 0, 0, 0, jkt); !got.Equal(want) {
+		t.Errorf("ParseEnd(datetime) = %v, want %v", got, want)
+	}
+
+	start, err := ParseStart("31-10-2026", jkt)
+	if err != nil || !start.Equal(time.Date(2026, 10, 31, 0, 0, 0, 0, jkt)) {
+		t.Errorf("ParseStart = %v, %v", start, err)
+	}
+}
+
+func TestWorkingDays(t *testing.T) {
+	jkt, _ := Location("Asia/Jakarta")
+	berlin, _ := Location("Europe/Berlin")
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		loc        *time.Location
+		want       int
+	}{
+		{"one full week", time.Date(2026, 10, 19, 0, 0, 0, 0, jkt), time.Date(2026, 10, 25, 0, 0, 0, 0, jkt), jkt, 5},
+		{"single weekday", time.Date(2026, 10, 19, 8, 0, 0, 0, jkt), time.Date(2026, 10, 19, 17, 0, 0, 0, jkt), jkt, 1},
+		{"weekend only", time.Date(2026, 10, 24, 0, 0, 0, 0, jkt), time.Date(2026, 10, 25, 0, 0, 0, 0, jkt), jkt, 0},
+		{"end before start", time.Date(2026, 10, 23, 0, 0, 0, 0, jkt), time.Date(2026, 10, 19, 0, 0, 0, 0, jkt), jkt, 0},
+		// Jumat 20:00 UTC sudah Sabtu di Jakarta
+		{"friday utc is saturday in jakarta", time.Date(2026, 10, 23, 20, 0, 0, 0, time.UTC), time.Date(2026, 10, 23, 22, 0, 0, 0, time.UTC), jkt, 0},
+		{"friday utc in utc", time.Date(2026, 10, 23, 20, 0, 0, 0, time.UTC), time.Date(2026, 10, 23, 22, 0, 0, 0, time.UTC), time.UTC, 1},
+		// Minggu 20:00 UTC sudah Senin di Jakarta
+		{"sunday utc is monday in jakarta", time.Date(2026, 10, 25, 20, 0, 0, 0, time.UTC), time.Date(2026, 10, 26, 5, 0, 0, 0, time.UTC), jkt, 1},
+		// pergantian DST (25 Oktober 2026) tidak menggeser hitungan hari
+		{"across dst change", time.Date(2026, 10, 23, 0, 0, 0, 0, berlin), time.Date(2026, 10, 27, 0, 0, 0, 0, berlin), berlin, 3},
+	}
+	for _, tt := range tests {
+		if got := WorkingDays(tt.start, tt.end, tt.loc); got != tt.want {
+			t.Errorf("%s: WorkingDays = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// utf8BOM membuat Excel membaca CSV sebagai UTF-8.
+const utf8BOM = "\ufeff"
+
+func writeCSV(w io.Writer, lang string, sheet Sheet) error {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	headers := make([]string, len(sheet.Columns))
+	for i, c := range sheet.Columns {
+		headers[i] = c.Header(lang)
+	}
+	if err := cw.Write(headers); err != nil {
+		return err
+	}
+
+	record := make([]string, 0, len(sheet.Columns))
+	err := sheet.Rows(func(cells ...interface{}) error {
+		record = record[:0]
+		for _, cell := range cells {
+			text, num, isNum := cellValue(cell)
+			if isNum {
+				text = formatNumber(num)
+			} else {
+				text = escapeFormula(text)
+			}
+			record = append(record, text)
+		}
+		return cw.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeFormula mencegah teks seperti "=HYPERLINK(...)" dari ClickUp
+// dieksekusi sebagai formula saat CSV dibuka di spreadsheet.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,174 @@
+// Package export menulis data laporan sebagai CSV atau XLSX. Baris di-encode
+// langsung ke writer tanpa menampung file hasil, tetapi data di balik Rows
+// sudah dimuat ke memori oleh pemanggil; karena itu ukuran export dibatasi
+// MaxRows (lihat CheckRows).
+package export
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/i18n"
+)
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+const (
+	ContentTypeCSV  = "text/csv; charset=utf-8"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+var ErrUnknownFormat = apperr.New(apperr.CodeValidationFailed, "unknown export format")
+
+// MaxRows is the largest export, counted in rows of the detail sheet. The
+// rows are built in memory before the first byte is sent, so this bounds
+// that memory as well as the file size.
+const MaxRows = 20000
+
+var ErrTooManyRows = apperr.New(apperr.CodeValidationFailed, "export has too many rows")
+
+// CheckRows returns ErrTooManyRows when an export of n rows is over MaxRows.
+// Call it before writing: once the download starts the status is sent.
+func CheckRows(n int) error {
+	if n > MaxRows {
+		return fmt.Errorf("%w: %d rows, at most %d; narrow the date range or filters", ErrTooManyRows, n, MaxRows)
+	}
+	return nil
+}
+
+// Negotiate picks the export format from ?format= first, then the Accept
+// header. Anything else is JSON.
+func Negotiate(format, accept string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "":
+	case "json":
+		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	case "xlsx", "excel":
+		return FormatXLSX, nil
+	default:
+		return "", fmt.Errorf("%w %q, use json, csv or xlsx", ErrUnknownFormat, format)
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mt {
+		case "text/csv":
+			return FormatCSV, nil
+		case ContentTypeXLSX:
+			return FormatXLSX, nil
+		case "application/json":
+			return FormatJSON, nil
+		}
+	}
+	return FormatJSON, nil
+}
+
+// Column is a sheet column (or sheet name) with an English and an
+// Indonesian header.
+type Column struct {
+	EN string
+	ID string
+}
+
+// Header returns the header for lang (i18n.EN or i18n.ID).
+func (c Column) Header(lang string) string {
+	return i18n.Pick(lang, c.EN, c.ID)
+}
+
+// Sheet is one table of an export. Rows is called once with an emit
+// function; each emit call writes one row straight to the output.
+type Sheet struct {
+	Name    Column
+	Columns []Column
+	Rows    func(emit func(cells ...interface{}) error) error
+}
+
+// ContentType returns the response content type of f.
+func ContentType(f Format) string {
+	if f == FormatXLSX {
+		return ContentTypeXLSX
+	}
+	return ContentTypeCSV
+}
+
+// Write streams sheets to w. CSV has no sheets, so only the first one is
+// written; callers put the flattened detail sheet first.
+func Write(w io.Writer, f Format, lang string, sheets ...Sheet) error {
+	switch f {
+	case FormatCSV:
+		if len(sheets) == 0 {
+			return nil
+		}
+		return writeCSV(w, lang, sheets[0])
+	case FormatXLSX:
+		return writeXLSX(w, lang, sheets)
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownFormat, f)
+	}
+}
+
+// cellValue turns a cell into a number (isNum) or text. Pointers are
+// dereferenced and nil becomes an empty cell.
+func cellValue(v interface{}) (text string, num float64, isNum bool) {
+	switch x := v.(type) {
+	case nil:
+		return "", 0, false
+	case string:
+		return x, 0, false
+	case *string:
+		if x == nil {
+			return "", 0, false
+		}
+		return *x, 0, false
+	case int:
+		return "", float64(x), true
+	case int64:
+		return "", float64(x), true
+	case *int64:
+		if x == nil {
+			return "", 0, false
+		}
+		return "", float64(*x), true
+	case float64:
+		return "", x, true
+	case *float64:
+		if x == nil {
+			return "", 0, false
+		}
+		return "", *x, true
+	case bool:
+		return strconv.FormatBool(x), 0, false
+	case time.Time:
+		if x.IsZero() {
+			return "", 0, false
+		}
+		return x.Format("2006-01-02 15:04"), 0, false
+	case *time.Time:
+		if x == nil || x.IsZero() {
+			return "", 0, false
+		}
+		return x.Format("2006-01-02 15:04"), 0, false
+	default:
+		return fmt.Sprint(x), 0, false
+	}
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,126 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/i18n"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		format, accept string
+		want           Format
+		wantErr        bool
+	}{
+		{"", "", FormatJSON, false},
+		{"csv", "", FormatCSV, false},
+		{" XLSX ", "", FormatXLSX, false},
+		{"excel", "", FormatXLSX, false},
+		// ?format= menang atas Accept
+		{"json", "text/csv", FormatJSON, false},
+		{"", "text/csv; charset=utf-8", FormatCSV, false},
+		{"", "text/html, " + ContentTypeXLSX, FormatXLSX, false},
+		{"", "application/json, text/csv", FormatJSON, false},
+		{"", "*/*", FormatJSON, false},
+		{"", "not a media type;;, text/csv", FormatCSV, false},
+		{"pdf", "", "", true},
+	}
+	for _, tt := range tests {
+		got, err := Negotiate(tt.format, tt.accept)
+		if tt.wantErr {
+			if !errors.Is(err, ErrUnknownFormat) {
+				t.Errorf("Negotiate(%q, %q) err = %v, want ErrUnknownFormat", tt.format, tt.accept, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Negotiate(%q, %q) = %q, %v, want %q", tt.format, tt.accept, got, err, tt.want)
+		}
+	}
+}
+
+func testSheet() Sheet {
+	due := time.Date(2026, 10, 9, 17, 0, 0, 0, time.UTC)
+	var missing *float64
+	return Sheet{
+		Name:    Column{EN: "Tasks", ID: "Task"},
+		Columns: []Column{{EN: "Name", ID: "Nama"}, {EN: "Hours", ID: "Jam"}, {EN: "Due", ID: "Tenggat"}},
+		Rows: func(emit func(...interface{}) error) error {
+			if err := emit("=HYPERLINK(\"x\")", 1.5, due); err != nil {
+				return err
+			}
+			return emit("Ana, \"web\"", missing, nil)
+		},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write(&b, FormatCSV, i18n.ID, testSheet()); err != nil {
+		t.Fatal(err)
+	}
+	want := utf8BOM + "Nama,Jam,Tenggat\n" +
+		"\"'=HYPERLINK(\"\"x\"\")\",1.5,2026-10-09 17:00\n" +
+		"\"Ana, \"\"web\"\"\",,\n"
+	if b.String() != want {
+		t.Errorf("csv =\n%q\nwant\n%q", b.String(), want)
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var b bytes.Buffer
+	second := testSheet()
+	if err := Write(&b, FormatXLSX, i18n.EN, testSheet(), second); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("missing part %s", name)
+		}
+	}
+	// nama sheet kembar diberi akhiran
+	if wb := files["xl/workbook.xml"]; !strings.Contains(wb, `name="Tasks"`) || !strings.Contains(wb, `name="Tasks (2)"`) {
+		t.Errorf("workbook sheets: %s", wb)
+	}
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c t="inlineStr" s="1"><is><t xml:space="preserve">Name</t></is></c>`,
+		`<c><v>1.5</v></c>`,
+		`<t xml:space="preserve">=HYPERLINK(&#34;x&#34;)</t>`,
+		`<t xml:space="preserve">2026-10-09 17:00</t>`,
+		`<c/><c/></row>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet1 lacks %s:\n%s", want, sheet)
+		}
+	}
+}
+
+func TestCheckRows(t *testing.T) {
+	if err := CheckRows(MaxRows); err != nil {
+		t.Errorf("CheckRows(MaxRows) = %v", err)
+	}
+	if err := CheckRows(MaxRows + 1); !errors.Is(err, ErrTooManyRows) {
+		t.Errorf("CheckRows(MaxRows+1) = %v, want ErrTooManyRows", err)
+	}
+}
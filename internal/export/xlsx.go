@@ -0,0 +1,179 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// XLSX minimal: setiap sheet ditulis langsung ke entry zip-nya dengan inline
+// string, jadi tidak perlu shared strings table dan baris tidak ditahan di
+// memori. Workbook dan content types ditulis terakhir karena baru tahu
+// daftar sheet setelah semua selesai.
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+%s</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+// Style 1 = header tebal.
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>
+</styleSheet>`
+
+const xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetFooter = `</sheetData></worksheet>`
+
+func writeXLSX(w io.Writer, lang string, sheets []Sheet) error {
+	zw := zip.NewWriter(w)
+	names := make([]string, 0, len(sheets))
+	used := map[string]bool{}
+
+	for i, sheet := range sheets {
+		name := uniqueSheetName(sheet.Name.Header(lang), i+1, used)
+		names = append(names, name)
+		f, err := zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1))
+		if err != nil {
+			return err
+		}
+		if err := writeXLSXSheet(f, lang, sheet); err != nil {
+			return err
+		}
+	}
+	if len(names) == 0 {
+		// Workbook tanpa sheet tidak valid di Excel.
+		names = append(names, "Sheet1")
+		f, err := zw.Create("xl/worksheets/sheet1.xml")
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, xlsxSheetHeader+xlsxSheetFooter); err != nil {
+			return err
+		}
+	}
+
+	var overrides, sheetTags, rels strings.Builder
+	for i, name := range names {
+		n := i + 1
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`+"\n", n)
+		fmt.Fprintf(&sheetTags, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(name), n, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`+"\n", n, n)
+	}
+	fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`+"\n", len(names)+1)
+
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", fmt.Sprintf(xlsxContentTypes, overrides.String())},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` + sheetTags.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+` + rels.String() + `</Relationships>`},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeXLSXSheet(w io.Writer, lang string, sheet Sheet) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(xlsxSheetHeader)
+
+	bw.WriteString(`<row>`)
+	for _, c := range sheet.Columns {
+		writeXLSXText(bw, c.Header(lang), true)
+	}
+	bw.WriteString(`</row>`)
+
+	err := sheet.Rows(func(cells ...interface{}) error {
+		bw.WriteString(`<row>`)
+		for _, cell := range cells {
+			text, num, isNum := cellValue(cell)
+			switch {
+			case isNum:
+				bw.WriteString(`<c><v>` + formatNumber(num) + `</v></c>`)
+			case text == "":
+				bw.WriteString(`<c/>`)
+			default:
+				writeXLSXText(bw, text, false)
+			}
+		}
+		_, err := bw.WriteString(`</row>`)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	bw.WriteString(xlsxSheetFooter)
+	return bw.Flush()
+}
+
+func writeXLSXText(w *bufio.Writer, text string, header bool) {
+	if header {
+		w.WriteString(`<c t="inlineStr" s="1"><is><t xml:space="preserve">`)
+	} else {
+		w.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+	}
+	xml.EscapeText(w, []byte(text))
+	w.WriteString(`</t></is></c>`)
+}
+
+// uniqueSheetName menyesuaikan nama dengan aturan Excel: maksimal 31
+// karakter, tanpa []:*?/\ dan unik (case-insensitive).
+func uniqueSheetName(name string, n int, used map[string]bool) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		name = fmt.Sprintf("Sheet%d", n)
+	}
+	if r := []rune(name); len(r) > 31 {
+		name = string(r[:31])
+	}
+	base := name
+	for i := 2; used[strings.ToLower(name)]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		r := []rune(base)
+		if len(r)+len(suffix) > 31 {
+			r = r[:31-len(suffix)]
+		}
+		name = string(r) + suffix
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,155 @@
+// Package graph serves a read-only GraphQL API over the same services as
+// REST v2, so a dashboard can fetch members, workload, tasks, projects and
+// scorecards in one request:
+//
+//	{
+//	  members(role: "backend") {
+//	    name
+//	    workload(start: "2026-10-01", end: "2026-10-31") { loggedHours expectedHours }
+//	    tasks(start: "2026-10-01", end: "2026-10-31") { name dueAt project { name } }
+//	  }
+//	}
+//
+// Relations (Member.tasks, Task.assignees, Task.project, ...) go through
+// per-request loaders, so each relation costs one query per level however
+// many parents there are. Depth and complexity are checked before execution,
+// see limits.go.
+package graph
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/resource"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+type Executor struct {
+	schema    graphql.Schema
+	resources *service.ResourceService
+	kpi       *service.KPIService
+}
+
+func New(resources *service.ResourceService, kpi *service.KPIService) (*Executor, error) {
+	e := &Executor{resources: resources, kpi: kpi}
+	schema, err := e.buildSchema()
+	if err != nil {
+		return nil, err
+	}
+	e.schema = schema
+	return e, nil
+}
+
+// Request is the standard GraphQL-over-HTTP body.
+type Request struct {
+	Query         string                 `json:"query" binding:"required"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+}
+
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []Error     `json:"errors,omitempty"`
+}
+
+// Error follows the GraphQL spec; extensions.code is an apperr code.
+type Error struct {
+	Message    string                    `json:"message"`
+	Locations  []location.SourceLocation `json:"locations,omitempty"`
+	Path       []interface{}             `json:"path,omitempty"`
+	Extensions map[string]interface{}    `json:"extensions,omitempty"`
+}
+
+// Execute parses, validates, checks the limits and runs req for caller.
+// Errors are returned in the response, rendered in lang.
+func (e *Executor) Execute(ctx context.Context, req Request, view resource.View, caller model.Caller, lang string) Response {
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		return errorResponse(lang, gqlerrors.FormatError(err))
+	}
+	if vr := graphql.ValidateDocument(&e.schema, doc, nil); !vr.IsValid {
+		return errorResponse(lang, vr.Errors...)
+	}
+	c, err := analyze(&e.schema, doc, req.OperationName, req.Variables)
+	if err == nil {
+		err = checkLimits(c)
+	}
+	if err != nil {
+		return errorResponse(lang, gqlerrors.FormatError(err))
+	}
+
+	r := &request{view: view, caller: caller}
+	r.members = newLoader(func(ctx context.Context, ids []string) (map[string]resource.Member, error) {
+		return e.resources.MembersByID(ctx, ids, resource.View{Location: view.Location})
+	})
+	r.projects = newLoader(func(ctx context.Context, ids []string) (map[string]resource.Project, error) {
+		return e.resources.ProjectsByID(ctx, ids, resource.View{Location: view.Location})
+	})
+
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        e.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       context.WithValue(ctx, ctxKey{}, r),
+	})
+	resp := errorResponse(lang, result.Errors...)
+	resp.Data = result.Data
+	return resp
+}
+
+// errorResponse renders errors with the apperr code and localized message.
+// Failures inside resolvers (they carry a path) go through apperr.From, so
+// internal errors are logged and masked like in REST.
+func errorResponse(lang string, errs ...gqlerrors.FormattedError) Response {
+	var resp Response
+	for _, fe := range errs {
+		out := Error{Message: fe.Message, Locations: fe.Locations, Path: fe.Path}
+		cause := rootCause(fe)
+		var ae *apperr.Error
+		switch {
+		case errors.As(cause, &ae) || fe.Path != nil:
+			ae = apperr.From(cause)
+			if ae.Code == apperr.CodeInternal {
+				log.Printf("ERROR graphql %v: %v", fe.Path, cause)
+			}
+			body := ae.Render(lang)
+			out.Message = body.Error.Message
+			out.Extensions = map[string]interface{}{"code": ae.Code}
+			if body.Error.Details != nil {
+				out.Extensions["details"] = body.Error.Details
+			}
+		default:
+			out.Extensions = map[string]interface{}{"code": apperr.CodeValidationFailed}
+		}
+		resp.Errors = append(resp.Errors, out)
+	}
+	return resp
+}
+
+// rootCause unwraps the layers graphql-go puts around a resolver error.
+func rootCause(err error) error {
+	for {
+		switch e := err.(type) {
+		case gqlerrors.FormattedError:
+			if e.OriginalError() == nil {
+				return err
+			}
+			err = e.OriginalError()
+		case *gqlerrors.Error:
+			if e.OriginalError == nil {
+				return err
+			}
+			err = e.OriginalError
+		default:
+			return err
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/i18n"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/resource"
+)
+
+func newTestExecutor(t *testing.T) *Executor {
+	t.Helper()
+	e, err := New(nil, nil)
+	if err != nil {
+		t.Fatalf("schema: %v", err)
+	}
+	return e
+}
+
+func analyzeQuery(t *testing.T, e *Executor, q string, vars map[string]interface{}) cost {
+	t.Helper()
+	doc, err := parser.Parse(parser.ParseParams{Source: q})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	c, err := analyze(&e.schema, doc, "", vars)
+	if err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+	return c
+}
+
+func TestAnalyze(t *testing.T) {
+	e := newTestExecutor(t)
+	tests := []struct {
+		name  string
+		query string
+		vars  map[string]interface{}
+		want  cost
+	}{
+		{"scalar root", `{ task(id: "1") { id name } }`, nil, cost{Depth: 2, Complexity: 3}},
+		{"list with limit", `{ members(limit: 5) { id name } }`, nil, cost{Depth: 2, Complexity: 11}},
+		{"list uses default limit", `{ members { id } }`, nil, cost{Depth: 2, Complexity: 51}},
+		{"limit from variable", `query($n: Int) { members(limit: $n) { id } }`, map[string]interface{}{"n": float64(3)}, cost{Depth: 2, Complexity: 4}},
+		{"nested list without limit", `{ members(limit: 2) { tasks(start: "2026-10-01", end: "2026-10-31") { id } } }`, nil,
+			cost{Depth: 3, Complexity: 1 + 2*(1+defaultListSize)}},
+		{"fragments", `{ task(id: "1") { ...T } } fragment T on Task { id project { name } }`, nil, cost{Depth: 3, Complexity: 4}},
+		{"introspection is free", `{ __schema { types { name } } task(id: "1") { id } }`, nil, cost{Depth: 2, Complexity: 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := analyzeQuery(t, e, tt.query, tt.vars); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecuteRejectsOverLimit(t *testing.T) {
+	e := newTestExecutor(t)
+	deep := `{ tasks { project { parent { parent { parent { parent { parent { parent { id } } } } } } } } }`
+	wide := `{ members(limit: 500) { tasks(start: "2026-10-01", end: "2026-10-31") { assignees { id name email } } } }`
+
+	for name, q := range map[string]string{"depth": deep, "complexity": wide} {
+		t.Run(name, func(t *testing.T) {
+			resp := e.Execute(context.Background(), Request{Query: q}, resource.View{}, model.Caller{}, i18n.EN)
+			if resp.Data != nil || len(resp.Errors) != 1 {
+				t.Fatalf("got %+v, want a single error and no data", resp)
+			}
+			err := resp.Errors[0]
+			if err.Extensions["code"] != apperr.CodeValidationFailed {
+				t.Errorf("code = %v", err.Extensions["code"])
+			}
+			if details, _ := err.Extensions["details"].(string); !strings.Contains(details, name) {
+				t.Errorf("details = %q, want it to mention %s", details, name)
+			}
+		})
+	}
+}
+
+func TestExecuteReportsValidationErrors(t *testing.T) {
+	e := newTestExecutor(t)
+	resp := e.Execute(context.Background(), Request{Query: `{ members { nope } }`}, resource.View{}, model.Caller{}, i18n.EN)
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != apperr.CodeValidationFailed {
+		t.Fatalf("got %+v", resp.Errors)
+	}
+	if !strings.Contains(resp.Errors[0].Message, "nope") {
+		t.Errorf("message %q should name the unknown field", resp.Errors[0].Message)
+	}
+}
+
+// Anggota tidak bisa membaca scorecard anggota lain lewat GraphQL; ditolak
+// sebelum service disentuh.
+func TestScorecardsScopedToCaller(t *testing.T) {
+	e := newTestExecutor(t)
+	own := int64(7)
+	member := model.Caller{Subject: "a1", Role: model.RoleMember, UserID: &own}
+	queries := map[string]model.Caller{
+		`{ scorecards(period: "2026-09", memberId: "8") { id } }`: member,
+		`{ scorecards(period: "2026-09") { id } }`:                {Subject: "a2", Role: model.RoleMember},
+	}
+	for q, caller := range queries {
+		resp := e.Execute(context.Background(), Request{Query: q}, resource.View{}, caller, i18n.EN)
+		if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != apperr.CodeForbidden {
+			t.Errorf("%s as %+v: got %+v, want one forbidden error", q, caller, resp.Errors)
+		}
+	}
+}
+
+func TestLoaderBatchesQueuedKeys(t *testing.T) {
+	var calls int32
+	var got []string
+	l := newLoader(func(_ context.Context, keys []string) (map[string]int, error) {
+		atomic.AddInt32(&calls, 1)
+		got = keys
+		out := map[string]int{}
+		for _, k := range keys {
+			if k != "missing" {
+				out[k] = len(k)
+			}
+		}
+		return out, nil
+	})
+
+	ctx := context.Background()
+	loads := []struct {
+		thunk func() (interface{}, error)
+		want  interface{}
+	}{
+		{l.Load(ctx, "a", -1), 1},
+		{l.Load(ctx, "bb", -1), 2},
+		{l.Load(ctx, "a", -1), 1},
+		{l.Load(ctx, "missing", -1), -1},
+	}
+	for _, ld := range loads {
+		if v, err := ld.thunk(); err != nil || v != ld.want {
+			t.Errorf("got %v, %v; want %v", v, err, ld.want)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetch called %d times, want 1", calls)
+	}
+	if strings.Join(got, ",") != "a,bb,missing" {
+		t.Errorf("fetched keys %v", got)
+	}
+
+	// Key yang sudah dimuat tidak diambil ulang.
+	if v, _ := l.Load(ctx, "bb", -1)(); v != 2 || calls != 1 {
+		t.Errorf("cached load = %v after %d calls", v, calls)
+	}
+}
@@ -0,0 +1,187 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+)
+
+// Batas query, dicek sebelum eksekusi.
+const (
+	// MaxDepth is the deepest field nesting allowed, root fields being 1.
+	MaxDepth = 7
+	// MaxComplexity caps the estimated number of resolved fields.
+	MaxComplexity = 5000
+	// defaultListSize is the size assumed for a list without a limit argument.
+	defaultListSize = 10
+)
+
+// cost is what analyze measured for one operation.
+type cost struct {
+	Depth      int
+	Complexity int
+}
+
+// analyze walks the operation against the schema. Every field costs 1; the
+// fields under a list cost as many times as the list's limit argument, or
+// its default, or defaultListSize. Introspection fields are free.
+func analyze(schema *graphql.Schema, doc *ast.Document, operationName string, vars map[string]interface{}) (cost, error) {
+	var op *ast.OperationDefinition
+	fragments := map[string]*ast.FragmentDefinition{}
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				op = def
+			}
+		case *ast.FragmentDefinition:
+			fragments[def.Name.Value] = def
+		}
+	}
+	if op == nil {
+		return cost{}, apperr.Validation("unknown operation " + strconv.Quote(operationName))
+	}
+
+	a := analyzer{schema: schema, fragments: fragments, vars: vars}
+	var root *graphql.Object
+	switch op.Operation {
+	case ast.OperationTypeQuery:
+		root = schema.QueryType()
+	default:
+		return cost{}, apperr.Validation(op.Operation + " is not supported")
+	}
+	complexity, depth := a.selections(op.SelectionSet, root, 1, map[string]bool{})
+	return cost{Depth: depth, Complexity: complexity}, nil
+}
+
+// checkLimits returns a validation error when c exceeds MaxDepth or MaxComplexity.
+func checkLimits(c cost) error {
+	switch {
+	case c.Depth > MaxDepth:
+		return apperr.Validation(fmt.Sprintf("query depth %d exceeds the limit of %d", c.Depth, MaxDepth))
+	case c.Complexity > MaxComplexity:
+		return apperr.Validation(fmt.Sprintf("query complexity %d exceeds the limit of %d", c.Complexity, MaxComplexity))
+	}
+	return nil
+}
+
+type analyzer struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	vars      map[string]interface{}
+}
+
+// selections returns the complexity of set and the deepest level reached.
+// visiting guards against fragment cycles.
+func (a analyzer) selections(set *ast.SelectionSet, parent *graphql.Object, level int, visiting map[string]bool) (complexity, depth int) {
+	if set == nil || parent == nil {
+		return 0, level - 1
+	}
+	depth = level - 1
+	for _, sel := range set.Selections {
+		var c, d int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			c, d = a.field(sel, parent, level, visiting)
+		case *ast.InlineFragment:
+			c, d = a.selections(sel.SelectionSet, a.condition(sel.TypeCondition, parent), level, visiting)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			frag, ok := a.fragments[name]
+			if !ok || visiting[name] {
+				continue
+			}
+			visiting[name] = true
+			c, d = a.selections(frag.SelectionSet, a.condition(frag.TypeCondition, parent), level, visiting)
+			delete(visiting, name)
+		}
+		complexity += c
+		if d > depth {
+			depth = d
+		}
+	}
+	return complexity, depth
+}
+
+func (a analyzer) condition(named *ast.Named, parent *graphql.Object) *graphql.Object {
+	if named == nil {
+		return parent
+	}
+	obj, _ := a.schema.Type(named.Name.Value).(*graphql.Object)
+	return obj
+}
+
+func (a analyzer) field(f *ast.Field, parent *graphql.Object, level int, visiting map[string]bool) (complexity, depth int) {
+	name := f.Name.Value
+	if strings.HasPrefix(name, "__") {
+		return 0, level - 1
+	}
+	def, ok := parent.Fields()[name]
+	if !ok {
+		return 0, level - 1
+	}
+
+	typ, isList := unwrap(def.Type)
+	obj, _ := typ.(*graphql.Object)
+	children, depth := a.selections(f.SelectionSet, obj, level+1, visiting)
+	if depth < level {
+		depth = level
+	}
+	if isList {
+		children *= a.listSize(f, def)
+	}
+	return 1 + children, depth
+}
+
+// listSize is the limit argument of a list field: the literal or variable
+// passed in the query, else the argument default, else defaultListSize.
+func (a analyzer) listSize(f *ast.Field, def *graphql.FieldDefinition) int {
+	for _, arg := range f.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil && n > 0 {
+				return n
+			}
+		case *ast.Variable:
+			switch n := a.vars[v.Name.Value].(type) {
+			case float64:
+				if n > 0 {
+					return int(n)
+				}
+			case int:
+				if n > 0 {
+					return n
+				}
+			}
+		}
+	}
+	for _, arg := range def.Args {
+		if n, ok := arg.DefaultValue.(int); ok && arg.Name() == "limit" && n > 0 {
+			return n
+		}
+	}
+	return defaultListSize
+}
+
+// unwrap strips NonNull and List wrappers and reports whether a List was seen.
+func unwrap(t graphql.Type) (graphql.Type, bool) {
+	isList := false
+	for {
+		switch w := t.(type) {
+		case *graphql.NonNull:
+			t = w.OfType
+		case *graphql.List:
+			isList = true
+			t = w.OfType
+		default:
+			return t, isList
+		}
+	}
+}
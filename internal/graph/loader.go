@@ -0,0 +1,99 @@
+package graph
+
+import (
+	"context"
+	"sync"
+)
+
+// loader batches lookups by key, in the style of dataloader. Resolvers call
+// Load, which only queues the key and returns a thunk; graphql-go runs the
+// thunks after every field of the current level has been resolved, so the
+// first thunk fetches all queued keys in a single call.
+type loader[V any] struct {
+	fetch func(ctx context.Context, keys []string) (map[string]V, error)
+
+	mu      sync.Mutex
+	pending []string
+	results map[string]result[V]
+}
+
+type result[V any] struct {
+	value V
+	found bool
+	err   error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, keys []string) (map[string]V, error)) *loader[V] {
+	return &loader[V]{fetch: fetch, results: map[string]result[V]{}}
+}
+
+// Load queues key and returns a thunk resolving to its value. missing is
+// returned when the fetch does not know the key.
+func (l *loader[V]) Load(ctx context.Context, key string, missing interface{}) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, done := l.results[key]; !done && !contains(l.pending, key) {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		r := l.get(ctx, key)
+		if r.err != nil {
+			return nil, r.err
+		}
+		if !r.found {
+			return missing, nil
+		}
+		return r.value, nil
+	}
+}
+
+func (l *loader[V]) get(ctx context.Context, key string) result[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if r, ok := l.results[key]; ok {
+		return r
+	}
+
+	keys := l.pending
+	l.pending = nil
+	if !contains(keys, key) {
+		keys = append(keys, key)
+	}
+	values, err := l.fetch(ctx, keys)
+	for _, k := range keys {
+		v, found := values[k]
+		l.results[k] = result[V]{value: v, found: found, err: err}
+	}
+	return l.results[key]
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// loaderSet holds one loader per argument combination, e.g. one task loader
+// per date range.
+type loaderSet[V any] struct {
+	mu      sync.Mutex
+	loaders map[string]*loader[V]
+}
+
+func (s *loaderSet[V]) get(args string, fetch func(ctx context.Context, keys []string) (map[string]V, error)) *loader[V] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loaders == nil {
+		s.loaders = map[string]*loader[V]{}
+	}
+	l, ok := s.loaders[args]
+	if !ok {
+		l = newLoader(fetch)
+		s.loaders[args] = l
+	}
+	return l
+}
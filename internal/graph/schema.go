@@ -0,0 +1,486 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+	"github.com/roksva123/go-kinerja-backend/internal/resource"
+	"github.com/roksva123/go-kinerja-backend/internal/service"
+)
+
+// request is the per-request state: the view, the caller and the loaders,
+// so batches never mix two requests.
+type request struct {
+	view       resource.View
+	caller     model.Caller
+	members    *loader[resource.Member]
+	projects   *loader[resource.Project]
+	tasks      loaderSet[[]resource.Task]
+	workload   loaderSet[resource.Workload]
+	scorecards loaderSet[[]model.KPIScorecard]
+}
+
+type ctxKey struct{}
+
+func requestFrom(ctx context.Context) *request {
+	r, _ := ctx.Value(ctxKey{}).(*request)
+	return r
+}
+
+// source returns the parent value of a field whether it is T or *T.
+func source[T any](p graphql.ResolveParams) T {
+	switch v := p.Source.(type) {
+	case T:
+		return v
+	case *T:
+		if v != nil {
+			return *v
+		}
+	}
+	var zero T
+	return zero
+}
+
+func field[T any](typ graphql.Output, get func(T) interface{}) *graphql.Field {
+	return &graphql.Field{Type: typ, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+		return get(source[T](p)), nil
+	}}
+}
+
+func nonNull(t graphql.Type) graphql.Output { return graphql.NewNonNull(t) }
+
+func listOf(t graphql.Type) graphql.Output {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
+
+func stringArg(p graphql.ResolveParams, name string) string {
+	s, _ := p.Args[name].(string)
+	return s
+}
+
+func intArg(p graphql.ResolveParams, name string) int {
+	n, _ := p.Args[name].(int)
+	return n
+}
+
+// rangeArgs membaca argumen start/end dalam zona waktu request.
+func rangeArgs(p graphql.ResolveParams) (start, end time.Time, err error) {
+	loc := requestFrom(p.Context).view.Location
+	if start, err = dates.ParseStart(stringArg(p, "start"), loc); err != nil {
+		return start, end, apperr.Validation("invalid start: " + err.Error())
+	}
+	if end, err = dates.ParseEnd(stringArg(p, "end"), loc); err != nil {
+		return start, end, apperr.Validation("invalid end: " + err.Error())
+	}
+	if end.Before(start) {
+		return start, end, apperr.Validation("end must not be before start")
+	}
+	return start, end, nil
+}
+
+var rangeArgConfig = graphql.FieldConfigArgument{
+	"start": {Type: nonNull(graphql.String), Description: "Range start, any format package dates accepts"},
+	"end":   {Type: nonNull(graphql.String), Description: "Range end; a bare date covers the whole day"},
+}
+
+func pageArgs(defaultLimit int) graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"limit":  {Type: graphql.Int, DefaultValue: defaultLimit},
+		"offset": {Type: graphql.Int, DefaultValue: 0},
+		"search": {Type: graphql.String},
+	}
+}
+
+// listParams builds query.Params from the page arguments and the given
+// filter arguments.
+func listParams(p graphql.ResolveParams, filters ...string) query.Params {
+	params := query.Params{
+		Limit:   intArg(p, "limit"),
+		Offset:  intArg(p, "offset"),
+		Search:  stringArg(p, "search"),
+		Filters: map[string][]string{},
+	}
+	for _, name := range filters {
+		if v := stringArg(p, name); v != "" {
+			params.Filters[name] = []string{strings.ToLower(v)}
+		}
+	}
+	return params
+}
+
+func (e *Executor) buildSchema() (graphql.Schema, error) {
+	status := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TaskStatus",
+		Fields: graphql.Fields{
+			"id":    field(nonNull(graphql.ID), func(s resource.Status) interface{} { return s.ID }),
+			"name":  field(nonNull(graphql.String), func(s resource.Status) interface{} { return s.Name }),
+			"type":  field(nonNull(graphql.String), func(s resource.Status) interface{} { return s.Type }),
+			"color": field(nonNull(graphql.String), func(s resource.Status) interface{} { return s.Color }),
+		},
+	})
+
+	member := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Member",
+		Fields: graphql.Fields{
+			"id":        field(nonNull(graphql.ID), func(m resource.Member) interface{} { return m.ID }),
+			"name":      field(nonNull(graphql.String), func(m resource.Member) interface{} { return m.Name }),
+			"email":     field(nonNull(graphql.String), func(m resource.Member) interface{} { return m.Email }),
+			"role":      field(nonNull(graphql.String), func(m resource.Member) interface{} { return m.Role }),
+			"status":    field(nonNull(graphql.String), func(m resource.Member) interface{} { return m.Status }),
+			"createdAt": field(graphql.DateTime, func(m resource.Member) interface{} { return m.CreatedAt }),
+			"updatedAt": field(graphql.DateTime, func(m resource.Member) interface{} { return m.UpdatedAt }),
+		},
+	})
+
+	project := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Project",
+		Fields: graphql.Fields{
+			"id":       field(nonNull(graphql.ID), func(p resource.Project) interface{} { return p.ID }),
+			"level":    field(nonNull(graphql.String), func(p resource.Project) interface{} { return p.Level }),
+			"name":     field(nonNull(graphql.String), func(p resource.Project) interface{} { return p.Name }),
+			"archived": field(nonNull(graphql.Boolean), func(p resource.Project) interface{} { return p.Archived }),
+			"parentId": field(graphql.ID, func(p resource.Project) interface{} { return p.ParentID }),
+		},
+	})
+	project.AddFieldConfig("parent", &graphql.Field{
+		Type: project,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			parent := source[resource.Project](p).ParentID
+			if parent == nil {
+				return nil, nil
+			}
+			return requestFrom(p.Context).projects.Load(p.Context, *parent, nil), nil
+		},
+	})
+
+	task := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Task",
+		Fields: graphql.Fields{
+			"id":                   field(nonNull(graphql.ID), func(t resource.Task) interface{} { return t.ID }),
+			"name":                 field(nonNull(graphql.String), func(t resource.Task) interface{} { return t.Name }),
+			"description":          field(nonNull(graphql.String), func(t resource.Task) interface{} { return t.Description }),
+			"status":               field(nonNull(status), func(t resource.Task) interface{} { return t.Status }),
+			"projectId":            field(graphql.ID, func(t resource.Task) interface{} { return t.ProjectID }),
+			"assigneeIds":          field(listOf(graphql.ID), func(t resource.Task) interface{} { return t.AssigneeIDs }),
+			"startAt":              field(graphql.DateTime, func(t resource.Task) interface{} { return t.StartAt }),
+			"dueAt":                field(graphql.DateTime, func(t resource.Task) interface{} { return t.DueAt }),
+			"doneAt":               field(graphql.DateTime, func(t resource.Task) interface{} { return t.DoneAt }),
+			"closedAt":             field(graphql.DateTime, func(t resource.Task) interface{} { return t.ClosedAt }),
+			"createdAt":            field(graphql.DateTime, func(t resource.Task) interface{} { return t.CreatedAt }),
+			"updatedAt":            field(graphql.DateTime, func(t resource.Task) interface{} { return t.UpdatedAt }),
+			"estimateHours":        field(graphql.Float, func(t resource.Task) interface{} { return t.EstimateHours }),
+			"estimateSource":       field(nonNull(graphql.String), func(t resource.Task) interface{} { return t.EstimateSource }),
+			"spentHours":           field(graphql.Float, func(t resource.Task) interface{} { return t.SpentHours }),
+			"remainingHours":       field(graphql.Float, func(t resource.Task) interface{} { return t.RemainingHours }),
+			"efficiencyPercentage": field(graphql.Float, func(t resource.Task) interface{} { return t.EfficiencyPercentage }),
+		},
+	})
+	task.AddFieldConfig("project", &graphql.Field{
+		Type: project,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			id := source[resource.Task](p).ProjectID
+			if id == nil {
+				return nil, nil
+			}
+			return requestFrom(p.Context).projects.Load(p.Context, *id, nil), nil
+		},
+	})
+	task.AddFieldConfig("assignees", &graphql.Field{
+		Type: listOf(member),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			members := requestFrom(p.Context).members
+			var thunks []func() (interface{}, error)
+			for _, id := range source[resource.Task](p).AssigneeIDs {
+				thunks = append(thunks, members.Load(p.Context, id, nil))
+			}
+			return func() (interface{}, error) {
+				out := []resource.Member{}
+				for _, thunk := range thunks {
+					v, err := thunk()
+					if err != nil {
+						return nil, err
+					}
+					if m, ok := v.(resource.Member); ok {
+						out = append(out, m)
+					}
+				}
+				return out, nil
+			}, nil
+		},
+	})
+
+	workload := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "WorkloadPeriod",
+		Description: "Logged time of one member over a period",
+		Fields: graphql.Fields{
+			"memberId":              field(nonNull(graphql.ID), func(w resource.Workload) interface{} { return w.MemberID }),
+			"periodStartAt":         field(nonNull(graphql.DateTime), func(w resource.Workload) interface{} { return w.PeriodStartAt }),
+			"periodEndAt":           field(nonNull(graphql.DateTime), func(w resource.Workload) interface{} { return w.PeriodEndAt }),
+			"taskCount":             field(nonNull(graphql.Int), func(w resource.Workload) interface{} { return w.TaskCount }),
+			"loggedHours":           field(nonNull(graphql.Float), func(w resource.Workload) interface{} { return w.LoggedHours }),
+			"expectedHours":         field(nonNull(graphql.Float), func(w resource.Workload) interface{} { return w.ExpectedHours }),
+			"utilizationPercentage": field(graphql.Float, func(w resource.Workload) interface{} { return w.UtilizationPercentage }),
+			"member": {
+				Type: member,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return requestFrom(p.Context).members.Load(p.Context, source[resource.Workload](p).MemberID, nil), nil
+				},
+			},
+		},
+	})
+
+	scorecardItem := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ScorecardItem",
+		Fields: graphql.Fields{
+			"indicatorCode": field(nonNull(graphql.String), func(i model.KPIScorecardItem) interface{} { return i.IndicatorCode }),
+			"indicatorName": field(nonNull(graphql.String), func(i model.KPIScorecardItem) interface{} { return i.IndicatorName }),
+			"unit":          field(nonNull(graphql.String), func(i model.KPIScorecardItem) interface{} { return i.Unit }),
+			"value":         field(graphql.Float, func(i model.KPIScorecardItem) interface{} { return i.Value }),
+			"score":         field(nonNull(graphql.Float), func(i model.KPIScorecardItem) interface{} { return i.Score }),
+			"weight":        field(nonNull(graphql.Float), func(i model.KPIScorecardItem) interface{} { return i.Weight }),
+			"weightedScore": field(nonNull(graphql.Float), func(i model.KPIScorecardItem) interface{} { return i.WeightedScore }),
+			"band":          field(nonNull(graphql.String), func(i model.KPIScorecardItem) interface{} { return i.Band }),
+			"taskCount":     field(nonNull(graphql.Int), func(i model.KPIScorecardItem) interface{} { return i.TaskCount }),
+		},
+	})
+
+	// Waktu scorecard dikonversi ke zona request seperti resource lain.
+	scorecardTime := func(get func(model.KPIScorecard) time.Time) *graphql.Field {
+		return &graphql.Field{Type: nonNull(graphql.DateTime), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			t := get(source[model.KPIScorecard](p))
+			if loc := requestFrom(p.Context).view.Location; loc != nil {
+				t = t.In(loc)
+			}
+			return t, nil
+		}}
+	}
+	scorecard := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Scorecard",
+		Fields: graphql.Fields{
+			"id":            field(nonNull(graphql.ID), func(s model.KPIScorecard) interface{} { return s.ID }),
+			"memberId":      field(nonNull(graphql.ID), func(s model.KPIScorecard) interface{} { return resource.MemberID(s.UserID) }),
+			"periodType":    field(nonNull(graphql.String), func(s model.KPIScorecard) interface{} { return s.PeriodType }),
+			"periodLabel":   field(nonNull(graphql.String), func(s model.KPIScorecard) interface{} { return s.PeriodLabel }),
+			"periodStartAt": scorecardTime(func(s model.KPIScorecard) time.Time { return s.PeriodStart }),
+			"periodEndAt":   scorecardTime(func(s model.KPIScorecard) time.Time { return s.PeriodEnd }),
+			"computedAt":    scorecardTime(func(s model.KPIScorecard) time.Time { return s.ComputedAt }),
+			"totalScore":    field(nonNull(graphql.Float), func(s model.KPIScorecard) interface{} { return s.TotalScore }),
+			"items":         field(listOf(scorecardItem), func(s model.KPIScorecard) interface{} { return s.Items }),
+			"member": {
+				Type: member,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return requestFrom(p.Context).members.Load(p.Context, resource.MemberID(source[model.KPIScorecard](p).UserID), nil), nil
+				},
+			},
+		},
+	})
+
+	member.AddFieldConfig("tasks", &graphql.Field{
+		Type:        listOf(task),
+		Description: "Tasks scheduled across, done in or closed in the range",
+		Args:        rangeArgConfig,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			start, end, err := rangeArgs(p)
+			if err != nil {
+				return nil, err
+			}
+			r := requestFrom(p.Context)
+			l := r.tasks.get(start.String()+"|"+end.String(), func(ctx context.Context, ids []string) (map[string][]resource.Task, error) {
+				return e.resources.TasksForMembers(ctx, ids, start, end, resource.View{Location: r.view.Location})
+			})
+			return l.Load(p.Context, source[resource.Member](p).ID, []resource.Task{}), nil
+		},
+	})
+	member.AddFieldConfig("workload", &graphql.Field{
+		Type: workload,
+		Args: rangeArgConfig,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			start, end, err := rangeArgs(p)
+			if err != nil {
+				return nil, err
+			}
+			r := requestFrom(p.Context)
+			l := r.workload.get(start.String()+"|"+end.String(), func(ctx context.Context, ids []string) (map[string]resource.Workload, error) {
+				all, _, err := e.resources.GetWorkload(ctx, start, end, query.Params{Limit: query.NoLimit}, resource.View{Location: r.view.Location})
+				if err != nil {
+					return nil, err
+				}
+				out := make(map[string]resource.Workload, len(all))
+				for _, w := range all {
+					out[w.MemberID] = w
+				}
+				return out, nil
+			})
+			return l.Load(p.Context, source[resource.Member](p).ID, nil), nil
+		},
+	})
+	member.AddFieldConfig("scorecards", &graphql.Field{
+		Type: listOf(scorecard),
+		Args: graphql.FieldConfigArgument{
+			"period": {Type: nonNull(graphql.String), Description: "KPI period label, e.g. 2026-09 or 2026-Q3"},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			// anggota hanya melihat scorecard miliknya, seperti di REST
+			r := requestFrom(p.Context)
+			memberID, err := resource.ParseMemberID(source[resource.Member](p).ID)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := service.ScopeUserID(r.caller, &memberID); err != nil {
+				return nil, err
+			}
+			period := stringArg(p, "period")
+			l := r.scorecards.get(period, func(ctx context.Context, ids []string) (map[string][]model.KPIScorecard, error) {
+				scope, err := service.ScopeUserID(r.caller, nil)
+				if err != nil {
+					return nil, err
+				}
+				all, err := e.kpi.GetScorecards(ctx, period, scope)
+				if err != nil {
+					return nil, err
+				}
+				out := map[string][]model.KPIScorecard{}
+				for _, sc := range all {
+					id := resource.MemberID(sc.UserID)
+					out[id] = append(out[id], sc)
+				}
+				return out, nil
+			})
+			return l.Load(p.Context, source[resource.Member](p).ID, []model.KPIScorecard{}), nil
+		},
+	})
+
+	idArg := graphql.FieldConfigArgument{"id": {Type: nonNull(graphql.ID)}}
+	root := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"members": {
+				Type: listOf(member),
+				Args: withArgs(pageArgs(query.DefaultLimit), graphql.FieldConfigArgument{
+					query.FilterRole:   {Type: graphql.String},
+					query.FilterStatus: {Type: graphql.String},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					r := requestFrom(p.Context)
+					members, _, err := e.resources.ListMembers(p.Context, listParams(p, query.FilterRole, query.FilterStatus), r.view)
+					return members, err
+				},
+			},
+			"member": {
+				Type: member,
+				Args: idArg,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return notFoundAsNull(e.resources.GetMember(p.Context, stringArg(p, "id"), requestFrom(p.Context).view))
+				},
+			},
+			"tasks": {
+				Type: listOf(task),
+				Args: withArgs(pageArgs(query.DefaultLimit), graphql.FieldConfigArgument{
+					query.FilterStatus:   {Type: graphql.String},
+					query.FilterRole:     {Type: graphql.String},
+					query.FilterAssignee: {Type: graphql.String, Description: "Member id, name or email"},
+					query.FilterProject:  {Type: graphql.String, Description: "List or folder id or name"},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					params := listParams(p, query.FilterStatus, query.FilterRole, query.FilterAssignee, query.FilterProject)
+					tasks, _, err := e.resources.ListTasks(p.Context, params, requestFrom(p.Context).view)
+					return tasks, err
+				},
+			},
+			"task": {
+				Type: task,
+				Args: idArg,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return notFoundAsNull(e.resources.GetTask(p.Context, stringArg(p, "id"), requestFrom(p.Context).view))
+				},
+			},
+			"projects": {
+				Type: listOf(project),
+				Args: withArgs(pageArgs(query.DefaultLimit), graphql.FieldConfigArgument{
+					"level":           {Type: graphql.String, Description: "space, folder or list; default all"},
+					"includeArchived": {Type: graphql.Boolean, DefaultValue: false},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					archived, _ := p.Args["includeArchived"].(bool)
+					projects, _, err := e.resources.ListProjects(p.Context, stringArg(p, "level"), archived, listParams(p), requestFrom(p.Context).view)
+					return projects, err
+				},
+			},
+			"project": {
+				Type: project,
+				Args: idArg,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return notFoundAsNull(e.resources.GetProject(p.Context, stringArg(p, "id"), requestFrom(p.Context).view))
+				},
+			},
+			"workload": {
+				Type: listOf(workload),
+				Args: withArgs(rangeArgConfig, graphql.FieldConfigArgument{
+					"limit":  {Type: graphql.Int, DefaultValue: query.DefaultLimit},
+					"offset": {Type: graphql.Int, DefaultValue: 0},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					start, end, err := rangeArgs(p)
+					if err != nil {
+						return nil, err
+					}
+					periods, _, err := e.resources.GetWorkload(p.Context, start, end, listParams(p), requestFrom(p.Context).view)
+					return periods, err
+				},
+			},
+			"scorecards": {
+				Type: listOf(scorecard),
+				Args: graphql.FieldConfigArgument{
+					"period":   {Type: nonNull(graphql.String), Description: "KPI period label, e.g. 2026-09 or 2026-Q3"},
+					"memberId": {Type: graphql.ID},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var userID *int64
+					if id := stringArg(p, "memberId"); id != "" {
+						n, err := resource.ParseMemberID(id)
+						if err != nil {
+							return nil, err
+						}
+						userID = &n
+					}
+					userID, err := service.ScopeUserID(requestFrom(p.Context).caller, userID)
+					if err != nil {
+						return nil, err
+					}
+					return e.kpi.GetScorecards(p.Context, stringArg(p, "period"), userID)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: root})
+}
+
+func withArgs(a, b graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	out := graphql.FieldConfigArgument{}
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		out[k] = v
+	}
+	return out
+}
+
+// notFoundAsNull maps not_found to null, as GraphQL clients expect for a
+// lookup by id.
+func notFoundAsNull[T any](v *T, err error) (interface{}, error) {
+	if err != nil {
+		if apperr.From(err).Code == apperr.CodeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return v, nil
+}
@@ -0,0 +1,152 @@
+// Package i18n picks the response language and holds the user-facing strings
+// the API produces itself: messages, duration text, status labels and
+// report/export headers. ClickUp data (task names, raw statuses) is passed
+// through untouched.
+//
+// The language comes from ?lang= first, then the member's saved preference,
+// then Accept-Language; anything other than Indonesian falls back to English.
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	EN = "en"
+	ID = "id"
+)
+
+// Default is the language used when nothing else matches.
+const Default = EN
+
+// Supported reports whether lang is a language the API speaks.
+func Supported(lang string) bool {
+	return lang == EN || lang == ID
+}
+
+// Normalize maps a tag such as "id-ID" or "EN_us" to a supported language,
+// or "" when it is not one.
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	switch tag {
+	case "id", "in":
+		return ID
+	case "en":
+		return EN
+	}
+	return ""
+}
+
+// Negotiate returns the first supported language among the explicit choices
+// (in order, empty ones skipped) and then the Accept-Language header, which
+// is read by q-value.
+func Negotiate(acceptLanguage string, explicit ...string) string {
+	for _, e := range explicit {
+		if lang := Normalize(e); lang != "" {
+			return lang
+		}
+	}
+	if lang := fromAcceptLanguage(acceptLanguage); lang != "" {
+		return lang
+	}
+	return Default
+}
+
+func fromAcceptLanguage(header string) string {
+	type tag struct {
+		lang string
+		q    float64
+	}
+	var tags []tag
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		q := 1.0
+		for _, p := range fields[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				if v, err := strconv.ParseFloat(p[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if lang := Normalize(fields[0]); lang != "" && q > 0 {
+			tags = append(tags, tag{lang, q})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	if len(tags) == 0 {
+		return ""
+	}
+	return tags[0].lang
+}
+
+// T returns the message for key in lang, formatted with args. Unknown keys
+// are returned as is so a missing entry shows up instead of an empty string.
+func T(lang, key string, args ...interface{}) string {
+	e, ok := messages[key]
+	if !ok {
+		return key
+	}
+	msg := e.en
+	if lang == ID && e.id != "" {
+		msg = e.id
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// Pick returns id for Indonesian and en otherwise, for strings that live
+// next to their data (such as export columns) rather than in the catalogue.
+func Pick(lang, en, id string) string {
+	if lang == ID && id != "" {
+		return id
+	}
+	return en
+}
+
+// Working and calendar day lengths for Duration.
+const (
+	WorkDayHours     = 8
+	CalendarDayHours = 24
+)
+
+// Duration spells hours as whole days of dayHours plus remaining hours,
+// e.g. "1 day, 3 hours" or "1 hari, 3 jam".
+func Duration(lang string, hours float64, dayHours int) string {
+	sign := ""
+	if hours < 0 {
+		sign = "-"
+		hours = -hours
+	}
+	days := int(hours / float64(dayHours))
+	rest := int(math.Mod(hours, float64(dayHours)))
+	if lang == ID {
+		return fmt.Sprintf("%s%d hari, %d jam", sign, days, rest)
+	}
+	return fmt.Sprintf("%s%d %s, %d %s", sign, days, plural(days, "day", "days"), rest, plural(rest, "hour", "hours"))
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
+
+// StatusLabel returns the display label of a normalized task status
+// ("to do", "progres", "done", "canceled"); other statuses come back as is.
+func StatusLabel(lang, status string) string {
+	if _, ok := messages["status."+status]; !ok {
+		return status
+	}
+	return T(lang, "status."+status)
+}
@@ -0,0 +1,84 @@
+package i18n
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name     string
+		accept   string
+		explicit []string
+		want     string
+	}{
+		{"nothing", "", nil, EN},
+		{"accept-language", "id-ID,id;q=0.9,en;q=0.8", nil, ID},
+		{"q-value wins over order", "en;q=0.5, id;q=0.9", nil, ID},
+		{"q=0 is refused", "id;q=0, en;q=0.1", nil, EN},
+		{"unsupported tags skipped", "fr-FR, de;q=0.9, in;q=0.5", nil, ID},
+		{"only unsupported", "fr, ja", nil, EN},
+		// ?lang= menang atas klaim token dan Accept-Language
+		{"query over claim", "en", []string{"id", "en"}, ID},
+		{"claim over header", "en", []string{"", "ID_id"}, ID},
+		{"empty explicit skipped", "id", []string{"", ""}, ID},
+		{"unsupported explicit skipped", "id", []string{"fr", "xx"}, ID},
+		{"explicit english over header", "id", []string{"en-GB"}, EN},
+	}
+	for _, tt := range tests {
+		if got := Negotiate(tt.accept, tt.explicit...); got != tt.want {
+			t.Errorf("%s: Negotiate(%q, %q) = %q, want %q", tt.name, tt.accept, tt.explicit, got, tt.want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(ID, "msg.budget_deleted"); got != "budget dihapus" {
+		t.Errorf("T(id) = %q", got)
+	}
+	if got := T(EN, "msg.budget_deleted"); got != "budget deleted" {
+		t.Errorf("T(en) = %q", got)
+	}
+	// bahasa yang tidak dikenal jatuh ke bahasa Inggris
+	if got := T("fr", "msg.budget_deleted"); got != "budget deleted" {
+		t.Errorf("T(fr) = %q", got)
+	}
+	// kunci yang hilang dikembalikan apa adanya supaya terlihat
+	if got := T(ID, "msg.no_such_key"); got != "msg.no_such_key" {
+		t.Errorf("missing key = %q", got)
+	}
+	if got := StatusLabel(ID, "done"); got != "Selesai" {
+		t.Errorf("StatusLabel(done) = %q", got)
+	}
+	if got := StatusLabel(ID, "blocked"); got != "blocked" {
+		t.Errorf("StatusLabel(blocked) = %q", got)
+	}
+	if got := Pick(ID, "Name", ""); got != "Name" {
+		t.Errorf("Pick without id = %q", got)
+	}
+}
+
+// Setiap pesan di katalog punya terjemahan Indonesia.
+func TestMessagesTranslated(t *testing.T) {
+	for key, e := range messages {
+		if e.en == "" || e.id == "" {
+			t.Errorf("%s is missing a translation: %+v", key, e)
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		lang     string
+		hours    float64
+		dayHours int
+		want     string
+	}{
+		{EN, 11, WorkDayHours, "1 day, 3 hours"},
+		{EN, 25, CalendarDayHours, "1 day, 1 hour"},
+		{ID, 19, WorkDayHours, "2 hari, 3 jam"},
+		{EN, -9, WorkDayHours, "-1 day, 1 hour"},
+	}
+	for _, tt := range tests {
+		if got := Duration(tt.lang, tt.hours, tt.dayHours); got != tt.want {
+			t.Errorf("Duration(%s, %v, %d) = %q, want %q", tt.lang, tt.hours, tt.dayHours, got, tt.want)
+		}
+	}
+}
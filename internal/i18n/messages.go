@@ -0,0 +1,96 @@
+package i18n
+
+type entry struct{ en, id string }
+
+// messages is the catalogue for T. Keys are grouped by prefix:
+// error.* (apperr codes), msg.* (handler responses), status.* and
+// schedule.* (labels), report.* (digests and the member PDF). Export column
+// headers live next to their sheets as export.Column.
+var messages = map[string]entry{
+	"error.validation_failed":   {"The request is invalid.", "Permintaan tidak valid."},
+	"error.unauthorized":        {"Authentication is required.", "Autentikasi diperlukan."},
+	"error.forbidden":           {"You are not allowed to do this.", "Anda tidak diizinkan melakukan ini."},
+	"error.not_found":           {"The resource was not found.", "Data tidak ditemukan."},
+	"error.conflict":            {"The resource state does not allow this action.", "Status data tidak mengizinkan aksi ini."},
+	"error.rate_limited":        {"Too many requests, try again later.", "Terlalu banyak permintaan, coba lagi nanti."},
+	"error.internal_error":      {"An unexpected error occurred.", "Terjadi kesalahan tak terduga."},
+	"error.clickup_error":       {"ClickUp rejected the request.", "ClickUp menolak permintaan."},
+	"error.clickup_unavailable": {"ClickUp is unavailable, try again later.", "ClickUp tidak dapat dihubungi, coba lagi nanti."},
+	"error.upstream_error":      {"An external service failed.", "Layanan eksternal gagal."},
+
+	"msg.teams_synced":           {"teams synced", "tim berhasil disinkronkan"},
+	"msg.members_synced":         {"members synced", "member berhasil disinkronkan"},
+	"msg.tasks_synced":           {"tasks synced", "task berhasil disinkronkan"},
+	"msg.full_sync_done":         {"full sync completed successfully", "sinkronisasi penuh berhasil"},
+	"msg.workload_synced":        {"workload synced", "workload berhasil disinkronkan"},
+	"msg.structure_synced":       {"Sync for spaces, folders, and lists completed successfully", "Sinkronisasi space, folder, dan list berhasil"},
+	"msg.full_sync_started":      {"Full sync process has been started in the background.", "Sinkronisasi penuh sedang berjalan di background."},
+	"msg.budget_deleted":         {"budget deleted", "budget dihapus"},
+	"msg.sprint_deleted":         {"sprint deleted", "sprint dihapus"},
+	"msg.objective_deleted":      {"objective deleted", "objective dihapus"},
+	"msg.key_result_deleted":     {"key result deleted", "key result dihapus"},
+	"msg.subscription_deleted":   {"subscription deleted", "langganan dihapus"},
+	"msg.test_notification_sent": {"test notification sent", "notifikasi uji terkirim"},
+	"msg.report_deleted":         {"report definition deleted", "definisi laporan dihapus"},
+	"msg.language_updated":       {"language preference updated", "preferensi bahasa diperbarui"},
+	"msg.login_successful":       {"Login Successful", "Login berhasil"},
+
+	"status.to do":    {"To do", "Belum dikerjakan"},
+	"status.progres":  {"In progress", "Sedang dikerjakan"},
+	"status.done":     {"Done", "Selesai"},
+	"status.canceled": {"Canceled", "Dibatalkan"},
+
+	"schedule.Early":         {"Early", "Lebih cepat"},
+	"schedule.On Time":       {"On time", "Tepat waktu"},
+	"schedule.Late":          {"Late", "Terlambat"},
+	"schedule.Severely Late": {"Severely late", "Sangat terlambat"},
+
+	"report.member":            {"Member", "Member"},
+	"report.role":              {"Role", "Role"},
+	"report.tasks":             {"Tasks", "Jumlah Task"},
+	"report.hours_logged":      {"Hours logged", "Jam tercatat"},
+	"report.hours_estimated":   {"Hours estimated", "Jam estimasi"},
+	"report.hours_expected":    {"Hours expected", "Jam seharusnya"},
+	"report.expected_hours":    {"Expected hours", "Jam seharusnya"},
+	"report.utilisation":       {"Utilisation %", "Utilisasi %"},
+	"report.score":             {"Score", "Skor"},
+	"report.members_count":     {"Members: %d", "Jumlah member: %d"},
+	"report.total_logged":      {"Total hours logged: %s", "Total jam tercatat: %s"},
+	"report.avg_utilisation":   {"Average utilisation: %s%%", "Rata-rata utilisasi: %s%%"},
+	"report.over_capacity":     {"Members above 100%%: %d", "Member di atas 100%%: %d"},
+	"report.avg_score":         {"Average score: %s", "Rata-rata skor: %s"},
+	"report.no_data":           {"No data for this period.", "Tidak ada data untuk periode ini."},
+	"report.generated":         {"Generated", "Dibuat"},
+	"report.page":              {"Page %d", "Halaman %d"},
+	"report.performance":       {"Performance report", "Laporan kinerja"},
+	"report.footer":            {"%s · %s · generated %s", "%s · %s · dibuat %s"},
+	"report.workload_line":     {"%d tasks · %s h logged · %s h estimated · %s h expected · utilisation %s%%", "%d task · %s jam tercatat · %s jam estimasi · %s jam seharusnya · utilisasi %s%%"},
+	"report.on_time_line":      {"%d of %d completed tasks done on or before the due date.", "%d dari %d task selesai pada atau sebelum tenggat."},
+	"report.performance_title": {"Performance report %s %s", "Laporan kinerja %s %s"},
+	"report.no_role":           {"No role", "Tanpa role"},
+	"report.period":            {"Period %s (%s – %s)", "Periode %s (%s – %s)"},
+	"report.kpi_scorecard":     {"KPI scorecard", "Scorecard KPI"},
+	"report.no_scorecard":      {"No scorecard for this period.", "Tidak ada scorecard untuk periode ini."},
+	"report.total_score":       {"Total score: %s", "Skor total: %s"},
+	"report.indicator":         {"Indicator", "Indikator"},
+	"report.value":             {"Value", "Nilai"},
+	"report.band":              {"Band", "Band"},
+	"report.weight":            {"Weight", "Bobot"},
+	"report.weighted":          {"Weighted", "Tertimbang"},
+	"report.workload":          {"Workload", "Beban kerja"},
+	"report.tasks_by_status":   {"Tasks by status", "Task per status"},
+	"report.on_time":           {"On-time completion", "Penyelesaian tepat waktu"},
+	"report.no_completed":      {"No completed tasks in this period.", "Tidak ada task selesai pada periode ini."},
+	"report.on_time_rate":      {"On-time rate", "Tingkat tepat waktu"},
+	"report.top_projects":      {"Top projects", "Proyek teratas"},
+	"report.no_projects":       {"No project activity in this period.", "Tidak ada aktivitas proyek pada periode ini."},
+	"report.task_list":         {"Tasks (%d)", "Task (%d)"},
+	"report.no_tasks":          {"No tasks in this period.", "Tidak ada task pada periode ini."},
+	"report.task":              {"Task", "Task"},
+	"report.project":           {"Project", "Proyek"},
+	"report.status":            {"Status", "Status"},
+	"report.due":               {"Due", "Tenggat"},
+	"report.done":              {"Done", "Selesai"},
+	"report.est_h":             {"Est (h)", "Est (jam)"},
+	"report.spent_h":           {"Spent (h)", "Terpakai (jam)"},
+}
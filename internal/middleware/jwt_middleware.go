@@ -1,25 +1,28 @@
 package middleware
 
 import (
-	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
 )
 
 func JWTAuthMiddleware(secret string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		auth := c.GetHeader("Authorization")
 		if auth == "" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing token"})
+			_ = c.Error(apperr.New(apperr.CodeUnauthorized, "missing token"))
+			c.Abort()
 			return
 		}
 
 		parts := strings.SplitN(auth, " ", 2)
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid header"})
+			_ = c.Error(apperr.New(apperr.CodeUnauthorized, "invalid header"))
+			c.Abort()
 			return
 		}
 
@@ -29,14 +32,16 @@ func JWTAuthMiddleware(secret string) gin.HandlerFunc {
 		})
 
 		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			_ = c.Error(apperr.New(apperr.CodeUnauthorized, "invalid token"))
+			c.Abort()
 			return
 		}
 
 		if claims, ok := token.Claims.(jwt.MapClaims); ok {
 			if exp, ok := claims["exp"].(float64); ok {
 				if time.Unix(int64(exp), 0).Before(time.Now()) {
-					c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "token expired"})
+					_ = c.Error(apperr.New(apperr.CodeUnauthorized, "token expired"))
+					c.Abort()
 					return
 				}
 			}
@@ -46,3 +51,24 @@ func JWTAuthMiddleware(secret string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequireRole runs after JWTAuthMiddleware and only lets through tokens whose
+// "role" claim is one of roles.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := ""
+		if v, ok := c.Get("claims"); ok {
+			if claims, ok := v.(jwt.MapClaims); ok {
+				role, _ = claims["role"].(string)
+			}
+		}
+		for _, r := range roles {
+			if role == r {
+				c.Next()
+				return
+			}
+		}
+		_ = c.Error(apperr.New(apperr.CodeForbidden, "role "+strconv.Quote(role)+" may not do this"))
+		c.Abort()
+	}
+}
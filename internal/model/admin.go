@@ -1,8 +1,49 @@
 package model
 
+// Role akun admin, dibawa sebagai claim "role" di JWT.
+const (
+    RoleAdmin   = "admin"
+    RoleManager = "manager"
+    RoleMember  = "member"
+)
+
 type Admin struct {
     ID           string `json:"id"`
     Username     string `json:"username"`
     PasswordHash string `json:"password_hash"`
     CreatedAt    int16  `json:"createdat"`
+    Language     string `json:"language"`
+    Role         string `json:"role"`
+    // UserID menghubungkan akun dengan anggota ClickUp, dibawa sebagai claim
+    // "user_id" supaya anggota bisa meng-acknowledge review miliknya.
+    UserID       *int64 `json:"user_id,omitempty"`
+}
+
+// Caller adalah pemilik token pada request yang sedang diproses.
+type Caller struct {
+    Subject string
+    Role    string
+    UserID  *int64
+}
+
+// ValidRole reports whether role is one of RoleAdmin, RoleManager, RoleMember.
+func ValidRole(role string) bool {
+    return role == RoleAdmin || role == RoleManager || role == RoleMember
+}
+
+// AccountRequest membuat atau memperbarui akun beserta role dan anggota
+// ClickUp yang terhubung.
+type AccountRequest struct {
+    Password string `json:"password" binding:"required"`
+    Role     string `json:"role" binding:"required"`
+    UserID   *int64 `json:"user_id,omitempty"`
+}
+
+// Account adalah akun tanpa hash password, untuk response.
+type Account struct {
+    ID       string `json:"id"`
+    Username string `json:"username"`
+    Role     string `json:"role"`
+    UserID   *int64 `json:"user_id,omitempty"`
+    Language string `json:"language"`
 }
@@ -0,0 +1,75 @@
+package model
+
+import "time"
+
+// Kode rule alert yang dievaluasi oleh AlertService.
+const (
+	AlertRuleOverdue        = "overdue"
+	AlertRuleDueSoonNoTime  = "due_soon_no_time"
+	AlertRuleOverEstimate   = "over_estimate"
+	AlertRuleMemberOverload = "member_overload"
+)
+
+// Status alert: open -> acknowledged -> resolved. Alert yang kondisinya sudah
+// tidak terpenuhi pada evaluasi berikutnya otomatis resolved.
+const (
+	AlertStatusOpen         = "open"
+	AlertStatusAcknowledged = "acknowledged"
+	AlertStatusResolved     = "resolved"
+)
+
+const (
+	AlertSubjectTask   = "task"
+	AlertSubjectMember = "member"
+)
+
+// AlertRule is a configurable rule. The meaning of Threshold depends on the
+// rule: days for overdue/due_soon_no_time/member_overload, percent for
+// over_estimate.
+type AlertRule struct {
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Threshold   float64   `json:"threshold"`
+	Active      bool      `json:"active"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type AlertRuleRequest struct {
+	Threshold *float64 `json:"threshold"`
+	Active    *bool    `json:"active"`
+}
+
+// AlertCandidate is a rule violation found by one evaluation.
+type AlertCandidate struct {
+	RuleCode    string   `json:"rule_code"`
+	SubjectType string   `json:"subject_type"`
+	SubjectID   string   `json:"subject_id"`
+	SubjectName string   `json:"subject_name"`
+	UserID      *int64   `json:"user_id,omitempty"`
+	Message     string   `json:"message"`
+	Value       *float64 `json:"value,omitempty"`
+}
+
+type Alert struct {
+	ID int64 `json:"id"`
+	AlertCandidate
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastSeenAt     time.Time  `json:"last_seen_at"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy string     `json:"acknowledged_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+}
+
+type AlertFilter struct {
+	Status   string
+	RuleCode string
+	UserID   *int64
+}
+
+type AlertEvaluation struct {
+	Opened   int `json:"opened"`
+	Updated  int `json:"updated"`
+	Resolved int `json:"resolved"`
+}
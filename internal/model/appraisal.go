@@ -0,0 +1,96 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// Status periode penilaian.
+const (
+	AppraisalOpen     = "open"
+	AppraisalComputed = "computed"
+	AppraisalLocked   = "locked"
+)
+
+type AppraisalPeriod struct {
+	ID          int64      `json:"id"`
+	Name        string     `json:"name"`
+	PeriodStart time.Time  `json:"period_start"`
+	PeriodEnd   time.Time  `json:"period_end"`
+	Status      string     `json:"status"`
+	ComputedAt  *time.Time `json:"computed_at,omitempty"`
+	LockedAt    *time.Time `json:"locked_at,omitempty"`
+	LockedBy    *string    `json:"locked_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ScorecardLabel is the KPI period label used for the live scorecards of an
+// appraisal period.
+func (p AppraisalPeriod) ScorecardLabel() string {
+	return "appraisal-" + strconv.FormatInt(p.ID, 10)
+}
+
+type AppraisalPeriodRequest struct {
+	Name        string `json:"name" binding:"required"`
+	PeriodStart string `json:"period_start" binding:"required"`
+	PeriodEnd   string `json:"period_end" binding:"required"`
+}
+
+type AppraisalAuditEntry struct {
+	ID        int64     `json:"id"`
+	PeriodID  int64     `json:"period_id"`
+	Action    string    `json:"action"`
+	Actor     string    `json:"actor"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AppraisalTaskSnapshot is a task metric row frozen when a period is locked.
+type AppraisalTaskSnapshot struct {
+	KPITaskMetric
+	SnapshotAt time.Time `json:"snapshot_at"`
+}
+
+type AppraisalScorecards struct {
+	Period     AppraisalPeriod            `json:"period"`
+	Frozen     bool                       `json:"frozen"`
+	Count      int                        `json:"count"`
+	Scorecards []AppraisalMemberScorecard `json:"scorecards"`
+}
+
+// AppraisalGrades adalah nilai akhir yang boleh diberikan manajer.
+var AppraisalGrades = []string{"A", "B", "C", "D", "E"}
+
+// AppraisalReview is the qualitative review a manager writes for one member
+// in one appraisal period.
+type AppraisalReview struct {
+	PeriodID       int64      `json:"period_id"`
+	UserID         int64      `json:"user_id"`
+	Reviewer       string     `json:"reviewer"`
+	Rating         int        `json:"rating"`
+	Strengths      string     `json:"strengths"`
+	Improvements   string     `json:"improvements"`
+	FinalGrade     string     `json:"final_grade"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at,omitempty"`
+	Rebuttal       string     `json:"rebuttal,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type AppraisalReviewRequest struct {
+	Rating       int    `json:"rating" binding:"required"`
+	Strengths    string `json:"strengths"`
+	Improvements string `json:"improvements"`
+	FinalGrade   string `json:"final_grade" binding:"required"`
+}
+
+type AppraisalAcknowledgeRequest struct {
+	Rebuttal string `json:"rebuttal"`
+}
+
+// AppraisalMemberScorecard is a KPI scorecard together with the manager review.
+type AppraisalMemberScorecard struct {
+	KPIScorecard
+	Review *AppraisalReview `json:"review"`
+}
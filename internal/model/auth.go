@@ -6,6 +6,10 @@ type LoginRequest struct {
 	Password string `json:"password" form:"password" binding:"required"`
 }
 
+type LanguageRequest struct {
+	Language string `json:"language" binding:"required"`
+}
+
 type LoginResponse struct {
 	Token    string   `json:"token"`
 }
\ No newline at end of file
@@ -0,0 +1,52 @@
+package model
+
+import "time"
+
+// DefaultBudgetThresholds adalah ambang alert (persen) bila tidak diatur.
+var DefaultBudgetThresholds = []float64{75, 90, 100}
+
+// ProjectBudget is an hour budget set on a ClickUp folder or list.
+type ProjectBudget struct {
+	ID          int64      `json:"id"`
+	Level       string     `json:"level"`
+	NodeID      string     `json:"node_id"`
+	NodeName    string     `json:"node_name"`
+	BudgetHours float64    `json:"budget_hours"`
+	StartDate   *time.Time `json:"start_date,omitempty"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+	Thresholds  []float64  `json:"thresholds"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// BudgetRequest: tanggal dalam format YYYY-MM-DD, boleh kosong.
+type BudgetRequest struct {
+	Level       string    `json:"level" binding:"required"`
+	NodeID      string    `json:"node_id" binding:"required"`
+	BudgetHours float64   `json:"budget_hours" binding:"required"`
+	StartDate   string    `json:"start_date"`
+	EndDate     string    `json:"end_date"`
+	Thresholds  []float64 `json:"thresholds"`
+}
+
+type BudgetAlert struct {
+	ID         int64     `json:"id"`
+	BudgetID   int64     `json:"budget_id"`
+	Threshold  float64   `json:"threshold"`
+	BurnPct    float64   `json:"burn_pct"`
+	SpentHours float64   `json:"spent_hours"`
+	FiredAt    time.Time `json:"fired_at"`
+}
+
+type BudgetStatus struct {
+	ProjectBudget
+	SpentHours          float64       `json:"spent_hours"`
+	RemainingHours      float64       `json:"remaining_hours"`
+	BurnPct             float64       `json:"burn_pct"`
+	BurnRatePerWeek     float64       `json:"burn_rate_per_week"`
+	ProjectedExhaustion *time.Time    `json:"projected_exhaustion,omitempty"`
+	ProjectedSpentAtEnd *float64      `json:"projected_spent_at_end,omitempty"`
+	TaskCount           int           `json:"task_count"`
+	Alerts              []BudgetAlert `json:"alerts"`
+	NewAlerts           []BudgetAlert `json:"new_alerts,omitempty"`
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type SpaceInfo struct {
 	ID   string `json:"id"`
 	Name string `json:"name,omitempty"`
@@ -22,6 +24,11 @@ type List struct {
 	Archived bool   `json:"archived"`
 	FolderID string `json:"-"` 
 	SpaceID  string `json:"-"` 
+	// start_date/due_date dari ClickUp berupa string milidetik, diparse saat upsert
+	RawStartDate interface{} `json:"start_date,omitempty"`
+	RawDueDate   interface{} `json:"due_date,omitempty"`
+	StartDate    *time.Time  `json:"-"`
+	DueDate      *time.Time  `json:"-"`
 }
 
 type ClickUpFoldersResponse struct {
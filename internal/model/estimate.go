@@ -0,0 +1,65 @@
+package model
+
+import "time"
+
+// Sumber estimasi task.
+const (
+	EstimateSourceClickUp = "clickup"
+	EstimateSourceDefault = "default"
+	EstimateSourceManual  = "manual"
+)
+
+// DefaultEstimateHours dipakai saat sync bila ClickUp tidak punya estimasi.
+const DefaultEstimateHours = 8.0
+
+// Pengelompokan laporan akurasi estimasi.
+const (
+	EstimateGroupMember  = "member"
+	EstimateGroupRole    = "role"
+	EstimateGroupProject = "project"
+)
+
+type ManualEstimateRequest struct {
+	Hours float64 `json:"hours" binding:"required"`
+}
+
+// EstimateSample is one closed (task, assignee) row used by the accuracy report.
+type EstimateSample struct {
+	TaskID         string    `json:"task_id"`
+	TaskName       string    `json:"task_name"`
+	UserID         int64     `json:"user_id"`
+	UserName       string    `json:"user_name"`
+	Role           string    `json:"role"`
+	ProjectName    string    `json:"project_name"`
+	EstimateHours  float64   `json:"estimate_hours"`
+	SpentHours     float64   `json:"spent_hours"`
+	EstimateSource string    `json:"estimate_source"`
+	ClosedAt       time.Time `json:"closed_at"`
+}
+
+// EstimateAccuracyGroup summarizes actual/estimate ratios of one member, role
+// or project. A ratio above 1 means the work took longer than estimated.
+type EstimateAccuracyGroup struct {
+	Key           string  `json:"key"`
+	Name          string  `json:"name"`
+	TaskCount     int     `json:"task_count"`
+	EstimateHours float64 `json:"estimate_hours"`
+	SpentHours    float64 `json:"spent_hours"`
+	MeanRatio     float64 `json:"mean_ratio"`
+	MedianRatio   float64 `json:"median_ratio"`
+	// BiasPct > 0 berarti underestimate, < 0 overestimate.
+	BiasPct float64 `json:"bias_pct"`
+	// Spread adalah standar deviasi ratio.
+	Spread           float64 `json:"spread"`
+	WithinTargetPct  float64 `json:"within_20pct"`
+	ExcludedDefaults int     `json:"excluded_defaults"`
+	ExcludedNoData   int     `json:"excluded_no_data"`
+}
+
+type EstimateAccuracyReport struct {
+	GroupBy   string                  `json:"group_by"`
+	StartDate time.Time               `json:"start_date"`
+	EndDate   time.Time               `json:"end_date"`
+	Overall   EstimateAccuracyGroup   `json:"overall"`
+	Groups    []EstimateAccuracyGroup `json:"groups"`
+}
@@ -0,0 +1,105 @@
+package model
+
+import "time"
+
+// Kode indikator KPI yang dihitung oleh KPIService.
+const (
+	KPIOnTimeRate       = "on_time_rate"
+	KPIEstimateAccuracy = "estimate_accuracy"
+	KPIThroughput       = "throughput"
+	KPIUtilisation      = "utilisation"
+	KPIReworkRate       = "rework_rate"
+)
+
+// KPIBand is one target band of an indicator. A value falls into the band
+// when Min <= value < Max; a nil bound is open.
+type KPIBand struct {
+	Label string   `json:"label"`
+	Min   *float64 `json:"min,omitempty"`
+	Max   *float64 `json:"max,omitempty"`
+	Score float64  `json:"score"`
+}
+
+type KPIIndicator struct {
+	Code          string    `json:"code"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	Unit          string    `json:"unit"`
+	DefaultWeight float64   `json:"default_weight"`
+	Bands         []KPIBand `json:"bands"`
+	Active        bool      `json:"active"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// KPIIndicatorRequest updates an indicator; omitted fields keep their stored
+// value.
+type KPIIndicatorRequest struct {
+	Name          *string   `json:"name"`
+	Description   *string   `json:"description"`
+	Unit          *string   `json:"unit"`
+	DefaultWeight *float64  `json:"default_weight"`
+	Bands         []KPIBand `json:"bands"`
+	Active        *bool     `json:"active"`
+}
+
+type KPIRoleWeight struct {
+	Role          string  `json:"role"`
+	IndicatorCode string  `json:"indicator_code"`
+	Weight        float64 `json:"weight"`
+}
+
+// KPITaskMetric is one (task, assignee) row used as input for the indicators.
+type KPITaskMetric struct {
+	TaskID        string     `json:"task_id"`
+	TaskName      string     `json:"task_name"`
+	ProjectName   *string    `json:"project_name"`
+	StatusName    string     `json:"status_name"`
+	StatusType    string     `json:"status_type"`
+	StartDate     *time.Time `json:"start_date"`
+	DueDate       *time.Time `json:"due_date"`
+	DateDone      *time.Time `json:"date_done"`
+	DateClosed    *time.Time `json:"date_closed"`
+	EstimateHours float64    `json:"time_estimate_hours"`
+	SpentHours    float64    `json:"time_spent_hours"`
+	// EstimateSource: clickup, default atau manual; kosong untuk data lama.
+	EstimateSource string `json:"estimate_source,omitempty"`
+	UserID         int64  `json:"user_id"`
+}
+
+type KPIScorecard struct {
+	ID          int64              `json:"id"`
+	UserID      int64              `json:"user_id"`
+	Name        string             `json:"name"`
+	Email       string             `json:"email"`
+	Role        string             `json:"role"`
+	PeriodType  string             `json:"period_type"`
+	PeriodLabel string             `json:"period_label"`
+	PeriodStart time.Time          `json:"period_start"`
+	PeriodEnd   time.Time          `json:"period_end"`
+	TotalScore  float64            `json:"total_score"`
+	ComputedAt  time.Time          `json:"computed_at"`
+	Items       []KPIScorecardItem `json:"items"`
+}
+
+type KPIScorecardItem struct {
+	ID            int64         `json:"id"`
+	IndicatorCode string        `json:"indicator_code"`
+	IndicatorName string        `json:"indicator_name"`
+	Unit          string        `json:"unit"`
+	Value         *float64      `json:"value"`
+	Score         float64       `json:"score"`
+	Weight        float64       `json:"weight"`
+	WeightedScore float64       `json:"weighted_score"`
+	Band          string        `json:"band"`
+	TaskCount     int           `json:"task_count"`
+	Tasks         []KPIItemTask `json:"tasks,omitempty"`
+}
+
+// KPIItemTask is a task behind an indicator value, used for the drill-down.
+type KPIItemTask struct {
+	TaskID   string  `json:"task_id"`
+	TaskName string  `json:"task_name"`
+	Counted  bool    `json:"counted"`
+	Note     string  `json:"note"`
+	Value    float64 `json:"value"`
+}
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// MemberReport is the per-employee performance document used for appraisals.
+type MemberReport struct {
+	Member      User           `json:"member"`
+	PeriodType  string         `json:"period_type"`
+	PeriodLabel string         `json:"period_label"`
+	PeriodStart time.Time      `json:"period_start"`
+	PeriodEnd   time.Time      `json:"period_end"`
+	Scorecard   *KPIScorecard  `json:"scorecard,omitempty"`
+	Workload    MemberWorkload `json:"workload"`
+	OnTime      OnTimeRate     `json:"on_time"`
+	TopProjects []ProjectHours `json:"top_projects"`
+	Tasks       []TaskItem     `json:"tasks"`
+	GeneratedAt time.Time      `json:"generated_at"`
+}
+
+type MemberWorkload struct {
+	TaskCount     int            `json:"task_count"`
+	HoursLogged   float64        `json:"hours_logged"`
+	HoursEstimate float64        `json:"hours_estimate"`
+	ExpectedHours float64        `json:"expected_hours"`
+	Utilisation   float64        `json:"utilisation"`
+	ByStatus      []LabeledValue `json:"by_status"`
+}
+
+type OnTimeRate struct {
+	Completed int      `json:"completed"`
+	OnTime    int      `json:"on_time"`
+	Rate      *float64 `json:"rate,omitempty"`
+}
+
+type ProjectHours struct {
+	Project string  `json:"project"`
+	Tasks   int     `json:"tasks"`
+	Hours   float64 `json:"hours"`
+}
+
+// LabeledValue is one bar of a chart.
+type LabeledValue struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Jenis event notifikasi yang bisa dilanggan.
+const (
+	NotificationEventAlert  = "alert"
+	NotificationEventBudget = "budget"
+	NotificationEventReport = "report"
+)
+
+var NotificationEvents = []string{NotificationEventAlert, NotificationEventBudget, NotificationEventReport}
+
+const (
+	SubscriberTypeUser = "user"
+	SubscriberTypeRole = "role"
+)
+
+// Status pengiriman. Pending yang gagal dicoba lagi sampai batas percobaan,
+// setelah itu failed.
+const (
+	DeliveryStatusPending = "pending"
+	DeliveryStatusSent    = "sent"
+	DeliveryStatusFailed  = "failed"
+)
+
+// NotificationSubscription sends the chosen events of a user or of every
+// active member of a role to one channel. An empty Target on an email
+// subscription means the member's own address; empty Events means all.
+type NotificationSubscription struct {
+	ID             int64     `json:"id"`
+	SubscriberType string    `json:"subscriber_type"`
+	UserID         *int64    `json:"user_id,omitempty"`
+	UserName       string    `json:"user_name,omitempty"`
+	Role           string    `json:"role,omitempty"`
+	Channel        string    `json:"channel"`
+	Target         string    `json:"target,omitempty"`
+	Events         []string  `json:"events"`
+	QuietStart     string    `json:"quiet_start,omitempty"`
+	QuietEnd       string    `json:"quiet_end,omitempty"`
+	Active         bool      `json:"active"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type NotificationSubscriptionRequest struct {
+	SubscriberType string   `json:"subscriber_type" binding:"required"`
+	UserID         *int64   `json:"user_id"`
+	Role           string   `json:"role"`
+	Channel        string   `json:"channel" binding:"required"`
+	Target         string   `json:"target"`
+	Events         []string `json:"events"`
+	QuietStart     string   `json:"quiet_start"`
+	QuietEnd       string   `json:"quiet_end"`
+	Active         *bool    `json:"active"`
+}
+
+// NotificationAudience narrows delivery to the subscriptions of one role or
+// one user. The zero value means every subscriber of the event.
+type NotificationAudience struct {
+	Role   string `json:"role,omitempty"`
+	UserID *int64 `json:"user_id,omitempty"`
+}
+
+// NotificationRecipient is one resolved (channel, target) of a subscription.
+type NotificationRecipient struct {
+	SubscriptionID int64
+	Channel        string
+	Target         string
+	QuietStart     string
+	QuietEnd       string
+}
+
+type NotificationDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID *int64          `json:"subscription_id,omitempty"`
+	Channel        string          `json:"channel"`
+	Target         string          `json:"target"`
+	Event          string          `json:"event"`
+	Subject        string          `json:"subject"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	SentAt         *time.Time      `json:"sent_at,omitempty"`
+}
+
+type NotificationTestRequest struct {
+	Channel string `json:"channel" binding:"required"`
+	Target  string `json:"target" binding:"required"`
+}
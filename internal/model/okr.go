@@ -0,0 +1,102 @@
+package model
+
+import "time"
+
+// Pemilik objective.
+const (
+	OKROwnerMember = "member"
+	OKROwnerRole   = "role"
+)
+
+// Metrik key result.
+const (
+	// OKRMetricTasksCompleted menghitung task ter-link yang sudah selesai.
+	OKRMetricTasksCompleted = "tasks_completed"
+	// OKRMetricHoursSpent menjumlahkan jam yang sudah dipakai di task ter-link.
+	OKRMetricHoursSpent = "hours_spent"
+)
+
+// Jenis link key result ke ClickUp.
+const (
+	OKRLinkList   = "list"
+	OKRLinkFolder = "folder"
+	OKRLinkTag    = "tag"
+	OKRLinkTask   = "task"
+)
+
+type Objective struct {
+	ID          int64       `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	Period      string      `json:"period"`
+	OwnerType   string      `json:"owner_type"`
+	OwnerUserID *int64      `json:"owner_user_id,omitempty"`
+	OwnerName   string      `json:"owner_name,omitempty"`
+	OwnerRole   string      `json:"owner_role,omitempty"`
+	Progress    float64     `json:"progress"`
+	KeyResults  []KeyResult `json:"key_results"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+type ObjectiveRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	Period      string `json:"period" binding:"required"`
+	OwnerType   string `json:"owner_type" binding:"required"`
+	OwnerUserID *int64 `json:"owner_user_id"`
+	OwnerRole   string `json:"owner_role"`
+}
+
+type KeyResult struct {
+	ID          int64           `json:"id"`
+	ObjectiveID int64           `json:"objective_id"`
+	Title       string          `json:"title"`
+	Metric      string          `json:"metric"`
+	TargetValue *float64        `json:"target_value,omitempty"`
+	Weight      float64         `json:"weight"`
+	Links       []KeyResultLink `json:"links"`
+
+	// Dihitung dari task ter-link.
+	CurrentValue float64 `json:"current_value"`
+	Target       float64 `json:"target"`
+	Progress     float64 `json:"progress"`
+	TaskCount    int     `json:"task_count"`
+	DoneCount    int     `json:"done_count"`
+	EstimateHrs  float64 `json:"estimate_hours"`
+	SpentHrs     float64 `json:"spent_hours"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// KeyResultRequest: TargetValue kosong berarti target diambil dari task
+// ter-link (jumlah task atau total estimasi jam).
+type KeyResultRequest struct {
+	Title       string          `json:"title" binding:"required"`
+	Metric      string          `json:"metric" binding:"required"`
+	TargetValue *float64        `json:"target_value"`
+	Weight      *float64        `json:"weight"`
+	Links       []KeyResultLink `json:"links"`
+}
+
+type KeyResultLink struct {
+	ID          int64  `json:"id"`
+	KeyResultID int64  `json:"key_result_id"`
+	LinkType    string `json:"link_type" binding:"required"`
+	LinkValue   string `json:"link_value" binding:"required"`
+}
+
+// OKRTask is a ClickUp task counted towards a key result.
+type OKRTask struct {
+	TaskID        string     `json:"task_id"`
+	TaskName      string     `json:"task_name"`
+	ListID        *string    `json:"list_id,omitempty"`
+	StatusName    string     `json:"status_name"`
+	StatusType    string     `json:"status_type"`
+	DueDate       *time.Time `json:"due_date,omitempty"`
+	DateDone      *time.Time `json:"date_done,omitempty"`
+	EstimateHours float64    `json:"estimate_hours"`
+	SpentHours    float64    `json:"spent_hours"`
+	Done          bool       `json:"done"`
+}
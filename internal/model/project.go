@@ -0,0 +1,83 @@
+package model
+
+import "time"
+
+// Level hirarki project ClickUp.
+const (
+	ProjectLevelSpace  = "space"
+	ProjectLevelFolder = "folder"
+	ProjectLevelList   = "list"
+)
+
+type ProjectNode struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Archived bool   `json:"archived"`
+	// Level and ParentID are only filled by GetProjectTree.
+	Level    string `json:"level,omitempty"`
+	ParentID string `json:"parent_id,omitempty"`
+}
+
+type ProjectAssignee struct {
+	UserID int64  `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+type ProjectTask struct {
+	TaskID        string            `json:"task_id"`
+	TaskName      string            `json:"task_name"`
+	ListID        string            `json:"list_id,omitempty"`
+	ListName      string            `json:"list_name,omitempty"`
+	FolderID      string            `json:"folder_id,omitempty"`
+	FolderName    string            `json:"folder_name,omitempty"`
+	SpaceID       string            `json:"space_id,omitempty"`
+	SpaceName     string            `json:"space_name,omitempty"`
+	StatusName    string            `json:"status_name"`
+	StatusType    string            `json:"status_type"`
+	DueDate       *time.Time        `json:"due_date,omitempty"`
+	DateDone      *time.Time        `json:"date_done,omitempty"`
+	EstimateHours float64           `json:"estimate_hours"`
+	SpentHours    float64           `json:"spent_hours"`
+	Assignees     []ProjectAssignee `json:"assignees"`
+}
+
+type ProjectSummary struct {
+	Level          string         `json:"level"`
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Archived       bool           `json:"archived"`
+	TaskCount      int            `json:"task_count"`
+	StatusCounts   map[string]int `json:"status_counts"`
+	EstimateHours  float64        `json:"estimate_hours"`
+	SpentHours     float64        `json:"spent_hours"`
+	OverdueCount   int            `json:"overdue_count"`
+	CompletionRate float64        `json:"completion_rate"`
+	Health         string         `json:"health"`
+	// StatusLabels is the display label of each StatusCounts key in the
+	// request language, filled by the handler.
+	StatusLabels map[string]string `json:"status_labels,omitempty"`
+}
+
+type ProjectContributor struct {
+	UserID     int64   `json:"user_id"`
+	Name       string  `json:"name"`
+	TaskCount  int     `json:"task_count"`
+	SpentHours float64 `json:"spent_hours"`
+	Share      float64 `json:"share"`
+}
+
+// ProjectTrendPoint is one week of the completion trend. Scheduled is the
+// cumulative number of tasks due by the end of the week.
+type ProjectTrendPoint struct {
+	WeekStart           time.Time `json:"week_start"`
+	Completed           int       `json:"completed"`
+	CumulativeCompleted int       `json:"cumulative_completed"`
+	Scheduled           int       `json:"scheduled"`
+}
+
+type ProjectDetail struct {
+	ProjectSummary
+	Contributors []ProjectContributor `json:"contributors"`
+	OverdueTasks []ProjectTask        `json:"overdue_tasks"`
+	Trend        []ProjectTrendPoint  `json:"trend"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+// OpenTaskAssignment is one (task, assignee) pair for a task that is not done yet.
+type OpenTaskAssignment struct {
+	TaskID        string     `json:"task_id"`
+	TaskName      string     `json:"task_name"`
+	StatusName    string     `json:"status_name"`
+	StartDate     *time.Time `json:"start_date"`
+	DueDate       *time.Time `json:"due_date"`
+	EstimateHours float64    `json:"time_estimate_hours"`
+	SpentHours    float64    `json:"time_spent_hours"`
+	UserID        int64      `json:"user_id"`
+	Name          string     `json:"name"`
+	Role          string     `json:"role"`
+	AssigneeCount int        `json:"assignee_count"`
+}
+
+// RemainingHours is the estimate that still has to be worked on the task.
+func (a OpenTaskAssignment) RemainingHours() float64 {
+	if a.SpentHours >= a.EstimateHours {
+		return 0
+	}
+	return a.EstimateHours - a.SpentHours
+}
+
+type RebalanceSuggestion struct {
+	ID             int64      `json:"id"`
+	TaskID         string     `json:"task_id"`
+	TaskName       string     `json:"task_name"`
+	DueDate        *time.Time `json:"due_date"`
+	RemainingHours float64    `json:"remaining_hours"`
+	FromUserID     int64      `json:"from_user_id"`
+	FromName       string     `json:"from_name"`
+	ToUserID       int64      `json:"to_user_id"`
+	ToName         string     `json:"to_name"`
+	Role           string     `json:"role"`
+	Score          float64    `json:"score"`
+	Reason         string     `json:"reason"`
+	Status         string     `json:"status"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+}
+
+type RebalanceResponse struct {
+	HorizonStart time.Time             `json:"horizon_start"`
+	HorizonEnd   time.Time             `json:"horizon_end"`
+	Count        int                   `json:"count"`
+	Suggestions  []RebalanceSuggestion `json:"suggestions"`
+}
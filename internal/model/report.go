@@ -0,0 +1,91 @@
+package model
+
+import "time"
+
+// Jenis laporan terjadwal.
+const (
+	ReportTypeWorkload = "workload"
+	ReportTypeKPI      = "kpi"
+)
+
+const (
+	ReportPeriodWeekly  = "weekly"
+	ReportPeriodMonthly = "monthly"
+)
+
+// Format lampiran laporan. HTML selalu menjadi isi pesan.
+const (
+	ReportFormatHTML    = "html"
+	ReportFormatCSV     = "csv"
+	ReportFormatHTMLCSV = "html+csv"
+)
+
+const (
+	ReportRunSent   = "sent"
+	ReportRunFailed = "failed"
+)
+
+// ReportFilters limit the rows of a report.
+type ReportFilters struct {
+	Role   string `json:"role,omitempty"`
+	UserID *int64 `json:"user_id,omitempty"`
+}
+
+// ReportDefinition is a report the scheduler renders at the start of every
+// week (Monday) or month and sends to its audience through the notification
+// subscriptions of the "report" event.
+type ReportDefinition struct {
+	ID         int64                `json:"id"`
+	Name       string               `json:"name"`
+	ReportType string               `json:"report_type"`
+	Period     string               `json:"period"`
+	Audience   NotificationAudience `json:"audience"`
+	Filters    ReportFilters        `json:"filters"`
+	Format     string               `json:"format"`
+	Language   string               `json:"language"`
+	Active     bool                 `json:"active"`
+	LastRunAt  *time.Time           `json:"last_run_at,omitempty"`
+	NextRunAt  *time.Time           `json:"next_run_at,omitempty"`
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+}
+
+type ReportDefinitionRequest struct {
+	Name       string               `json:"name" binding:"required"`
+	ReportType string               `json:"report_type" binding:"required"`
+	Period     string               `json:"period" binding:"required"`
+	Audience   NotificationAudience `json:"audience"`
+	Filters    ReportFilters        `json:"filters"`
+	Format     string               `json:"format"`
+	Language   string               `json:"language"`
+	Active     *bool                `json:"active"`
+}
+
+// ReportTable is the rendered content of a report, shared by HTML and CSV.
+type ReportTable struct {
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
+type ReportDocument struct {
+	Title       string      `json:"title"`
+	PeriodLabel string      `json:"period_label"`
+	PeriodStart time.Time   `json:"period_start"`
+	PeriodEnd   time.Time   `json:"period_end"`
+	Summary     []string    `json:"summary"`
+	Table       ReportTable `json:"table"`
+	GeneratedAt time.Time   `json:"generated_at"`
+	Language    string      `json:"language"`
+}
+
+type ReportRun struct {
+	ID           int64     `json:"id"`
+	DefinitionID int64     `json:"definition_id"`
+	PeriodLabel  string    `json:"period_label"`
+	PeriodStart  time.Time `json:"period_start"`
+	PeriodEnd    time.Time `json:"period_end"`
+	Status       string    `json:"status"`
+	Deliveries   int       `json:"deliveries"`
+	Error        string    `json:"error,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
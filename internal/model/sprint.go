@@ -0,0 +1,91 @@
+package model
+
+import "time"
+
+// Sumber sprint.
+const (
+	SprintSourceManual  = "manual"
+	SprintSourceClickUp = "clickup"
+)
+
+// Sprint is an iteration backed by a ClickUp list.
+type Sprint struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	ListID    string    `json:"list_id"`
+	ListName  string    `json:"list_name,omitempty"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Source    string    `json:"source"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type SprintRequest struct {
+	Name      string `json:"name" binding:"required"`
+	ListID    string `json:"list_id" binding:"required"`
+	StartDate string `json:"start_date" binding:"required"`
+	EndDate   string `json:"end_date" binding:"required"`
+}
+
+type SprintTask struct {
+	TaskID        string     `json:"task_id"`
+	TaskName      string     `json:"task_name"`
+	StatusName    string     `json:"status_name"`
+	StatusType    string     `json:"status_type"`
+	EstimateHours float64    `json:"estimate_hours"`
+	DateCreated   *time.Time `json:"date_created,omitempty"`
+	DateDone      *time.Time `json:"date_done,omitempty"`
+}
+
+type TaskStatusChange struct {
+	TaskID     string    `json:"task_id"`
+	StatusID   string    `json:"status_id"`
+	StatusName string    `json:"status_name"`
+	StatusType string    `json:"status_type"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// SprintDay is one day of the burndown/burnup chart, in estimate hours.
+type SprintDay struct {
+	Date      time.Time `json:"date"`
+	Remaining *float64  `json:"remaining"`
+	Completed *float64  `json:"completed"`
+	Scope     *float64  `json:"scope"`
+	Ideal     float64   `json:"ideal"`
+}
+
+type SprintScopeChange struct {
+	TaskID        string    `json:"task_id"`
+	TaskName      string    `json:"task_name"`
+	AddedAt       time.Time `json:"added_at"`
+	EstimateHours float64   `json:"estimate_hours"`
+}
+
+type SprintReport struct {
+	Sprint         Sprint              `json:"sprint"`
+	CommittedHours float64             `json:"committed_hours"`
+	CompletedHours float64             `json:"completed_hours"`
+	AddedHours     float64             `json:"added_hours"`
+	TaskCount      int                 `json:"task_count"`
+	DoneCount      int                 `json:"done_count"`
+	UsesHistory    bool                `json:"uses_status_history"`
+	Days           []SprintDay         `json:"days"`
+	ScopeChanges   []SprintScopeChange `json:"scope_changes"`
+}
+
+type SprintVelocity struct {
+	SprintID       int64     `json:"sprint_id"`
+	Name           string    `json:"name"`
+	StartDate      time.Time `json:"start_date"`
+	EndDate        time.Time `json:"end_date"`
+	CommittedHours float64   `json:"committed_hours"`
+	CompletedHours float64   `json:"completed_hours"`
+	CompletedTasks int       `json:"completed_tasks"`
+	Finished       bool      `json:"finished"`
+}
+
+type VelocityHistory struct {
+	Sprints         []SprintVelocity `json:"sprints"`
+	AverageVelocity float64          `json:"average_velocity"`
+}
@@ -156,6 +156,7 @@ type TaskResponse struct {
     AssigneeEmail    *string `json:"assignee_email"`
 	TimeEstimateHours *float64 `json:"time_estimate_hours,omitempty"`
 	TimeSpentHours    *float64 `json:"time_spent_hours,omitempty"`
+	EstimateSource    string   `json:"estimate_source,omitempty"`
 
 	TimeEfficiencyPercentage *float64 `json:"time_efficiency_percentage,omitempty"`
 	RemainingTimeHours         *float64 `json:"remaining_time_hours,omitempty"`
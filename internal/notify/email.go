@@ -0,0 +1,141 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SMTPNotifier sends plain-text email. Auth is only used when Username is set,
+// so a local relay or test server works without credentials.
+type SMTPNotifier struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (n *SMTPNotifier) Channel() string { return ChannelEmail }
+
+func (n *SMTPNotifier) Send(ctx context.Context, to string, msg Message) error {
+	if n.Host == "" {
+		return errors.New("smtp host not configured")
+	}
+	to = strings.TrimSpace(to)
+	if to == "" || strings.ContainsAny(to, "\r\n") {
+		return fmt.Errorf("invalid email recipient %q", to)
+	}
+
+	var auth smtp.Auth
+	if n.Username != "" {
+		auth = smtp.PlainAuth("", n.Username, n.Password, n.Host)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(net.JoinHostPort(n.Host, n.Port), auth, n.From, []string{to}, buildEmail(n.From, to, msg))
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func buildEmail(from, to string, msg Message) []byte {
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(msg.Subject)
+	sentAt := msg.SentAt
+	if sentAt.IsZero() {
+		sentAt = time.Now()
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", sentAt.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+
+	text := emailText(msg)
+	if msg.HTML == "" && len(msg.Attachments) == 0 {
+		b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+		b.WriteString("\r\n")
+		b.WriteString(text)
+		return b.Bytes()
+	}
+
+	// multipart/mixed: isi (teks, atau teks+HTML) lalu lampiran base64.
+	mixed := multipart.NewWriter(&b)
+	fmt.Fprintf(&b, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", mixed.Boundary())
+
+	if msg.HTML != "" {
+		var alt bytes.Buffer
+		altw := multipart.NewWriter(&alt)
+		writePart(altw, "text/plain; charset=UTF-8", "", []byte(text))
+		writePart(altw, "text/html; charset=UTF-8", "", []byte(msg.HTML))
+		altw.Close()
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Type", "multipart/alternative; boundary="+altw.Boundary())
+		pw, _ := mixed.CreatePart(h)
+		pw.Write(alt.Bytes())
+	} else {
+		writePart(mixed, "text/plain; charset=UTF-8", "", []byte(text))
+	}
+	for _, a := range msg.Attachments {
+		ct := a.ContentType
+		if ct == "" {
+			ct = "application/octet-stream"
+		}
+		writePart(mixed, ct, a.Filename, a.Data)
+	}
+	mixed.Close()
+	return b.Bytes()
+}
+
+func emailText(msg Message) string {
+	var b strings.Builder
+	b.WriteString(strings.ReplaceAll(msg.Text, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	if len(msg.Fields) > 0 {
+		keys := make([]string, 0, len(msg.Fields))
+		for k := range msg.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteString("\r\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "%s: %s\r\n", k, msg.Fields[k])
+		}
+	}
+	return b.String()
+}
+
+// writePart writes a base64 part; a filename makes it an attachment.
+func writePart(w *multipart.Writer, contentType, filename string, data []byte) {
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Type", contentType)
+	h.Set("Content-Transfer-Encoding", "base64")
+	if filename != "" {
+		h.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	}
+	pw, _ := w.CreatePart(h)
+
+	enc := base64.StdEncoding.EncodeToString(data)
+	for len(enc) > 76 {
+		pw.Write([]byte(enc[:76] + "\r\n"))
+		enc = enc[76:]
+	}
+	pw.Write([]byte(enc + "\r\n"))
+}
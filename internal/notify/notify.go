@@ -0,0 +1,158 @@
+// Package notify mengirim notifikasi (alert, laporan) lewat email SMTP,
+// webhook HTTP bertanda tangan HMAC, dan incoming webhook Slack/Discord.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+)
+
+// Channel pengiriman.
+const (
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+	ChannelChat    = "chat"
+)
+
+// ErrUnknownChannel is returned by Registry.Send for an unregistered channel.
+var ErrUnknownChannel = apperr.New(apperr.CodeValidationFailed, "unknown notification channel")
+
+// Message is the channel-independent content of a notification. HTML and
+// attachments are used by channels that support them (email, webhook).
+type Message struct {
+	Event       string            `json:"event"`
+	Subject     string            `json:"subject"`
+	Text        string            `json:"text"`
+	HTML        string            `json:"html,omitempty"`
+	Fields      map[string]string `json:"fields,omitempty"`
+	Attachments []Attachment      `json:"attachments,omitempty"`
+	SentAt      time.Time         `json:"sent_at"`
+}
+
+// Attachment is a file sent with a message; Data is base64 in JSON.
+type Attachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Data        []byte `json:"data"`
+}
+
+// Notifier delivers a message to one target: an email address for email, a
+// URL for webhook and chat.
+type Notifier interface {
+	Channel() string
+	Send(ctx context.Context, target string, msg Message) error
+}
+
+// Registry maps channels to their notifier.
+type Registry struct {
+	notifiers map[string]Notifier
+}
+
+func NewRegistry(notifiers ...Notifier) *Registry {
+	r := &Registry{notifiers: map[string]Notifier{}}
+	for _, n := range notifiers {
+		r.notifiers[n.Channel()] = n
+	}
+	return r
+}
+
+func (r *Registry) Has(channel string) bool {
+	_, ok := r.notifiers[channel]
+	return ok
+}
+
+func (r *Registry) Send(ctx context.Context, channel, target string, msg Message) error {
+	n, ok := r.notifiers[channel]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownChannel, channel)
+	}
+	if msg.SentAt.IsZero() {
+		msg.SentAt = time.Now()
+	}
+	return n.Send(ctx, target, msg)
+}
+
+// QuietHours is a daily window ("22:00"-"07:00") in which nothing is sent.
+// A window whose end is before its start wraps past midnight. Empty Start or
+// End disables it.
+type QuietHours struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+func parseClock(v string) (int, error) {
+	parts := strings.Split(v, ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", v)
+	}
+	h, err := strconv.Atoi(parts[0])
+	if err != nil || h < 0 || h > 23 {
+		return 0, fmt.Errorf("invalid hour in %q", v)
+	}
+	m, err := strconv.Atoi(parts[1])
+	if err != nil || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid minute in %q", v)
+	}
+	return h*60 + m, nil
+}
+
+func (q QuietHours) Enabled() bool {
+	return q.Start != "" && q.End != ""
+}
+
+// Validate checks both bounds are HH:MM.
+func (q QuietHours) Validate() error {
+	if !q.Enabled() {
+		if q.Start != "" || q.End != "" {
+			return errors.New("quiet hours need both start and end")
+		}
+		return nil
+	}
+	if _, err := parseClock(q.Start); err != nil {
+		return err
+	}
+	_, err := parseClock(q.End)
+	return err
+}
+
+// Until returns the end of the quiet window containing t, or the zero time
+// when t is outside the window (or the window is invalid/disabled).
+func (q QuietHours) Until(t time.Time) time.Time {
+	if !q.Enabled() {
+		return time.Time{}
+	}
+	start, err := parseClock(q.Start)
+	if err != nil {
+		return time.Time{}
+	}
+	end, err := parseClock(q.End)
+	if err != nil || start == end {
+		return time.Time{}
+	}
+
+	y, m, d := t.Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	now := t.Hour()*60 + t.Minute()
+	endToday := midnight.Add(time.Duration(end) * time.Minute)
+
+	if start < end {
+		if now >= start && now < end {
+			return endToday
+		}
+		return time.Time{}
+	}
+	// jendela melewati tengah malam
+	if now >= start {
+		return endToday.AddDate(0, 0, 1)
+	}
+	if now < end {
+		return endToday
+	}
+	return time.Time{}
+}
@@ -0,0 +1,299 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTP accepts one message and returns the raw DATA section.
+func fakeSMTP(t *testing.T) (host, port string, received <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	out := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+
+		reply("220 localhost ESMTP test")
+		var data strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM"), strings.HasPrefix(cmd, "RCPT TO"):
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				reply("250 queued")
+				out <- data.String()
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	h, p, _ := net.SplitHostPort(ln.Addr().String())
+	return h, p, out
+}
+
+func TestSMTPNotifierSendsPlainTextMail(t *testing.T) {
+	host, port, received := fakeSMTP(t)
+	n := &SMTPNotifier{Host: host, Port: port, From: "kinerja@example.com"}
+
+	err := n.Send(context.Background(), "pm@example.com", Message{
+		Event:   "alert",
+		Subject: "Task overdue\r\nBcc: evil@example.com",
+		Text:    "Task A is 2 day(s) overdue",
+		Fields:  map[string]string{"rule": "overdue"},
+	})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	select {
+	case data := <-received:
+		if !strings.Contains(data, "To: pm@example.com\r\n") {
+			t.Errorf("missing To header:\n%s", data)
+		}
+		if !strings.Contains(data, "Subject: Task overdue  Bcc: evil@example.com\r\n") {
+			t.Errorf("subject not sanitized:\n%s", data)
+		}
+		if !strings.Contains(data, "Task A is 2 day(s) overdue") || !strings.Contains(data, "rule: overdue") {
+			t.Errorf("missing body:\n%s", data)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no mail received")
+	}
+}
+
+func TestSMTPNotifierRejectsHeaderInjection(t *testing.T) {
+	n := &SMTPNotifier{Host: "127.0.0.1", Port: "25", From: "a@example.com"}
+	if err := n.Send(context.Background(), "a@example.com\r\nBcc: b@example.com", Message{}); err == nil {
+		t.Fatal("expected error for recipient with newline")
+	}
+}
+
+func TestWebhookNotifierSignsBody(t *testing.T) {
+	const secret = "s3cret"
+	var got Message
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		if err != nil || !Verify(secret, ts, body, r.Header.Get(SignatureHeader)) {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		json.Unmarshal(body, &got)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	n := &WebhookNotifier{Secret: secret, Client: srv.Client()}
+	if err := n.Send(context.Background(), srv.URL, Message{Event: "budget", Subject: "Budget 90%"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if got.Event != "budget" || got.Subject != "Budget 90%" {
+		t.Errorf("unexpected payload %+v", got)
+	}
+
+	wrong := &WebhookNotifier{Secret: "other", Client: srv.Client()}
+	if err := wrong.Send(context.Background(), srv.URL, Message{Event: "budget"}); err == nil {
+		t.Error("expected error when receiver rejects signature")
+	}
+}
+
+func TestWebhookNotifierRejectsInvalidURL(t *testing.T) {
+	n := &WebhookNotifier{}
+	if err := n.Send(context.Background(), "ftp://example.com/hook", Message{}); err == nil {
+		t.Fatal("expected error for non-http url")
+	}
+}
+
+func TestCheckTarget(t *testing.T) {
+	tests := []struct {
+		target  string
+		private bool
+	}{
+		{"https://hooks.slack.com/services/T/B/X", false},
+		{"http://93.184.216.34/hook", false},
+		{"http://127.0.0.1:8080/hook", true},
+		{"http://localhost/hook", true},
+		{"http://[::1]/hook", true},
+		{"http://10.0.0.5/hook", true},
+		{"http://192.168.1.1/hook", true},
+		{"http://169.254.169.254/latest/meta-data", true},
+		{"http://100.64.0.1/hook", true},
+		{"http://[::ffff:127.0.0.1]/hook", true},
+		{"http://0.0.0.0/hook", true},
+	}
+	for _, tt := range tests {
+		err := CheckTarget(tt.target)
+		if got := errors.Is(err, ErrPrivateTarget); got != tt.private {
+			t.Errorf("CheckTarget(%q) = %v, want private=%v", tt.target, err, tt.private)
+		}
+	}
+}
+
+// Tanpa Client sendiri, notifier memakai dialer yang menolak alamat internal
+// saat dial, jadi hostname yang resolve ke alamat internal juga ditolak.
+func TestWebhookNotifierRefusesInternalAddress(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+	_, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+
+	n := &WebhookNotifier{}
+	for _, target := range []string{srv.URL, "http://localhost:" + port} {
+		if err := n.Send(context.Background(), target, Message{}); !errors.Is(err, ErrPrivateTarget) {
+			t.Errorf("Send(%s) = %v, want ErrPrivateTarget", target, err)
+		}
+	}
+	if called {
+		t.Error("internal address was reached")
+	}
+}
+
+func TestChatPayload(t *testing.T) {
+	msg := Message{Subject: "Overdue", Text: "Task A", Fields: map[string]string{"b": "2", "a": "1"}}
+
+	slack := ChatPayload("https://hooks.slack.com/services/T/B/X", msg)
+	if slack["text"] != "*Overdue*\nTask A\n• a: 1\n• b: 2" {
+		t.Errorf("slack payload = %q", slack["text"])
+	}
+	discord := ChatPayload("https://discord.com/api/webhooks/1/abc", msg)
+	if discord["content"] != "**Overdue**\nTask A\n• a: 1\n• b: 2" {
+		t.Errorf("discord payload = %q", discord["content"])
+	}
+}
+
+func TestChatNotifierPostsToStandIn(t *testing.T) {
+	var payload map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&payload)
+	}))
+	defer srv.Close()
+
+	n := &ChatNotifier{Client: srv.Client()}
+	if err := n.Send(context.Background(), srv.URL, Message{Text: "hello"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if payload["text"] != "hello" {
+		t.Errorf("payload = %v", payload)
+	}
+}
+
+func TestRegistryUnknownChannel(t *testing.T) {
+	r := NewRegistry(&ChatNotifier{})
+	if !r.Has(ChannelChat) || r.Has(ChannelEmail) {
+		t.Fatal("unexpected registry contents")
+	}
+	err := r.Send(context.Background(), ChannelEmail, "a@example.com", Message{})
+	if !errors.Is(err, ErrUnknownChannel) {
+		t.Fatalf("err = %v, want ErrUnknownChannel", err)
+	}
+}
+
+func TestQuietHours(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 10, 19, h, m, 0, 0, time.UTC) }
+
+	overnight := QuietHours{Start: "22:00", End: "07:00"}
+	cases := []struct {
+		q    QuietHours
+		t    time.Time
+		want time.Time
+	}{
+		{overnight, at(23, 30), time.Date(2026, 10, 20, 7, 0, 0, 0, time.UTC)},
+		{overnight, at(6, 59), at(7, 0)},
+		{overnight, at(7, 0), time.Time{}},
+		{overnight, at(12, 0), time.Time{}},
+		{QuietHours{Start: "12:00", End: "13:00"}, at(12, 30), at(13, 0)},
+		{QuietHours{Start: "12:00", End: "13:00"}, at(13, 30), time.Time{}},
+		{QuietHours{}, at(12, 30), time.Time{}},
+	}
+	for _, c := range cases {
+		if got := c.q.Until(c.t); !got.Equal(c.want) {
+			t.Errorf("%+v.Until(%s) = %s, want %s", c.q, c.t.Format("15:04"), got, c.want)
+		}
+	}
+
+	if err := (QuietHours{Start: "25:00", End: "07:00"}).Validate(); err == nil {
+		t.Error("expected invalid hour error")
+	}
+	if err := (QuietHours{Start: "22:00"}).Validate(); err == nil {
+		t.Error("expected error for missing end")
+	}
+}
+
+func TestSMTPNotifierSendsHTMLWithAttachment(t *testing.T) {
+	host, port, received := fakeSMTP(t)
+	n := &SMTPNotifier{Host: host, Port: port, From: "kinerja@example.com"}
+
+	err := n.Send(context.Background(), "pm@example.com", Message{
+		Event:       "report",
+		Subject:     "Weekly workload",
+		Text:        "3 members",
+		HTML:        "<h1>Weekly workload</h1>",
+		Attachments: []Attachment{{Filename: "workload.csv", ContentType: "text/csv", Data: []byte("member,hours\nA,40\n")}},
+	})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	select {
+	case data := <-received:
+		for _, want := range []string{
+			"Content-Type: multipart/mixed; boundary=",
+			"multipart/alternative; boundary=",
+			"Content-Type: text/html; charset=UTF-8",
+			`Content-Disposition: attachment; filename=workload.csv`,
+			base64.StdEncoding.EncodeToString([]byte("member,hours\nA,40\n")),
+		} {
+			if !strings.Contains(data, want) {
+				t.Errorf("mail missing %q:\n%s", want, data)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no mail received")
+	}
+}
@@ -0,0 +1,211 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+)
+
+// Header tanda tangan webhook. Signature = hex(HMAC-SHA256(secret, timestamp + "." + body)).
+const (
+	SignatureHeader = "X-Kinerja-Signature"
+	TimestampHeader = "X-Kinerja-Timestamp"
+)
+
+// Sign returns the signature header value for a webhook body.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign.
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// ErrPrivateTarget is returned for a webhook or chat target on a loopback,
+// link-local or private address; subscriptions may not reach internal hosts.
+var ErrPrivateTarget = apperr.New(apperr.CodeValidationFailed, "webhook target must be a public address")
+
+// cgnat is the shared address space (RFC 6598), internal like RFC 1918.
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+// CheckTarget validates a webhook or chat URL: http(s) with a host that is
+// not a literal internal address. Hostnames are checked again when dialing,
+// see publicOnly.
+func CheckTarget(target string) error {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook url %q", target)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrPrivateTarget, host)
+	}
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return checkAddr(ip)
+	}
+	return nil
+}
+
+func checkAddr(ip netip.Addr) error {
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() || cgnat.Contains(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateTarget, ip)
+	}
+	return nil
+}
+
+// publicOnly is a net.Dialer Control hook. It sees the resolved address of
+// every connection, redirects included, so a public hostname that resolves
+// to an internal address is refused too.
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	return checkAddr(ip)
+}
+
+// publicClient is used when a notifier has no Client of its own. It does not
+// honour proxy variables, which would move the dial to the proxy.
+var publicClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: 5 * time.Second, Control: publicOnly}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	},
+}
+
+// postJSON posts body to target. Without a client it uses publicClient,
+// which refuses internal addresses when dialing; tests pass their own to
+// reach a local stand-in.
+func postJSON(ctx context.Context, client *http.Client, target string, body []byte, header http.Header) error {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook url %q", target)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = publicClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		snippet, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		return fmt.Errorf("webhook returned %d: %s", res.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return nil
+}
+
+// WebhookNotifier posts the message as JSON. When Secret is set the body is
+// signed so receivers can verify it came from this service.
+type WebhookNotifier struct {
+	Secret string
+	Client *http.Client
+}
+
+func (n *WebhookNotifier) Channel() string { return ChannelWebhook }
+
+func (n *WebhookNotifier) Send(ctx context.Context, target string, msg Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	if n.Secret != "" {
+		ts := time.Now().Unix()
+		header.Set(TimestampHeader, strconv.FormatInt(ts, 10))
+		header.Set(SignatureHeader, Sign(n.Secret, ts, body))
+	}
+	return postJSON(ctx, n.Client, target, body, header)
+}
+
+// ChatNotifier posts to Slack- or Discord-compatible incoming webhooks.
+// Discord URLs get {"content": ...}; everything else gets Slack's {"text": ...}.
+type ChatNotifier struct {
+	Client *http.Client
+}
+
+func (n *ChatNotifier) Channel() string { return ChannelChat }
+
+func (n *ChatNotifier) Send(ctx context.Context, target string, msg Message) error {
+	body, err := json.Marshal(ChatPayload(target, msg))
+	if err != nil {
+		return err
+	}
+	return postJSON(ctx, n.Client, target, body, nil)
+}
+
+// ChatPayload builds the incoming-webhook body for a chat target.
+func ChatPayload(target string, msg Message) map[string]string {
+	discord := false
+	if u, err := url.Parse(target); err == nil {
+		host := strings.ToLower(u.Hostname())
+		discord = host == "discord.com" || host == "discordapp.com" || strings.HasSuffix(host, ".discord.com")
+	}
+	bold := "*"
+	if discord {
+		bold = "**"
+	}
+
+	var b strings.Builder
+	if msg.Subject != "" {
+		b.WriteString(bold + msg.Subject + bold + "\n")
+	}
+	b.WriteString(msg.Text)
+	if len(msg.Fields) > 0 {
+		keys := make([]string, 0, len(msg.Fields))
+		for k := range msg.Fields {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			b.WriteString("\n• " + k + ": " + msg.Fields[k])
+		}
+	}
+	// incoming webhook tidak mendukung lampiran; cukup sebutkan namanya
+	for _, a := range msg.Attachments {
+		b.WriteString("\n📎 " + a.Filename + " (sent by email/webhook)")
+	}
+
+	if discord {
+		return map[string]string{"content": b.String()}
+	}
+	return map[string]string{"text": b.String()}
+}
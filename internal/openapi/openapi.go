@@ -0,0 +1,295 @@
+// Package openapi describes the HTTP API as an OpenAPI 3 document, served at
+// /api/openapi.json with a Swagger UI page at /api/docs.
+//
+// The document is generated from the operation table in operations.go and
+// the Go request and response types, and committed as openapi.json. Tests
+// fail when a registered route is missing from the table or when a type no
+// longer matches the committed document; after changing either, run
+//
+//	go test ./internal/openapi -update
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+// Operation is one route of the API.
+type Operation struct {
+	Method  string
+	Path    string // gin syntax, e.g. /api/v1/budgets/:id
+	Tag     string
+	Summary string
+	Query   []Param
+	// Body is a zero value of the JSON request body, if any.
+	Body interface{}
+	// Status defaults to 200.
+	Status int
+	// Response is a zero value of the JSON response, an Object for gin.H
+	// responses, or nil when the body is not JSON (see Content).
+	Response interface{}
+	// Content is the media type of a non-JSON response.
+	Content string
+	// Paged lists accept the shared list syntax (package query) and return
+	// X-Total-Count / X-Next-Cursor headers.
+	Paged bool
+	// Export lists can also be downloaded as CSV or XLSX.
+	Export bool
+	// Auth routes need a bearer token.
+	Auth bool
+}
+
+// Param is a query parameter.
+type Param struct {
+	Name        string
+	Type        string // string (default), integer, boolean
+	Description string
+	Required    bool
+}
+
+// Object describes a gin.H response: each value is a Go value, *Schema or
+// nested Object. Every key is required.
+type Object map[string]interface{}
+
+// JSON returns the committed document.
+func JSON() []byte { return spec }
+
+// ServeJSON serves the committed document.
+func ServeJSON(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+}
+
+// ServeUI serves a Swagger UI page for the document.
+func ServeUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUI))
+}
+
+const swaggerUI = `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>go-kinerja-backend API</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css"></head>
+<body><div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+<script>window.ui = SwaggerUIBundle({url: "/api/openapi.json", dom_id: "#swagger-ui"});</script>
+</body></html>`
+
+type document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       info                             `json:"info"`
+	Tags       []tag                            `json:"tags"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components components                       `json:"components"`
+}
+
+type info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+}
+
+type tag struct {
+	Name string `json:"name"`
+}
+
+type operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Tags        []string              `json:"tags"`
+	Parameters  []parameter           `json:"parameters,omitempty"`
+	RequestBody *requestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]mediaType `json:"content"`
+}
+
+type header struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema"`
+}
+
+type response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Headers     map[string]header    `json:"headers,omitempty"`
+	Content     map[string]mediaType `json:"content,omitempty"`
+}
+
+type components struct {
+	Schemas         map[string]*Schema           `json:"schemas"`
+	Responses       map[string]*response         `json:"responses"`
+	SecuritySchemes map[string]map[string]string `json:"securitySchemes"`
+}
+
+const description = `Responses are localized from ?lang=, the lang claim of the bearer token or
+Accept-Language (en or id). Dates accept YYYY-MM-DD, DD-MM-YYYY or RFC 3339 and
+are read in ?tz= or the workspace time zone. Errors use the envelope
+{"error": {"code", "message", "details"}}; GET /api/v1/errors lists the codes.`
+
+// Build generates the document from Operations.
+func Build() ([]byte, error) {
+	reg := newRegistry()
+	doc := document{
+		OpenAPI: "3.0.3",
+		Info:    info{Title: "go-kinerja-backend API", Version: "1.0.0", Description: description},
+		Paths:   map[string]map[string]*operation{},
+	}
+	tags := map[string]bool{}
+	for _, op := range Operations {
+		path, params := openAPIPath(op.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*operation{}
+		}
+		doc.Paths[path][strings.ToLower(op.Method)] = buildOperation(reg, op, params)
+		if !tags[op.Tag] {
+			tags[op.Tag] = true
+			doc.Tags = append(doc.Tags, tag{op.Tag})
+		}
+	}
+	doc.Components = components{
+		Schemas: reg.components,
+		Responses: map[string]*response{
+			"Error": {
+				Description: "Error envelope",
+				Content:     map[string]mediaType{"application/json": {Schema: reg.schemaOf(errorBody)}},
+			},
+		},
+		SecuritySchemes: map[string]map[string]string{
+			"bearerAuth": {"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+		},
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// openAPIPath turns /budgets/:id into /budgets/{id} and returns the path
+// parameter names.
+func openAPIPath(p string) (string, []string) {
+	var names []string
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, ":") || strings.HasPrefix(part, "*") {
+			names = append(names, part[1:])
+			parts[i] = "{" + part[1:] + "}"
+		}
+	}
+	return strings.Join(parts, "/"), names
+}
+
+// operationID derives e.g. getBudgetsById from GET /api/v1/budgets/:id.
+func operationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, part := range strings.Split(path, "/") {
+		if part == "" || part == "api" || part == "v1" {
+			continue
+		}
+		if strings.HasPrefix(part, ":") {
+			id += "By"
+			part = part[1:]
+		}
+		for _, w := range strings.FieldsFunc(part, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+			id += strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return id
+}
+
+func buildOperation(reg *registry, op Operation, pathParams []string) *operation {
+	o := &operation{
+		OperationID: operationID(op.Method, op.Path),
+		Summary:     op.Summary,
+		Tags:        []string{op.Tag},
+		Responses:   map[string]*response{"default": {Ref: "#/components/responses/Error"}},
+	}
+	for _, name := range pathParams {
+		o.Parameters = append(o.Parameters, parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+	}
+	query := op.Query
+	if op.Paged {
+		query = append(query, listParams...)
+	}
+	if op.Export {
+		query = append(query, formatParam)
+	}
+	seen := map[string]bool{}
+	for _, p := range query {
+		if seen[p.Name] {
+			continue
+		}
+		seen[p.Name] = true
+		typ := p.Type
+		if typ == "" {
+			typ = "string"
+		}
+		o.Parameters = append(o.Parameters, parameter{Name: p.Name, In: "query", Description: p.Description, Required: p.Required, Schema: &Schema{Type: typ}})
+	}
+	if op.Body != nil {
+		o.RequestBody = &requestBody{Required: true, Content: map[string]mediaType{"application/json": {Schema: reg.schemaOf(op.Body)}}}
+	}
+	if op.Auth {
+		o.Security = []map[string][]string{{"bearerAuth": {}}}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	res := &response{Description: http.StatusText(status), Content: map[string]mediaType{}}
+	if op.Response != nil {
+		res.Content["application/json"] = mediaType{Schema: reg.schemaOf(op.Response)}
+	}
+	if op.Content != "" {
+		res.Content[op.Content] = mediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	if op.Export {
+		res.Content["text/csv"] = mediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+		res.Content["application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"] = mediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	if op.Paged {
+		res.Headers = map[string]header{
+			"X-Total-Count": {Description: "Number of matching rows", Schema: &Schema{Type: "integer"}},
+			"X-Next-Cursor": {Description: "Cursor of the next page, absent on the last page", Schema: &Schema{Type: "string"}},
+		}
+	}
+	if len(res.Content) == 0 {
+		res.Content = nil
+	}
+	o.Responses[strconv.Itoa(status)] = res
+	return o
+}
+
+// Routes returns "METHOD path" for every operation, sorted.
+func Routes() []string {
+	out := make([]string, len(Operations))
+	for i, op := range Operations {
+		out[i] = op.Method + " " + op.Path
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,39 @@
+package openapi
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite openapi.json from Operations")
+
+// TestSpecUpToDate fails when a request or response type, or the operation
+// table, no longer matches the committed openapi.json.
+func TestSpecUpToDate(t *testing.T) {
+	got, err := Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err := os.WriteFile("openapi.json", got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	if !bytes.Equal(got, JSON()) {
+		t.Fatal("openapi.json is out of date with the Go types or Operations; run: go test ./internal/openapi -update")
+	}
+}
+
+func TestOperationIDsUnique(t *testing.T) {
+	seen := map[string]string{}
+	for _, op := range Operations {
+		id := operationID(op.Method, op.Path)
+		if prev, ok := seen[id]; ok {
+			t.Errorf("operationId %s used by %s and %s %s", id, prev, op.Method, op.Path)
+		}
+		seen[id] = op.Method + " " + op.Path
+	}
+}
@@ -0,0 +1,301 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/roksva123/go-kinerja-backend/internal/api/handlers"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/graph"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+	"github.com/roksva123/go-kinerja-backend/internal/resource"
+)
+
+var errorBody = apperr.Body{}
+
+var listParams = []Param{
+	{Name: "limit", Type: "integer", Description: "Page size, default 50, max 500"},
+	{Name: "offset", Type: "integer", Description: "Rows to skip"},
+	{Name: "cursor", Description: "next_cursor of the previous page"},
+	{Name: "sort", Description: "Comma-separated fields, - for descending, e.g. -due_date,name"},
+	{Name: "q", Description: "Free-text search"},
+	{Name: "tz", Description: "IANA time zone for date filters"},
+}
+
+var formatParam = Param{Name: "format", Description: "json (default), csv or xlsx; the Accept header works too. Files hold at most 20000 detail rows"}
+
+var (
+	tzParam        = Param{Name: "tz", Description: "IANA time zone, default the workspace zone"}
+	periodParam    = Param{Name: "period", Description: "KPI period label, e.g. 2026-09 or 2026-Q3"}
+	userIDParam    = Param{Name: "user_id", Type: "integer", Description: "ClickUp user id"}
+	statusParam    = Param{Name: "status", Description: "Filter by status"}
+	roleParam      = Param{Name: "role", Description: "Filter by role"}
+	usernameParam  = Param{Name: "username", Description: "Filter by username"}
+	startDateParam = Param{Name: "start_date", Description: "Range start"}
+	endDateParam   = Param{Name: "end_date", Description: "Range end; a bare date covers the whole day"}
+)
+
+func includeParam(relations string) Param {
+	return Param{Name: "include", Description: "Comma-separated relations to expand: " + relations}
+}
+
+func required(p Param) Param {
+	p.Required = true
+	return p
+}
+
+// messageResponse is the {"message": "..."} body of actions without data.
+var messageResponse = Object{"message": ""}
+
+// Operations lists every route registered by api.Register.
+var Operations = []Operation{
+	{Method: http.MethodGet, Path: "/api/openapi.json", Tag: "docs", Summary: "This OpenAPI document", Response: &Schema{Type: "object"}},
+	{Method: http.MethodGet, Path: "/api/docs", Tag: "docs", Summary: "Swagger UI", Content: "text/html"},
+	{Method: http.MethodGet, Path: "/api/graphql", Tag: "graphql", Summary: "Run a GraphQL query passed in the query string", Auth: true,
+		Query:    []Param{required(Param{Name: "query"}), {Name: "variables", Description: "JSON object"}, {Name: "operationName"}, tzParam},
+		Response: graph.Response{}},
+	{Method: http.MethodPost, Path: "/api/graphql", Tag: "graphql", Summary: "Run a GraphQL query (members, tasks, projects, workload, scorecards)", Auth: true,
+		Query: []Param{tzParam}, Body: graph.Request{}, Response: graph.Response{}},
+	{Method: http.MethodGet, Path: "/api/v1/errors", Tag: "docs", Summary: "Error code catalogue",
+		Response: Object{"count": 0, "codes": []apperr.Entry{}}},
+
+	// ClickUp
+	{Method: http.MethodPost, Path: "/api/v1/clickup/sync/team", Tag: "clickup", Summary: "Sync teams from ClickUp", Response: messageResponse},
+	{Method: http.MethodPost, Path: "/api/v1/clickup/sync/members", Tag: "clickup", Summary: "Sync members from ClickUp", Response: messageResponse},
+	{Method: http.MethodPost, Path: "/api/v1/clickup/sync/tasks", Tag: "clickup", Summary: "Sync tasks from ClickUp",
+		Response: Object{"message": "", "count": 0}},
+	{Method: http.MethodPost, Path: "/api/v1/clickup/sync/all", Tag: "clickup", Summary: "Sync teams, members and tasks", Response: messageResponse},
+	{Method: http.MethodGet, Path: "/api/v1/clickup/spaces", Tag: "clickup", Summary: "List spaces",
+		Response: Object{"spaces": []model.SpaceInfo{}}},
+	{Method: http.MethodGet, Path: "/api/v1/clickup/members", Tag: "clickup", Summary: "List members", Paged: true,
+		Query:    []Param{statusParam, roleParam},
+		Response: Object{"count": 0, "users": []model.User{}, "page": query.Page{}}},
+	{Method: http.MethodGet, Path: "/api/v1/clickup/tasks", Tag: "clickup", Summary: "List tasks", Paged: true,
+		Query:    []Param{statusParam, roleParam, {Name: "assignee"}, {Name: "project"}, {Name: "due_date_from"}, {Name: "due_date_to"}},
+		Response: Object{"count": 0, "tasks": []model.TaskResponse{}, "page": query.Page{}}},
+	{Method: http.MethodGet, Path: "/api/v1/clickup/fullsync", Tag: "clickup", Summary: "Tasks with their members",
+		Query:    []Param{roleParam, usernameParam, startDateParam, endDateParam, tzParam},
+		Response: Object{"data": []model.TaskWithMember{}}},
+	{Method: http.MethodGet, Path: "/api/v1/clickup/fullsync/filter", Tag: "clickup", Summary: "Filtered full sync view",
+		Query:    []Param{usernameParam, {Name: "email"}, roleParam, {Name: "range", Description: "Preset range, e.g. this_week"}, startDateParam, endDateParam, tzParam},
+		Response: Object{"tasks": []model.FullSync{}}},
+	{Method: http.MethodGet, Path: "/api/v1/clickup/data", Tag: "clickup", Summary: "Tasks with members, exportable", Export: true,
+		Query:    []Param{roleParam, usernameParam, startDateParam, endDateParam, tzParam},
+		Response: Object{"data": []model.TaskWithMember{}}},
+
+	// Sync
+	{Method: http.MethodPost, Path: "/api/v1/sync/spaces-folders-lists", Tag: "sync", Summary: "Sync spaces, folders and lists", Response: messageResponse},
+	{Method: http.MethodGet, Path: "/api/v1/sync/lists", Tag: "sync", Summary: "List ClickUp lists", Paged: true,
+		Query: []Param{{Name: "project"}}, Response: []model.List{}},
+	{Method: http.MethodGet, Path: "/api/v1/sync/folders", Tag: "sync", Summary: "Sync and list folders", Paged: true,
+		Response: []model.Folder{}},
+	{Method: http.MethodPost, Path: "/api/v1/sync/all", Tag: "sync", Summary: "Start a full sync in the background", Status: http.StatusAccepted,
+		Response: messageResponse},
+	{Method: http.MethodGet, Path: "/api/v1/sync/history", Tag: "sync", Summary: "Sync history", Paged: true,
+		Query: []Param{statusParam, {Name: "sync_time_from"}, {Name: "sync_time_to"}}, Response: []model.SyncHistory{}},
+	{Method: http.MethodGet, Path: "/api/v1/sync/all/stream", Tag: "sync", Summary: "Run a full sync and stream progress as server-sent events",
+		Content: "text/event-stream"},
+
+	// Workload
+	{Method: http.MethodPost, Path: "/api/v1/workload/sync", Tag: "workload", Summary: "Sync everything used by workload", Response: messageResponse},
+	{Method: http.MethodGet, Path: "/api/v1/workload", Tag: "workload", Summary: "Hours per member", Paged: true, Export: true,
+		Query:    []Param{required(Param{Name: "start"}), required(Param{Name: "end"}), usernameParam, tzParam},
+		Response: []model.WorkloadUser{}},
+	{Method: http.MethodGet, Path: "/api/v1/workload/workload", Tag: "workload", Summary: "Hours per member (alias of /workload)", Paged: true, Export: true,
+		Query:    []Param{required(Param{Name: "start"}), required(Param{Name: "end"}), usernameParam, tzParam},
+		Response: []model.WorkloadUser{}},
+	{Method: http.MethodGet, Path: "/api/v1/workload/tasks-by-range", Tag: "workload", Summary: "Tasks per assignee with schedule metrics", Paged: true, Export: true,
+		Query:    []Param{required(startDateParam), required(endDateParam), tzParam},
+		Response: Object{"count": 0, "assignees": []handlers.AssigneeWithTasks{}, "page": query.Page{}}},
+	{Method: http.MethodGet, Path: "/api/v1/workload/summary", Tag: "workload", Summary: "Task summary per member", Paged: true, Export: true,
+		Query:    []Param{required(startDateParam), required(endDateParam), {Name: "name"}, {Name: "email"}, tzParam},
+		Response: []model.TaskSummary{}},
+	{Method: http.MethodPost, Path: "/api/v1/workload/rebalance", Tag: "workload", Summary: "Generate rebalance suggestions (manager or admin)", Auth: true,
+		Query: []Param{{Name: "days", Type: "integer", Description: "Horizon in days, default 14"}}, Response: model.RebalanceResponse{}},
+	{Method: http.MethodGet, Path: "/api/v1/workload/rebalance", Tag: "workload", Summary: "List rebalance suggestions",
+		Query: []Param{statusParam}, Response: Object{"count": 0, "suggestions": []model.RebalanceSuggestion{}}},
+	{Method: http.MethodPost, Path: "/api/v1/workload/rebalance/:id/accept", Tag: "workload", Summary: "Accept a suggestion and reassign the task (manager or admin)", Auth: true,
+		Response: model.RebalanceSuggestion{}},
+	{Method: http.MethodPost, Path: "/api/v1/workload/rebalance/:id/dismiss", Tag: "workload", Summary: "Dismiss a suggestion (manager or admin)", Auth: true,
+		Response: model.RebalanceSuggestion{}},
+
+	// KPI
+	{Method: http.MethodGet, Path: "/api/v1/kpi/indicators", Tag: "kpi", Summary: "List KPI indicators", Auth: true,
+		Response: Object{"indicators": []model.KPIIndicator{}}},
+	{Method: http.MethodPut, Path: "/api/v1/kpi/indicators/:code", Tag: "kpi", Summary: "Update an indicator; omitted fields are kept (admin only)", Auth: true,
+		Body: model.KPIIndicatorRequest{}, Response: model.KPIIndicator{}},
+	{Method: http.MethodGet, Path: "/api/v1/kpi/weights", Tag: "kpi", Summary: "Indicator weights per role", Auth: true,
+		Response: Object{"weights": []model.KPIRoleWeight{}}},
+	{Method: http.MethodPut, Path: "/api/v1/kpi/weights/:role", Tag: "kpi", Summary: "Set indicator weights of a role (admin only)", Auth: true,
+		Body: struct {
+			Weights map[string]float64 `json:"weights"`
+		}{},
+		Response: Object{"role": "", "weights": map[string]float64{}}},
+	{Method: http.MethodPost, Path: "/api/v1/kpi/scorecards/compute", Tag: "kpi", Summary: "Compute scorecards of a period (admin only)", Auth: true,
+		Query: []Param{required(periodParam)}, Response: Object{"count": 0, "scorecards": []model.KPIScorecard{}}},
+	{Method: http.MethodGet, Path: "/api/v1/kpi/scorecards", Tag: "kpi", Summary: "List scorecards; members only see their own", Auth: true,
+		Query: []Param{periodParam, userIDParam}, Response: Object{"count": 0, "scorecards": []model.KPIScorecard{}}},
+	{Method: http.MethodGet, Path: "/api/v1/kpi/scorecards/:id", Tag: "kpi", Summary: "Get a scorecard", Auth: true, Response: model.KPIScorecard{}},
+	{Method: http.MethodGet, Path: "/api/v1/kpi/scorecards/:id/indicators/:code/tasks", Tag: "kpi", Summary: "Tasks behind an indicator value", Auth: true,
+		Response: Object{"count": 0, "tasks": []model.KPIItemTask{}}},
+
+	// Appraisals
+	{Method: http.MethodPost, Path: "/api/v1/appraisals", Tag: "appraisals", Summary: "Create an appraisal period (admin only)", Auth: true, Status: http.StatusCreated,
+		Body: model.AppraisalPeriodRequest{}, Response: model.AppraisalPeriod{}},
+	{Method: http.MethodGet, Path: "/api/v1/appraisals", Tag: "appraisals", Summary: "List appraisal periods", Auth: true,
+		Response: Object{"count": 0, "periods": []model.AppraisalPeriod{}}},
+	{Method: http.MethodGet, Path: "/api/v1/appraisals/:id", Tag: "appraisals", Summary: "Get an appraisal period", Auth: true, Response: model.AppraisalPeriod{}},
+	{Method: http.MethodPost, Path: "/api/v1/appraisals/:id/compute", Tag: "appraisals", Summary: "Recompute scorecards of an open period (admin only)", Auth: true,
+		Response: model.AppraisalScorecards{}},
+	{Method: http.MethodPost, Path: "/api/v1/appraisals/:id/lock", Tag: "appraisals", Summary: "Lock a period and freeze its data (admin only)", Auth: true, Response: model.AppraisalPeriod{}},
+	{Method: http.MethodPost, Path: "/api/v1/appraisals/:id/reopen", Tag: "appraisals", Summary: "Reopen a locked period (admin only)", Auth: true,
+		Body: struct {
+			Reason string `json:"reason"`
+		}{},
+		Response: model.AppraisalPeriod{}},
+	{Method: http.MethodGet, Path: "/api/v1/appraisals/:id/scorecards", Tag: "appraisals", Summary: "Scorecards of a period; members only see their own", Auth: true,
+		Query: []Param{userIDParam}, Response: model.AppraisalScorecards{}},
+	{Method: http.MethodGet, Path: "/api/v1/appraisals/:id/tasks", Tag: "appraisals", Summary: "Tasks of a period, frozen once locked; members only see their own", Auth: true,
+		Query: []Param{userIDParam}, Response: Object{"frozen": false, "count": 0, "tasks": []model.AppraisalTaskSnapshot{}}},
+	{Method: http.MethodGet, Path: "/api/v1/appraisals/:id/audit", Tag: "appraisals", Summary: "Audit log of a period (manager or admin)", Auth: true,
+		Response: Object{"count": 0, "entries": []model.AppraisalAuditEntry{}}},
+	{Method: http.MethodGet, Path: "/api/v1/appraisals/:id/reviews", Tag: "appraisals", Summary: "Manager reviews of a period; members only see their own", Auth: true,
+		Query: []Param{userIDParam}, Response: Object{"count": 0, "reviews": []model.AppraisalReview{}}},
+	{Method: http.MethodPut, Path: "/api/v1/appraisals/:id/reviews/:user_id", Tag: "appraisals", Summary: "Save a manager review (manager or admin)", Auth: true,
+		Body: model.AppraisalReviewRequest{}, Response: model.AppraisalReview{}},
+	{Method: http.MethodPost, Path: "/api/v1/appraisals/:id/reviews/:user_id/acknowledge", Tag: "appraisals", Summary: "Acknowledge a review (the reviewed member only)", Auth: true,
+		Body: model.AppraisalAcknowledgeRequest{}, Response: model.AppraisalReview{}},
+
+	// OKRs
+	{Method: http.MethodPost, Path: "/api/v1/okrs/objectives", Tag: "okrs", Summary: "Create an objective (admin only)", Auth: true, Status: http.StatusCreated,
+		Body: model.ObjectiveRequest{}, Response: model.Objective{}},
+	{Method: http.MethodGet, Path: "/api/v1/okrs/objectives", Tag: "okrs", Summary: "List objectives",
+		Query: []Param{{Name: "period"}, userIDParam, roleParam}, Response: Object{"count": 0, "objectives": []model.Objective{}}},
+	{Method: http.MethodGet, Path: "/api/v1/okrs/objectives/:id", Tag: "okrs", Summary: "Get an objective with its key results", Response: model.Objective{}},
+	{Method: http.MethodPut, Path: "/api/v1/okrs/objectives/:id", Tag: "okrs", Summary: "Update an objective (admin only)", Auth: true,
+		Body: model.ObjectiveRequest{}, Response: model.Objective{}},
+	{Method: http.MethodDelete, Path: "/api/v1/okrs/objectives/:id", Tag: "okrs", Summary: "Delete an objective (admin only)", Auth: true, Response: messageResponse},
+	{Method: http.MethodPost, Path: "/api/v1/okrs/objectives/:id/key-results", Tag: "okrs", Summary: "Add a key result (admin only)", Auth: true, Status: http.StatusCreated,
+		Body: model.KeyResultRequest{}, Response: model.KeyResult{}},
+	{Method: http.MethodGet, Path: "/api/v1/okrs/key-results/:id", Tag: "okrs", Summary: "Get a key result", Response: model.KeyResult{}},
+	{Method: http.MethodPut, Path: "/api/v1/okrs/key-results/:id", Tag: "okrs", Summary: "Update a key result (admin only)", Auth: true,
+		Body: model.KeyResultRequest{}, Response: model.KeyResult{}},
+	{Method: http.MethodDelete, Path: "/api/v1/okrs/key-results/:id", Tag: "okrs", Summary: "Delete a key result (admin only)", Auth: true, Response: messageResponse},
+	{Method: http.MethodGet, Path: "/api/v1/okrs/key-results/:id/tasks", Tag: "okrs", Summary: "Tasks linked to a key result",
+		Response: Object{"count": 0, "tasks": []model.OKRTask{}}},
+
+	// Projects
+	{Method: http.MethodGet, Path: "/api/v1/projects", Tag: "projects", Summary: "Project health summaries",
+		Query:    []Param{{Name: "level", Description: "space, folder (default) or list"}, {Name: "include_archived", Type: "boolean"}},
+		Response: Object{"level": "", "count": 0, "projects": []model.ProjectSummary{}}},
+	{Method: http.MethodGet, Path: "/api/v1/projects/:level/:id", Tag: "projects", Summary: "Project health detail",
+		Query: []Param{{Name: "weeks", Type: "integer", Description: "Trend length"}}, Response: model.ProjectDetail{}},
+
+	// Budgets
+	{Method: http.MethodPost, Path: "/api/v1/budgets", Tag: "budgets", Summary: "Set a project budget (admin only)", Auth: true,
+		Body: model.BudgetRequest{}, Response: model.BudgetStatus{}},
+	{Method: http.MethodGet, Path: "/api/v1/budgets", Tag: "budgets", Summary: "List budgets with burn (admin only)", Auth: true,
+		Response: Object{"count": 0, "budgets": []model.BudgetStatus{}}},
+	{Method: http.MethodGet, Path: "/api/v1/budgets/:id", Tag: "budgets", Summary: "Get a budget (admin only)", Auth: true, Response: model.BudgetStatus{}},
+	{Method: http.MethodPut, Path: "/api/v1/budgets/:id", Tag: "budgets", Summary: "Update a budget (admin only)", Auth: true,
+		Body: model.BudgetRequest{}, Response: model.BudgetStatus{}},
+	{Method: http.MethodDelete, Path: "/api/v1/budgets/:id", Tag: "budgets", Summary: "Delete a budget (admin only)", Auth: true, Response: messageResponse},
+
+	// Sprints
+	{Method: http.MethodPost, Path: "/api/v1/sprints", Tag: "sprints", Summary: "Create a sprint (admin only)", Auth: true, Status: http.StatusCreated,
+		Body: model.SprintRequest{}, Response: model.Sprint{}},
+	{Method: http.MethodGet, Path: "/api/v1/sprints", Tag: "sprints", Summary: "List sprints",
+		Response: Object{"count": 0, "sprints": []model.Sprint{}}},
+	{Method: http.MethodPost, Path: "/api/v1/sprints/detect", Tag: "sprints", Summary: "Create sprints from dated ClickUp lists (admin only)", Auth: true,
+		Response: Object{"detected": int64(0)}},
+	{Method: http.MethodGet, Path: "/api/v1/sprints/velocity", Tag: "sprints", Summary: "Velocity history", Response: model.VelocityHistory{}},
+	{Method: http.MethodGet, Path: "/api/v1/sprints/:id", Tag: "sprints", Summary: "Get a sprint", Response: model.Sprint{}},
+	{Method: http.MethodPut, Path: "/api/v1/sprints/:id", Tag: "sprints", Summary: "Update a sprint (admin only)", Auth: true,
+		Body: model.SprintRequest{}, Response: model.Sprint{}},
+	{Method: http.MethodDelete, Path: "/api/v1/sprints/:id", Tag: "sprints", Summary: "Delete a sprint (admin only)", Auth: true, Response: messageResponse},
+	{Method: http.MethodGet, Path: "/api/v1/sprints/:id/report", Tag: "sprints", Summary: "Sprint report with burndown", Response: model.SprintReport{}},
+
+	// Estimates
+	{Method: http.MethodGet, Path: "/api/v1/estimates/accuracy", Tag: "estimates", Summary: "Estimate accuracy",
+		Query:    []Param{startDateParam, endDateParam, {Name: "group_by", Description: "member, role or project"}},
+		Response: model.EstimateAccuracyReport{}},
+	{Method: http.MethodPut, Path: "/api/v1/estimates/tasks/:id", Tag: "estimates", Summary: "Set a manual estimate (admin only)", Auth: true,
+		Body:     model.ManualEstimateRequest{},
+		Response: Object{"task_id": "", "time_estimate_hours": 0.0, "estimate_source": ""}},
+
+	// Alerts
+	{Method: http.MethodGet, Path: "/api/v1/alerts", Tag: "alerts", Summary: "List alerts",
+		Query: []Param{statusParam, {Name: "rule"}, userIDParam}, Response: Object{"count": 0, "alerts": []model.Alert{}}},
+	{Method: http.MethodPost, Path: "/api/v1/alerts/evaluate", Tag: "alerts", Summary: "Evaluate alert rules now", Response: model.AlertEvaluation{}},
+	{Method: http.MethodGet, Path: "/api/v1/alerts/rules", Tag: "alerts", Summary: "List alert rules",
+		Response: Object{"count": 0, "rules": []model.AlertRule{}}},
+	{Method: http.MethodPut, Path: "/api/v1/alerts/rules/:code", Tag: "alerts", Summary: "Update an alert rule (admin only)", Auth: true,
+		Body: model.AlertRuleRequest{}, Response: model.AlertRule{}},
+	{Method: http.MethodGet, Path: "/api/v1/alerts/:id", Tag: "alerts", Summary: "Get an alert", Response: model.Alert{}},
+	{Method: http.MethodPost, Path: "/api/v1/alerts/:id/acknowledge", Tag: "alerts", Summary: "Acknowledge an alert", Response: model.Alert{}},
+	{Method: http.MethodPost, Path: "/api/v1/alerts/:id/resolve", Tag: "alerts", Summary: "Resolve an alert", Response: model.Alert{}},
+
+	// Notifications
+	{Method: http.MethodPost, Path: "/api/v1/notifications/subscriptions", Tag: "notifications", Summary: "Create a subscription (admin only)", Auth: true, Status: http.StatusCreated,
+		Body: model.NotificationSubscriptionRequest{}, Response: model.NotificationSubscription{}},
+	{Method: http.MethodGet, Path: "/api/v1/notifications/subscriptions", Tag: "notifications", Summary: "List subscriptions (admin only)", Auth: true,
+		Response: Object{"count": 0, "subscriptions": []model.NotificationSubscription{}}},
+	{Method: http.MethodGet, Path: "/api/v1/notifications/subscriptions/:id", Tag: "notifications", Summary: "Get a subscription (admin only)", Auth: true,
+		Response: model.NotificationSubscription{}},
+	{Method: http.MethodPut, Path: "/api/v1/notifications/subscriptions/:id", Tag: "notifications", Summary: "Update a subscription (admin only)", Auth: true,
+		Body: model.NotificationSubscriptionRequest{}, Response: model.NotificationSubscription{}},
+	{Method: http.MethodDelete, Path: "/api/v1/notifications/subscriptions/:id", Tag: "notifications", Summary: "Delete a subscription (admin only)", Auth: true,
+		Response: messageResponse},
+	{Method: http.MethodGet, Path: "/api/v1/notifications/deliveries", Tag: "notifications", Summary: "Delivery log (admin only)", Auth: true,
+		Query:    []Param{statusParam, {Name: "limit", Type: "integer"}},
+		Response: Object{"count": 0, "deliveries": []model.NotificationDelivery{}}},
+	{Method: http.MethodPost, Path: "/api/v1/notifications/deliveries/:id/retry", Tag: "notifications", Summary: "Retry a failed delivery (admin only)", Auth: true,
+		Response: model.NotificationDelivery{}},
+	{Method: http.MethodPost, Path: "/api/v1/notifications/test", Tag: "notifications", Summary: "Send a test notification (admin only)", Auth: true,
+		Body: model.NotificationTestRequest{}, Response: messageResponse},
+
+	// Reports
+	{Method: http.MethodPost, Path: "/api/v1/reports/definitions", Tag: "reports", Summary: "Create a scheduled report (manager or admin)", Auth: true, Status: http.StatusCreated,
+		Body: model.ReportDefinitionRequest{}, Response: model.ReportDefinition{}},
+	{Method: http.MethodGet, Path: "/api/v1/reports/definitions", Tag: "reports", Summary: "List scheduled reports (manager or admin)", Auth: true,
+		Response: Object{"count": 0, "definitions": []model.ReportDefinition{}}},
+	{Method: http.MethodGet, Path: "/api/v1/reports/definitions/:id", Tag: "reports", Summary: "Get a scheduled report (manager or admin)", Auth: true, Response: model.ReportDefinition{}},
+	{Method: http.MethodPut, Path: "/api/v1/reports/definitions/:id", Tag: "reports", Summary: "Update a scheduled report (manager or admin)", Auth: true,
+		Body: model.ReportDefinitionRequest{}, Response: model.ReportDefinition{}},
+	{Method: http.MethodDelete, Path: "/api/v1/reports/definitions/:id", Tag: "reports", Summary: "Delete a scheduled report (manager or admin)", Auth: true, Response: messageResponse},
+	{Method: http.MethodPost, Path: "/api/v1/reports/definitions/:id/run", Tag: "reports", Summary: "Run and deliver a report now (manager or admin)", Auth: true, Response: model.ReportRun{}},
+	{Method: http.MethodGet, Path: "/api/v1/reports/definitions/:id/preview", Tag: "reports", Summary: "Render the last period without sending (manager or admin)", Auth: true,
+		Query: []Param{{Name: "format", Description: "json (default), html or csv"}}, Response: model.ReportDocument{}, Content: "text/html"},
+	{Method: http.MethodGet, Path: "/api/v1/reports/definitions/:id/runs", Tag: "reports", Summary: "Run history (manager or admin)", Auth: true,
+		Response: Object{"count": 0, "runs": []model.ReportRun{}}},
+	{Method: http.MethodGet, Path: "/api/v1/reports/members/:file", Tag: "reports", Summary: "Member performance report as PDF, e.g. 123.pdf; members only get their own", Auth: true,
+		Query: []Param{periodParam}, Content: "application/pdf"},
+
+	// Auth
+	{Method: http.MethodPost, Path: "/api/v1/auth/login", Tag: "auth", Summary: "Log in as admin",
+		Body: model.LoginRequest{}, Response: model.ResponseApi{}},
+	{Method: http.MethodPut, Path: "/api/v1/auth/language", Tag: "auth", Summary: "Save the admin's language and get a new token", Auth: true,
+		Body: model.LanguageRequest{}, Response: Object{"message": "", "language": "", "token": ""}},
+	{Method: http.MethodPut, Path: "/api/v1/auth/accounts/:username", Tag: "auth", Summary: "Create or update an account with its role and linked member (admin only)", Auth: true,
+		Body: model.AccountRequest{}, Response: model.Account{}},
+
+	// API v2
+	{Method: http.MethodGet, Path: "/api/v2/tasks", Tag: "v2", Summary: "List tasks", Paged: true,
+		Query: []Param{statusParam, roleParam, {Name: "assignee", Description: "Member id, name or email"}, {Name: "project", Description: "List or folder id or name"},
+			{Name: "due_at_from"}, {Name: "due_at_to"}, {Name: "updated_at_from"}, {Name: "updated_at_to"}, includeParam("assignees, project")},
+		Response: Object{"data": []resource.Task{}, "page": query.Page{}}},
+	{Method: http.MethodGet, Path: "/api/v2/tasks/:id", Tag: "v2", Summary: "Get a task",
+		Query: []Param{includeParam("assignees, project"), tzParam}, Response: Object{"data": resource.Task{}}},
+	{Method: http.MethodGet, Path: "/api/v2/members", Tag: "v2", Summary: "List members", Paged: true,
+		Query: []Param{statusParam, roleParam}, Response: Object{"data": []resource.Member{}, "page": query.Page{}}},
+	{Method: http.MethodGet, Path: "/api/v2/members/:id", Tag: "v2", Summary: "Get a member",
+		Query: []Param{tzParam}, Response: Object{"data": resource.Member{}}},
+	{Method: http.MethodGet, Path: "/api/v2/projects", Tag: "v2", Summary: "List spaces, folders and lists", Paged: true,
+		Query:    []Param{{Name: "level", Description: "space, folder or list; default all"}, {Name: "include_archived", Type: "boolean"}, {Name: "project", Description: "Id, name or parent id"}},
+		Response: Object{"data": []resource.Project{}, "page": query.Page{}}},
+	{Method: http.MethodGet, Path: "/api/v2/projects/:id", Tag: "v2", Summary: "Get a space, folder or list",
+		Response: Object{"data": resource.Project{}}},
+	{Method: http.MethodGet, Path: "/api/v2/workload", Tag: "v2", Summary: "Logged hours per member over a period", Paged: true,
+		Query:    []Param{required(Param{Name: "start"}), required(Param{Name: "end"}), {Name: "assignee", Description: "Member id"}, includeParam("member")},
+		Response: Object{"data": []resource.Workload{}, "page": query.Page{}}},
+}
@@ -0,0 +1,183 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// Schema is an OpenAPI 3.0 schema object. Only the keywords this API needs
+// are modelled.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	rawJSONType = reflect.TypeOf(json.RawMessage(nil))
+)
+
+// marshalers are types with their own MarshalJSON, described by hand.
+var marshalers = map[reflect.Type]Schema{
+	reflect.TypeOf(model.JsonNullInt64{}): {Type: "integer", Format: "int64", Nullable: true},
+}
+
+// componentNames overrides names too generic outside their package.
+var componentNames = map[reflect.Type]string{
+	reflect.TypeOf(apperr.Body{}):      "ErrorEnvelope",
+	reflect.TypeOf(apperr.BodyError{}): "ErrorObject",
+	reflect.TypeOf(apperr.Entry{}):     "ErrorCode",
+}
+
+// registry turns Go types into schemas, collecting named structs as
+// components so each appears once in the document.
+type registry struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newRegistry() *registry {
+	return &registry{components: map[string]*Schema{}, names: map[reflect.Type]string{}}
+}
+
+// schemaOf describes v. v is a Go value (usually a zero value), a *Schema
+// used as is, or an Object built from other values.
+func (r *registry) schemaOf(v interface{}) *Schema {
+	switch x := v.(type) {
+	case nil:
+		return nil
+	case *Schema:
+		return x
+	case Object:
+		return r.object(x)
+	}
+	return r.typeSchema(reflect.TypeOf(v))
+}
+
+func (r *registry) object(o Object) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for name, v := range o {
+		s.Properties[name] = r.schemaOf(v)
+		s.Required = append(s.Required, name)
+	}
+	sort.Strings(s.Required)
+	return s
+}
+
+func (r *registry) typeSchema(t reflect.Type) *Schema {
+	if t == rawJSONType {
+		return &Schema{}
+	}
+	if m, ok := marshalers[t]; ok {
+		return &m
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := r.typeSchema(t.Elem())
+		if s.Ref != "" {
+			// $ref siblings are ignored in 3.0, so a nullable ref is left as is.
+			return s
+		}
+		s.Nullable = true
+		return s
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.typeSchema(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return r.ref(t)
+	}
+	return &Schema{}
+}
+
+func (r *registry) ref(t reflect.Type) *Schema {
+	name, ok := r.names[t]
+	if !ok {
+		name = t.Name()
+		if n, ok := componentNames[t]; ok {
+			name = n
+		}
+		if _, taken := r.components[name]; taken {
+			pkg := t.PkgPath()
+			pkg = pkg[strings.LastIndex(pkg, "/")+1:]
+			name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+		}
+		r.names[t] = name
+		r.components[name] = &Schema{} // placeholder for recursive types
+		*r.components[name] = *r.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// structSchema follows encoding/json: json:"-" and unexported fields are
+// skipped, untagged embedded structs are flattened and omitempty fields are
+// optional.
+func (r *registry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	r.addFields(s, t)
+	sort.Strings(s.Required)
+	return s
+}
+
+func (r *registry) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				r.addFields(s, ft)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties[name] = r.typeSchema(f.Type)
+		if !strings.Contains(","+opts+",", ",omitempty,") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+// Package pdf is a small PDF 1.4 writer: A4 pages, the built-in Helvetica
+// fonts, text, lines and filled rectangles. Enough for printable reports
+// without external tools. Coordinates are in points from the top-left corner.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+type Color struct{ R, G, B uint8 }
+
+var (
+	Black = Color{0, 0, 0}
+	Gray  = Color{120, 120, 120}
+	White = Color{255, 255, 255}
+)
+
+type Document struct {
+	pages []*bytes.Buffer
+	page  *bytes.Buffer
+	bold  bool
+	size  float64
+	title string
+}
+
+func New(title string) *Document {
+	return &Document{size: 10, title: title}
+}
+
+// AddPage starts a new page; drawing calls go to the latest page.
+func (d *Document) AddPage() {
+	d.page = &bytes.Buffer{}
+	d.pages = append(d.pages, d.page)
+}
+
+func (d *Document) PageCount() int { return len(d.pages) }
+
+func (d *Document) SetFont(bold bool, size float64) {
+	d.bold, d.size = bold, size
+}
+
+// Text writes s with its baseline at y.
+func (d *Document) Text(x, y float64, s string, c Color) {
+	font := "F1"
+	if d.bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page, "BT /%s %s Tf %s rg %s %s Td (%s) Tj ET\n",
+		font, num(d.size), rgb(c), num(x), num(PageHeight-y), escape(s))
+}
+
+// Rect draws a filled rectangle with its top-left corner at x, y.
+func (d *Document) Rect(x, y, w, h float64, c Color) {
+	fmt.Fprintf(d.page, "%s rg %s %s %s %s re f\n", rgb(c), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+func (d *Document) Line(x1, y1, x2, y2, width float64, c Color) {
+	fmt.Fprintf(d.page, "%s RG %s w %s %s m %s %s l S\n",
+		rgb(c), num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// TextWidth is the width of s in the current font.
+func (d *Document) TextWidth(s string) float64 {
+	return TextWidth(s, d.bold, d.size)
+}
+
+// TextWidth measures s in Helvetica (bold) at size points.
+func TextWidth(s string, bold bool, size float64) float64 {
+	var units int
+	for _, b := range encode(s) {
+		w := 556
+		if b >= 32 && int(b-32) < len(helveticaWidths) {
+			w = helveticaWidths[b-32]
+		}
+		units += w
+	}
+	if bold {
+		units = units * 106 / 100
+	}
+	return float64(units) * size / 1000
+}
+
+// Truncate shortens s with "..." so it fits in width.
+func Truncate(s string, bold bool, size, width float64) string {
+	if TextWidth(s, bold, size) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && TextWidth(string(r)+"...", bold, size) > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "..."
+}
+
+// Wrap splits s into lines no wider than width, breaking on spaces.
+func Wrap(s string, bold bool, size, width float64) []string {
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			next := word
+			if line != "" {
+				next = line + " " + word
+			}
+			if line != "" && TextWidth(next, bold, size) > width {
+				lines = append(lines, line)
+				next = word
+			}
+			line = next
+		}
+		lines = append(lines, Truncate(line, bold, size, width))
+	}
+	return lines
+}
+
+// WriteTo writes the finished document. A document without pages gets one
+// empty page.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// 1 catalog, 2 pages, 3-4 fonts, 5 info, lalu pasangan page/content.
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+i*2)
+	}
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title (%s) /Producer (go-kinerja-backend) >>", escape(d.title)))
+
+	for i, p := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), 7+i*2))
+
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		zw.Write(p.Bytes())
+		zw.Close()
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", len(offsets), z.Len())
+		out.Write(z.Bytes())
+		out.WriteString("\nendstream\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.WriteTo(w)
+}
+
+func num(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+func rgb(c Color) string {
+	return fmt.Sprintf("%s %s %s", num(float64(c.R)/255), num(float64(c.G)/255), num(float64(c.B)/255))
+}
+
+// encode maps s to WinAnsi bytes. Latin-1 is kept, a few common typographic
+// characters are mapped, everything else becomes "?".
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\t':
+			out = append(out, ' ')
+		case r >= 32 && r < 127, r >= 0xA0 && r <= 0xFF:
+			out = append(out, byte(r))
+		case r == '–':
+			out = append(out, 0x96)
+		case r == '—':
+			out = append(out, 0x97)
+		case r == '‘', r == '’':
+			out = append(out, '\'')
+		case r == '“', r == '”':
+			out = append(out, '"')
+		case r == '•':
+			out = append(out, 0x95)
+		case r == '€':
+			out = append(out, 0x80)
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	for _, c := range encode(s) {
+		switch c {
+		case '\\', '(', ')':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// helveticaWidths are the Helvetica AFM widths of characters 32-126.
+var helveticaWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space - /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0-9
+	278, 278, 584, 584, 584, 556, 1015, // : - @
+	667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // A-M
+	722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N-Z
+	278, 278, 278, 469, 556, 333, // [ - `
+	556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // a-m
+	556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // n-z
+	334, 260, 334, 584, // { - ~
+}
@@ -0,0 +1,149 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MemorySpec whitelists what an in-memory list accepts, for endpoints whose
+// rows are computed in Go (workload, summaries).
+type MemorySpec[T any] struct {
+	// Sort maps a field name to a comparison returning <0, 0 or >0.
+	Sort        map[string]func(a, b T) int
+	DefaultSort []SortField
+	// Filters maps a filter name to the lowercased value(s) of a row.
+	Filters map[string]func(T) []string
+	Dates   map[string]func(T) *time.Time
+	// Search returns the texts matched by ?q=.
+	Search func(T) []string
+
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Apply filters, sorts and pages items.
+func Apply[T any](items []T, p Params, s MemorySpec[T]) ([]T, Page, error) {
+	for name := range p.Filters {
+		if _, ok := s.Filters[name]; !ok {
+			return nil, Page{}, fmt.Errorf("%w: filter %q is not supported here", ErrInvalidQuery, name)
+		}
+	}
+	for field := range p.Dates {
+		if _, ok := s.Dates[field]; !ok {
+			return nil, Page{}, fmt.Errorf("%w: date filter %q is not supported here", ErrInvalidQuery, field)
+		}
+	}
+	if p.Search != "" && s.Search == nil {
+		return nil, Page{}, fmt.Errorf("%w: search is not supported here", ErrInvalidQuery)
+	}
+	sortFields := p.Sort
+	if len(sortFields) == 0 {
+		sortFields = s.DefaultSort
+	}
+	for _, f := range sortFields {
+		if _, ok := s.Sort[f.Field]; !ok {
+			names := make([]string, 0, len(s.Sort))
+			for k := range s.Sort {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			return nil, Page{}, fmt.Errorf("%w: cannot sort by %q, use one of %s", ErrInvalidQuery, f.Field, strings.Join(names, ", "))
+		}
+	}
+
+	search := strings.ToLower(p.Search)
+	out := make([]T, 0, len(items))
+	for _, it := range items {
+		if matches(it, p, s, search) {
+			out = append(out, it)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		for _, f := range sortFields {
+			c := s.Sort[f.Field](out[i], out[j])
+			if c == 0 {
+				continue
+			}
+			if f.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	limit := p.limit(s.DefaultLimit, s.MaxLimit)
+	total := len(out)
+	start := p.Offset
+	if start > total {
+		start = total
+	}
+	end := total
+	if limit < total-start {
+		end = start + limit
+	}
+	page := out[start:end]
+	return page, NewPage(p, limit, total, len(page)), nil
+}
+
+func matches[T any](it T, p Params, s MemorySpec[T], search string) bool {
+	for name, want := range p.Filters {
+		if !anyEqual(s.Filters[name](it), want) {
+			return false
+		}
+	}
+	for field, r := range p.Dates {
+		t := s.Dates[field](it)
+		if t == nil || (r.From != nil && t.Before(*r.From)) || (r.To != nil && t.After(*r.To)) {
+			return false
+		}
+	}
+	if search != "" {
+		found := false
+		for _, text := range s.Search(it) {
+			if strings.Contains(strings.ToLower(text), search) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func anyEqual(have, want []string) bool {
+	for _, h := range have {
+		h = strings.ToLower(h)
+		for _, w := range want {
+			if h == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Compare helpers for MemorySpec.Sort.
+
+func CompareString(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func CompareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func CompareInt(a, b int) int {
+	return CompareFloat(float64(a), float64(b))
+}
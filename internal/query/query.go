@@ -0,0 +1,249 @@
+// Package query parses the shared list syntax (pagination, sorting,
+// filtering) used by list endpoints and turns it into SQL clauses or applies
+// it to an in-memory slice.
+//
+//	?limit=50&offset=100            offset pagination
+//	?limit=50&cursor=<next_cursor>  cursor from the previous page
+//	?sort=-due_date,name            multi-field sort, "-" = descending
+//	?status=open,in progress        comma-separated values, OR-ed
+//	?role=backend&assignee=123&project=Kinerja
+//	?q=login                        free-text search
+//	?due_date_from=2026-10-01&due_date_to=2026-10-31&tz=Asia/Jakarta
+package query
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+	// NoLimit returns every matching row of data that is already in memory;
+	// exports use it and are capped by export.MaxRows instead.
+	NoLimit = -1
+)
+
+// Filter yang dikenal. Endpoint memilih mana yang didukung lewat Spec.
+const (
+	FilterStatus   = "status"
+	FilterRole     = "role"
+	FilterAssignee = "assignee"
+	FilterProject  = "project"
+)
+
+var filterNames = []string{FilterStatus, FilterRole, FilterAssignee, FilterProject}
+
+var ErrInvalidQuery = apperr.New(apperr.CodeValidationFailed, "invalid query")
+
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// DateRange is inclusive on both ends; a bare date in To covers the whole day.
+type DateRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+type Params struct {
+	Limit   int
+	Offset  int
+	Sort    []SortField
+	Filters map[string][]string
+	Dates   map[string]DateRange
+	Search  string
+}
+
+// Page is the pagination metadata returned with every list.
+type Page struct {
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Parse reads the list syntax from query values. It only checks syntax;
+// unsupported fields are rejected by Spec.Build or Apply.
+func Parse(v url.Values) (Params, error) {
+	p := Params{Filters: map[string][]string{}, Dates: map[string]DateRange{}}
+
+	if s := v.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return p, fmt.Errorf("%w: limit must be a positive number", ErrInvalidQuery)
+		}
+		p.Limit = n
+	}
+	if s := v.Get("offset"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return p, fmt.Errorf("%w: offset must be zero or a positive number", ErrInvalidQuery)
+		}
+		p.Offset = n
+	}
+
+	if s := strings.TrimSpace(v.Get("sort")); s != "" {
+		for _, f := range strings.Split(s, ",") {
+			f = strings.TrimSpace(f)
+			desc := strings.HasPrefix(f, "-")
+			f = strings.TrimPrefix(strings.TrimPrefix(f, "-"), "+")
+			if f == "" {
+				return p, fmt.Errorf("%w: empty sort field", ErrInvalidQuery)
+			}
+			p.Sort = append(p.Sort, SortField{Field: f, Desc: desc})
+		}
+	}
+
+	for _, name := range filterNames {
+		for _, raw := range v[name] {
+			for _, val := range strings.Split(raw, ",") {
+				if val = strings.TrimSpace(val); val != "" {
+					p.Filters[name] = append(p.Filters[name], strings.ToLower(val))
+				}
+			}
+		}
+	}
+	p.Search = strings.TrimSpace(v.Get("q"))
+	if p.Search == "" {
+		p.Search = strings.TrimSpace(v.Get("search"))
+	}
+
+	loc, err := dates.Location(v.Get("tz"))
+	if err != nil {
+		return p, fmt.Errorf("%w: %v", ErrInvalidQuery, err)
+	}
+	for key := range v {
+		var field string
+		var to bool
+		switch {
+		case strings.HasSuffix(key, "_from"):
+			field = strings.TrimSuffix(key, "_from")
+		case strings.HasSuffix(key, "_to"):
+			field, to = strings.TrimSuffix(key, "_to"), true
+		default:
+			continue
+		}
+		parse := dates.ParseStart
+		if to {
+			parse = dates.ParseEnd
+		}
+		t, err := parse(v.Get(key), loc)
+		if err != nil {
+			return p, fmt.Errorf("%w: %s: %v", ErrInvalidQuery, key, err)
+		}
+		r := p.Dates[field]
+		if to {
+			r.To = &t
+		} else {
+			r.From = &t
+		}
+		p.Dates[field] = r
+	}
+
+	if c := v.Get("cursor"); c != "" {
+		offset, err := p.decodeCursor(c)
+		if err != nil {
+			return p, err
+		}
+		p.Offset = offset
+	}
+	return p, nil
+}
+
+// limit returns the effective page size.
+func (p Params) limit(def, max int) int {
+	if def <= 0 {
+		def = DefaultLimit
+	}
+	if max <= 0 {
+		max = MaxLimit
+	}
+	switch {
+	case p.Limit == NoLimit:
+		return math.MaxInt
+	case p.Limit <= 0:
+		return def
+	case p.Limit > max:
+		return max
+	}
+	return p.Limit
+}
+
+// fingerprint identifies the sort and filters a cursor belongs to, so a
+// cursor can't be replayed against a different query.
+func (p Params) fingerprint() string {
+	var b strings.Builder
+	for _, s := range p.Sort {
+		fmt.Fprintf(&b, "s:%s:%t;", s.Field, s.Desc)
+	}
+	names := make([]string, 0, len(p.Filters))
+	for k := range p.Filters {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		fmt.Fprintf(&b, "f:%s=%s;", k, strings.Join(p.Filters[k], ","))
+	}
+	dates := make([]string, 0, len(p.Dates))
+	for k := range p.Dates {
+		dates = append(dates, k)
+	}
+	sort.Strings(dates)
+	for _, k := range dates {
+		r := p.Dates[k]
+		fmt.Fprintf(&b, "d:%s=%v-%v;", k, r.From, r.To)
+	}
+	fmt.Fprintf(&b, "q:%s", p.Search)
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:6])
+}
+
+type cursor struct {
+	Offset      int    `json:"o"`
+	Fingerprint string `json:"f"`
+}
+
+func (p Params) encodeCursor(offset int) string {
+	b, _ := json.Marshal(cursor{Offset: offset, Fingerprint: p.fingerprint()})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (p Params) decodeCursor(s string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	var c cursor
+	if err == nil {
+		err = json.Unmarshal(raw, &c)
+	}
+	if err != nil || c.Offset < 0 {
+		return 0, fmt.Errorf("%w: malformed cursor", ErrInvalidQuery)
+	}
+	if c.Fingerprint != p.fingerprint() {
+		return 0, fmt.Errorf("%w: cursor belongs to a different sort or filter", ErrInvalidQuery)
+	}
+	return c.Offset, nil
+}
+
+// NewPage builds the page metadata for a result of n rows out of total.
+func NewPage(p Params, limit, total, n int) Page {
+	page := Page{Total: total, Limit: limit, Offset: p.Offset}
+	if next := p.Offset + n; n > 0 && next < total {
+		page.HasMore = true
+		page.NextCursor = p.encodeCursor(next)
+	}
+	return page
+}
@@ -0,0 +1,288 @@
+package query
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, raw string) Params {
+	t.Helper()
+	v, err := url.ParseQuery(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := Parse(v)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", raw, err)
+	}
+	return p
+}
+
+func TestParse(t *testing.T) {
+	p := mustParse(t, "limit=20&offset=40&sort=-due_date,+name&status=Open,%20done&status=closed&role=Backend&q=%20api%20&due_date_from=2026-10-01&due_date_to=2026-10-31&tz=Asia/Jakarta")
+
+	if p.Limit != 20 || p.Offset != 40 {
+		t.Errorf("limit/offset = %d/%d", p.Limit, p.Offset)
+	}
+	if want := []SortField{{"due_date", true}, {"name", false}}; !reflect.DeepEqual(p.Sort, want) {
+		t.Errorf("sort = %+v, want %+v", p.Sort, want)
+	}
+	// nilai filter di-lowercase dan boleh dipisah koma atau diulang
+	if want := []string{"open", "done", "closed"}; !reflect.DeepEqual(p.Filters[FilterStatus], want) {
+		t.Errorf("status = %v, want %v", p.Filters[FilterStatus], want)
+	}
+	if p.Filters[FilterRole][0] != "backend" || p.Search != "api" {
+		t.Errorf("role = %v, search = %q", p.Filters[FilterRole], p.Search)
+	}
+	r := p.Dates["due_date"]
+	jkt := time.FixedZone("WIB", 7*3600)
+	if r.From == nil || !r.From.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, jkt)) {
+		t.Errorf("due_date_from = %v", r.From)
+	}
+	// tanggal tanpa jam di _to mencakup seluruh hari
+	if r.To == nil || !r.To.Equal(time.Date(2026, 11, 1, 0, 0, 0, 0, jkt).Add(-time.Nanosecond)) {
+		t.Errorf("due_date_to = %v", r.To)
+	}
+
+	if p := mustParse(t, "search=budi"); p.Search != "budi" {
+		t.Errorf("search fallback = %q", p.Search)
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, raw := range []string{
+		"limit=0",
+		"limit=abc",
+		"offset=-1",
+		"offset=x",
+		"sort=name,,due_date",
+		"sort=-",
+		"tz=Mars/Olympus",
+		"due_date_from=someday",
+		"cursor=!!!",
+		"cursor=" + (Params{}).encodeCursor(-5),
+	} {
+		v, _ := url.ParseQuery(raw)
+		if _, err := Parse(v); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("Parse(%q) = %v, want ErrInvalidQuery", raw, err)
+		}
+	}
+}
+
+// Cursor terikat pada sort, filter, tanggal dan pencarian; memakai cursor di
+// query lain ditolak.
+func TestCursorFingerprint(t *testing.T) {
+	base := "sort=name&status=open&q=api&due_date_from=2026-10-01"
+	p := mustParse(t, base)
+	page := NewPage(p, 10, 25, 10)
+	if !page.HasMore || page.NextCursor == "" {
+		t.Fatalf("page = %+v, want a next cursor", page)
+	}
+
+	next := mustParse(t, base+"&cursor="+page.NextCursor)
+	if next.Offset != 10 {
+		t.Errorf("cursor offset = %d, want 10", next.Offset)
+	}
+	// urutan parameter tidak memengaruhi fingerprint
+	if p := mustParse(t, "q=api&due_date_from=2026-10-01&status=open&sort=name&cursor="+page.NextCursor); p.Offset != 10 {
+		t.Errorf("reordered query offset = %d, want 10", p.Offset)
+	}
+
+	for _, other := range []string{
+		"sort=-name&status=open&q=api&due_date_from=2026-10-01",
+		"sort=name&status=done&q=api&due_date_from=2026-10-01",
+		"sort=name&status=open&q=web&due_date_from=2026-10-01",
+		"sort=name&status=open&q=api&due_date_from=2026-10-02",
+		"sort=name&status=open&q=api",
+	} {
+		v, _ := url.ParseQuery(other + "&cursor=" + page.NextCursor)
+		if _, err := Parse(v); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("cursor replayed on %q: err = %v, want ErrInvalidQuery", other, err)
+		}
+	}
+
+	p.Offset = 20
+	if last := NewPage(p, 10, 25, 5); last.HasMore {
+		t.Errorf("page ending at total = %+v, want no next cursor", last)
+	}
+}
+
+var testSpec = Spec{
+	Sort:         map[string]string{"name": "t.name", "due_date": "t.due_date"},
+	DefaultSort:  []SortField{{Field: "name"}},
+	Tiebreak:     "t.id",
+	Filters:      map[string]string{FilterStatus: "LOWER(s.name) = ANY(%s)"},
+	Dates:        map[string]string{"due_date": "t.due_date"},
+	Search:       []string{"t.name", "u.name"},
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+func TestSpecBuild(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	p := Params{
+		Limit:   500,
+		Offset:  30,
+		Sort:    []SortField{{Field: "due_date", Desc: true}},
+		Filters: map[string][]string{FilterStatus: {"open"}},
+		Dates:   map[string]DateRange{"due_date": {From: &from}},
+		Search:  `50%_off\`,
+	}
+	c, err := testSpec.Build(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := ` WHERE LOWER(s.name) = ANY($1) AND t.due_date >= $2 AND (t.name ILIKE $3 OR u.name ILIKE $3)`; c.Where != want {
+		t.Errorf("where = %q\nwant    %q", c.Where, want)
+	}
+	if want := ` ORDER BY t.due_date DESC NULLS LAST, t.id ASC LIMIT 100 OFFSET 30`; c.Tail != want {
+		t.Errorf("tail = %q\nwant   %q", c.Tail, want)
+	}
+	// % _ dan \ dari request dicocokkan apa adanya
+	if len(c.Args) != 3 || c.Args[2] != `%50\%\_off\\%` {
+		t.Errorf("args = %#v", c.Args)
+	}
+	if c.Limit != 100 {
+		t.Errorf("limit = %d, want MaxLimit", c.Limit)
+	}
+
+	c, err = testSpec.Build(Params{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Where != "" || c.Tail != " ORDER BY t.name ASC NULLS LAST, t.id ASC LIMIT 20 OFFSET 0" {
+		t.Errorf("defaults: where %q, tail %q", c.Where, c.Tail)
+	}
+}
+
+type row struct {
+	Name   string
+	Status string
+	Due    *time.Time
+}
+
+var testMemorySpec = MemorySpec[row]{
+	Sort: map[string]func(a, b row) int{
+		"name":     func(a, b row) int { return CompareString(a.Name, b.Name) },
+		"due_date": func(a, b row) int { return a.Due.Compare(*b.Due) },
+	},
+	DefaultSort:  []SortField{{Field: "name"}},
+	Filters:      map[string]func(row) []string{FilterStatus: func(r row) []string { return []string{r.Status} }},
+	Dates:        map[string]func(row) *time.Time{"due_date": func(r row) *time.Time { return r.Due }},
+	Search:       func(r row) []string { return []string{r.Name} },
+	DefaultLimit: 20,
+	MaxLimit:     100,
+}
+
+func TestApply(t *testing.T) {
+	day := func(d int) *time.Time {
+		t := time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	rows := []row{
+		{"Ceria", "Open", day(3)},
+		{"alpha 50%", "open", day(9)},
+		{"Budi", "done", day(5)},
+		{"beta 50x", "open", day(1)},
+	}
+	names := func(rs []row) []string {
+		out := make([]string, len(rs))
+		for i, r := range rs {
+			out[i] = r.Name
+		}
+		return out
+	}
+
+	got, page, err := Apply(rows, Params{}, testMemorySpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"alpha 50%", "beta 50x", "Budi", "Ceria"}; !reflect.DeepEqual(names(got), want) {
+		t.Errorf("default sort = %v, want %v", names(got), want)
+	}
+	if page.Total != 4 || page.Limit != 20 || page.HasMore {
+		t.Errorf("page = %+v", page)
+	}
+
+	from := day(2)
+	p := Params{
+		Limit:   1,
+		Sort:    []SortField{{Field: "due_date", Desc: true}},
+		Filters: map[string][]string{FilterStatus: {"open"}},
+		Dates:   map[string]DateRange{"due_date": {From: from}},
+	}
+	got, page, err = Apply(rows, p, testMemorySpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"alpha 50%"}; !reflect.DeepEqual(names(got), want) || page.Total != 2 || !page.HasMore {
+		t.Errorf("filtered = %v %+v, want %v of 2", names(got), page, want)
+	}
+
+	// % dari ?q= literal, sama seperti LIKE yang di-escape di SQL
+	got, _, err = Apply(rows, Params{Search: "50%"}, testMemorySpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"alpha 50%"}; !reflect.DeepEqual(names(got), want) {
+		t.Errorf("search = %v, want %v", names(got), want)
+	}
+
+	got, page, err = Apply(rows, Params{Offset: 10}, testMemorySpec)
+	if err != nil || len(got) != 0 || page.Total != 4 {
+		t.Errorf("offset past the end = %v %+v %v", got, page, err)
+	}
+	if got, _, _ := Apply(rows, Params{Limit: NoLimit}, testMemorySpec); len(got) != 4 {
+		t.Errorf("NoLimit returned %d rows", len(got))
+	}
+}
+
+// Jalur SQL dan memori harus menolak hal yang sama dengan pesan yang sama,
+// dan sepakat soal limit efektif.
+func TestSQLAndMemoryAgree(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Params
+		err  string
+	}{
+		{"unknown sort", Params{Sort: []SortField{{Field: "email"}}}, `cannot sort by "email", use one of due_date, name`},
+		{"unknown filter", Params{Filters: map[string][]string{FilterRole: {"web"}}}, `filter "role" is not supported here`},
+		{"unknown date", Params{Dates: map[string]DateRange{"created": {}}}, `date filter "created" is not supported here`},
+		{"limit over max", Params{Limit: 1000}, ""},
+		{"default limit", Params{}, ""},
+		{"explicit limit", Params{Limit: 7}, ""},
+	}
+	for _, tt := range tests {
+		c, sqlErr := testSpec.Build(tt.p)
+		_, page, memErr := Apply([]row{}, tt.p, testMemorySpec)
+		if tt.err != "" {
+			for path, err := range map[string]error{"sql": sqlErr, "memory": memErr} {
+				if !errors.Is(err, ErrInvalidQuery) || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("%s (%s): err = %v, want %q", tt.name, path, err, tt.err)
+				}
+			}
+			continue
+		}
+		if sqlErr != nil || memErr != nil {
+			t.Errorf("%s: errors %v / %v", tt.name, sqlErr, memErr)
+			continue
+		}
+		if c.Limit != page.Limit {
+			t.Errorf("%s: sql limit %d, memory limit %d", tt.name, c.Limit, page.Limit)
+		}
+	}
+
+	noSearch := testSpec
+	noSearch.Search = nil
+	memNoSearch := testMemorySpec
+	memNoSearch.Search = nil
+	_, sqlErr := noSearch.Build(Params{Search: "x"})
+	_, _, memErr := Apply([]row{}, Params{Search: "x"}, memNoSearch)
+	if !errors.Is(sqlErr, ErrInvalidQuery) || !errors.Is(memErr, ErrInvalidQuery) {
+		t.Errorf("search without Search: %v / %v", sqlErr, memErr)
+	}
+}
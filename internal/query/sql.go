@@ -0,0 +1,135 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Spec whitelists what a SQL-backed list accepts. Column expressions are
+// written by the repository and never come from the request.
+type Spec struct {
+	// Sort maps an API field name to its SQL expression.
+	Sort        map[string]string
+	DefaultSort []SortField
+	// Tiebreak is a unique expression appended to ORDER BY so pages are stable.
+	Tiebreak string
+	// Filters maps a filter name to a condition with one %s for the text[]
+	// parameter. Values arrive lowercased, so compare with LOWER(...).
+	Filters map[string]string
+	// Dates maps a date field name to its SQL expression.
+	Dates map[string]string
+	// Search lists the expressions matched with ILIKE for ?q=.
+	Search []string
+
+	DefaultLimit int
+	MaxLimit     int
+}
+
+// Clause is a rendered query fragment. Where is empty or starts with
+// " WHERE "; Tail holds ORDER BY, LIMIT and OFFSET.
+type Clause struct {
+	Where string
+	Tail  string
+	Args  []interface{}
+	Limit int
+}
+
+// Build renders p against the spec. Placeholders start at $1.
+func (s Spec) Build(p Params) (Clause, error) {
+	var conds []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	names := make([]string, 0, len(p.Filters))
+	for k := range p.Filters {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cond, ok := s.Filters[name]
+		if !ok {
+			return Clause{}, fmt.Errorf("%w: filter %q is not supported here", ErrInvalidQuery, name)
+		}
+		conds = append(conds, fmt.Sprintf(cond, arg(pq.Array(p.Filters[name]))))
+	}
+
+	fields := make([]string, 0, len(p.Dates))
+	for k := range p.Dates {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		expr, ok := s.Dates[field]
+		if !ok {
+			return Clause{}, fmt.Errorf("%w: date filter %q is not supported here", ErrInvalidQuery, field)
+		}
+		r := p.Dates[field]
+		if r.From != nil {
+			conds = append(conds, expr+" >= "+arg(*r.From))
+		}
+		if r.To != nil {
+			conds = append(conds, expr+" <= "+arg(*r.To))
+		}
+	}
+
+	if p.Search != "" {
+		if len(s.Search) == 0 {
+			return Clause{}, fmt.Errorf("%w: search is not supported here", ErrInvalidQuery)
+		}
+		ph := arg("%" + escapeLike(p.Search) + "%")
+		ors := make([]string, len(s.Search))
+		for i, expr := range s.Search {
+			ors[i] = expr + " ILIKE " + ph
+		}
+		conds = append(conds, "("+strings.Join(ors, " OR ")+")")
+	}
+
+	c := Clause{Args: args, Limit: p.limit(s.DefaultLimit, s.MaxLimit)}
+	if len(conds) > 0 {
+		c.Where = " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	sortFields := p.Sort
+	if len(sortFields) == 0 {
+		sortFields = s.DefaultSort
+	}
+	var order []string
+	for _, f := range sortFields {
+		expr, ok := s.Sort[f.Field]
+		if !ok {
+			return Clause{}, fmt.Errorf("%w: cannot sort by %q, use one of %s", ErrInvalidQuery, f.Field, strings.Join(sortedKeys(s.Sort), ", "))
+		}
+		dir := " ASC NULLS LAST"
+		if f.Desc {
+			dir = " DESC NULLS LAST"
+		}
+		order = append(order, expr+dir)
+	}
+	if s.Tiebreak != "" {
+		order = append(order, s.Tiebreak+" ASC")
+	}
+	if len(order) > 0 {
+		c.Tail = " ORDER BY " + strings.Join(order, ", ")
+	}
+	c.Tail += fmt.Sprintf(" LIMIT %d OFFSET %d", c.Limit, p.Offset)
+	return c, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
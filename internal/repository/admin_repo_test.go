@@ -0,0 +1,47 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/repotest"
+)
+
+// TestSaveAdminUpserts butuh TEST_DATABASE_URL (lihat repotest).
+func TestSaveAdminUpserts(t *testing.T) {
+	repo := repotest.New(t, "admin")
+	ctx := context.Background()
+	if _, err := repo.DB.ExecContext(ctx, `INSERT INTO users (clickup_id, name) VALUES (7, 'Ana')`); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	ana := int64(7)
+	a := &model.Admin{Username: "ana", PasswordHash: "h1", Role: model.RoleMember, UserID: &ana}
+	if err := repo.SaveAdmin(ctx, a); err != nil {
+		t.Fatal(err)
+	}
+	// simpan ulang dengan username sama memperbarui akun, id tetap
+	again := &model.Admin{Username: "ana", PasswordHash: "h2", Role: model.RoleManager}
+	if err := repo.SaveAdmin(ctx, again); err != nil {
+		t.Fatal(err)
+	}
+	if again.ID != a.ID {
+		t.Errorf("id changed from %s to %s", a.ID, again.ID)
+	}
+	got, err := repo.GetAdminByUsername(ctx, "ana")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PasswordHash != "h2" || got.Role != model.RoleManager || got.UserID != nil {
+		t.Errorf("saved account = %+v, want the second save", got)
+	}
+
+	unknown := int64(99)
+	err = repo.SaveAdmin(ctx, &model.Admin{Username: "budi", PasswordHash: "h", Role: model.RoleMember, UserID: &unknown})
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("linking an unknown member = %v, want sql.ErrNoRows", err)
+	}
+}
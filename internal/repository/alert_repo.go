@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+func (r *PostgresRepo) GetAlertRules(ctx context.Context) ([]model.AlertRule, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT code, name, COALESCE(description, ''), threshold, active, updated_at
+		FROM alert_rules
+		ORDER BY code
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying alert rules failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.AlertRule{}
+	for rows.Next() {
+		var ar model.AlertRule
+		if err := rows.Scan(&ar.Code, &ar.Name, &ar.Description, &ar.Threshold, &ar.Active, &ar.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, ar)
+	}
+	return out, rows.Err()
+}
+
+// UpdateAlertRule returns sql.ErrNoRows when the rule does not exist.
+func (r *PostgresRepo) UpdateAlertRule(ctx context.Context, ar *model.AlertRule) error {
+	return r.DB.QueryRowContext(ctx, `
+		UPDATE alert_rules SET threshold = $2, active = $3, updated_at = now()
+		WHERE code = $1
+		RETURNING name, COALESCE(description, ''), updated_at
+	`, ar.Code, ar.Threshold, ar.Active).Scan(&ar.Name, &ar.Description, &ar.UpdatedAt)
+}
+
+const alertSelect = `
+	SELECT id, rule_code, subject_type, subject_id, COALESCE(subject_name, ''), user_clickup_id,
+		message, value, status, created_at, last_seen_at, acknowledged_at, COALESCE(acknowledged_by, ''), resolved_at
+	FROM alerts
+`
+
+func scanAlert(row interface{ Scan(...interface{}) error }) (model.Alert, error) {
+	var a model.Alert
+	var userID sql.NullInt64
+	var value sql.NullFloat64
+	var ackAt, resolvedAt sql.NullTime
+	if err := row.Scan(&a.ID, &a.RuleCode, &a.SubjectType, &a.SubjectID, &a.SubjectName, &userID,
+		&a.Message, &value, &a.Status, &a.CreatedAt, &a.LastSeenAt, &ackAt, &a.AcknowledgedBy, &resolvedAt); err != nil {
+		return a, err
+	}
+	if userID.Valid {
+		a.UserID = &userID.Int64
+	}
+	if value.Valid {
+		a.Value = &value.Float64
+	}
+	if ackAt.Valid {
+		a.AcknowledgedAt = &ackAt.Time
+	}
+	if resolvedAt.Valid {
+		a.ResolvedAt = &resolvedAt.Time
+	}
+	return a, nil
+}
+
+func (r *PostgresRepo) GetAlerts(ctx context.Context, f model.AlertFilter) ([]model.Alert, error) {
+	var where []string
+	var args []interface{}
+	if f.Status != "" {
+		args = append(args, f.Status)
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if f.RuleCode != "" {
+		args = append(args, f.RuleCode)
+		where = append(where, fmt.Sprintf("rule_code = $%d", len(args)))
+	}
+	if f.UserID != nil {
+		args = append(args, *f.UserID)
+		where = append(where, fmt.Sprintf("user_clickup_id = $%d", len(args)))
+	}
+	query := alertSelect
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY last_seen_at DESC, id DESC"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying alerts failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.Alert{}
+	for rows.Next() {
+		a, err := scanAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// GetAlert returns nil, nil when the alert does not exist.
+func (r *PostgresRepo) GetAlert(ctx context.Context, id int64) (*model.Alert, error) {
+	a, err := scanAlert(r.DB.QueryRowContext(ctx, alertSelect+" WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// SyncAlerts menyimpan hasil evaluasi: pelanggaran baru dibuka, yang masih ada
+// diperbarui (status acknowledged dipertahankan) dan alert aktif yang tidak
+// muncul lagi otomatis resolved.
+func (r *PostgresRepo) SyncAlerts(ctx context.Context, candidates []model.AlertCandidate) (model.AlertEvaluation, []model.Alert, error) {
+	var res model.AlertEvaluation
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return res, nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `SELECT id, rule_code, subject_type, subject_id FROM alerts WHERE status <> 'resolved'`)
+	if err != nil {
+		return res, nil, fmt.Errorf("querying active alerts failed: %w", err)
+	}
+	active := map[string]int64{}
+	for rows.Next() {
+		var id int64
+		var rule, subjectType, subjectID string
+		if err := rows.Scan(&id, &rule, &subjectType, &subjectID); err != nil {
+			rows.Close()
+			return res, nil, err
+		}
+		active[rule+"|"+subjectType+"|"+subjectID] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return res, nil, err
+	}
+
+	opened := []model.Alert{}
+	for _, c := range candidates {
+		key := c.RuleCode + "|" + c.SubjectType + "|" + c.SubjectID
+		if id, ok := active[key]; ok {
+			delete(active, key)
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE alerts SET subject_name = $2, user_clickup_id = $3, message = $4, value = $5, last_seen_at = now()
+				WHERE id = $1
+			`, id, c.SubjectName, c.UserID, c.Message, c.Value); err != nil {
+				return res, nil, fmt.Errorf("failed to update alert %d: %w", id, err)
+			}
+			res.Updated++
+			continue
+		}
+		a := model.Alert{AlertCandidate: c, Status: model.AlertStatusOpen}
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO alerts (rule_code, subject_type, subject_id, subject_name, user_clickup_id, message, value)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id, created_at, last_seen_at
+		`, c.RuleCode, c.SubjectType, c.SubjectID, c.SubjectName, c.UserID, c.Message, c.Value).Scan(&a.ID, &a.CreatedAt, &a.LastSeenAt); err != nil {
+			return res, nil, fmt.Errorf("failed to open alert %s: %w", key, err)
+		}
+		opened = append(opened, a)
+		res.Opened++
+	}
+
+	for _, id := range active {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE alerts SET status = 'resolved', resolved_at = now() WHERE id = $1
+		`, id); err != nil {
+			return res, nil, fmt.Errorf("failed to resolve alert %d: %w", id, err)
+		}
+		res.Resolved++
+	}
+	return res, opened, tx.Commit()
+}
+
+// SetAlertStatus moves an alert from one of the given statuses to a new one.
+// It returns sql.ErrNoRows when no alert with that id is in a `from` status.
+func (r *PostgresRepo) SetAlertStatus(ctx context.Context, id int64, from []string, to, actor string) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE alerts SET
+			status = $2::text,
+			acknowledged_at = CASE WHEN $2::text = 'acknowledged' THEN now() ELSE acknowledged_at END,
+			acknowledged_by = CASE WHEN $2::text = 'acknowledged' THEN $3 ELSE acknowledged_by END,
+			resolved_at = CASE WHEN $2::text = 'resolved' THEN now() ELSE resolved_at END
+		WHERE id = $1 AND status = ANY($4)
+	`, id, to, actor, pq.Array(from))
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,417 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+const appraisalPeriodSelect = `
+	SELECT id, name, period_start, period_end, status, computed_at, locked_at, locked_by, created_at, updated_at
+	FROM appraisal_periods
+`
+
+func scanAppraisalPeriod(row interface{ Scan(...interface{}) error }) (model.AppraisalPeriod, error) {
+	var p model.AppraisalPeriod
+	var computedAt, lockedAt sql.NullTime
+	var lockedBy sql.NullString
+	err := row.Scan(&p.ID, &p.Name, &p.PeriodStart, &p.PeriodEnd, &p.Status, &computedAt, &lockedAt, &lockedBy, &p.CreatedAt, &p.UpdatedAt)
+	if computedAt.Valid {
+		p.ComputedAt = &computedAt.Time
+	}
+	if lockedAt.Valid {
+		p.LockedAt = &lockedAt.Time
+	}
+	if lockedBy.Valid {
+		p.LockedBy = &lockedBy.String
+	}
+	return p, err
+}
+
+// isForeignKeyViolation reports whether err is a Postgres foreign_key_violation.
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
+func insertAppraisalAudit(ctx context.Context, tx *sql.Tx, periodID int64, action, actor, reason string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO appraisal_audit_log (period_id, action, actor, reason) VALUES ($1, $2, $3, NULLIF($4, ''))
+	`, periodID, action, actor, reason)
+	if err != nil {
+		return fmt.Errorf("failed to write audit entry %s for period %d: %w", action, periodID, err)
+	}
+	return nil
+}
+
+func (r *PostgresRepo) CreateAppraisalPeriod(ctx context.Context, p *model.AppraisalPeriod, actor string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO appraisal_periods (name, period_start, period_end, status)
+		VALUES ($1, $2, $3, 'open')
+		RETURNING id, status, created_at, updated_at
+	`, p.Name, p.PeriodStart, p.PeriodEnd).Scan(&p.ID, &p.Status, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if err := insertAppraisalAudit(ctx, tx, p.ID, "create", actor, ""); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRepo) GetAppraisalPeriods(ctx context.Context) ([]model.AppraisalPeriod, error) {
+	rows, err := r.DB.QueryContext(ctx, appraisalPeriodSelect+" ORDER BY period_start DESC")
+	if err != nil {
+		return nil, fmt.Errorf("querying appraisal periods failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.AppraisalPeriod{}
+	for rows.Next() {
+		p, err := scanAppraisalPeriod(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// GetAppraisalPeriod returns nil, nil when the period does not exist.
+func (r *PostgresRepo) GetAppraisalPeriod(ctx context.Context, id int64) (*model.AppraisalPeriod, error) {
+	p, err := scanAppraisalPeriod(r.DB.QueryRowContext(ctx, appraisalPeriodSelect+" WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// MarkAppraisalComputed returns sql.ErrNoRows when the period is locked.
+func (r *PostgresRepo) MarkAppraisalComputed(ctx context.Context, id int64, actor string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var periodID int64
+	err = tx.QueryRowContext(ctx, `
+		UPDATE appraisal_periods SET status = 'computed', computed_at = now(), updated_at = now()
+		WHERE id = $1 AND status <> 'locked'
+		RETURNING id
+	`, id).Scan(&periodID)
+	if err != nil {
+		return err
+	}
+	if err := insertAppraisalAudit(ctx, tx, id, "compute", actor, ""); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// LockAppraisalPeriod stores the given scorecards (see SaveScorecard), freezes
+// them and the task metrics into the snapshot tables and marks the period
+// locked, all in one transaction. It returns sql.ErrNoRows when the period is
+// already locked; nothing is written then.
+func (r *PostgresRepo) LockAppraisalPeriod(ctx context.Context, id int64, actor string, scorecards []model.KPIScorecard, tasks []model.KPITaskMetric) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var periodID int64
+	err = tx.QueryRowContext(ctx, `
+		UPDATE appraisal_periods SET status = 'locked', locked_at = now(), locked_by = $2, updated_at = now()
+		WHERE id = $1 AND status <> 'locked'
+		RETURNING id
+	`, id, actor).Scan(&periodID)
+	if err != nil {
+		return err
+	}
+
+	for i := range scorecards {
+		sc := &scorecards[i]
+		if err := saveScorecard(ctx, tx, sc); err != nil {
+			return err
+		}
+		items, err := json.Marshal(sc.Items)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO appraisal_scorecard_snapshots (period_id, user_clickup_id, name, email, role, total_score, items, computed_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		`, id, sc.UserID, sc.Name, sc.Email, sc.Role, sc.TotalScore, items, sc.ComputedAt); err != nil {
+			return fmt.Errorf("failed to snapshot scorecard of user %d: %w", sc.UserID, err)
+		}
+	}
+
+	for _, t := range tasks {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO appraisal_task_snapshots (
+				period_id, user_clickup_id, task_id, task_name, project_name, status_name, status_type,
+				start_date, due_date, date_done, date_closed, time_estimate_hours, time_spent_hours
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			ON CONFLICT DO NOTHING
+		`, id, t.UserID, t.TaskID, t.TaskName, t.ProjectName, t.StatusName, t.StatusType,
+			t.StartDate, t.DueDate, t.DateDone, t.DateClosed, t.EstimateHours, t.SpentHours); err != nil {
+			return fmt.Errorf("failed to snapshot task %s: %w", t.TaskID, err)
+		}
+	}
+
+	if err := insertAppraisalAudit(ctx, tx, id, "lock", actor, ""); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ReopenAppraisalPeriod drops the snapshots of a locked period and opens it
+// again. It returns sql.ErrNoRows when the period is not locked.
+func (r *PostgresRepo) ReopenAppraisalPeriod(ctx context.Context, id int64, actor, reason string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var periodID int64
+	err = tx.QueryRowContext(ctx, `
+		UPDATE appraisal_periods SET status = 'open', locked_at = NULL, locked_by = NULL, updated_at = now()
+		WHERE id = $1 AND status = 'locked'
+		RETURNING id
+	`, id).Scan(&periodID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM appraisal_scorecard_snapshots WHERE period_id = $1`, id); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM appraisal_task_snapshots WHERE period_id = $1`, id); err != nil {
+		return err
+	}
+
+	if err := insertAppraisalAudit(ctx, tx, id, "reopen", actor, reason); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetAppraisalSnapshots returns the frozen scorecards of a locked period.
+func (r *PostgresRepo) GetAppraisalSnapshots(ctx context.Context, periodID int64, userID *int64) ([]model.KPIScorecard, error) {
+	query := `
+		SELECT s.user_clickup_id, COALESCE(s.name, ''), COALESCE(s.email, ''), COALESCE(s.role, ''),
+			s.total_score, s.items, COALESCE(s.computed_at, s.snapshot_at), p.name, p.period_start, p.period_end
+		FROM appraisal_scorecard_snapshots s
+		JOIN appraisal_periods p ON s.period_id = p.id
+		WHERE s.period_id = $1
+	`
+	args := []interface{}{periodID}
+	if userID != nil {
+		query += " AND s.user_clickup_id = $2"
+		args = append(args, *userID)
+	}
+	query += " ORDER BY s.total_score DESC, s.name ASC"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying appraisal snapshots failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.KPIScorecard{}
+	for rows.Next() {
+		var sc model.KPIScorecard
+		var items []byte
+		if err := rows.Scan(&sc.UserID, &sc.Name, &sc.Email, &sc.Role, &sc.TotalScore, &items, &sc.ComputedAt,
+			&sc.PeriodLabel, &sc.PeriodStart, &sc.PeriodEnd); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(items, &sc.Items); err != nil {
+			return nil, fmt.Errorf("invalid snapshot items for user %d: %w", sc.UserID, err)
+		}
+		sc.PeriodType = "appraisal"
+		out = append(out, sc)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) GetAppraisalTaskSnapshots(ctx context.Context, periodID int64, userID *int64) ([]model.AppraisalTaskSnapshot, error) {
+	query := `
+		SELECT task_id, COALESCE(task_name, ''), project_name, COALESCE(status_name, ''), COALESCE(status_type, ''),
+			start_date, due_date, date_done, date_closed,
+			COALESCE(time_estimate_hours, 0), COALESCE(time_spent_hours, 0), user_clickup_id, snapshot_at
+		FROM appraisal_task_snapshots
+		WHERE period_id = $1
+	`
+	args := []interface{}{periodID}
+	if userID != nil {
+		query += " AND user_clickup_id = $2"
+		args = append(args, *userID)
+	}
+	query += " ORDER BY user_clickup_id, due_date"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying appraisal task snapshots failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.AppraisalTaskSnapshot{}
+	for rows.Next() {
+		var s model.AppraisalTaskSnapshot
+		var projectName sql.NullString
+		var startDate, dueDate, dateDone, dateClosed sql.NullTime
+		if err := rows.Scan(&s.TaskID, &s.TaskName, &projectName, &s.StatusName, &s.StatusType,
+			&startDate, &dueDate, &dateDone, &dateClosed,
+			&s.EstimateHours, &s.SpentHours, &s.UserID, &s.SnapshotAt); err != nil {
+			return nil, err
+		}
+		if projectName.Valid {
+			s.ProjectName = &projectName.String
+		}
+		if startDate.Valid {
+			s.StartDate = &startDate.Time
+		}
+		if dueDate.Valid {
+			s.DueDate = &dueDate.Time
+		}
+		if dateDone.Valid {
+			s.DateDone = &dateDone.Time
+		}
+		if dateClosed.Valid {
+			s.DateClosed = &dateClosed.Time
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) GetAppraisalAuditLog(ctx context.Context, periodID int64) ([]model.AppraisalAuditEntry, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, period_id, action, actor, COALESCE(reason, ''), created_at
+		FROM appraisal_audit_log
+		WHERE period_id = $1
+		ORDER BY created_at ASC, id ASC
+	`, periodID)
+	if err != nil {
+		return nil, fmt.Errorf("querying appraisal audit log failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.AppraisalAuditEntry{}
+	for rows.Next() {
+		var e model.AppraisalAuditEntry
+		if err := rows.Scan(&e.ID, &e.PeriodID, &e.Action, &e.Actor, &e.Reason, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+const appraisalReviewSelect = `
+	SELECT period_id, user_clickup_id, reviewer, rating, COALESCE(strengths, ''), COALESCE(improvements, ''),
+		final_grade, acknowledged_at, COALESCE(rebuttal, ''), created_at, updated_at
+	FROM appraisal_reviews
+`
+
+func scanAppraisalReview(row interface{ Scan(...interface{}) error }) (model.AppraisalReview, error) {
+	var rv model.AppraisalReview
+	var ackAt sql.NullTime
+	err := row.Scan(&rv.PeriodID, &rv.UserID, &rv.Reviewer, &rv.Rating, &rv.Strengths, &rv.Improvements,
+		&rv.FinalGrade, &ackAt, &rv.Rebuttal, &rv.CreatedAt, &rv.UpdatedAt)
+	if ackAt.Valid {
+		rv.AcknowledgedAt = &ackAt.Time
+	}
+	return rv, err
+}
+
+// UpsertAppraisalReview menyimpan review manajer. Perubahan review membatalkan
+// acknowledgement dan rebuttal sebelumnya supaya anggota meninjau ulang.
+// It returns sql.ErrNoRows when the member does not exist.
+func (r *PostgresRepo) UpsertAppraisalReview(ctx context.Context, rv *model.AppraisalReview) error {
+	row := r.DB.QueryRowContext(ctx, `
+		INSERT INTO appraisal_reviews (period_id, user_clickup_id, reviewer, rating, strengths, improvements, final_grade)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7)
+		ON CONFLICT (period_id, user_clickup_id) DO UPDATE SET
+			reviewer = EXCLUDED.reviewer,
+			rating = EXCLUDED.rating,
+			strengths = EXCLUDED.strengths,
+			improvements = EXCLUDED.improvements,
+			final_grade = EXCLUDED.final_grade,
+			acknowledged_at = NULL,
+			rebuttal = NULL,
+			updated_at = now()
+		RETURNING period_id, user_clickup_id, reviewer, rating, COALESCE(strengths, ''), COALESCE(improvements, ''),
+			final_grade, acknowledged_at, COALESCE(rebuttal, ''), created_at, updated_at
+	`, rv.PeriodID, rv.UserID, rv.Reviewer, rv.Rating, rv.Strengths, rv.Improvements, rv.FinalGrade)
+
+	saved, err := scanAppraisalReview(row)
+	if isForeignKeyViolation(err) {
+		return sql.ErrNoRows
+	}
+	if err != nil {
+		return err
+	}
+	*rv = saved
+	return nil
+}
+
+// AcknowledgeAppraisalReview returns sql.ErrNoRows when there is no review yet.
+func (r *PostgresRepo) AcknowledgeAppraisalReview(ctx context.Context, periodID, userID int64, rebuttal string) (*model.AppraisalReview, error) {
+	row := r.DB.QueryRowContext(ctx, `
+		UPDATE appraisal_reviews SET acknowledged_at = now(), rebuttal = NULLIF($3, ''), updated_at = now()
+		WHERE period_id = $1 AND user_clickup_id = $2
+		RETURNING period_id, user_clickup_id, reviewer, rating, COALESCE(strengths, ''), COALESCE(improvements, ''),
+			final_grade, acknowledged_at, COALESCE(rebuttal, ''), created_at, updated_at
+	`, periodID, userID, rebuttal)
+
+	rv, err := scanAppraisalReview(row)
+	if err != nil {
+		return nil, err
+	}
+	return &rv, nil
+}
+
+func (r *PostgresRepo) GetAppraisalReviews(ctx context.Context, periodID int64, userID *int64) ([]model.AppraisalReview, error) {
+	query := appraisalReviewSelect + " WHERE period_id = $1"
+	args := []interface{}{periodID}
+	if userID != nil {
+		query += " AND user_clickup_id = $2"
+		args = append(args, *userID)
+	}
+	query += " ORDER BY user_clickup_id"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying appraisal reviews failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.AppraisalReview{}
+	for rows.Next() {
+		rv, err := scanAppraisalReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rv)
+	}
+	return out, rows.Err()
+}
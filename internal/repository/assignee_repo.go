@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// taskOverlap is the condition for a task that belongs to the period between
+// the start and end placeholders: scheduled across it, or done or closed in it.
+// Every per-period task query uses it, so summaries, task lists, workload and
+// KPI agree on which tasks are counted.
+func taskOverlap(start, end string) string {
+	return fmt.Sprintf(`(
+		(t.start_date IS NOT NULL AND t.due_date IS NOT NULL AND t.start_date <= %[2]s AND t.due_date >= %[1]s) OR
+		(t.date_done IS NOT NULL AND t.date_done BETWEEN %[1]s AND %[2]s) OR
+		(t.date_closed IS NOT NULL AND t.date_closed BETWEEN %[1]s AND %[2]s)
+	)`, start, end)
+}
+
+// taskItemSelect is the SELECT of model.TaskItem rows, one per task and
+// assignee; the first column is the assignee. Read it with scanTaskItem.
+const taskItemSelect = `
+	SELECT
+		ta.user_clickup_id,
+		t.id,
+		t.name,
+		t.description,
+		COALESCE(ts.id, ''),
+		COALESCE(ts.name, ''),
+		COALESCE(ts.type, ''),
+		t.date_done,
+		t.date_closed,
+		t.start_date,
+		t.due_date,
+		t.time_estimate_hours,
+		t.time_spent_hours,
+		t.remaining_time_hours,
+		t.time_efficiency_percentage,
+		COALESCE(f.name, l.name) AS project_name
+	FROM tasks t
+	INNER JOIN task_assignees ta ON t.id = ta.task_id
+	LEFT JOIN task_statuses ts ON t.status_id = ts.id
+	LEFT JOIN lists l ON t.list_id = l.id
+	LEFT JOIN folders f ON l.folder_id = f.id
+`
+
+func scanTaskItem(rows *sql.Rows) (int64, model.TaskItem, error) {
+	var userID int64
+	var t model.TaskItem
+	var description, projectName sql.NullString
+	var dateDone, dateClosed, startDate, dueDate sql.NullTime
+	var timeEstimate, timeSpent, remainingHours, efficiency sql.NullFloat64
+	if err := rows.Scan(
+		&userID, &t.ID, &t.Name, &description,
+		&t.StatusID, &t.StatusName, &t.StatusType,
+		&dateDone, &dateClosed, &startDate, &dueDate,
+		&timeEstimate, &timeSpent, &remainingHours, &efficiency,
+		&projectName,
+	); err != nil {
+		return 0, t, err
+	}
+	t.Description = description.String
+	t.DateDone = nullTimePtr(dateDone)
+	t.DateClosed = nullTimePtr(dateClosed)
+	t.StartDate = nullTimePtr(startDate)
+	t.DueDate = nullTimePtr(dueDate)
+	t.TimeEstimateHours = timeEstimate.Float64
+	t.TimeSpentHours = timeSpent.Float64
+	t.RemainingTimeHours = nullFloatPtr(remainingHours)
+	t.TimeEfficiencyPercentage = nullFloatPtr(efficiency)
+	if projectName.Valid {
+		t.ProjectName = &projectName.String
+	}
+	return userID, t, nil
+}
+
+// queryTaskItems runs a taskItemSelect query and groups the rows by assignee,
+// keeping the query order within each group.
+func (r *PostgresRepo) queryTaskItems(ctx context.Context, query string, args ...interface{}) (map[int64][]model.TaskItem, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[int64][]model.TaskItem{}
+	for rows.Next() {
+		userID, t, err := scanTaskItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		out[userID] = append(out[userID], t)
+	}
+	return out, rows.Err()
+}
+
+// GetTasksByAssignee returns every active member with their summary and
+// tasks for the period. It runs two queries whatever the team size: the
+// per-member summary and the task rows of all active members.
+func (r *PostgresRepo) GetTasksByAssignee(ctx context.Context, start, end time.Time) ([]model.AssigneeWithTasks, error) {
+	summaries, err := r.GetTasksSummaryByDateRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return []model.AssigneeWithTasks{}, nil
+	}
+
+	tasks, err := r.queryTaskItems(ctx, taskItemSelect+`
+		INNER JOIN users u ON u.clickup_id = ta.user_clickup_id
+		WHERE u.status_id = (SELECT id FROM user_statuses WHERE name = 'aktif')
+		  AND `+taskOverlap("$1", "$2")+`
+		ORDER BY ta.user_clickup_id, t.start_date DESC, t.name ASC
+	`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("querying tasks by assignee failed: %w", err)
+	}
+
+	out := make([]model.AssigneeWithTasks, 0, len(summaries))
+	for _, s := range summaries {
+		out = append(out, model.AssigneeWithTasks{
+			ClickUpID:          s.UserID,
+			Username:           s.Name,
+			Email:              s.Email,
+			Role:               s.Role,
+			Name:               s.Name,
+			TotalSpentHours:    s.TotalSpentHours,
+			ExpectedHours:      s.TotalWorkHours,
+			TotalTasks:         s.TotalTasks,
+			TotalUpcomingHours: s.TotalUpcomingHours,
+			Tasks:              tasks[s.UserID],
+		})
+	}
+	return out, nil
+}
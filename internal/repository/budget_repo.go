@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+const budgetSelect = `
+	SELECT b.id, b.level, b.node_id, COALESCE(f.name, l.name, ''), b.budget_hours,
+		b.start_date, b.end_date, b.thresholds, b.created_at, b.updated_at
+	FROM project_budgets b
+	LEFT JOIN folders f ON b.level = 'folder' AND f.id = b.node_id
+	LEFT JOIN lists l ON b.level = 'list' AND l.id = b.node_id
+`
+
+func scanBudget(row interface{ Scan(...interface{}) error }) (model.ProjectBudget, error) {
+	var b model.ProjectBudget
+	var start, end sql.NullTime
+	var thresholds []byte
+	if err := row.Scan(&b.ID, &b.Level, &b.NodeID, &b.NodeName, &b.BudgetHours,
+		&start, &end, &thresholds, &b.CreatedAt, &b.UpdatedAt); err != nil {
+		return b, err
+	}
+	if start.Valid {
+		b.StartDate = &start.Time
+	}
+	if end.Valid {
+		b.EndDate = &end.Time
+	}
+	if err := json.Unmarshal(thresholds, &b.Thresholds); err != nil {
+		return b, fmt.Errorf("invalid thresholds for budget %d: %w", b.ID, err)
+	}
+	return b, nil
+}
+
+// UpsertBudget membuat budget baru atau mengganti budget yang sudah ada
+// untuk folder/list yang sama.
+func (r *PostgresRepo) UpsertBudget(ctx context.Context, b *model.ProjectBudget) error {
+	thresholds, err := json.Marshal(b.Thresholds)
+	if err != nil {
+		return err
+	}
+	return r.DB.QueryRowContext(ctx, `
+		INSERT INTO project_budgets (level, node_id, budget_hours, start_date, end_date, thresholds)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (level, node_id) DO UPDATE SET
+			budget_hours = EXCLUDED.budget_hours,
+			start_date = EXCLUDED.start_date,
+			end_date = EXCLUDED.end_date,
+			thresholds = EXCLUDED.thresholds,
+			updated_at = now()
+		RETURNING id, created_at, updated_at
+	`, b.Level, b.NodeID, b.BudgetHours, b.StartDate, b.EndDate, thresholds).Scan(&b.ID, &b.CreatedAt, &b.UpdatedAt)
+}
+
+// UpdateBudget returns sql.ErrNoRows when the budget does not exist.
+func (r *PostgresRepo) UpdateBudget(ctx context.Context, b *model.ProjectBudget) error {
+	thresholds, err := json.Marshal(b.Thresholds)
+	if err != nil {
+		return err
+	}
+	return r.DB.QueryRowContext(ctx, `
+		UPDATE project_budgets SET
+			level = $2, node_id = $3, budget_hours = $4, start_date = $5, end_date = $6, thresholds = $7, updated_at = now()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`, b.ID, b.Level, b.NodeID, b.BudgetHours, b.StartDate, b.EndDate, thresholds).Scan(&b.CreatedAt, &b.UpdatedAt)
+}
+
+func (r *PostgresRepo) DeleteBudget(ctx context.Context, id int64) (bool, error) {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM project_budgets WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func (r *PostgresRepo) GetBudgets(ctx context.Context) ([]model.ProjectBudget, error) {
+	rows, err := r.DB.QueryContext(ctx, budgetSelect+" ORDER BY b.level, COALESCE(f.name, l.name, b.node_id)")
+	if err != nil {
+		return nil, fmt.Errorf("querying budgets failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.ProjectBudget{}
+	for rows.Next() {
+		b, err := scanBudget(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+// GetBudget returns nil, nil when the budget does not exist.
+func (r *PostgresRepo) GetBudget(ctx context.Context, id int64) (*model.ProjectBudget, error) {
+	b, err := scanBudget(r.DB.QueryRowContext(ctx, budgetSelect+" WHERE b.id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *PostgresRepo) GetBudgetAlerts(ctx context.Context, budgetID int64) ([]model.BudgetAlert, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, budget_id, threshold, burn_pct, spent_hours, fired_at
+		FROM project_budget_alerts
+		WHERE budget_id = $1
+		ORDER BY threshold
+	`, budgetID)
+	if err != nil {
+		return nil, fmt.Errorf("querying budget alerts failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.BudgetAlert{}
+	for rows.Next() {
+		var a model.BudgetAlert
+		if err := rows.Scan(&a.ID, &a.BudgetID, &a.Threshold, &a.BurnPct, &a.SpentHours, &a.FiredAt); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// SyncBudgetAlerts mencatat alert untuk setiap threshold yang sudah terlewati
+// (sekali per threshold) dan menghapus alert yang tidak lagi terlewati, misalnya
+// setelah budget dinaikkan. Yang dikembalikan hanya alert yang baru terpicu.
+func (r *PostgresRepo) SyncBudgetAlerts(ctx context.Context, budgetID int64, burnPct, spentHours float64, crossed []float64) ([]model.BudgetAlert, error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM project_budget_alerts WHERE budget_id = $1 AND threshold > $2`, budgetID, burnPct); err != nil {
+		return nil, err
+	}
+
+	fired := []model.BudgetAlert{}
+	for _, threshold := range crossed {
+		a := model.BudgetAlert{BudgetID: budgetID, Threshold: threshold, BurnPct: burnPct, SpentHours: spentHours}
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO project_budget_alerts (budget_id, threshold, burn_pct, spent_hours)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (budget_id, threshold) DO NOTHING
+			RETURNING id, fired_at
+		`, budgetID, threshold, burnPct, spentHours).Scan(&a.ID, &a.FiredAt)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to record alert %.0f%% for budget %d: %w", threshold, budgetID, err)
+		}
+		fired = append(fired, a)
+	}
+	return fired, tx.Commit()
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// SetManualEstimate menyimpan estimasi manual; sync berikutnya tidak menimpanya
+// dengan default. Returns sql.ErrNoRows when the task does not exist.
+func (r *PostgresRepo) SetManualEstimate(ctx context.Context, taskID string, hours float64) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE tasks SET time_estimate_hours = $2, estimate_source = 'manual'
+		WHERE id = $1
+	`, taskID, hours)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// GetEstimateSamples returns closed (task, assignee) rows finished in the range.
+func (r *PostgresRepo) GetEstimateSamples(ctx context.Context, start, end time.Time) ([]model.EstimateSample, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT
+			t.id,
+			COALESCE(t.name, ''),
+			ta.user_clickup_id,
+			COALESCE(u.name, ''),
+			COALESCE(ro.name, ''),
+			COALESCE(f.name, l.name, ''),
+			COALESCE(t.time_estimate_hours, 0),
+			COALESCE(t.time_spent_hours, 0),
+			COALESCE(t.estimate_source, ''),
+			COALESCE(t.date_closed, t.date_done)
+		FROM tasks t
+		INNER JOIN task_assignees ta ON t.id = ta.task_id
+		INNER JOIN task_statuses ts ON t.status_id = ts.id
+		LEFT JOIN users u ON u.clickup_id = ta.user_clickup_id
+		LEFT JOIN roles ro ON u.role_id = ro.id
+		LEFT JOIN lists l ON t.list_id = l.id
+		LEFT JOIN folders f ON l.folder_id = f.id
+		WHERE ts.type IN ('done', 'closed')
+			AND COALESCE(t.date_closed, t.date_done) BETWEEN $1 AND $2
+		ORDER BY ta.user_clickup_id, t.id
+	`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("querying estimate samples failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.EstimateSample{}
+	for rows.Next() {
+		var s model.EstimateSample
+		if err := rows.Scan(
+			&s.TaskID, &s.TaskName, &s.UserID, &s.UserName, &s.Role, &s.ProjectName,
+			&s.EstimateHours, &s.SpentHours, &s.EstimateSource, &s.ClosedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scanning estimate sample failed: %w", err)
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
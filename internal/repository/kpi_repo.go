@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+func (r *PostgresRepo) GetKPIIndicators(ctx context.Context) ([]model.KPIIndicator, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT code, name, COALESCE(description, ''), COALESCE(unit, ''), default_weight, bands, active, updated_at
+		FROM kpi_indicators
+		ORDER BY code
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying kpi indicators failed: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.KPIIndicator
+	for rows.Next() {
+		var ind model.KPIIndicator
+		var bands []byte
+		if err := rows.Scan(&ind.Code, &ind.Name, &ind.Description, &ind.Unit, &ind.DefaultWeight, &bands, &ind.Active, &ind.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scanning kpi indicator failed: %w", err)
+		}
+		if err := json.Unmarshal(bands, &ind.Bands); err != nil {
+			return nil, fmt.Errorf("invalid bands for indicator %s: %w", ind.Code, err)
+		}
+		out = append(out, ind)
+	}
+	return out, rows.Err()
+}
+
+// UpdateKPIIndicator returns sql.ErrNoRows when the indicator does not exist.
+func (r *PostgresRepo) UpdateKPIIndicator(ctx context.Context, ind *model.KPIIndicator) error {
+	bands, err := json.Marshal(ind.Bands)
+	if err != nil {
+		return err
+	}
+	return r.DB.QueryRowContext(ctx, `
+		UPDATE kpi_indicators SET
+			name = $2,
+			description = $3,
+			unit = $4,
+			default_weight = $5,
+			bands = $6,
+			active = $7,
+			updated_at = now()
+		WHERE code = $1
+		RETURNING updated_at
+	`, ind.Code, ind.Name, ind.Description, ind.Unit, ind.DefaultWeight, bands, ind.Active).Scan(&ind.UpdatedAt)
+}
+
+func (r *PostgresRepo) GetKPIRoleWeights(ctx context.Context) ([]model.KPIRoleWeight, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT ro.name, w.indicator_code, w.weight
+		FROM kpi_role_weights w
+		JOIN roles ro ON w.role_id = ro.id
+		ORDER BY ro.name, w.indicator_code
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying kpi role weights failed: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.KPIRoleWeight
+	for rows.Next() {
+		var w model.KPIRoleWeight
+		if err := rows.Scan(&w.Role, &w.IndicatorCode, &w.Weight); err != nil {
+			return nil, err
+		}
+		out = append(out, w)
+	}
+	return out, rows.Err()
+}
+
+// SetKPIRoleWeights replaces all weights of a role. It returns sql.ErrNoRows
+// when the role does not exist.
+func (r *PostgresRepo) SetKPIRoleWeights(ctx context.Context, role string, weights map[string]float64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var roleID int
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM roles WHERE lower(name) = lower($1) LIMIT 1`, role).Scan(&roleID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM kpi_role_weights WHERE role_id = $1`, roleID); err != nil {
+		return err
+	}
+	for code, weight := range weights {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO kpi_role_weights (role_id, indicator_code, weight) VALUES ($1, $2, $3)
+		`, roleID, code, weight); err != nil {
+			return fmt.Errorf("failed to set weight %s for role %s: %w", code, role, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetMetricTasks returns the (task, assignee) rows relevant for a period:
+// tasks scheduled across it or finished inside it.
+func (r *PostgresRepo) GetMetricTasks(ctx context.Context, start, end time.Time) ([]model.KPITaskMetric, error) {
+	query := `
+		SELECT
+			t.id,
+			COALESCE(t.name, ''),
+			COALESCE(f.name, l.name),
+			COALESCE(ts.name, ''),
+			COALESCE(ts.type, ''),
+			t.start_date,
+			t.due_date,
+			t.date_done,
+			t.date_closed,
+			COALESCE(t.time_estimate_hours, 0),
+			COALESCE(t.time_spent_hours, 0),
+			COALESCE(t.estimate_source, ''),
+			ta.user_clickup_id
+		FROM tasks t
+		INNER JOIN task_assignees ta ON t.id = ta.task_id
+		LEFT JOIN task_statuses ts ON t.status_id = ts.id
+		LEFT JOIN lists l ON t.list_id = l.id
+		LEFT JOIN folders f ON l.folder_id = f.id
+		WHERE `+taskOverlap("$1", "$2")+`
+		ORDER BY ta.user_clickup_id, t.due_date
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("querying metric tasks failed: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.KPITaskMetric
+	for rows.Next() {
+		var m model.KPITaskMetric
+		var projectName sql.NullString
+		var startDate, dueDate, dateDone, dateClosed sql.NullTime
+		if err := rows.Scan(
+			&m.TaskID, &m.TaskName, &projectName, &m.StatusName, &m.StatusType,
+			&startDate, &dueDate, &dateDone, &dateClosed,
+			&m.EstimateHours, &m.SpentHours, &m.EstimateSource, &m.UserID,
+		); err != nil {
+			return nil, fmt.Errorf("scanning metric task failed: %w", err)
+		}
+		if projectName.Valid {
+			m.ProjectName = &projectName.String
+		}
+		if startDate.Valid {
+			m.StartDate = &startDate.Time
+		}
+		if dueDate.Valid {
+			m.DueDate = &dueDate.Time
+		}
+		if dateDone.Valid {
+			m.DateDone = &dateDone.Time
+		}
+		if dateClosed.Valid {
+			m.DateClosed = &dateClosed.Time
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// SaveScorecard stores a scorecard with its items and drill-down tasks,
+// replacing an earlier computation for the same member and period.
+func (r *PostgresRepo) SaveScorecard(ctx context.Context, sc *model.KPIScorecard) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := saveScorecard(ctx, tx, sc); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// saveScorecard is SaveScorecard inside the caller's transaction.
+func saveScorecard(ctx context.Context, tx *sql.Tx, sc *model.KPIScorecard) error {
+	err := tx.QueryRowContext(ctx, `
+		INSERT INTO kpi_scorecards (user_clickup_id, period_type, period_label, period_start, period_end, total_score, computed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, now())
+		ON CONFLICT (user_clickup_id, period_type, period_label) DO UPDATE SET
+			period_start = EXCLUDED.period_start,
+			period_end = EXCLUDED.period_end,
+			total_score = EXCLUDED.total_score,
+			computed_at = now()
+		RETURNING id, computed_at
+	`, sc.UserID, sc.PeriodType, sc.PeriodLabel, sc.PeriodStart, sc.PeriodEnd, sc.TotalScore).Scan(&sc.ID, &sc.ComputedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save scorecard for user %d: %w", sc.UserID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM kpi_scorecard_items WHERE scorecard_id = $1`, sc.ID); err != nil {
+		return err
+	}
+
+	for i := range sc.Items {
+		item := &sc.Items[i]
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO kpi_scorecard_items (scorecard_id, indicator_code, value, score, weight, band, task_count)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING id
+		`, sc.ID, item.IndicatorCode, item.Value, item.Score, item.Weight, item.Band, item.TaskCount).Scan(&item.ID)
+		if err != nil {
+			return fmt.Errorf("failed to save scorecard item %s: %w", item.IndicatorCode, err)
+		}
+
+		for _, t := range item.Tasks {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO kpi_scorecard_item_tasks (item_id, task_id, task_name, counted, value, note)
+				VALUES ($1, $2, $3, $4, $5, $6)
+				ON CONFLICT DO NOTHING
+			`, item.ID, t.TaskID, t.TaskName, t.Counted, t.Value, t.Note); err != nil {
+				return fmt.Errorf("failed to save drill-down task %s: %w", t.TaskID, err)
+			}
+		}
+	}
+	return nil
+}
+
+const scorecardSelect = `
+	SELECT sc.id, sc.user_clickup_id, COALESCE(u.name, ''), COALESCE(u.email, ''), COALESCE(ro.name, ''),
+		sc.period_type, sc.period_label, sc.period_start, sc.period_end, sc.total_score, sc.computed_at
+	FROM kpi_scorecards sc
+	LEFT JOIN users u ON sc.user_clickup_id = u.clickup_id
+	LEFT JOIN roles ro ON u.role_id = ro.id
+`
+
+func scanScorecard(row interface{ Scan(...interface{}) error }) (model.KPIScorecard, error) {
+	var sc model.KPIScorecard
+	err := row.Scan(
+		&sc.ID, &sc.UserID, &sc.Name, &sc.Email, &sc.Role,
+		&sc.PeriodType, &sc.PeriodLabel, &sc.PeriodStart, &sc.PeriodEnd, &sc.TotalScore, &sc.ComputedAt,
+	)
+	return sc, err
+}
+
+// GetScorecards lists scorecards of a period, optionally for one member.
+// Items are included.
+func (r *PostgresRepo) GetScorecards(ctx context.Context, periodType, periodLabel string, userID *int64) ([]model.KPIScorecard, error) {
+	query := scorecardSelect + " WHERE sc.period_type = $1 AND sc.period_label = $2"
+	args := []interface{}{periodType, periodLabel}
+	if userID != nil {
+		query += " AND sc.user_clickup_id = $3"
+		args = append(args, *userID)
+	}
+	query += " ORDER BY sc.total_score DESC, u.name ASC"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying scorecards failed: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.KPIScorecard
+	for rows.Next() {
+		sc, err := scanScorecard(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning scorecard failed: %w", err)
+		}
+		out = append(out, sc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range out {
+		items, err := r.getScorecardItems(ctx, out[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		out[i].Items = items
+	}
+	return out, nil
+}
+
+// GetScorecard returns nil, nil when the scorecard does not exist.
+func (r *PostgresRepo) GetScorecard(ctx context.Context, id int64) (*model.KPIScorecard, error) {
+	sc, err := scanScorecard(r.DB.QueryRowContext(ctx, scorecardSelect+" WHERE sc.id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sc.Items, err = r.getScorecardItems(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+func (r *PostgresRepo) getScorecardItems(ctx context.Context, scorecardID int64) ([]model.KPIScorecardItem, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT i.id, i.indicator_code, COALESCE(k.name, i.indicator_code), COALESCE(k.unit, ''),
+			i.value, i.score, i.weight, COALESCE(i.band, ''), i.task_count
+		FROM kpi_scorecard_items i
+		LEFT JOIN kpi_indicators k ON i.indicator_code = k.code
+		WHERE i.scorecard_id = $1
+		ORDER BY i.weight DESC, i.indicator_code
+	`, scorecardID)
+	if err != nil {
+		return nil, fmt.Errorf("querying scorecard items failed: %w", err)
+	}
+	defer rows.Close()
+
+	items := []model.KPIScorecardItem{}
+	for rows.Next() {
+		var it model.KPIScorecardItem
+		var value sql.NullFloat64
+		if err := rows.Scan(&it.ID, &it.IndicatorCode, &it.IndicatorName, &it.Unit, &value, &it.Score, &it.Weight, &it.Band, &it.TaskCount); err != nil {
+			return nil, err
+		}
+		if value.Valid {
+			it.Value = &value.Float64
+		}
+		it.WeightedScore = it.Score * it.Weight
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+// GetScorecardItemTasks returns the tasks behind one indicator of a scorecard.
+func (r *PostgresRepo) GetScorecardItemTasks(ctx context.Context, scorecardID int64, indicatorCode string) ([]model.KPIItemTask, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT it.task_id, COALESCE(it.task_name, ''), it.counted, COALESCE(it.value, 0), COALESCE(it.note, '')
+		FROM kpi_scorecard_item_tasks it
+		JOIN kpi_scorecard_items i ON it.item_id = i.id
+		WHERE i.scorecard_id = $1 AND i.indicator_code = $2
+		ORDER BY it.counted DESC, it.task_name
+	`, scorecardID, indicatorCode)
+	if err != nil {
+		return nil, fmt.Errorf("querying scorecard item tasks failed: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := []model.KPIItemTask{}
+	for rows.Next() {
+		var t model.KPIItemTask
+		if err := rows.Scan(&t.TaskID, &t.TaskName, &t.Counted, &t.Value, &t.Note); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
@@ -0,0 +1,426 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+)
+
+// Daftar endpoint list memakai query.Spec di bawah sebagai whitelist kolom
+// sort, filter dan pencarian. Total dihitung dengan WHERE yang sama.
+
+func (r *PostgresRepo) countRows(ctx context.Context, from string, c query.Clause) (int, error) {
+	var total int
+	if err := r.DB.QueryRowContext(ctx, "SELECT COUNT(*) "+from+c.Where, c.Args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("counting rows failed: %w", err)
+	}
+	return total, nil
+}
+
+const taskListFrom = `
+	FROM tasks t
+	LEFT JOIN task_statuses ts ON ts.id = t.status_id
+	LEFT JOIN lists l ON l.id = t.list_id
+	LEFT JOIN folders f ON f.id = l.folder_id
+`
+
+const taskListSelect = `
+	SELECT t.id, COALESCE(t.name, ''), COALESCE(t.text_content, ''), COALESCE(t.description, ''),
+		COALESCE(ts.id, ''), COALESCE(ts.name, ''), COALESCE(ts.type, ''), COALESCE(ts.color, ''),
+		t.date_done, t.date_closed, t.start_date, t.due_date, t.date_created, t.updated_at,
+		t.list_id, t.time_estimate_hours, t.time_spent_hours, COALESCE(t.estimate_source, ''),
+		t.time_efficiency_percentage, t.remaining_time_hours
+` + taskListFrom
+
+const taskAssigneeCondition = `EXISTS (
+	SELECT 1 FROM task_assignees ta
+	JOIN users u ON u.clickup_id = ta.user_clickup_id
+	LEFT JOIN roles ro ON ro.id = u.role_id
+	WHERE ta.task_id = t.id AND %s
+)`
+
+var taskListSpec = query.Spec{
+	Sort: map[string]string{
+		"name":                "LOWER(t.name)",
+		"status":              "LOWER(ts.name)",
+		"project":             "LOWER(COALESCE(f.name, l.name))",
+		"start_date":          "t.start_date",
+		"due_date":            "t.due_date",
+		"date_done":           "t.date_done",
+		"date_closed":         "t.date_closed",
+		"date_created":        "t.date_created",
+		"updated_at":          "t.updated_at",
+		"time_estimate_hours": "t.time_estimate_hours",
+		"time_spent_hours":    "t.time_spent_hours",
+	},
+	DefaultSort: []query.SortField{{Field: "date_done", Desc: true}},
+	Tiebreak:    "t.id",
+	Filters: map[string]string{
+		query.FilterStatus:   "(LOWER(ts.name) = ANY(%[1]s) OR LOWER(ts.type) = ANY(%[1]s))",
+		query.FilterProject:  "(LOWER(COALESCE(f.name, l.name)) = ANY(%[1]s) OR t.list_id = ANY(%[1]s) OR l.folder_id = ANY(%[1]s))",
+		query.FilterAssignee: fmt.Sprintf(taskAssigneeCondition, "(ta.user_clickup_id::text = ANY(%[1]s) OR LOWER(u.name) = ANY(%[1]s) OR LOWER(u.email) = ANY(%[1]s))"),
+		query.FilterRole:     fmt.Sprintf(taskAssigneeCondition, "LOWER(ro.name) = ANY(%s)"),
+	},
+	Dates: map[string]string{
+		"start_date":   "t.start_date",
+		"due_date":     "t.due_date",
+		"date_done":    "t.date_done",
+		"date_closed":  "t.date_closed",
+		"date_created": "t.date_created",
+		"updated_at":   "t.updated_at",
+	},
+	Search: []string{"t.name", "t.text_content", "t.id"},
+}
+
+func (r *PostgresRepo) queryTaskList(ctx context.Context, q string, args ...interface{}) ([]model.TaskResponse, error) {
+	rows, err := r.DB.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying tasks failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.TaskResponse{}
+	for rows.Next() {
+		var t model.TaskResponse
+		var dateDone, dateClosed, startDate, dueDate, dateCreated, updatedAt sql.NullTime
+		var listID sql.NullString
+		var estimate, spent, efficiency, remaining sql.NullFloat64
+		if err := rows.Scan(&t.ID, &t.Name, &t.TextContent, &t.Description,
+			&t.Status.ID, &t.Status.Name, &t.Status.Type, &t.Status.Color,
+			&dateDone, &dateClosed, &startDate, &dueDate, &dateCreated, &updatedAt,
+			&listID, &estimate, &spent, &t.EstimateSource, &efficiency, &remaining); err != nil {
+			return nil, err
+		}
+		t.DateDone = nullTimePtr(dateDone)
+		t.DateClosed = nullTimePtr(dateClosed)
+		t.StartDate = nullTimePtr(startDate)
+		t.DueDate = nullTimePtr(dueDate)
+		t.DateCreated = nullTimePtr(dateCreated)
+		t.DateUpdated = nullTimePtr(updatedAt)
+		if listID.Valid {
+			t.ListID = &listID.String
+		}
+		t.TimeEstimateHours = nullFloatPtr(estimate)
+		t.TimeSpentHours = nullFloatPtr(spent)
+		t.TimeEfficiencyPercentage = nullFloatPtr(efficiency)
+		t.RemainingTimeHours = nullFloatPtr(remaining)
+		t.Assignees = []model.TaskAssignee{}
+		out = append(out, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, r.attachTaskAssignees(ctx, out)
+}
+
+// attachTaskAssignees mengisi Assignees dengan satu query untuk semua task.
+// Username/Email diisi dari assignee pertama untuk klien lama.
+func (r *PostgresRepo) attachTaskAssignees(ctx context.Context, tasks []model.TaskResponse) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	ids := make([]string, len(tasks))
+	index := make(map[string]int, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+		index[t.ID] = i
+	}
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT ta.task_id, u.clickup_id, COALESCE(u.name, ''), COALESCE(u.email, '')
+		FROM task_assignees ta
+		JOIN users u ON u.clickup_id = ta.user_clickup_id
+		WHERE ta.task_id = ANY($1)
+		ORDER BY ta.task_id, ta.id
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("querying task assignees failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, email string
+		var a model.TaskAssignee
+		if err := rows.Scan(&taskID, &a.ID, &a.Username, &email); err != nil {
+			return err
+		}
+		a.Initials = initials(a.Username)
+		t := &tasks[index[taskID]]
+		if len(t.Assignees) == 0 {
+			id := a.ID
+			t.Username, t.Email = a.Username, email
+			t.AssigneeClickUpID = &id
+		}
+		t.Assignees = append(t.Assignees, a)
+	}
+	return rows.Err()
+}
+
+func initials(name string) string {
+	var out []rune
+	newWord := true
+	for _, r := range name {
+		if r == ' ' {
+			newWord = true
+			continue
+		}
+		if newWord && len(out) < 2 {
+			out = append(out, r)
+		}
+		newWord = false
+	}
+	return string(out)
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func nullFloatPtr(f sql.NullFloat64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
+
+// GetTasksPage returns one page of tasks with their assignees.
+func (r *PostgresRepo) GetTasksPage(ctx context.Context, p query.Params) ([]model.TaskResponse, query.Page, error) {
+	c, err := taskListSpec.Build(p)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	total, err := r.countRows(ctx, taskListFrom, c)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	tasks, err := r.queryTaskList(ctx, taskListSelect+c.Where+c.Tail, c.Args...)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	return tasks, query.NewPage(p, c.Limit, total, len(tasks)), nil
+}
+
+const memberListFrom = `
+	FROM users u
+	LEFT JOIN roles r ON u.role_id = r.id
+	LEFT JOIN user_statuses us ON u.status_id = us.id
+`
+
+var memberListSpec = query.Spec{
+	Sort: map[string]string{
+		"name":       "LOWER(u.name)",
+		"email":      "LOWER(u.email)",
+		"role":       "LOWER(r.name)",
+		"status":     "us.name",
+		"created_at": "u.created_at",
+		"updated_at": "u.updated_at",
+	},
+	DefaultSort: []query.SortField{{Field: "name"}},
+	Tiebreak:    "u.clickup_id",
+	Filters: map[string]string{
+		query.FilterRole:   "LOWER(r.name) = ANY(%s)",
+		query.FilterStatus: "LOWER(us.name) = ANY(%s)",
+	},
+	Dates: map[string]string{
+		"created_at": "u.created_at",
+		"updated_at": "u.updated_at",
+	},
+	Search: []string{"u.name", "u.email", "u.clickup_id::text"},
+}
+
+func (r *PostgresRepo) GetMembersPage(ctx context.Context, p query.Params) ([]model.User, query.Page, error) {
+	c, err := memberListSpec.Build(p)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	total, err := r.countRows(ctx, memberListFrom, c)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT u.clickup_id, COALESCE(u.name, ''), COALESCE(u.email, ''), COALESCE(r.name, ''),
+			COALESCE(us.name, ''), u.created_at, u.updated_at
+	`+memberListFrom+c.Where+c.Tail, c.Args...)
+	if err != nil {
+		return nil, query.Page{}, fmt.Errorf("querying members failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.User{}
+	for rows.Next() {
+		var u model.User
+		if err := rows.Scan(&u.ClickUpID, &u.Name, &u.Email, &u.Role, &u.Status, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, query.Page{}, err
+		}
+		out = append(out, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, query.Page{}, err
+	}
+	return out, query.NewPage(p, c.Limit, total, len(out)), nil
+}
+
+const folderListFrom = `
+	FROM folders f
+	LEFT JOIN spaces s ON f.space_id = s.id
+`
+
+var folderListSpec = query.Spec{
+	Sort: map[string]string{
+		"name":  "LOWER(f.name)",
+		"space": "LOWER(s.name)",
+	},
+	DefaultSort: []query.SortField{{Field: "space"}, {Field: "name"}},
+	Tiebreak:    "f.id",
+	Filters: map[string]string{
+		query.FilterProject: "(LOWER(f.name) = ANY(%[1]s) OR f.id = ANY(%[1]s))",
+	},
+	Search: []string{"f.name", "s.name"},
+}
+
+func (r *PostgresRepo) GetFoldersPage(ctx context.Context, p query.Params) ([]model.Folder, query.Page, error) {
+	c, err := folderListSpec.Build(p)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	total, err := r.countRows(ctx, folderListFrom, c)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT f.id, f.name, COALESCE(f.archived, FALSE), COALESCE(f.space_id, ''), COALESCE(s.name, '')
+	`+folderListFrom+c.Where+c.Tail, c.Args...)
+	if err != nil {
+		return nil, query.Page{}, fmt.Errorf("querying folders failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.Folder{}
+	for rows.Next() {
+		var f model.Folder
+		if err := rows.Scan(&f.ID, &f.Name, &f.Archived, &f.Space.ID, &f.Space.Name); err != nil {
+			return nil, query.Page{}, err
+		}
+		out = append(out, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, query.Page{}, err
+	}
+	return out, query.NewPage(p, c.Limit, total, len(out)), nil
+}
+
+const listListFrom = `
+	FROM lists l
+	LEFT JOIN folders f ON f.id = l.folder_id
+`
+
+var listListSpec = query.Spec{
+	Sort: map[string]string{
+		"name":       "LOWER(l.name)",
+		"project":    "LOWER(f.name)",
+		"start_date": "l.start_date",
+		"due_date":   "l.due_date",
+	},
+	DefaultSort: []query.SortField{{Field: "name"}},
+	Tiebreak:    "l.id",
+	Filters: map[string]string{
+		query.FilterProject: "(LOWER(f.name) = ANY(%[1]s) OR l.folder_id = ANY(%[1]s))",
+	},
+	Dates: map[string]string{
+		"start_date": "l.start_date",
+		"due_date":   "l.due_date",
+	},
+	Search: []string{"l.name", "f.name"},
+}
+
+func (r *PostgresRepo) GetListsPage(ctx context.Context, p query.Params) ([]model.List, query.Page, error) {
+	c, err := listListSpec.Build(p)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	total, err := r.countRows(ctx, listListFrom, c)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT l.id, l.name, COALESCE(l.archived, FALSE), COALESCE(l.folder_id, ''), COALESCE(l.space_id, '')
+	`+listListFrom+c.Where+c.Tail, c.Args...)
+	if err != nil {
+		return nil, query.Page{}, fmt.Errorf("querying lists failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.List{}
+	for rows.Next() {
+		var l model.List
+		if err := rows.Scan(&l.ID, &l.Name, &l.Archived, &l.FolderID, &l.SpaceID); err != nil {
+			return nil, query.Page{}, err
+		}
+		out = append(out, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, query.Page{}, err
+	}
+	return out, query.NewPage(p, c.Limit, total, len(out)), nil
+}
+
+const syncHistoryFrom = ` FROM sync_history`
+
+var syncHistorySpec = query.Spec{
+	Sort: map[string]string{
+		"sync_time":   "sync_time",
+		"sync_type":   "sync_type",
+		"status":      "status",
+		"duration_ms": "duration_ms",
+	},
+	DefaultSort: []query.SortField{{Field: "sync_time", Desc: true}},
+	Tiebreak:    "id",
+	Filters: map[string]string{
+		query.FilterStatus: "LOWER(status) = ANY(%s)",
+	},
+	Dates: map[string]string{
+		"sync_time": "sync_time",
+	},
+	Search:       []string{"sync_type", "status"},
+	DefaultLimit: 20,
+}
+
+func (r *PostgresRepo) GetSyncHistoryPage(ctx context.Context, p query.Params) ([]model.SyncHistory, query.Page, error) {
+	c, err := syncHistorySpec.Build(p)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	total, err := r.countRows(ctx, syncHistoryFrom, c)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	rows, err := r.DB.QueryContext(ctx, `SELECT id, sync_time, sync_type, status, COALESCE(duration_ms, 0), details`+syncHistoryFrom+c.Where+c.Tail, c.Args...)
+	if err != nil {
+		return nil, query.Page{}, fmt.Errorf("querying sync history failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.SyncHistory{}
+	for rows.Next() {
+		var h model.SyncHistory
+		var details []byte
+		if err := rows.Scan(&h.ID, &h.SyncTime, &h.SyncType, &h.Status, &h.DurationMs, &details); err != nil {
+			return nil, query.Page{}, fmt.Errorf("scanning sync history row failed: %w", err)
+		}
+		if details != nil {
+			h.Details = details
+		}
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, query.Page{}, err
+	}
+	return out, query.NewPage(p, c.Limit, total, len(out)), nil
+}
@@ -0,0 +1,131 @@
+package memstore
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+func (s *Store) AddAlertRule(ar model.AlertRule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.alertRules = append(s.alertRules, ar)
+}
+
+func (s *Store) GetAlertRules(ctx context.Context) ([]model.AlertRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := append([]model.AlertRule{}, s.alertRules...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out, nil
+}
+
+func (s *Store) UpdateAlertRule(ctx context.Context, ar *model.AlertRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.alertRules {
+		r := &s.alertRules[i]
+		if r.Code == ar.Code {
+			r.Threshold, r.Active, r.UpdatedAt = ar.Threshold, ar.Active, s.Now()
+			*ar = *r
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// GetAlerts orders by last seen, newest first, then id.
+func (s *Store) GetAlerts(ctx context.Context, f model.AlertFilter) ([]model.Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.Alert{}
+	for _, a := range s.alerts {
+		if f.Status != "" && a.Status != f.Status || f.RuleCode != "" && a.RuleCode != f.RuleCode {
+			continue
+		}
+		if f.UserID != nil && (a.UserID == nil || *a.UserID != *f.UserID) {
+			continue
+		}
+		out = append(out, a)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].LastSeenAt.Equal(out[j].LastSeenAt) {
+			return out[i].LastSeenAt.After(out[j].LastSeenAt)
+		}
+		return out[i].ID > out[j].ID
+	})
+	return out, nil
+}
+
+func (s *Store) GetAlert(ctx context.Context, id int64) (*model.Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range s.alerts {
+		if a.ID == id {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+
+// SyncAlerts opens new violations, refreshes the active ones it sees again and
+// resolves the active ones it does not.
+func (s *Store) SyncAlerts(ctx context.Context, candidates []model.AlertCandidate) (model.AlertEvaluation, []model.Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var res model.AlertEvaluation
+	now := s.Now()
+	key := func(c model.AlertCandidate) string { return c.RuleCode + "|" + c.SubjectType + "|" + c.SubjectID }
+
+	active := map[string]int{}
+	for i, a := range s.alerts {
+		if a.Status != model.AlertStatusResolved {
+			active[key(a.AlertCandidate)] = i
+		}
+	}
+
+	opened := []model.Alert{}
+	for _, c := range candidates {
+		if i, ok := active[key(c)]; ok {
+			delete(active, key(c))
+			a := &s.alerts[i]
+			a.SubjectName, a.UserID, a.Message, a.Value, a.LastSeenAt = c.SubjectName, c.UserID, c.Message, c.Value, now
+			res.Updated++
+			continue
+		}
+		a := model.Alert{ID: s.id(), AlertCandidate: c, Status: model.AlertStatusOpen, CreatedAt: now, LastSeenAt: now}
+		s.alerts = append(s.alerts, a)
+		opened = append(opened, a)
+		res.Opened++
+	}
+
+	for _, i := range active {
+		s.alerts[i].Status, s.alerts[i].ResolvedAt = model.AlertStatusResolved, &now
+		res.Resolved++
+	}
+	return res, opened, nil
+}
+
+func (s *Store) SetAlertStatus(ctx context.Context, id int64, from []string, to, actor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.Now()
+	for i := range s.alerts {
+		a := &s.alerts[i]
+		if a.ID != id || !slices.Contains(from, a.Status) {
+			continue
+		}
+		a.Status = to
+		switch to {
+		case model.AlertStatusAcknowledged:
+			a.AcknowledgedAt, a.AcknowledgedBy = &now, actor
+		case model.AlertStatusResolved:
+			a.ResolvedAt = &now
+		}
+		return nil
+	}
+	return sql.ErrNoRows
+}
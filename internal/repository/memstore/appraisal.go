@@ -0,0 +1,221 @@
+package memstore
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strconv"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+func (s *Store) audit(periodID int64, action, actor, reason string) {
+	s.appraisalAudit = append(s.appraisalAudit, model.AppraisalAuditEntry{
+		ID: s.id(), PeriodID: periodID, Action: action, Actor: actor, Reason: reason, CreatedAt: s.Now(),
+	})
+}
+
+func (s *Store) period(id int64) *model.AppraisalPeriod {
+	for i := range s.periods {
+		if s.periods[i].ID == id {
+			return &s.periods[i]
+		}
+	}
+	return nil
+}
+
+func (s *Store) CreateAppraisalPeriod(ctx context.Context, p *model.AppraisalPeriod, actor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.Now()
+	p.ID, p.Status, p.CreatedAt, p.UpdatedAt = s.id(), model.AppraisalOpen, now, now
+	s.periods = append(s.periods, *p)
+	s.audit(p.ID, "create", actor, "")
+	return nil
+}
+
+// GetAppraisalPeriods orders by period start, latest first.
+func (s *Store) GetAppraisalPeriods(ctx context.Context) ([]model.AppraisalPeriod, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := append([]model.AppraisalPeriod{}, s.periods...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].PeriodStart.After(out[j].PeriodStart) })
+	return out, nil
+}
+
+func (s *Store) GetAppraisalPeriod(ctx context.Context, id int64) (*model.AppraisalPeriod, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if p := s.period(id); p != nil {
+		out := *p
+		return &out, nil
+	}
+	return nil, nil
+}
+
+func (s *Store) MarkAppraisalComputed(ctx context.Context, id int64, actor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.period(id)
+	if p == nil || p.Status == model.AppraisalLocked {
+		return sql.ErrNoRows
+	}
+	now := s.Now()
+	p.Status, p.ComputedAt, p.UpdatedAt = model.AppraisalComputed, &now, now
+	s.audit(id, "compute", actor, "")
+	return nil
+}
+
+// LockAppraisalPeriod saves the scorecards and snapshots them with the task
+// metrics; nothing is written when the period is already locked.
+func (s *Store) LockAppraisalPeriod(ctx context.Context, id int64, actor string, scorecards []model.KPIScorecard, tasks []model.KPITaskMetric) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.period(id)
+	if p == nil || p.Status == model.AppraisalLocked {
+		return sql.ErrNoRows
+	}
+	now := s.Now()
+	p.Status, p.LockedAt, p.LockedBy, p.UpdatedAt = model.AppraisalLocked, &now, &actor, now
+
+	for i := range scorecards {
+		sc := &scorecards[i]
+		s.saveScorecard(sc)
+		snap := *sc
+		snap.Items = append([]model.KPIScorecardItem(nil), sc.Items...)
+		s.appraisalScorecards[id] = append(s.appraisalScorecards[id], snap)
+	}
+
+	seen := map[string]bool{}
+	for _, t := range tasks {
+		key := t.TaskID + "|" + strconv.FormatInt(t.UserID, 10)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		s.appraisalTasks[id] = append(s.appraisalTasks[id], model.AppraisalTaskSnapshot{KPITaskMetric: t, SnapshotAt: now})
+	}
+
+	s.audit(id, "lock", actor, "")
+	return nil
+}
+
+func (s *Store) ReopenAppraisalPeriod(ctx context.Context, id int64, actor, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.period(id)
+	if p == nil || p.Status != model.AppraisalLocked {
+		return sql.ErrNoRows
+	}
+	p.Status, p.LockedAt, p.LockedBy, p.UpdatedAt = model.AppraisalOpen, nil, nil, s.Now()
+	delete(s.appraisalScorecards, id)
+	delete(s.appraisalTasks, id)
+	s.audit(id, "reopen", actor, reason)
+	return nil
+}
+
+// GetAppraisalSnapshots orders by total score, best first, then by name.
+func (s *Store) GetAppraisalSnapshots(ctx context.Context, periodID int64, userID *int64) ([]model.KPIScorecard, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.KPIScorecard{}
+	p := s.period(periodID)
+	for _, sc := range s.appraisalScorecards[periodID] {
+		if userID != nil && sc.UserID != *userID {
+			continue
+		}
+		sc.ID, sc.PeriodType = 0, "appraisal"
+		sc.PeriodLabel, sc.PeriodStart, sc.PeriodEnd = p.Name, p.PeriodStart, p.PeriodEnd
+		out = append(out, sc)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].TotalScore != out[j].TotalScore {
+			return out[i].TotalScore > out[j].TotalScore
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+// GetAppraisalTaskSnapshots orders by member, then due date.
+func (s *Store) GetAppraisalTaskSnapshots(ctx context.Context, periodID int64, userID *int64) ([]model.AppraisalTaskSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.AppraisalTaskSnapshot{}
+	for _, t := range s.appraisalTasks[periodID] {
+		if userID == nil || t.UserID == *userID {
+			out = append(out, t)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].UserID != out[j].UserID {
+			return out[i].UserID < out[j].UserID
+		}
+		return compareTime(out[i].DueDate, out[j].DueDate) < 0
+	})
+	return out, nil
+}
+
+func (s *Store) GetAppraisalAuditLog(ctx context.Context, periodID int64) ([]model.AppraisalAuditEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.AppraisalAuditEntry{}
+	for _, e := range s.appraisalAudit {
+		if e.PeriodID == periodID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// UpsertAppraisalReview clears an earlier acknowledgement and rebuttal. It
+// returns sql.ErrNoRows for an unknown member.
+func (s *Store) UpsertAppraisalReview(ctx context.Context, rv *model.AppraisalReview) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.member(rv.UserID); !ok {
+		return sql.ErrNoRows
+	}
+	now := s.Now()
+	rv.AcknowledgedAt, rv.Rebuttal, rv.UpdatedAt = nil, "", now
+	for i := range s.reviews {
+		if s.reviews[i].PeriodID == rv.PeriodID && s.reviews[i].UserID == rv.UserID {
+			rv.CreatedAt = s.reviews[i].CreatedAt
+			s.reviews[i] = *rv
+			return nil
+		}
+	}
+	rv.CreatedAt = now
+	s.reviews = append(s.reviews, *rv)
+	return nil
+}
+
+// AcknowledgeAppraisalReview returns sql.ErrNoRows when there is no review yet.
+func (s *Store) AcknowledgeAppraisalReview(ctx context.Context, periodID, userID int64, rebuttal string) (*model.AppraisalReview, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.reviews {
+		rv := &s.reviews[i]
+		if rv.PeriodID == periodID && rv.UserID == userID {
+			now := s.Now()
+			rv.AcknowledgedAt, rv.Rebuttal, rv.UpdatedAt = &now, rebuttal, now
+			out := *rv
+			return &out, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// GetAppraisalReviews orders by member id.
+func (s *Store) GetAppraisalReviews(ctx context.Context, periodID int64, userID *int64) ([]model.AppraisalReview, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.AppraisalReview{}
+	for _, rv := range s.reviews {
+		if rv.PeriodID == periodID && (userID == nil || rv.UserID == *userID) {
+			out = append(out, rv)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].UserID < out[j].UserID })
+	return out, nil
+}
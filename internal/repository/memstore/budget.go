@@ -0,0 +1,139 @@
+package memstore
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// UpsertBudget replaces the budget of the same folder or list.
+func (s *Store) UpsertBudget(ctx context.Context, b *model.ProjectBudget) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.Now()
+	for i := range s.budgets {
+		old := &s.budgets[i]
+		if old.Level == b.Level && old.NodeID == b.NodeID {
+			b.ID, b.CreatedAt, b.UpdatedAt = old.ID, old.CreatedAt, now
+			*old = *b
+			return nil
+		}
+	}
+	b.ID, b.CreatedAt, b.UpdatedAt = s.id(), now, now
+	s.budgets = append(s.budgets, *b)
+	return nil
+}
+
+func (s *Store) UpdateBudget(ctx context.Context, b *model.ProjectBudget) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.budgets {
+		if s.budgets[i].ID == b.ID {
+			b.CreatedAt, b.UpdatedAt = s.budgets[i].CreatedAt, s.Now()
+			s.budgets[i] = *b
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// DeleteBudget removes its alerts too, as the cascade does.
+func (s *Store) DeleteBudget(ctx context.Context, id int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.budgets)
+	s.budgets = slices.DeleteFunc(s.budgets, func(b model.ProjectBudget) bool { return b.ID == id })
+	s.budgetAlerts = slices.DeleteFunc(s.budgetAlerts, func(a model.BudgetAlert) bool { return a.BudgetID == id })
+	return len(s.budgets) < n, nil
+}
+
+// GetBudgets orders by level, then by node name (the node id when unknown).
+func (s *Store) GetBudgets(ctx context.Context) ([]model.ProjectBudget, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.ProjectBudget{}
+	for _, b := range s.budgets {
+		out = append(out, s.budgetView(b))
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Level != out[j].Level {
+			return out[i].Level < out[j].Level
+		}
+		return sortName(out[i]) < sortName(out[j])
+	})
+	return out, nil
+}
+
+func sortName(b model.ProjectBudget) string {
+	if b.NodeName != "" {
+		return b.NodeName
+	}
+	return b.NodeID
+}
+
+func (s *Store) GetBudget(ctx context.Context, id int64) (*model.ProjectBudget, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, b := range s.budgets {
+		if b.ID == id {
+			v := s.budgetView(b)
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+// budgetView reads the node name at query time, as the join does.
+func (s *Store) budgetView(b model.ProjectBudget) model.ProjectBudget {
+	b.NodeName = ""
+	switch b.Level {
+	case model.ProjectLevelFolder:
+		f, _ := s.folder(b.NodeID)
+		b.NodeName = f.Name
+	case model.ProjectLevelList:
+		l, _ := s.list(b.NodeID)
+		b.NodeName = l.Name
+	}
+	b.Thresholds = append([]float64{}, b.Thresholds...)
+	return b
+}
+
+// GetBudgetAlerts orders by threshold.
+func (s *Store) GetBudgetAlerts(ctx context.Context, budgetID int64) ([]model.BudgetAlert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.BudgetAlert{}
+	for _, a := range s.budgetAlerts {
+		if a.BudgetID == budgetID {
+			out = append(out, a)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Threshold < out[j].Threshold })
+	return out, nil
+}
+
+// SyncBudgetAlerts drops alerts above burnPct and records each crossed
+// threshold once; it returns only the newly fired alerts.
+func (s *Store) SyncBudgetAlerts(ctx context.Context, budgetID int64, burnPct, spentHours float64, crossed []float64) ([]model.BudgetAlert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.budgetAlerts = slices.DeleteFunc(s.budgetAlerts, func(a model.BudgetAlert) bool {
+		return a.BudgetID == budgetID && a.Threshold > burnPct
+	})
+
+	fired := []model.BudgetAlert{}
+	for _, threshold := range crossed {
+		if slices.ContainsFunc(s.budgetAlerts, func(a model.BudgetAlert) bool {
+			return a.BudgetID == budgetID && a.Threshold == threshold
+		}) {
+			continue
+		}
+		a := model.BudgetAlert{ID: s.id(), BudgetID: budgetID, Threshold: threshold, BurnPct: burnPct, SpentHours: spentHours, FiredAt: s.Now()}
+		s.budgetAlerts = append(s.budgetAlerts, a)
+		fired = append(fired, a)
+	}
+	return fired, nil
+}
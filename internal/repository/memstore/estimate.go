@@ -0,0 +1,63 @@
+package memstore
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// SetManualEstimate returns sql.ErrNoRows for an unknown task.
+func (s *Store) SetManualEstimate(ctx context.Context, taskID string, hours float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.tasks {
+		if s.tasks[i].ID == taskID {
+			s.tasks[i].EstimateHours, s.tasks[i].EstimateSource = hours, "manual"
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// GetEstimateSamples returns one row per (done or closed task, assignee)
+// finished in the range, ordered by assignee and task id.
+func (s *Store) GetEstimateSamples(ctx context.Context, start, end time.Time) ([]model.EstimateSample, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.EstimateSample{}
+	for _, t := range s.tasks {
+		closed := firstTime(t.DateClosed, t.DateDone)
+		if t.Status.Type != "done" && t.Status.Type != "closed" || closed == nil || closed.Before(start) || closed.After(end) {
+			continue
+		}
+		project := ""
+		if p := s.projectName(t.ListID); p != nil {
+			project = *p
+		}
+		for _, uid := range t.Assignees {
+			u, _ := s.member(uid)
+			out = append(out, model.EstimateSample{
+				TaskID:         t.ID,
+				TaskName:       t.Name,
+				UserID:         uid,
+				UserName:       u.Name,
+				Role:           u.Role,
+				ProjectName:    project,
+				EstimateHours:  t.EstimateHours,
+				SpentHours:     t.SpentHours,
+				EstimateSource: t.EstimateSource,
+				ClosedAt:       *closed,
+			})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].UserID != out[j].UserID {
+			return out[i].UserID < out[j].UserID
+		}
+		return out[i].TaskID < out[j].TaskID
+	})
+	return out, nil
+}
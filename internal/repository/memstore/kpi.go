@@ -0,0 +1,195 @@
+package memstore
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+func (s *Store) GetKPIIndicators(ctx context.Context) ([]model.KPIIndicator, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := append([]model.KPIIndicator(nil), s.indicators...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out, nil
+}
+
+func (s *Store) UpdateKPIIndicator(ctx context.Context, ind *model.KPIIndicator) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.indicators {
+		if s.indicators[i].Code == ind.Code {
+			ind.UpdatedAt = s.Now()
+			s.indicators[i] = *ind
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (s *Store) GetKPIRoleWeights(ctx context.Context) ([]model.KPIRoleWeight, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := append([]model.KPIRoleWeight(nil), s.roleWeights...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Role != out[j].Role {
+			return out[i].Role < out[j].Role
+		}
+		return out[i].IndicatorCode < out[j].IndicatorCode
+	})
+	return out, nil
+}
+
+// SetKPIRoleWeights matches the role case-insensitively, like the ILIKE in
+// the repository.
+func (s *Store) SetKPIRoleWeights(ctx context.Context, role string, weights map[string]float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := ""
+	for r := range s.roles {
+		if strings.EqualFold(r, role) {
+			name = r
+			break
+		}
+	}
+	if name == "" {
+		return sql.ErrNoRows
+	}
+
+	kept := s.roleWeights[:0]
+	for _, w := range s.roleWeights {
+		if w.Role != name {
+			kept = append(kept, w)
+		}
+	}
+	s.roleWeights = kept
+	for code, weight := range weights {
+		s.roleWeights = append(s.roleWeights, model.KPIRoleWeight{Role: name, IndicatorCode: code, Weight: weight})
+	}
+	return nil
+}
+
+// SaveScorecard replaces an earlier scorecard of the same member and period
+// and assigns ids to the scorecard and its items.
+func (s *Store) SaveScorecard(ctx context.Context, sc *model.KPIScorecard) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saveScorecard(sc)
+	return nil
+}
+
+func (s *Store) saveScorecard(sc *model.KPIScorecard) {
+	sc.ComputedAt = s.Now()
+
+	stored := *sc
+	stored.Items = make([]model.KPIScorecardItem, len(sc.Items))
+	for i := range sc.Items {
+		sc.Items[i].ID = s.id()
+		s.itemTasks[sc.Items[i].ID] = append([]model.KPIItemTask(nil), sc.Items[i].Tasks...)
+		stored.Items[i] = sc.Items[i]
+		stored.Items[i].Tasks = nil
+	}
+
+	for i, old := range s.scorecards {
+		if old.UserID == sc.UserID && old.PeriodType == sc.PeriodType && old.PeriodLabel == sc.PeriodLabel {
+			for _, it := range old.Items {
+				delete(s.itemTasks, it.ID)
+			}
+			sc.ID = old.ID
+			stored.ID = old.ID
+			s.scorecards[i] = stored
+			return
+		}
+	}
+	sc.ID = s.id()
+	stored.ID = sc.ID
+	s.scorecards = append(s.scorecards, stored)
+}
+
+// GetScorecards orders by total score, best first, then by name. Member
+// details and indicator names are read at query time, as in the join.
+func (s *Store) GetScorecards(ctx context.Context, periodType, periodLabel string, userID *int64) ([]model.KPIScorecard, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []model.KPIScorecard
+	for _, sc := range s.scorecards {
+		if sc.PeriodType != periodType || sc.PeriodLabel != periodLabel {
+			continue
+		}
+		if userID != nil && sc.UserID != *userID {
+			continue
+		}
+		out = append(out, s.scorecardView(sc))
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].TotalScore != out[j].TotalScore {
+			return out[i].TotalScore > out[j].TotalScore
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+func (s *Store) GetScorecard(ctx context.Context, id int64) (*model.KPIScorecard, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sc := range s.scorecards {
+		if sc.ID == id {
+			v := s.scorecardView(sc)
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Store) scorecardView(sc model.KPIScorecard) model.KPIScorecard {
+	u, _ := s.member(sc.UserID)
+	sc.Name, sc.Email, sc.Role = u.Name, u.Email, u.Role
+
+	items := make([]model.KPIScorecardItem, len(sc.Items))
+	for i, it := range sc.Items {
+		it.IndicatorName, it.Unit = it.IndicatorCode, ""
+		for _, ind := range s.indicators {
+			if ind.Code == it.IndicatorCode {
+				it.IndicatorName, it.Unit = ind.Name, ind.Unit
+			}
+		}
+		it.WeightedScore = it.Score * it.Weight
+		items[i] = it
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Weight != items[j].Weight {
+			return items[i].Weight > items[j].Weight
+		}
+		return items[i].IndicatorCode < items[j].IndicatorCode
+	})
+	sc.Items = items
+	return sc
+}
+
+// GetScorecardItemTasks orders counted tasks first, then by name.
+func (s *Store) GetScorecardItemTasks(ctx context.Context, scorecardID int64, indicatorCode string) ([]model.KPIItemTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tasks := []model.KPIItemTask{}
+	for _, sc := range s.scorecards {
+		if sc.ID != scorecardID {
+			continue
+		}
+		for _, it := range sc.Items {
+			if it.IndicatorCode == indicatorCode {
+				tasks = append(tasks, s.itemTasks[it.ID]...)
+			}
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if tasks[i].Counted != tasks[j].Counted {
+			return tasks[i].Counted
+		}
+		return tasks[i].TaskName < tasks[j].TaskName
+	})
+	return tasks, nil
+}
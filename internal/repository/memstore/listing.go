@@ -0,0 +1,358 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+)
+
+// taskResponse builds the listing row of a task. Username and Email come
+// from the first assignee for old clients, as in the repository.
+func (s *Store) taskResponse(t Task) model.TaskResponse {
+	r := model.TaskResponse{
+		ID:                       t.ID,
+		Name:                     t.Name,
+		TextContent:              t.TextContent,
+		Description:              t.Description,
+		DateDone:                 t.DateDone,
+		DateClosed:               t.DateClosed,
+		StartDate:                t.StartDate,
+		DueDate:                  t.DueDate,
+		DateCreated:              t.DateCreated,
+		TimeEstimateHours:        &t.EstimateHours,
+		TimeSpentHours:           &t.SpentHours,
+		EstimateSource:           t.EstimateSource,
+		RemainingTimeHours:       t.RemainingHours,
+		TimeEfficiencyPercentage: t.EfficiencyPct,
+		Assignees:                []model.TaskAssignee{},
+	}
+	r.Status.ID, r.Status.Name, r.Status.Type, r.Status.Color = t.Status.ID, t.Status.Name, t.Status.Type, t.Status.Color
+	if t.ListID != "" {
+		listID := t.ListID
+		r.ListID = &listID
+	}
+	for _, uid := range t.Assignees {
+		u, ok := s.member(uid)
+		if !ok {
+			continue
+		}
+		if len(r.Assignees) == 0 {
+			id := uid
+			r.Username, r.Email, r.AssigneeClickUpID = u.Name, u.Email, &id
+		}
+		r.Assignees = append(r.Assignees, model.TaskAssignee{ID: uid, Username: u.Name, Initials: initials(u.Name)})
+	}
+	return r
+}
+
+func initials(name string) string {
+	var out []rune
+	for _, word := range strings.Fields(name) {
+		if len(out) < 2 {
+			out = append(out, []rune(word)[0])
+		}
+	}
+	return string(out)
+}
+
+// taskRow carries what the task filters match on besides the row itself.
+type taskRow struct {
+	model.TaskResponse
+	project   string
+	folderID  string
+	assignees []string
+	roles     []string
+}
+
+// taskRows returns every task as a listing row, ordered by id for the
+// tiebreak.
+func (s *Store) taskRows() []taskRow {
+	var out []taskRow
+	for _, t := range s.sortedTasks(func(a, b Task) bool { return a.ID < b.ID }) {
+		row := taskRow{TaskResponse: s.taskResponse(t)}
+		if p := s.projectName(t.ListID); p != nil {
+			row.project = *p
+		}
+		if l, ok := s.list(t.ListID); ok {
+			row.folderID = l.FolderID
+		}
+		for _, uid := range t.Assignees {
+			if u, ok := s.member(uid); ok {
+				row.assignees = append(row.assignees, strconv.FormatInt(uid, 10), u.Name, u.Email)
+				row.roles = append(row.roles, u.Role)
+			}
+		}
+		out = append(out, row)
+	}
+	return out
+}
+
+// taskSpec builds the task listing spec; fields names the sortable and
+// date fields (start, due, done, closed, created, updated, estimate, spent)
+// as the API spells them.
+func taskSpec(fields map[string]string, defaultSort query.SortField) query.MemorySpec[taskRow] {
+	dates := map[string]func(taskRow) *time.Time{
+		fields["start"]:   func(r taskRow) *time.Time { return r.StartDate },
+		fields["due"]:     func(r taskRow) *time.Time { return r.DueDate },
+		fields["done"]:    func(r taskRow) *time.Time { return r.DateDone },
+		fields["closed"]:  func(r taskRow) *time.Time { return r.DateClosed },
+		fields["created"]: func(r taskRow) *time.Time { return r.DateCreated },
+		fields["updated"]: func(r taskRow) *time.Time { return r.DateUpdated },
+	}
+	sorts := map[string]func(a, b taskRow) int{
+		"name":             func(a, b taskRow) int { return query.CompareString(a.Name, b.Name) },
+		"status":           func(a, b taskRow) int { return query.CompareString(a.Status.Name, b.Status.Name) },
+		"project":          func(a, b taskRow) int { return query.CompareString(a.project, b.project) },
+		fields["estimate"]: func(a, b taskRow) int { return query.CompareFloat(*a.TimeEstimateHours, *b.TimeEstimateHours) },
+		fields["spent"]:    func(a, b taskRow) int { return query.CompareFloat(*a.TimeSpentHours, *b.TimeSpentHours) },
+	}
+	for name, date := range dates {
+		sorts[name] = func(a, b taskRow) int { return compareTime(date(a), date(b)) }
+	}
+	return query.MemorySpec[taskRow]{
+		Sort:        sorts,
+		DefaultSort: []query.SortField{defaultSort},
+		Filters: map[string]func(taskRow) []string{
+			query.FilterStatus:   func(r taskRow) []string { return []string{r.Status.Name, r.Status.Type} },
+			query.FilterProject:  func(r taskRow) []string { return []string{r.project, derefString(r.ListID), r.folderID} },
+			query.FilterAssignee: func(r taskRow) []string { return r.assignees },
+			query.FilterRole:     func(r taskRow) []string { return r.roles },
+		},
+		Dates:  dates,
+		Search: func(r taskRow) []string { return []string{r.Name, r.TextContent, r.ID} },
+	}
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+var taskListSpec = taskSpec(map[string]string{
+	"start": "start_date", "due": "due_date", "done": "date_done", "closed": "date_closed",
+	"created": "date_created", "updated": "updated_at", "estimate": "time_estimate_hours", "spent": "time_spent_hours",
+}, query.SortField{Field: "date_done", Desc: true})
+
+func (s *Store) pageTasks(p query.Params, spec query.MemorySpec[taskRow]) ([]model.TaskResponse, query.Page, error) {
+	s.mu.Lock()
+	rows := s.taskRows()
+	s.mu.Unlock()
+	page, info, err := query.Apply(rows, p, spec)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	out := make([]model.TaskResponse, len(page))
+	for i, r := range page {
+		out[i] = r.TaskResponse
+	}
+	return out, info, nil
+}
+
+// GetTasks orders by done date, latest first and undone last, then id.
+func (s *Store) GetTasks(ctx context.Context) ([]model.TaskResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.TaskResponse{}
+	for _, t := range s.sortedTasks(func(a, b Task) bool {
+		if (a.DateDone == nil) != (b.DateDone == nil) {
+			return b.DateDone == nil
+		}
+		if c := compareTime(a.DateDone, b.DateDone); c != 0 {
+			return c > 0
+		}
+		return a.ID < b.ID
+	}) {
+		out = append(out, s.taskResponse(t))
+	}
+	return out, nil
+}
+
+func (s *Store) GetTasksPage(ctx context.Context, p query.Params) ([]model.TaskResponse, query.Page, error) {
+	return s.pageTasks(p, taskListSpec)
+}
+
+var memberListSpec = query.MemorySpec[model.User]{
+	Sort: map[string]func(a, b model.User) int{
+		"name":       func(a, b model.User) int { return query.CompareString(a.Name, b.Name) },
+		"email":      func(a, b model.User) int { return query.CompareString(a.Email, b.Email) },
+		"role":       func(a, b model.User) int { return query.CompareString(a.Role, b.Role) },
+		"status":     func(a, b model.User) int { return strings.Compare(a.Status, b.Status) },
+		"created_at": func(a, b model.User) int { return a.CreatedAt.Compare(b.CreatedAt) },
+		"updated_at": func(a, b model.User) int { return a.UpdatedAt.Compare(b.UpdatedAt) },
+	},
+	DefaultSort: []query.SortField{{Field: "name"}},
+	Filters: map[string]func(model.User) []string{
+		query.FilterRole:   func(u model.User) []string { return []string{u.Role} },
+		query.FilterStatus: func(u model.User) []string { return []string{u.Status} },
+	},
+	Dates: map[string]func(model.User) *time.Time{
+		"created_at": func(u model.User) *time.Time { return &u.CreatedAt },
+		"updated_at": func(u model.User) *time.Time { return &u.UpdatedAt },
+	},
+	Search: func(u model.User) []string { return []string{u.Name, u.Email, strconv.FormatInt(u.ClickUpID, 10)} },
+}
+
+func (s *Store) GetMembersPage(ctx context.Context, p query.Params) ([]model.User, query.Page, error) {
+	s.mu.Lock()
+	users := append([]model.User(nil), s.members...)
+	s.mu.Unlock()
+	sort.SliceStable(users, func(i, j int) bool { return users[i].ClickUpID < users[j].ClickUpID })
+	return query.Apply(users, p, memberListSpec)
+}
+
+var folderListSpec = query.MemorySpec[model.Folder]{
+	Sort: map[string]func(a, b model.Folder) int{
+		"name":  func(a, b model.Folder) int { return query.CompareString(a.Name, b.Name) },
+		"space": func(a, b model.Folder) int { return query.CompareString(a.Space.Name, b.Space.Name) },
+	},
+	DefaultSort: []query.SortField{{Field: "space"}, {Field: "name"}},
+	Filters: map[string]func(model.Folder) []string{
+		query.FilterProject: func(f model.Folder) []string { return []string{f.Name, f.ID} },
+	},
+	Search: func(f model.Folder) []string { return []string{f.Name, f.Space.Name} },
+}
+
+func (s *Store) GetFoldersPage(ctx context.Context, p query.Params) ([]model.Folder, query.Page, error) {
+	s.mu.Lock()
+	folders := append([]model.Folder(nil), s.folders...)
+	for i := range folders {
+		folders[i].Space.Name = s.spaceName(folders[i].Space.ID)
+	}
+	s.mu.Unlock()
+	sortByID(folders, func(f model.Folder) string { return f.ID })
+	return query.Apply(folders, p, folderListSpec)
+}
+
+// listRow is a list with the name of its folder.
+type listRow struct {
+	model.List
+	folder string
+}
+
+var listListSpec = query.MemorySpec[listRow]{
+	Sort: map[string]func(a, b listRow) int{
+		"name":       func(a, b listRow) int { return query.CompareString(a.Name, b.Name) },
+		"project":    func(a, b listRow) int { return query.CompareString(a.folder, b.folder) },
+		"start_date": func(a, b listRow) int { return compareTime(a.StartDate, b.StartDate) },
+		"due_date":   func(a, b listRow) int { return compareTime(a.DueDate, b.DueDate) },
+	},
+	DefaultSort: []query.SortField{{Field: "name"}},
+	Filters: map[string]func(listRow) []string{
+		query.FilterProject: func(l listRow) []string { return []string{l.folder, l.FolderID} },
+	},
+	Dates: map[string]func(listRow) *time.Time{
+		"start_date": func(l listRow) *time.Time { return l.StartDate },
+		"due_date":   func(l listRow) *time.Time { return l.DueDate },
+	},
+	Search: func(l listRow) []string { return []string{l.Name, l.folder} },
+}
+
+func (s *Store) GetListsPage(ctx context.Context, p query.Params) ([]model.List, query.Page, error) {
+	s.mu.Lock()
+	rows := make([]listRow, len(s.lists))
+	for i, l := range s.lists {
+		f, _ := s.folder(l.FolderID)
+		rows[i] = listRow{List: l, folder: f.Name}
+	}
+	s.mu.Unlock()
+	sortByID(rows, func(l listRow) string { return l.ID })
+	page, info, err := query.Apply(rows, p, listListSpec)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	out := make([]model.List, len(page))
+	for i, r := range page {
+		out[i] = r.List
+	}
+	return out, info, nil
+}
+
+// fullRows returns one row per task and assignee, and one row without member
+// for an unassigned task, like the LEFT JOINs of the full data queries.
+func (s *Store) fullRows(keep func(Task, model.User) bool) []model.TaskWithMember {
+	var out []model.TaskWithMember
+	for _, t := range s.tasks {
+		ids := t.Assignees
+		if len(ids) == 0 {
+			ids = []int64{0}
+		}
+		for _, uid := range ids {
+			u, _ := s.member(uid)
+			if !keep(t, u) {
+				continue
+			}
+			estimate, spent := t.EstimateHours, t.SpentHours
+			out = append(out, model.TaskWithMember{
+				TaskID: t.ID, TaskName: t.Name, TaskDescription: t.Description,
+				TaskStatus: t.Status.Name, TaskStatusType: t.Status.Type,
+				StartDate: t.StartDate, DueDate: t.DueDate, DateDone: t.DateDone, DateClosed: t.DateClosed,
+				TimeEstimateHours: &estimate, TimeSpentHours: &spent,
+				UserID: u.ClickUpID, Username: u.Name, Email: u.Email, Role: u.Role,
+			})
+		}
+	}
+	return out
+}
+
+func betweenMs(ts *time.Time, start, end *int64) bool {
+	return ts != nil && ts.UnixMilli() >= *start && ts.UnixMilli() <= *end
+}
+
+// GetFullSyncFiltered keeps tasks started or done in [start, end] (epoch ms)
+// and, given a role, rows of members with exactly that role; latest start
+// first. As in the repository, dates are only set on rows with a member and
+// the role is returned as Color.
+func (s *Store) GetFullSyncFiltered(ctx context.Context, start, end *int64, role string) ([]model.TaskWithMember, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rows := s.fullRows(func(t Task, u model.User) bool {
+		if start != nil && end != nil && !betweenMs(t.StartDate, start, end) && !betweenMs(t.DateDone, start, end) {
+			return false
+		}
+		return role == "" || u.Role == role
+	})
+	out := make([]model.TaskWithMember, len(rows))
+	for i, r := range rows {
+		out[i] = model.TaskWithMember{TaskID: r.TaskID, TaskName: r.TaskName, TaskStatus: r.TaskStatus}
+		if r.UserID != 0 {
+			out[i].StartDate, out[i].DateDone, out[i].DateClosed = r.StartDate, r.DateDone, r.DateClosed
+			out[i].UserID, out[i].Username, out[i].Email, out[i].Color = r.UserID, r.Username, r.Email, r.Role
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return compareTime(out[i].StartDate, out[j].StartDate) > 0 })
+	return out, nil
+}
+
+// GetFullDataFiltered keeps tasks starting or due in [startMs, endMs], rows
+// of members with the given role and of members whose name contains
+// username; latest start (or done) first.
+func (s *Store) GetFullDataFiltered(ctx context.Context, startMs, endMs *int64, role, username string) ([]model.TaskWithMember, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	username = strings.ToLower(username)
+	out := s.fullRows(func(t Task, u model.User) bool {
+		if startMs != nil && endMs != nil && !betweenMs(t.StartDate, startMs, endMs) && !betweenMs(t.DueDate, startMs, endMs) {
+			return false
+		}
+		if role != "" && u.Role != role {
+			return false
+		}
+		return username == "" || u.ClickUpID != 0 && strings.Contains(strings.ToLower(u.Name), username)
+	})
+	sort.SliceStable(out, func(i, j int) bool {
+		return compareTime(firstTime(out[i].StartDate, out[i].DateDone), firstTime(out[j].StartDate, out[j].DateDone)) > 0
+	})
+	return out, nil
+}
+
+// sortByID orders rows by id, the tiebreak of the paged queries.
+func sortByID[T any](rows []T, id func(T) string) {
+	sort.SliceStable(rows, func(i, j int) bool { return id(rows[i]) < id(rows[j]) })
+}
@@ -0,0 +1,531 @@
+// Package memstore is an in-memory implementation of the repository stores,
+// for testing services without a database. It follows the semantics of the
+// Postgres queries (period overlap, active members, ordering, "not found"
+// results) closely enough for service logic; it is not a SQL emulator.
+//
+//	st := memstore.New()
+//	st.AddMember(model.User{ClickUpID: 1, Name: "Ana", Role: "backend", Status: "aktif"})
+//	st.AddTask(memstore.Task{ID: "t1", DueDate: &due, Assignees: []int64{1}})
+//	svc := service.NewWorkloadService(st, nil)
+package memstore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+// ActiveStatus is the member status counted as active, as in user_statuses.
+const ActiveStatus = "aktif"
+
+// Task is a row of the tasks table with its assignees.
+type Task struct {
+	ID             string
+	Name           string
+	Description    string
+	TextContent    string
+	Status         model.TaskStatus
+	ListID         string
+	StartDate      *time.Time
+	DueDate        *time.Time
+	DateCreated    *time.Time
+	DateDone       *time.Time
+	DateClosed     *time.Time
+	EstimateHours  float64
+	SpentHours     float64
+	EstimateSource string
+	RemainingHours *float64
+	EfficiencyPct  *float64
+	Assignees      []int64
+	Tags           []string
+}
+
+// Store implements every store interface of package repository.
+type Store struct {
+	mu sync.Mutex
+	// Now stamps created and updated rows; tests may replace it.
+	Now func() time.Time
+
+	roles       map[string]bool
+	members     []model.User
+	tasks       []Task
+	spaces      []model.ProjectNode
+	folders     []model.Folder
+	lists       []model.List
+	admins      []model.Admin
+	syncHistory []model.SyncHistory
+	indicators  []model.KPIIndicator
+	roleWeights []model.KPIRoleWeight
+	scorecards  []model.KPIScorecard
+	itemTasks   map[int64][]model.KPIItemTask
+	roleCompat  map[string][]string
+	suggestions []model.RebalanceSuggestion
+	alertRules  []model.AlertRule
+	alerts      []model.Alert
+	nextID      int64
+
+	periods             []model.AppraisalPeriod
+	appraisalAudit      []model.AppraisalAuditEntry
+	appraisalScorecards map[int64][]model.KPIScorecard
+	appraisalTasks      map[int64][]model.AppraisalTaskSnapshot
+	reviews             []model.AppraisalReview
+
+	objectives []objective
+	keyResults []model.KeyResult
+
+	budgets      []model.ProjectBudget
+	budgetAlerts []model.BudgetAlert
+
+	sprints       []model.Sprint
+	statusHistory []model.TaskStatusChange
+
+	subscriptions []model.NotificationSubscription
+	deliveries    []model.NotificationDelivery
+
+	reportDefinitions []model.ReportDefinition
+	reportRuns        []model.ReportRun
+
+	taskStatuses map[string]model.TaskStatus
+}
+
+var (
+	_ repository.TaskStore         = (*Store)(nil)
+	_ repository.MemberStore       = (*Store)(nil)
+	_ repository.HierarchyStore    = (*Store)(nil)
+	_ repository.SyncHistoryStore  = (*Store)(nil)
+	_ repository.AdminStore        = (*Store)(nil)
+	_ repository.KPIStore          = (*Store)(nil)
+	_ repository.RebalanceStore    = (*Store)(nil)
+	_ repository.AlertStore        = (*Store)(nil)
+	_ repository.AppraisalStore    = (*Store)(nil)
+	_ repository.OKRStore          = (*Store)(nil)
+	_ repository.BudgetStore       = (*Store)(nil)
+	_ repository.SprintStore       = (*Store)(nil)
+	_ repository.EstimateStore     = (*Store)(nil)
+	_ repository.NotificationStore = (*Store)(nil)
+	_ repository.ReportStore       = (*Store)(nil)
+	_ repository.ListingStore      = (*Store)(nil)
+	_ repository.ResourceStore     = (*Store)(nil)
+	_ repository.ClickUpSyncStore  = (*Store)(nil)
+)
+
+func New() *Store {
+	return &Store{
+		Now:                 time.Now,
+		roles:               map[string]bool{},
+		itemTasks:           map[int64][]model.KPIItemTask{},
+		roleCompat:          map[string][]string{},
+		appraisalScorecards: map[int64][]model.KPIScorecard{},
+		appraisalTasks:      map[int64][]model.AppraisalTaskSnapshot{},
+		taskStatuses:        map[string]model.TaskStatus{},
+	}
+}
+
+func (s *Store) id() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+// Seeding. Rows are stored as given; an existing id is replaced.
+
+// AddRole registers a role, as a row of the roles table.
+func (s *Store) AddRole(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roles[name] = true
+}
+
+// AddMember stores a user; its role is registered too.
+func (s *Store) AddMember(u model.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u.Role != "" {
+		s.roles[u.Role] = true
+	}
+	for i := range s.members {
+		if s.members[i].ClickUpID == u.ClickUpID {
+			s.members[i] = u
+			return
+		}
+	}
+	s.members = append(s.members, u)
+}
+
+func (s *Store) AddTask(t Task) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.tasks {
+		if s.tasks[i].ID == t.ID {
+			s.tasks[i] = t
+			return
+		}
+	}
+	s.tasks = append(s.tasks, t)
+}
+
+func (s *Store) AddSpace(n model.ProjectNode) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spaces = append(s.spaces, n)
+}
+
+// AddFolder stores a folder under f.Space.ID.
+func (s *Store) AddFolder(f model.Folder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.folders = append(s.folders, f)
+}
+
+// AddList stores a list under l.FolderID and l.SpaceID.
+func (s *Store) AddList(l model.List) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lists = append(s.lists, l)
+}
+
+func (s *Store) AddAdmin(a model.Admin) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.admins = append(s.admins, a)
+}
+
+func (s *Store) AddIndicator(ind model.KPIIndicator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.indicators = append(s.indicators, ind)
+}
+
+// MemberStore
+
+func (s *Store) GetMembers(ctx context.Context) ([]model.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := append([]model.User(nil), s.members...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (s *Store) GetMember(ctx context.Context, id int64) (*model.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if u, ok := s.member(id); ok {
+		return &u, nil
+	}
+	return nil, nil
+}
+
+func (s *Store) member(id int64) (model.User, bool) {
+	for _, u := range s.members {
+		if u.ClickUpID == id {
+			return u, true
+		}
+	}
+	return model.User{}, false
+}
+
+// activeMembers returns the active members ordered by name.
+func (s *Store) activeMembers() []model.User {
+	var out []model.User
+	for _, u := range s.members {
+		if u.Status == ActiveStatus {
+			out = append(out, u)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// roleName resolves a role case-insensitively, like the ILIKE lookups in the
+// repository.
+func (s *Store) roleName(name string) (string, bool) {
+	for r := range s.roles {
+		if strings.EqualFold(r, name) {
+			return r, true
+		}
+	}
+	return "", false
+}
+
+// HierarchyStore
+
+func (s *Store) GetSpaces(ctx context.Context) ([]model.SpaceInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []model.SpaceInfo
+	for _, n := range sortedNodes(s.spaces) {
+		out = append(out, model.SpaceInfo{ID: n.ID, Name: n.Name})
+	}
+	return out, nil
+}
+
+func (s *Store) GetFolders(ctx context.Context) ([]model.Folder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := append([]model.Folder(nil), s.folders...)
+	for i := range out {
+		out[i].Space.Name = s.spaceName(out[i].Space.ID)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Space.Name != out[j].Space.Name {
+			return out[i].Space.Name < out[j].Space.Name
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+func (s *Store) GetLists(ctx context.Context) ([]model.List, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := append([]model.List(nil), s.lists...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+func (s *Store) GetProjectNodes(ctx context.Context, level string) ([]model.ProjectNode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nodes, err := s.nodes(level)
+	if err != nil {
+		return nil, err
+	}
+	return sortedNodes(nodes), nil
+}
+
+func (s *Store) GetProjectNode(ctx context.Context, level, id string) (*model.ProjectNode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nodes, err := s.nodes(level)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.ID == id {
+			return &n, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetProjectTasks returns tasks with their list, folder and space; an empty
+// level returns every task.
+func (s *Store) GetProjectTasks(ctx context.Context, level, id string) ([]model.ProjectTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.nodes(level); level != "" && err != nil {
+		return nil, err
+	}
+
+	out := []model.ProjectTask{}
+	for _, t := range s.sortedTasks(func(a, b Task) bool { return a.ID < b.ID }) {
+		pt := model.ProjectTask{
+			TaskID:        t.ID,
+			TaskName:      t.Name,
+			ListID:        t.ListID,
+			StatusName:    t.Status.Name,
+			StatusType:    t.Status.Type,
+			DueDate:       t.DueDate,
+			DateDone:      firstTime(t.DateDone, t.DateClosed),
+			EstimateHours: t.EstimateHours,
+			SpentHours:    t.SpentHours,
+			Assignees:     []model.ProjectAssignee{},
+		}
+		if l, ok := s.list(t.ListID); ok {
+			pt.ListName = l.Name
+			pt.FolderID = l.FolderID
+			pt.SpaceID = l.SpaceID
+		}
+		if f, ok := s.folder(pt.FolderID); ok {
+			pt.FolderName = f.Name
+			if pt.SpaceID == "" {
+				pt.SpaceID = f.Space.ID
+			}
+		}
+		pt.SpaceName = s.spaceName(pt.SpaceID)
+
+		switch level {
+		case model.ProjectLevelSpace:
+			if pt.SpaceID != id {
+				continue
+			}
+		case model.ProjectLevelFolder:
+			if pt.FolderID != id {
+				continue
+			}
+		case model.ProjectLevelList:
+			if pt.ListID != id {
+				continue
+			}
+		}
+
+		for _, uid := range sortedIDs(t.Assignees) {
+			u, _ := s.member(uid)
+			pt.Assignees = append(pt.Assignees, model.ProjectAssignee{UserID: uid, Name: u.Name})
+		}
+		out = append(out, pt)
+	}
+	return out, nil
+}
+
+func (s *Store) nodes(level string) ([]model.ProjectNode, error) {
+	switch level {
+	case model.ProjectLevelSpace:
+		return s.spaces, nil
+	case model.ProjectLevelFolder:
+		out := make([]model.ProjectNode, len(s.folders))
+		for i, f := range s.folders {
+			out[i] = model.ProjectNode{ID: f.ID, Name: f.Name, Archived: f.Archived}
+		}
+		return out, nil
+	case model.ProjectLevelList:
+		out := make([]model.ProjectNode, len(s.lists))
+		for i, l := range s.lists {
+			out[i] = model.ProjectNode{ID: l.ID, Name: l.Name, Archived: l.Archived}
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("unknown project level %q", level)
+}
+
+func (s *Store) spaceName(id string) string {
+	for _, n := range s.spaces {
+		if n.ID == id {
+			return n.Name
+		}
+	}
+	return ""
+}
+
+func (s *Store) folder(id string) (model.Folder, bool) {
+	for _, f := range s.folders {
+		if f.ID == id && id != "" {
+			return f, true
+		}
+	}
+	return model.Folder{}, false
+}
+
+func (s *Store) list(id string) (model.List, bool) {
+	for _, l := range s.lists {
+		if l.ID == id && id != "" {
+			return l, true
+		}
+	}
+	return model.List{}, false
+}
+
+func sortedNodes(nodes []model.ProjectNode) []model.ProjectNode {
+	out := append([]model.ProjectNode{}, nodes...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// AdminStore
+
+func (s *Store) GetAdminByUsername(ctx context.Context, username string) (*model.Admin, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range s.admins {
+		if a.Username == username {
+			return &a, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s *Store) UpdateAdminLanguage(ctx context.Context, id, lang string) (*model.Admin, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.admins {
+		if s.admins[i].ID == id {
+			s.admins[i].Language = lang
+			return &model.Admin{ID: id, Username: s.admins[i].Username, Language: lang, Role: s.admins[i].Role, UserID: s.admins[i].UserID}, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// SaveAdmin upserts by username; new accounts get the next "admin-N" id.
+func (s *Store) SaveAdmin(ctx context.Context, a *model.Admin) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a.UserID != nil {
+		if _, ok := s.member(*a.UserID); !ok {
+			return sql.ErrNoRows
+		}
+	}
+	for i := range s.admins {
+		if s.admins[i].Username == a.Username {
+			s.admins[i].PasswordHash, s.admins[i].Role, s.admins[i].UserID = a.PasswordHash, a.Role, a.UserID
+			a.ID, a.Language = s.admins[i].ID, s.admins[i].Language
+			return nil
+		}
+	}
+	a.ID = fmt.Sprintf("admin-%d", len(s.admins)+1)
+	if a.Language == "" {
+		a.Language = "en"
+	}
+	s.admins = append(s.admins, *a)
+	return nil
+}
+
+// SyncHistoryStore
+
+var syncHistorySpec = query.MemorySpec[model.SyncHistory]{
+	Sort: map[string]func(a, b model.SyncHistory) int{
+		"sync_time":   func(a, b model.SyncHistory) int { return a.SyncTime.Compare(b.SyncTime) },
+		"sync_type":   func(a, b model.SyncHistory) int { return strings.Compare(a.SyncType, b.SyncType) },
+		"status":      func(a, b model.SyncHistory) int { return strings.Compare(a.Status, b.Status) },
+		"duration_ms": func(a, b model.SyncHistory) int { return query.CompareInt(int(a.DurationMs), int(b.DurationMs)) },
+	},
+	DefaultSort: []query.SortField{{Field: "sync_time", Desc: true}},
+	Filters: map[string]func(model.SyncHistory) []string{
+		query.FilterStatus: func(h model.SyncHistory) []string { return []string{h.Status} },
+	},
+	Dates: map[string]func(model.SyncHistory) *time.Time{
+		"sync_time": func(h model.SyncHistory) *time.Time { return &h.SyncTime },
+	},
+	Search:       func(h model.SyncHistory) []string { return []string{h.SyncType, h.Status} },
+	DefaultLimit: 20,
+}
+
+func (s *Store) CreateSyncHistory(ctx context.Context, syncType, status string, durationMs int64, details []byte) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := model.SyncHistory{ID: s.id(), SyncTime: s.Now(), SyncType: syncType, Status: status, DurationMs: durationMs}
+	if details != nil {
+		h.Details = append([]byte(nil), details...)
+	}
+	s.syncHistory = append(s.syncHistory, h)
+	return h.ID, nil
+}
+
+func (s *Store) GetSyncHistoryPage(ctx context.Context, p query.Params) ([]model.SyncHistory, query.Page, error) {
+	s.mu.Lock()
+	items := append([]model.SyncHistory(nil), s.syncHistory...)
+	s.mu.Unlock()
+	return query.Apply(items, p, syncHistorySpec)
+}
+
+func firstTime(ts ...*time.Time) *time.Time {
+	for _, t := range ts {
+		if t != nil {
+			return t
+		}
+	}
+	return nil
+}
+
+func sortedIDs(ids []int64) []int64 {
+	out := append([]int64(nil), ids...)
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
@@ -0,0 +1,266 @@
+package memstore
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// subscriberOK checks the subscription's user exists and resolves the role of
+// a role subscription, as the foreign keys and role lookup do.
+func (s *Store) subscriberOK(sub *model.NotificationSubscription) bool {
+	if sub.UserID != nil {
+		if _, ok := s.member(*sub.UserID); !ok {
+			return false
+		}
+	}
+	if sub.SubscriberType != model.SubscriberTypeRole {
+		sub.Role = ""
+		return true
+	}
+	r, ok := s.roleName(sub.Role)
+	if ok {
+		sub.Role = r
+	}
+	return ok
+}
+
+// CreateNotificationSubscription returns sql.ErrNoRows when the user or role
+// does not exist.
+func (s *Store) CreateNotificationSubscription(ctx context.Context, sub *model.NotificationSubscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.subscriberOK(sub) {
+		return sql.ErrNoRows
+	}
+	now := s.Now()
+	sub.ID, sub.CreatedAt, sub.UpdatedAt = s.id(), now, now
+	s.subscriptions = append(s.subscriptions, *sub)
+	return nil
+}
+
+func (s *Store) UpdateNotificationSubscription(ctx context.Context, sub *model.NotificationSubscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.subscriptions, func(old model.NotificationSubscription) bool { return old.ID == sub.ID })
+	if i < 0 || !s.subscriberOK(sub) {
+		return sql.ErrNoRows
+	}
+	sub.CreatedAt, sub.UpdatedAt = s.subscriptions[i].CreatedAt, s.Now()
+	s.subscriptions[i] = *sub
+	return nil
+}
+
+func (s *Store) DeleteNotificationSubscription(ctx context.Context, id int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.subscriptions)
+	s.subscriptions = slices.DeleteFunc(s.subscriptions, func(sub model.NotificationSubscription) bool { return sub.ID == id })
+	return len(s.subscriptions) < n, nil
+}
+
+// GetNotificationSubscriptions orders by id.
+func (s *Store) GetNotificationSubscriptions(ctx context.Context) ([]model.NotificationSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.NotificationSubscription{}
+	for _, sub := range s.subscriptions {
+		out = append(out, s.subscriptionView(sub))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (s *Store) GetNotificationSubscription(ctx context.Context, id int64) (*model.NotificationSubscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range s.subscriptions {
+		if sub.ID == id {
+			v := s.subscriptionView(sub)
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Store) subscriptionView(sub model.NotificationSubscription) model.NotificationSubscription {
+	sub.UserName = ""
+	if sub.UserID != nil {
+		u, _ := s.member(*sub.UserID)
+		sub.UserName = u.Name
+	}
+	sub.Events = append([]string{}, sub.Events...)
+	return sub
+}
+
+// GetNotificationRecipients resolves the active subscriptions of an event in
+// id order. A role email subscription without target fans out to the
+// addresses of the role's active members; rows without a target are dropped.
+func (s *Store) GetNotificationRecipients(ctx context.Context, event string, aud model.NotificationAudience) ([]model.NotificationRecipient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subs := append([]model.NotificationSubscription{}, s.subscriptions...)
+	sort.SliceStable(subs, func(i, j int) bool { return subs[i].ID < subs[j].ID })
+
+	out := []model.NotificationRecipient{}
+	for _, sub := range subs {
+		if !sub.Active || len(sub.Events) > 0 && !slices.Contains(sub.Events, event) {
+			continue
+		}
+		if aud.UserID != nil && (sub.SubscriberType != model.SubscriberTypeUser || derefID(sub.UserID) != *aud.UserID) {
+			continue
+		}
+		add := func(target, role string) {
+			if target == "" || aud.Role != "" && !strings.EqualFold(role, aud.Role) {
+				return
+			}
+			out = append(out, model.NotificationRecipient{
+				SubscriptionID: sub.ID, Channel: sub.Channel, Target: target, QuietStart: sub.QuietStart, QuietEnd: sub.QuietEnd,
+			})
+		}
+		switch {
+		case sub.SubscriberType == model.SubscriberTypeUser:
+			u, _ := s.member(derefID(sub.UserID))
+			target := sub.Target
+			if target == "" {
+				target = u.Email
+			}
+			add(target, u.Role)
+		case sub.Channel == "email" && sub.Target == "":
+			for _, u := range s.activeMembers() {
+				if strings.EqualFold(u.Role, sub.Role) {
+					add(u.Email, sub.Role)
+				}
+			}
+		default:
+			add(sub.Target, sub.Role)
+		}
+	}
+	return out, nil
+}
+
+func (s *Store) CreateNotificationDelivery(ctx context.Context, d *model.NotificationDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d.ID, d.Status, d.CreatedAt = s.id(), model.DeliveryStatusPending, s.Now()
+	s.deliveries = append(s.deliveries, *d)
+	return nil
+}
+
+// ClaimDueDeliveries takes due pending deliveries, oldest attempt first, and
+// pushes their next attempt past the lease.
+func (s *Store) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.NotificationDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.Now()
+	var due []*model.NotificationDelivery
+	for i := range s.deliveries {
+		d := &s.deliveries[i]
+		if d.Status == model.DeliveryStatusPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(*due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(*due[j].NextAttemptAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+
+	out := []model.NotificationDelivery{}
+	for _, d := range due {
+		if len(out) == limit {
+			break
+		}
+		next := now.Add(lease)
+		d.NextAttemptAt = &next
+		out = append(out, *d)
+	}
+	return out, nil
+}
+
+func (s *Store) delivery(id int64) *model.NotificationDelivery {
+	for i := range s.deliveries {
+		if s.deliveries[i].ID == id {
+			return &s.deliveries[i]
+		}
+	}
+	return nil
+}
+
+func (s *Store) MarkDeliverySent(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d := s.delivery(id); d != nil {
+		now := s.Now()
+		d.Status, d.LastError, d.SentAt, d.NextAttemptAt = model.DeliveryStatusSent, "", &now, nil
+		d.Attempts++
+	}
+	return nil
+}
+
+// MarkDeliveryFailed records a failed attempt; a nil next fails it for good.
+func (s *Store) MarkDeliveryFailed(ctx context.Context, id int64, errMsg string, next *time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d := s.delivery(id); d != nil {
+		d.Status = model.DeliveryStatusPending
+		if next == nil {
+			d.Status = model.DeliveryStatusFailed
+		}
+		d.LastError, d.NextAttemptAt = errMsg, next
+		d.Attempts++
+	}
+	return nil
+}
+
+// RequeueDelivery returns sql.ErrNoRows when the delivery does not exist or
+// was already sent.
+func (s *Store) RequeueDelivery(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d := s.delivery(id)
+	if d == nil || d.Status == model.DeliveryStatusSent {
+		return sql.ErrNoRows
+	}
+	now := s.Now()
+	d.Status, d.NextAttemptAt = model.DeliveryStatusPending, &now
+	return nil
+}
+
+// GetNotificationDeliveries orders by creation, newest first.
+func (s *Store) GetNotificationDeliveries(ctx context.Context, status string, limit int) ([]model.NotificationDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.NotificationDelivery{}
+	for _, d := range s.deliveries {
+		if status == "" || d.Status == status {
+			out = append(out, d)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.After(out[j].CreatedAt)
+		}
+		return out[i].ID > out[j].ID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (s *Store) GetNotificationDelivery(ctx context.Context, id int64) (*model.NotificationDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d := s.delivery(id); d != nil {
+		v := *d
+		return &v, nil
+	}
+	return nil, nil
+}
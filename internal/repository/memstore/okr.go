@@ -0,0 +1,293 @@
+package memstore
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// objective is a row of okr_objectives; key results are kept separately.
+type objective struct {
+	model.Objective
+	start, end time.Time
+}
+
+// ownerOK checks the owner member exists, or resolves a role objective's role
+// case-insensitively like the ILIKE in the repository.
+func (s *Store) ownerOK(o *model.Objective) bool {
+	if o.OwnerType != model.OKROwnerRole {
+		_, ok := s.member(derefID(o.OwnerUserID))
+		return ok
+	}
+	r, ok := s.roleName(o.OwnerRole)
+	if ok {
+		o.OwnerRole = r
+	}
+	return ok
+}
+
+func derefID(id *int64) int64 {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
+
+func (s *Store) CreateObjective(ctx context.Context, o *model.Objective, start, end time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ownerOK(o) {
+		return sql.ErrNoRows
+	}
+	now := s.Now()
+	o.ID, o.CreatedAt, o.UpdatedAt = s.id(), now, now
+	s.objectives = append(s.objectives, objective{Objective: *o, start: start, end: end})
+	return nil
+}
+
+func (s *Store) UpdateObjective(ctx context.Context, o *model.Objective, start, end time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.objectives {
+		if s.objectives[i].ID != o.ID {
+			continue
+		}
+		if !s.ownerOK(o) {
+			return sql.ErrNoRows
+		}
+		o.CreatedAt, o.UpdatedAt = s.objectives[i].CreatedAt, s.Now()
+		s.objectives[i] = objective{Objective: *o, start: start, end: end}
+		return nil
+	}
+	return sql.ErrNoRows
+}
+
+// DeleteObjective removes its key results too, as the cascade does.
+func (s *Store) DeleteObjective(ctx context.Context, id int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.objectives)
+	s.objectives = slices.DeleteFunc(s.objectives, func(o objective) bool { return o.ID == id })
+	s.keyResults = slices.DeleteFunc(s.keyResults, func(kr model.KeyResult) bool { return kr.ObjectiveID == id })
+	return len(s.objectives) < n, nil
+}
+
+// GetObjectives orders by period start, latest first, then id. A member
+// filter also matches objectives owned by the member's role.
+func (s *Store) GetObjectives(ctx context.Context, period string, userID *int64, role string) ([]model.Objective, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var member model.User
+	if userID != nil {
+		member, _ = s.member(*userID)
+	}
+
+	var rows []objective
+	for _, o := range s.objectives {
+		if period != "" && o.Period != period {
+			continue
+		}
+		if userID != nil && derefID(o.OwnerUserID) != *userID && (o.OwnerRole == "" || o.OwnerRole != member.Role) {
+			continue
+		}
+		if role != "" && !strings.EqualFold(o.OwnerRole, role) {
+			continue
+		}
+		rows = append(rows, o)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if !rows[i].start.Equal(rows[j].start) {
+			return rows[i].start.After(rows[j].start)
+		}
+		return rows[i].ID < rows[j].ID
+	})
+
+	out := []model.Objective{}
+	for _, o := range rows {
+		out = append(out, s.objectiveView(o))
+	}
+	return out, nil
+}
+
+func (s *Store) GetObjective(ctx context.Context, id int64) (*model.Objective, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, o := range s.objectives {
+		if o.ID == id {
+			v := s.objectiveView(o)
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Store) objectiveView(o objective) model.Objective {
+	v := o.Objective
+	v.OwnerName = ""
+	if v.OwnerUserID != nil {
+		u, _ := s.member(*v.OwnerUserID)
+		v.OwnerName = u.Name
+	}
+	v.KeyResults = []model.KeyResult{}
+	for _, kr := range s.keyResults {
+		if kr.ObjectiveID == o.ID {
+			kr.Links = append([]model.KeyResultLink{}, kr.Links...)
+			v.KeyResults = append(v.KeyResults, kr)
+		}
+	}
+	return v
+}
+
+func (s *Store) GetObjectiveRange(ctx context.Context, id int64) (time.Time, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, o := range s.objectives {
+		if o.ID == id {
+			return o.start, o.end, nil
+		}
+	}
+	return time.Time{}, time.Time{}, sql.ErrNoRows
+}
+
+// linkIDs assigns ids to new links and drops duplicates, like the upsert.
+func (s *Store) linkIDs(kr *model.KeyResult) {
+	var links []model.KeyResultLink
+	for _, l := range kr.Links {
+		if slices.ContainsFunc(links, func(x model.KeyResultLink) bool {
+			return x.LinkType == l.LinkType && x.LinkValue == l.LinkValue
+		}) {
+			continue
+		}
+		l.ID, l.KeyResultID = s.id(), kr.ID
+		links = append(links, l)
+	}
+	kr.Links = links
+}
+
+func (s *Store) CreateKeyResult(ctx context.Context, kr *model.KeyResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !slices.ContainsFunc(s.objectives, func(o objective) bool { return o.ID == kr.ObjectiveID }) {
+		return sql.ErrNoRows
+	}
+	now := s.Now()
+	kr.ID, kr.CreatedAt, kr.UpdatedAt = s.id(), now, now
+	s.linkIDs(kr)
+	s.keyResults = append(s.keyResults, *kr)
+	return nil
+}
+
+func (s *Store) UpdateKeyResult(ctx context.Context, kr *model.KeyResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.keyResults {
+		old := s.keyResults[i]
+		if old.ID != kr.ID {
+			continue
+		}
+		kr.ObjectiveID, kr.CreatedAt, kr.UpdatedAt = old.ObjectiveID, old.CreatedAt, s.Now()
+		s.linkIDs(kr)
+		s.keyResults[i] = *kr
+		return nil
+	}
+	return sql.ErrNoRows
+}
+
+func (s *Store) DeleteKeyResult(ctx context.Context, id int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.keyResults)
+	s.keyResults = slices.DeleteFunc(s.keyResults, func(kr model.KeyResult) bool { return kr.ID == id })
+	return len(s.keyResults) < n, nil
+}
+
+func (s *Store) GetKeyResult(ctx context.Context, id int64) (*model.KeyResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, kr := range s.keyResults {
+		if kr.ID == id {
+			kr.Links = append([]model.KeyResultLink{}, kr.Links...)
+			return &kr, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetKeyResultTasks counts task links always and list, folder and tag links
+// only for tasks overlapping the period; tasks are ordered by due date
+// (NULLs last), then id.
+func (s *Store) GetKeyResultTasks(ctx context.Context, keyResultIDs []int64, start, end time.Time) (map[int64][]model.OKRTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[int64][]model.OKRTask, len(keyResultIDs))
+	tasks := s.sortedTasks(func(a, b Task) bool {
+		if c := compareTime(a.DueDate, b.DueDate); c != 0 {
+			return c < 0
+		}
+		return a.ID < b.ID
+	})
+
+	for _, id := range keyResultIDs {
+		out[id] = []model.OKRTask{}
+		i := slices.IndexFunc(s.keyResults, func(kr model.KeyResult) bool { return kr.ID == id })
+		if i < 0 {
+			continue
+		}
+		for _, t := range tasks {
+			if s.linked(s.keyResults[i].Links, t, start, end) {
+				out[id] = append(out[id], okrTask(t))
+			}
+		}
+	}
+	return out, nil
+}
+
+func (s *Store) linked(links []model.KeyResultLink, t Task, start, end time.Time) bool {
+	l, _ := s.list(t.ListID)
+	for _, k := range links {
+		if k.LinkType == model.OKRLinkTask && k.LinkValue == t.ID {
+			return true
+		}
+		if !overlaps(t, start, end) {
+			continue
+		}
+		switch k.LinkType {
+		case model.OKRLinkList:
+			if t.ListID != "" && k.LinkValue == t.ListID {
+				return true
+			}
+		case model.OKRLinkFolder:
+			if l.FolderID != "" && k.LinkValue == l.FolderID {
+				return true
+			}
+		case model.OKRLinkTag:
+			if slices.Contains(t.Tags, k.LinkValue) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func okrTask(t Task) model.OKRTask {
+	ot := model.OKRTask{
+		TaskID:        t.ID,
+		TaskName:      t.Name,
+		StatusName:    t.Status.Name,
+		StatusType:    t.Status.Type,
+		DueDate:       t.DueDate,
+		DateDone:      firstTime(t.DateDone, t.DateClosed),
+		EstimateHours: t.EstimateHours,
+		SpentHours:    t.SpentHours,
+	}
+	if t.ListID != "" {
+		id := t.ListID
+		ot.ListID = &id
+	}
+	return ot
+}
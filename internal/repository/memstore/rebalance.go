@@ -0,0 +1,142 @@
+package memstore
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// AddRoleCompatibility lets members of role `to` take over tasks of role
+// `from`, as a row of the role_compatibility table.
+func (s *Store) AddRoleCompatibility(from, to string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.roles[from], s.roles[to] = true, true
+	s.roleCompat[from] = append(s.roleCompat[from], to)
+}
+
+func (s *Store) GetRoleCompatibility(ctx context.Context) (map[string][]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := map[string][]string{}
+	for from, to := range s.roleCompat {
+		out[from] = append([]string(nil), to...)
+		sort.Strings(out[from])
+	}
+	return out, nil
+}
+
+func (s *Store) ReplacePendingSuggestions(ctx context.Context, suggestions []model.RebalanceSuggestion) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	kept := s.suggestions[:0]
+	for _, sg := range s.suggestions {
+		if sg.Status != "pending" {
+			kept = append(kept, sg)
+		}
+	}
+	s.suggestions = kept
+	for i := range suggestions {
+		sg := &suggestions[i]
+		sg.ID, sg.Status, sg.CreatedAt = s.id(), "pending", s.Now()
+		s.suggestions = append(s.suggestions, *sg)
+	}
+	return nil
+}
+
+// GetRebalanceSuggestions orders by score, highest first, then id.
+func (s *Store) GetRebalanceSuggestions(ctx context.Context, status string) ([]model.RebalanceSuggestion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []model.RebalanceSuggestion
+	for _, sg := range s.suggestions {
+		if status == "" || sg.Status == status {
+			out = append(out, s.suggestionView(sg))
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Score != out[j].Score {
+			return out[i].Score > out[j].Score
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+func (s *Store) GetRebalanceSuggestion(ctx context.Context, id int64) (*model.RebalanceSuggestion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sg := range s.suggestions {
+		if sg.ID == id {
+			v := s.suggestionView(sg)
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+// suggestionView fills the task and member columns the repository joins in.
+func (s *Store) suggestionView(sg model.RebalanceSuggestion) model.RebalanceSuggestion {
+	sg.TaskName, sg.DueDate = "", nil
+	if t, ok := s.task(sg.TaskID); ok {
+		sg.TaskName, sg.DueDate = t.Name, t.DueDate
+	}
+	from, _ := s.member(sg.FromUserID)
+	to, _ := s.member(sg.ToUserID)
+	sg.FromName, sg.ToName, sg.Role = from.Name, to.Name, to.Role
+	return sg
+}
+
+// SetSuggestionStatus supersedes the other pending suggestions for the task
+// when one is accepted.
+func (s *Store) SetSuggestionStatus(ctx context.Context, id int64, status string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.Now()
+	for i := range s.suggestions {
+		if s.suggestions[i].ID != id {
+			continue
+		}
+		s.suggestions[i].Status, s.suggestions[i].DecidedAt = status, &now
+		if status == "accepted" {
+			for j := range s.suggestions {
+				sg := &s.suggestions[j]
+				if sg.TaskID == s.suggestions[i].TaskID && sg.ID != id && sg.Status == "pending" {
+					sg.Status, sg.DecidedAt = "superseded", &now
+				}
+			}
+		}
+		return nil
+	}
+	return sql.ErrNoRows
+}
+
+func (s *Store) ReassignTask(ctx context.Context, taskID string, fromUserID, toUserID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.tasks {
+		t := &s.tasks[i]
+		if t.ID != taskID {
+			continue
+		}
+		var ids []int64
+		for _, id := range t.Assignees {
+			if id != fromUserID && id != toUserID {
+				ids = append(ids, id)
+			}
+		}
+		t.Assignees = append(ids, toUserID)
+	}
+	return nil
+}
+
+func (s *Store) task(id string) (Task, bool) {
+	for _, t := range s.tasks {
+		if t.ID == id {
+			return t, true
+		}
+	}
+	return Task{}, false
+}
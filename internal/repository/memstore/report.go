@@ -0,0 +1,116 @@
+package memstore
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+func (s *Store) CreateReportDefinition(ctx context.Context, d *model.ReportDefinition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.Now()
+	d.ID, d.CreatedAt, d.UpdatedAt = s.id(), now, now
+	s.reportDefinitions = append(s.reportDefinitions, *d)
+	return nil
+}
+
+// UpdateReportDefinition keeps the last run time, which only claiming sets.
+func (s *Store) UpdateReportDefinition(ctx context.Context, d *model.ReportDefinition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.reportDefinitions {
+		old := &s.reportDefinitions[i]
+		if old.ID == d.ID {
+			d.CreatedAt, d.UpdatedAt = old.CreatedAt, s.Now()
+			last := old.LastRunAt
+			*old = *d
+			old.LastRunAt = last
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+// DeleteReportDefinition removes its runs too, as the cascade does.
+func (s *Store) DeleteReportDefinition(ctx context.Context, id int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.reportDefinitions)
+	s.reportDefinitions = slices.DeleteFunc(s.reportDefinitions, func(d model.ReportDefinition) bool { return d.ID == id })
+	s.reportRuns = slices.DeleteFunc(s.reportRuns, func(r model.ReportRun) bool { return r.DefinitionID == id })
+	return len(s.reportDefinitions) < n, nil
+}
+
+// GetReportDefinitions orders by id.
+func (s *Store) GetReportDefinitions(ctx context.Context) ([]model.ReportDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := append([]model.ReportDefinition{}, s.reportDefinitions...)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (s *Store) GetReportDefinition(ctx context.Context, id int64) (*model.ReportDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range s.reportDefinitions {
+		if d.ID == id {
+			return &d, nil
+		}
+	}
+	return nil, nil
+}
+
+// ClaimDueReports advances the schedule of active definitions due at now and
+// returns them with their previous schedule, earliest first.
+func (s *Store) ClaimDueReports(ctx context.Context, now time.Time, next func(model.ReportDefinition) time.Time) ([]model.ReportDefinition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	claimed := []model.ReportDefinition{}
+	for i := range s.reportDefinitions {
+		d := &s.reportDefinitions[i]
+		if !d.Active || d.NextRunAt == nil || d.NextRunAt.After(now) {
+			continue
+		}
+		claimed = append(claimed, *d)
+		n, ran := next(*d), s.Now()
+		d.NextRunAt, d.LastRunAt = &n, &ran
+	}
+	sort.SliceStable(claimed, func(i, j int) bool { return claimed[i].NextRunAt.Before(*claimed[j].NextRunAt) })
+	return claimed, nil
+}
+
+func (s *Store) CreateReportRun(ctx context.Context, run *model.ReportRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	run.ID, run.CreatedAt = s.id(), s.Now()
+	s.reportRuns = append(s.reportRuns, *run)
+	return nil
+}
+
+// GetReportRuns returns the latest 100 runs, newest first.
+func (s *Store) GetReportRuns(ctx context.Context, definitionID int64) ([]model.ReportRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.ReportRun{}
+	for _, r := range s.reportRuns {
+		if r.DefinitionID == definitionID {
+			out = append(out, r)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.After(out[j].CreatedAt)
+		}
+		return out[i].ID > out[j].ID
+	})
+	if len(out) > 100 {
+		out = out[:100]
+	}
+	return out, nil
+}
@@ -0,0 +1,115 @@
+package memstore
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+)
+
+var taskResourceSpec = taskSpec(map[string]string{
+	"start": "start_at", "due": "due_at", "done": "done_at", "closed": "closed_at",
+	"created": "created_at", "updated": "updated_at", "estimate": "estimate_hours", "spent": "spent_hours",
+}, query.SortField{Field: "updated_at", Desc: true})
+
+func (s *Store) GetTaskResourcesPage(ctx context.Context, p query.Params) ([]model.TaskResponse, query.Page, error) {
+	return s.pageTasks(p, taskResourceSpec)
+}
+
+func (s *Store) GetTask(ctx context.Context, id string) (*model.TaskResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if t, ok := s.task(id); ok {
+		r := s.taskResponse(t)
+		return &r, nil
+	}
+	return nil, nil
+}
+
+// GetMembersByID orders by name, then id; unknown ids are skipped.
+func (s *Store) GetMembersByID(ctx context.Context, ids []int64) ([]model.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.User{}
+	for _, u := range s.members {
+		if slices.Contains(ids, u.ClickUpID) {
+			out = append(out, u)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if c := query.CompareString(out[i].Name, out[j].Name); c != 0 {
+			return c < 0
+		}
+		return out[i].ClickUpID < out[j].ClickUpID
+	})
+	return out, nil
+}
+
+// GetTasksForMembers keys the tasks overlapping the period by each given
+// assignee, ordered by due date (undated last), then id.
+func (s *Store) GetTasksForMembers(ctx context.Context, ids []int64, start, end time.Time) (map[int64][]model.TaskResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[int64][]model.TaskResponse, len(ids))
+	for _, t := range s.sortedTasks(func(a, b Task) bool {
+		if c := compareTime(a.DueDate, b.DueDate); c != 0 {
+			return c < 0
+		}
+		return a.ID < b.ID
+	}) {
+		if !overlaps(t, start, end) {
+			continue
+		}
+		for _, uid := range t.Assignees {
+			if slices.Contains(ids, uid) {
+				out[uid] = append(out[uid], s.taskResponse(t))
+			}
+		}
+	}
+	return out, nil
+}
+
+// GetProjectTree returns spaces, folders and lists with Level and ParentID,
+// ordered by name, then id. An empty level returns every level; ids, when
+// not nil, limits the result.
+func (s *Store) GetProjectTree(ctx context.Context, level string, ids []string) ([]model.ProjectNode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if level != "" {
+		if _, err := s.nodes(level); err != nil {
+			return nil, err
+		}
+	}
+
+	var all []model.ProjectNode
+	for _, n := range s.spaces {
+		all = append(all, model.ProjectNode{ID: n.ID, Name: n.Name, Archived: n.Archived, Level: model.ProjectLevelSpace})
+	}
+	for _, f := range s.folders {
+		all = append(all, model.ProjectNode{ID: f.ID, Name: f.Name, Archived: f.Archived, Level: model.ProjectLevelFolder, ParentID: f.Space.ID})
+	}
+	for _, l := range s.lists {
+		parent := l.FolderID
+		if parent == "" {
+			parent = l.SpaceID
+		}
+		all = append(all, model.ProjectNode{ID: l.ID, Name: l.Name, Archived: l.Archived, Level: model.ProjectLevelList, ParentID: parent})
+	}
+
+	out := []model.ProjectNode{}
+	for _, n := range all {
+		if (level == "" || n.Level == level) && (ids == nil || slices.Contains(ids, n.ID)) {
+			out = append(out, n)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if c := query.CompareString(out[i].Name, out[j].Name); c != 0 {
+			return c < 0
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
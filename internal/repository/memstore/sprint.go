@@ -0,0 +1,173 @@
+package memstore
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// RecordTaskStatus appends to the status history unless the last recorded
+// status is the same.
+func (s *Store) RecordTaskStatus(ctx context.Context, taskID, statusID, statusName, statusType string, changedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var last *model.TaskStatusChange
+	for i := range s.statusHistory {
+		c := &s.statusHistory[i]
+		if c.TaskID == taskID && (last == nil || !c.ChangedAt.Before(last.ChangedAt)) {
+			last = c
+		}
+	}
+	if last != nil && last.StatusID == statusID {
+		return nil
+	}
+	s.statusHistory = append(s.statusHistory, model.TaskStatusChange{
+		TaskID: taskID, StatusID: statusID, StatusName: statusName, StatusType: statusType, ChangedAt: changedAt,
+	})
+	return nil
+}
+
+// SaveSprint replaces the sprint of the same list.
+func (s *Store) SaveSprint(ctx context.Context, sp *model.Sprint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saveSprint(sp)
+	return nil
+}
+
+func (s *Store) saveSprint(sp *model.Sprint) {
+	now := s.Now()
+	for i := range s.sprints {
+		old := &s.sprints[i]
+		if old.ListID == sp.ListID {
+			sp.ID, sp.CreatedAt, sp.UpdatedAt = old.ID, old.CreatedAt, now
+			*old = *sp
+			return
+		}
+	}
+	sp.ID, sp.CreatedAt, sp.UpdatedAt = s.id(), now, now
+	s.sprints = append(s.sprints, *sp)
+}
+
+func (s *Store) UpdateSprint(ctx context.Context, sp *model.Sprint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.sprints {
+		if s.sprints[i].ID == sp.ID {
+			sp.CreatedAt, sp.UpdatedAt = s.sprints[i].CreatedAt, s.Now()
+			s.sprints[i] = *sp
+			return nil
+		}
+	}
+	return sql.ErrNoRows
+}
+
+func (s *Store) DeleteSprint(ctx context.Context, id int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.sprints)
+	s.sprints = slices.DeleteFunc(s.sprints, func(sp model.Sprint) bool { return sp.ID == id })
+	return len(s.sprints) < n, nil
+}
+
+// DetectSprints turns dated, unarchived lists in folders named like "sprint"
+// into sprints, leaving manual sprints on the same list alone.
+func (s *Store) DetectSprints(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for _, l := range s.lists {
+		f, ok := s.folder(l.FolderID)
+		if !ok || !strings.Contains(strings.ToLower(f.Name), "sprint") || l.Archived || l.StartDate == nil || l.DueDate == nil {
+			continue
+		}
+		if slices.ContainsFunc(s.sprints, func(sp model.Sprint) bool {
+			return sp.ListID == l.ID && sp.Source != model.SprintSourceClickUp
+		}) {
+			continue
+		}
+		s.saveSprint(&model.Sprint{Name: l.Name, ListID: l.ID, StartDate: *l.StartDate, EndDate: *l.DueDate, Source: model.SprintSourceClickUp})
+		n++
+	}
+	return n, nil
+}
+
+// GetSprints orders by start date, latest first.
+func (s *Store) GetSprints(ctx context.Context) ([]model.Sprint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.Sprint{}
+	for _, sp := range s.sprints {
+		out = append(out, s.sprintView(sp))
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].StartDate.After(out[j].StartDate) })
+	return out, nil
+}
+
+func (s *Store) GetSprint(ctx context.Context, id int64) (*model.Sprint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sp := range s.sprints {
+		if sp.ID == id {
+			v := s.sprintView(sp)
+			return &v, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Store) sprintView(sp model.Sprint) model.Sprint {
+	l, _ := s.list(sp.ListID)
+	sp.ListName = l.Name
+	return sp
+}
+
+// GetSprintTasks orders by creation time (NULLs first), then id.
+func (s *Store) GetSprintTasks(ctx context.Context, listID string) ([]model.SprintTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.SprintTask{}
+	for _, t := range s.sortedTasks(func(a, b Task) bool {
+		if (a.DateCreated == nil) != (b.DateCreated == nil) {
+			return a.DateCreated == nil
+		}
+		if c := compareTime(a.DateCreated, b.DateCreated); c != 0 {
+			return c < 0
+		}
+		return a.ID < b.ID
+	}) {
+		if t.ListID != listID {
+			continue
+		}
+		out = append(out, model.SprintTask{
+			TaskID:        t.ID,
+			TaskName:      t.Name,
+			StatusName:    t.Status.Name,
+			StatusType:    t.Status.Type,
+			EstimateHours: t.EstimateHours,
+			DateCreated:   t.DateCreated,
+			DateDone:      firstTime(t.DateDone, t.DateClosed),
+		})
+	}
+	return out, nil
+}
+
+func (s *Store) GetListStatusHistory(ctx context.Context, listID string) (map[string][]model.TaskStatusChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := map[string][]model.TaskStatusChange{}
+	for _, c := range s.statusHistory {
+		if t, ok := s.task(c.TaskID); ok && t.ListID == listID {
+			out[c.TaskID] = append(out[c.TaskID], c)
+		}
+	}
+	for _, changes := range out {
+		sort.SliceStable(changes, func(i, j int) bool { return changes[i].ChangedAt.Before(changes[j].ChangedAt) })
+	}
+	return out, nil
+}
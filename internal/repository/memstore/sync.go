@@ -0,0 +1,186 @@
+package memstore
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// UpsertTeam is a no-op, as in the repository.
+func (s *Store) UpsertTeam(ctx context.Context, teamID, name, parentID string) error {
+	return nil
+}
+
+// UpsertUser resolves the role among the registered roles; an unknown role
+// keeps the member's current one.
+func (s *Store) UpsertUser(ctx context.Context, u *model.User) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.Now()
+	role, ok := s.roleName(u.Role)
+	for i := range s.members {
+		m := &s.members[i]
+		if m.ClickUpID == u.ClickUpID {
+			m.Name, m.Email, m.Status, m.UpdatedAt = u.Name, u.Email, u.Status, now
+			if ok {
+				m.Role = role
+			}
+			return nil
+		}
+	}
+	s.members = append(s.members, model.User{
+		ClickUpID: u.ClickUpID, Name: u.Name, Email: u.Email, Role: role, Status: u.Status, CreatedAt: now, UpdatedAt: now,
+	})
+	return nil
+}
+
+func (s *Store) UpsertSpace(ctx context.Context, space *model.SpaceInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.spaces {
+		if s.spaces[i].ID == space.ID {
+			s.spaces[i].Name = space.Name
+			return nil
+		}
+	}
+	s.spaces = append(s.spaces, model.ProjectNode{ID: space.ID, Name: space.Name})
+	return nil
+}
+
+// UpsertFolder keeps the current space when the folder comes without one.
+func (s *Store) UpsertFolder(ctx context.Context, folder *model.Folder) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := model.Folder{ID: folder.ID, Name: folder.Name, Archived: folder.Archived, Space: model.SpaceInfo{ID: folder.Space.ID}}
+	for i := range s.folders {
+		if s.folders[i].ID == f.ID {
+			if f.Space.ID == "" {
+				f.Space.ID = s.folders[i].Space.ID
+			}
+			s.folders[i] = f
+			return nil
+		}
+	}
+	s.folders = append(s.folders, f)
+	return nil
+}
+
+// UpsertList keeps the archived flag, which the sync does not write.
+func (s *Store) UpsertList(ctx context.Context, list *model.List) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l := model.List{ID: list.ID, Name: list.Name, FolderID: list.FolderID, SpaceID: list.SpaceID, StartDate: list.StartDate, DueDate: list.DueDate}
+	for i := range s.lists {
+		if s.lists[i].ID == l.ID {
+			l.Archived = s.lists[i].Archived
+			s.lists[i] = l
+			return nil
+		}
+	}
+	s.lists = append(s.lists, l)
+	return nil
+}
+
+func (s *Store) UpsertTaskStatus(ctx context.Context, status *model.TaskStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.taskStatuses[status.ID] = *status
+	return nil
+}
+
+// UpsertTask keeps a manual estimate over a defaulted one, and the creation
+// date when the update has none. The status is resolved from the upserted
+// task statuses.
+func (s *Store) UpsertTask(ctx context.Context, t *model.TaskResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	row := Task{
+		ID:             t.ID,
+		Name:           t.Name,
+		Description:    t.Description,
+		TextContent:    t.TextContent,
+		Status:         s.taskStatuses[t.Status.ID],
+		ListID:         derefString(t.ListID),
+		StartDate:      t.StartDate,
+		DueDate:        t.DueDate,
+		DateCreated:    t.DateCreated,
+		DateDone:       t.DateDone,
+		DateClosed:     t.DateClosed,
+		EstimateSource: t.EstimateSource,
+		RemainingHours: t.RemainingTimeHours,
+		EfficiencyPct:  t.TimeEfficiencyPercentage,
+	}
+	if t.TimeEstimateHours != nil {
+		row.EstimateHours = *t.TimeEstimateHours
+	}
+	if t.TimeSpentHours != nil {
+		row.SpentHours = *t.TimeSpentHours
+	}
+	for i := range s.tasks {
+		old := &s.tasks[i]
+		if old.ID != t.ID {
+			continue
+		}
+		if old.EstimateSource == model.EstimateSourceManual && row.EstimateSource == model.EstimateSourceDefault {
+			row.EstimateHours, row.EstimateSource = old.EstimateHours, old.EstimateSource
+		}
+		if row.EstimateSource == "" {
+			row.EstimateSource = old.EstimateSource
+		}
+		if row.DateCreated == nil {
+			row.DateCreated = old.DateCreated
+		}
+		row.Assignees, row.Tags = old.Assignees, old.Tags
+		*old = row
+		return nil
+	}
+	s.tasks = append(s.tasks, row)
+	return nil
+}
+
+// UpsertTaskAssignees replaces the assignees of a task, keeping their order.
+func (s *Store) UpsertTaskAssignees(ctx context.Context, taskID string, assigneeIDs []int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []int64
+	for _, id := range assigneeIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	for i := range s.tasks {
+		if s.tasks[i].ID == taskID {
+			s.tasks[i].Assignees = ids
+		}
+	}
+	return nil
+}
+
+func (s *Store) ReplaceTaskTags(ctx context.Context, taskID string, tags []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var unique []string
+	for _, tag := range tags {
+		if !slices.Contains(unique, tag) {
+			unique = append(unique, tag)
+		}
+	}
+	for i := range s.tasks {
+		if s.tasks[i].ID == taskID {
+			s.tasks[i].Tags = unique
+		}
+	}
+	return nil
+}
+
+// TaskTags returns the tags of a task, sorted.
+func (s *Store) TaskTags(taskID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t, _ := s.task(taskID)
+	out := append([]string(nil), t.Tags...)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,308 @@
+package memstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// overlaps mirrors the repository's period predicate: scheduled across the
+// period, or done or closed inside it.
+func overlaps(t Task, start, end time.Time) bool {
+	within := func(ts *time.Time) bool { return ts != nil && !ts.Before(start) && !ts.After(end) }
+	if t.StartDate != nil && t.DueDate != nil && !t.StartDate.After(end) && !t.DueDate.Before(start) {
+		return true
+	}
+	return within(t.DateDone) || within(t.DateClosed)
+}
+
+func (t Task) assignedTo(id int64) bool {
+	for _, a := range t.Assignees {
+		if a == id {
+			return true
+		}
+	}
+	return false
+}
+
+// expectedHours is the 8 hours per working day the repository uses.
+func expectedHours(start, end time.Time) float64 {
+	return float64(dates.WorkingDays(start, end, dates.Default()) * 8)
+}
+
+// sortedTasks returns a copy of the tasks in the given order.
+func (s *Store) sortedTasks(less func(a, b Task) bool) []Task {
+	out := append([]Task(nil), s.tasks...)
+	sort.SliceStable(out, func(i, j int) bool { return less(out[i], out[j]) })
+	return out
+}
+
+// projectName is COALESCE(folder name, list name).
+func (s *Store) projectName(listID string) *string {
+	l, ok := s.list(listID)
+	if !ok {
+		return nil
+	}
+	if f, ok := s.folder(l.FolderID); ok {
+		name := f.Name
+		return &name
+	}
+	name := l.Name
+	return &name
+}
+
+// compareTime orders like Postgres: NULLs are greater than any time.
+func compareTime(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return a.Compare(*b)
+}
+
+// byStartDescName is ORDER BY start_date DESC, name ASC.
+func byStartDescName(a, b Task) bool {
+	if c := compareTime(a.StartDate, b.StartDate); c != 0 {
+		return c > 0
+	}
+	return a.Name < b.Name
+}
+
+func (s *Store) GetTasksSummaryByDateRange(ctx context.Context, start, end time.Time) ([]model.TaskSummary, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.summaries(start, end), nil
+}
+
+func (s *Store) summaries(start, end time.Time) []model.TaskSummary {
+	var out []model.TaskSummary
+	for _, u := range s.activeMembers() {
+		sum := model.TaskSummary{UserID: u.ClickUpID, Name: u.Name, Email: u.Email, Role: u.Role, TotalWorkHours: expectedHours(start, end)}
+		for _, t := range s.tasks {
+			if !t.assignedTo(u.ClickUpID) || !overlaps(t, start, end) {
+				continue
+			}
+			sum.TotalTasks++
+			sum.TotalSpentHours += t.SpentHours
+			if t.Status.Type == "open" || strings.Contains(strings.ToLower(t.Status.Name), "to do") {
+				sum.TotalUpcomingHours += t.EstimateHours
+			}
+		}
+		out = append(out, sum)
+	}
+	return out
+}
+
+func (s *Store) GetTasksByAssignee(ctx context.Context, start, end time.Time) ([]model.AssigneeWithTasks, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := []model.AssigneeWithTasks{}
+	for _, sum := range s.summaries(start, end) {
+		out = append(out, model.AssigneeWithTasks{
+			ClickUpID:          sum.UserID,
+			Username:           sum.Name,
+			Email:              sum.Email,
+			Role:               sum.Role,
+			Name:               sum.Name,
+			TotalSpentHours:    sum.TotalSpentHours,
+			ExpectedHours:      sum.TotalWorkHours,
+			TotalTasks:         sum.TotalTasks,
+			TotalUpcomingHours: sum.TotalUpcomingHours,
+			Tasks:              s.taskItems(sum.UserID, start, end),
+		})
+	}
+	return out, nil
+}
+
+func (s *Store) GetTasksByUser(ctx context.Context, userID int64, start, end time.Time) ([]model.TaskItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.taskItems(userID, start, end), nil
+}
+
+func (s *Store) taskItems(userID int64, start, end time.Time) []model.TaskItem {
+	var out []model.TaskItem
+	for _, t := range s.sortedTasks(byStartDescName) {
+		if !t.assignedTo(userID) || !overlaps(t, start, end) {
+			continue
+		}
+		out = append(out, model.TaskItem{
+			ID:                t.ID,
+			Name:              t.Name,
+			Description:       t.Description,
+			StatusID:          t.Status.ID,
+			StatusName:        t.Status.Name,
+			StatusType:        t.Status.Type,
+			DateDone:          t.DateDone,
+			DateClosed:        t.DateClosed,
+			StartDate:         t.StartDate,
+			DueDate:           t.DueDate,
+			TimeEstimateHours: t.EstimateHours,
+			TimeSpentHours:    t.SpentHours,
+			ProjectName:       s.projectName(t.ListID),
+		})
+	}
+	return out
+}
+
+func (s *Store) GetWorkload(ctx context.Context, start, end time.Time) ([]model.WorkloadUser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []model.WorkloadUser
+	for _, sum := range s.summaries(start, end) {
+		out = append(out, model.WorkloadUser{
+			UserID:        sum.UserID,
+			Name:          sum.Name,
+			Email:         sum.Email,
+			Role:          sum.Role,
+			TotalHours:    sum.TotalSpentHours,
+			TaskCount:     sum.TotalTasks,
+			ExpectedHours: sum.TotalWorkHours,
+		})
+	}
+	return out, nil
+}
+
+// GetMetricTasks returns one row per (task, assignee), ordered by assignee
+// and due date.
+func (s *Store) GetMetricTasks(ctx context.Context, start, end time.Time) ([]model.KPITaskMetric, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []model.KPITaskMetric
+	for _, t := range s.tasks {
+		if !overlaps(t, start, end) {
+			continue
+		}
+		for _, uid := range t.Assignees {
+			out = append(out, model.KPITaskMetric{
+				TaskID:         t.ID,
+				TaskName:       t.Name,
+				ProjectName:    s.projectName(t.ListID),
+				StatusName:     t.Status.Name,
+				StatusType:     t.Status.Type,
+				StartDate:      t.StartDate,
+				DueDate:        t.DueDate,
+				DateDone:       t.DateDone,
+				DateClosed:     t.DateClosed,
+				EstimateHours:  t.EstimateHours,
+				SpentHours:     t.SpentHours,
+				EstimateSource: t.EstimateSource,
+				UserID:         uid,
+			})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].UserID != out[j].UserID {
+			return out[i].UserID < out[j].UserID
+		}
+		return compareTime(out[i].DueDate, out[j].DueDate) < 0
+	})
+	return out, nil
+}
+
+func (s *Store) GetTaskDetailsByRange(ctx context.Context, start, end time.Time, asc bool) ([]model.TaskDetail, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tasks := s.sortedTasks(func(a, b Task) bool {
+		if c := compareTime(a.StartDate, b.StartDate); c != 0 {
+			return (c < 0) == asc
+		}
+		return a.ID < b.ID
+	})
+
+	var out []model.TaskDetail
+	for _, t := range tasks {
+		if !overlaps(t, start, end) {
+			continue
+		}
+		d := model.TaskDetail{
+			ID:                t.ID,
+			Name:              t.Name,
+			Description:       t.Description,
+			TextContent:       t.TextContent,
+			StatusName:        t.Status.Name,
+			StartDate:         formatDate(t.StartDate),
+			DueDate:           formatDate(t.DueDate),
+			DateDone:          formatDate(t.DateDone),
+			TimeSpentHours:    t.SpentHours,
+			TimeEstimateHours: t.EstimateHours,
+			Assignees:         []model.AssigneeDetail{},
+		}
+		// TaskDetail memakai COALESCE(list, folder), kebalikan dari task item.
+		if l, ok := s.list(t.ListID); ok {
+			name := l.Name
+			d.ProjectName = &name
+		}
+		if t.StartDate != nil && t.DueDate != nil && t.DateDone != nil {
+			remaining := t.DueDate.Sub(*t.DateDone).Hours()
+			d.RemainingTimeHours = &remaining
+			if actual := t.DateDone.Sub(*t.StartDate).Hours(); actual > 0 {
+				efficiency := t.DueDate.Sub(*t.StartDate).Hours() / actual * 100
+				d.TimeEfficiencyPercentage = &efficiency
+			}
+		}
+		for _, uid := range t.Assignees {
+			if u, ok := s.member(uid); ok {
+				d.Assignees = append(d.Assignees, model.AssigneeDetail{ClickUpID: uid, Username: u.Name, Email: u.Email, Name: u.Name})
+			}
+		}
+		sort.SliceStable(d.Assignees, func(i, j int) bool { return d.Assignees[i].Name < d.Assignees[j].Name })
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// GetOpenAssignments returns one row per (unfinished task, active assignee),
+// ordered by assignee and due date.
+func (s *Store) GetOpenAssignments(ctx context.Context) ([]model.OpenTaskAssignment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []model.OpenTaskAssignment
+	for _, t := range s.tasks {
+		if t.DateDone != nil || t.DateClosed != nil || t.Status.Type == "done" || t.Status.Type == "closed" {
+			continue
+		}
+		for _, uid := range t.Assignees {
+			u, ok := s.member(uid)
+			if !ok || u.Status != ActiveStatus {
+				continue
+			}
+			out = append(out, model.OpenTaskAssignment{
+				TaskID:        t.ID,
+				TaskName:      t.Name,
+				StatusName:    t.Status.Name,
+				StartDate:     t.StartDate,
+				DueDate:       t.DueDate,
+				EstimateHours: t.EstimateHours,
+				SpentHours:    t.SpentHours,
+				UserID:        uid,
+				Name:          u.Name,
+				Role:          u.Role,
+				AssigneeCount: len(t.Assignees),
+			})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].UserID != out[j].UserID {
+			return out[i].UserID < out[j].UserID
+		}
+		return compareTime(out[i].DueDate, out[j].DueDate) < 0
+	})
+	return out, nil
+}
+
+func formatDate(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format("02-01-2006")
+	return &s
+}
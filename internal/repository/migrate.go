@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Migration files live in migrations/ as NNNN_name.up.sql and
+// NNNN_name.down.sql. Every version needs both; the checksum of the up file
+// is recorded in schema_migrations when it is applied.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration has been modified")
+	ErrInvalidMigration = errors.New("invalid migration file")
+)
+
+// migrationLockKey ("kinerja" in hex) is the advisory lock that serialises
+// migrators of several instances booting at once.
+const migrationLockKey int64 = 0x6b696e65726a61
+
+var migrationName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Migration states reported by Status.
+const (
+	MigrationApplied  = "applied"
+	MigrationPending  = "pending"
+	MigrationModified = "modified" // up file changed after it was applied
+	MigrationMissing  = "missing"  // applied, but no file in this build
+)
+
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	State     string
+	AppliedAt *time.Time
+}
+
+// LoadMigrations reads the migration pairs in fsys, ordered by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		m := migrationName.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidMigration, e.Name())
+		}
+		version, _ := strconv.ParseInt(m[1], 10, 64)
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("%w: version %d is used by %s and %s", ErrInvalidMigration, version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+			sum := sha256.Sum256(body)
+			mig.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	out := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("%w: version %d (%s) needs both an up and a down file", ErrInvalidMigration, mig.Version, mig.Name)
+		}
+		out = append(out, *mig)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+// Migrator applies and rolls back migrations, each in its own transaction
+// together with its schema_migrations row.
+type Migrator struct {
+	DB         *sql.DB
+	Migrations []Migration
+}
+
+// NewMigrator uses the migrations embedded in the binary.
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	sub, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := LoadMigrations(sub)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{DB: db, Migrations: migrations}, nil
+}
+
+type appliedMigration struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// queryer is a *sql.DB or the *sql.Conn holding the migration lock.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func (m *Migrator) ensureTable(ctx context.Context, q queryer) error {
+	_, err := q.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`)
+	return err
+}
+
+func (m *Migrator) applied(ctx context.Context, q queryer) (map[int64]appliedMigration, error) {
+	rows, err := q.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[int64]appliedMigration{}
+	for rows.Next() {
+		var v int64
+		var a appliedMigration
+		if err := rows.Scan(&v, &a.name, &a.checksum, &a.appliedAt); err != nil {
+			return nil, err
+		}
+		out[v] = a
+	}
+	return out, rows.Err()
+}
+
+// Status lists every known or applied version, oldest first.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.ensureTable(ctx, m.DB); err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx, m.DB)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []MigrationStatus
+	for _, mig := range m.Migrations {
+		st := MigrationStatus{Version: mig.Version, Name: mig.Name, State: MigrationPending}
+		if a, ok := applied[mig.Version]; ok {
+			at := a.appliedAt
+			st.AppliedAt = &at
+			st.State = MigrationApplied
+			if a.checksum != mig.Checksum {
+				st.State = MigrationModified
+			}
+			delete(applied, mig.Version)
+		}
+		out = append(out, st)
+	}
+	for v, a := range applied {
+		at := a.appliedAt
+		out = append(out, MigrationStatus{Version: v, Name: a.name, State: MigrationMissing, AppliedAt: &at})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+// withLock runs fn on one connection holding the migration advisory lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey)
+
+	if err := m.ensureTable(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// verify refuses to continue when an applied up file has changed since.
+func (m *Migrator) verify(applied map[int64]appliedMigration) error {
+	for _, mig := range m.Migrations {
+		if a, ok := applied[mig.Version]; ok && a.checksum != mig.Checksum {
+			return fmt.Errorf("%w: %04d_%s", ErrChecksumMismatch, mig.Version, mig.Name)
+		}
+	}
+	return nil
+}
+
+// Up applies all pending migrations in order and returns the applied ones.
+// It stops at the first failure; earlier migrations stay applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+		for _, mig := range m.Migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			err := runInTx(ctx, conn, mig.Up,
+				`INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+				mig.Version, mig.Name, mig.Checksum)
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s up: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down rolls back the latest steps applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.verify(applied); err != nil {
+			return err
+		}
+		for i := len(m.Migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			mig := m.Migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			err := runInTx(ctx, conn, mig.Down, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+			if err != nil {
+				return fmt.Errorf("migration %04d_%s down: %w", mig.Version, mig.Name, err)
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// runInTx executes a migration script and its bookkeeping statement
+// atomically.
+func runInTx(ctx context.Context, conn *sql.Conn, script, record string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,112 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/repotest"
+)
+
+func file(s string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(s)} }
+
+func TestLoadMigrations(t *testing.T) {
+	got, err := repository.LoadMigrations(fstest.MapFS{
+		"0002_tags.up.sql":   file("CREATE TABLE tags ();"),
+		"0002_tags.down.sql": file("DROP TABLE tags;"),
+		"0001_core.up.sql":   file("CREATE TABLE core ();"),
+		"0001_core.down.sql": file("DROP TABLE core;"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Name != "core" || got[1].Version != 2 || got[1].Down != "DROP TABLE tags;" {
+		t.Fatalf("migrations = %+v", got)
+	}
+	if got[0].Checksum == "" || got[0].Checksum == got[1].Checksum {
+		t.Errorf("checksums = %q, %q", got[0].Checksum, got[1].Checksum)
+	}
+
+	invalid := map[string]fstest.MapFS{
+		"missing down":  {"0001_core.up.sql": file("SELECT 1;")},
+		"bad name":      {"core.sql": file("SELECT 1;")},
+		"version clash": {"0001_a.up.sql": file("SELECT 1;"), "0001_a.down.sql": file("SELECT 1;"), "0001_b.up.sql": file("SELECT 1;")},
+	}
+	for name, fsys := range invalid {
+		if _, err := repository.LoadMigrations(fsys); !errors.Is(err, repository.ErrInvalidMigration) {
+			t.Errorf("%s: err = %v, want ErrInvalidMigration", name, err)
+		}
+	}
+}
+
+func TestEmbeddedMigrationsAreSequential(t *testing.T) {
+	m, err := repository.NewMigrator(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, mig := range m.Migrations {
+		if mig.Version != int64(i+1) {
+			t.Errorf("migration %d has version %d; versions must be 1..n without gaps", i, mig.Version)
+		}
+	}
+}
+
+// TestMigrateDownAndUp butuh TEST_DATABASE_URL (lihat repotest).
+func TestMigrateDownAndUp(t *testing.T) {
+	repo := repotest.New(t, "migrate")
+	ctx := context.Background()
+	m, err := repository.NewMigrator(repo.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables := func() int {
+		var n int
+		repo.DB.QueryRowContext(ctx, `
+			SELECT count(*) FROM information_schema.tables
+			WHERE table_schema = current_schema() AND table_name <> 'schema_migrations'`).Scan(&n)
+		return n
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range statuses {
+		if s.State != repository.MigrationApplied {
+			t.Errorf("%04d_%s is %s after RunMigrations", s.Version, s.Name, s.State)
+		}
+	}
+	if again, err := m.Up(ctx); err != nil || len(again) != 0 {
+		t.Errorf("second Up applied %d migrations, err %v", len(again), err)
+	}
+
+	reverted, err := m.Down(ctx, len(m.Migrations))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reverted) != len(m.Migrations) || reverted[0].Version != m.Migrations[len(m.Migrations)-1].Version {
+		t.Errorf("reverted %d migrations, newest first expected", len(reverted))
+	}
+	if n := tables(); n != 0 {
+		t.Errorf("%d tables left after rolling everything back", n)
+	}
+
+	if _, err := m.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if tables() == 0 {
+		t.Error("no tables after migrating up again")
+	}
+
+	if _, err := repo.DB.ExecContext(ctx, `UPDATE schema_migrations SET checksum = 'edited' WHERE version = 1`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(ctx); !errors.Is(err, repository.ErrChecksumMismatch) {
+		t.Errorf("Up after editing an applied migration = %v, want ErrChecksumMismatch", err)
+	}
+	if statuses, _ := m.Status(ctx); statuses[0].State != repository.MigrationModified {
+		t.Errorf("status of version 1 = %s, want modified", statuses[0].State)
+	}
+}
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS sync_history;
+DROP TABLE IF EXISTS lists;
+DROP TABLE IF EXISTS folders;
+DROP TABLE IF EXISTS spaces;
+DROP TABLE IF EXISTS task_assignees;
+DROP TABLE IF EXISTS tasks;
+DROP TABLE IF EXISTS task_statuses;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS teams;
+DROP TABLE IF EXISTS user_statuses;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS admins;
@@ -0,0 +1,151 @@
+-- Skema inti. Semua statement idempoten supaya database yang dibuat oleh
+-- RunMigrations lama (sebelum schema_migrations ada) bisa diadopsi apa adanya.
+CREATE EXTENSION IF NOT EXISTS "pgcrypto";
+
+CREATE TABLE IF NOT EXISTS admins (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    username VARCHAR(100) UNIQUE NOT NULL,
+    password_hash TEXT NOT NULL,
+    language TEXT NOT NULL DEFAULT 'en',
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS roles (
+    id SERIAL PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_statuses (
+    id SERIAL PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL
+);
+
+INSERT INTO roles (name) VALUES
+    ('infra'),
+    ('mobile apps'),
+    ('web'),
+    ('backend'),
+    ('pm'),
+    ('backend-web'),
+    ('analis'),
+    ('UI-UX')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO user_statuses (name) VALUES
+    ('aktif'),
+    ('nonaktif')
+ON CONFLICT (name) DO NOTHING;
+
+-- teams tidak lagi diisi (UpsertTeam deprecated), dipertahankan karena
+-- sudah ada di database produksi.
+CREATE TABLE IF NOT EXISTS teams (
+    team_id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    parent_id TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    clickup_id BIGINT PRIMARY KEY,
+    name TEXT,
+    email TEXT,
+    password TEXT,
+    role_id INT REFERENCES roles(id) ON DELETE SET NULL,
+    status_id INT REFERENCES user_statuses(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS task_statuses (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    type TEXT,
+    color TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    UNIQUE(id)
+);
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id TEXT PRIMARY KEY,
+    name TEXT,
+    text_content TEXT,
+    description TEXT,
+    status_id TEXT REFERENCES task_statuses(id) ON DELETE SET NULL,
+    date_done TIMESTAMPTZ,
+    date_closed TIMESTAMPTZ,
+    start_date TIMESTAMPTZ,
+    due_date TIMESTAMPTZ,
+    date_created TIMESTAMPTZ,
+    time_estimate_hours FLOAT,
+    time_spent_hours FLOAT,
+    estimate_source TEXT,
+    remaining_time_hours FLOAT8,
+    time_efficiency_percentage FLOAT8,
+    list_id TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS task_assignees (
+    id BIGSERIAL PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_clickup_id BIGINT NOT NULL REFERENCES users(clickup_id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    UNIQUE(task_id, user_clickup_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_start_date ON tasks(start_date);
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks(due_date);
+CREATE INDEX IF NOT EXISTS idx_tasks_status_id ON tasks(status_id);
+
+CREATE TABLE IF NOT EXISTS spaces (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    archived BOOLEAN DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS folders (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    archived BOOLEAN DEFAULT FALSE,
+    space_id TEXT,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS lists (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    archived BOOLEAN DEFAULT FALSE,
+    folder_id TEXT,
+    space_id TEXT,
+    start_date TIMESTAMPTZ,
+    due_date TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS sync_history (
+    id SERIAL PRIMARY KEY,
+    sync_time TIMESTAMPTZ DEFAULT now(),
+    sync_type TEXT NOT NULL,
+    status TEXT NOT NULL,
+    duration_ms BIGINT,
+    details JSONB
+);
+
+-- Kolom yang dulu ditambahkan lewat ALTER, untuk database lama.
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT 'en';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS list_id TEXT;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS time_estimate_hours FLOAT;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS time_spent_hours FLOAT;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS remaining_time_hours FLOAT8;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS time_efficiency_percentage FLOAT8;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS date_created TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimate_source TEXT;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS start_date TIMESTAMPTZ;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS due_date TIMESTAMPTZ;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS rebalance_suggestions (
+    id BIGSERIAL PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    from_user_id BIGINT NOT NULL REFERENCES users(clickup_id) ON DELETE CASCADE,
+    to_user_id BIGINT NOT NULL REFERENCES users(clickup_id) ON DELETE CASCADE,
+    remaining_hours FLOAT8,
+    score FLOAT8 NOT NULL,
+    reason TEXT,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ DEFAULT now(),
+    decided_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_rebalance_suggestions_status ON rebalance_suggestions(status);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS kpi_scorecard_item_tasks;
+DROP TABLE IF EXISTS kpi_scorecard_items;
+DROP TABLE IF EXISTS kpi_scorecards;
+DROP TABLE IF EXISTS kpi_role_weights;
+DROP TABLE IF EXISTS kpi_indicators;
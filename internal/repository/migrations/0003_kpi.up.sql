@@ -0,0 +1,64 @@
+CREATE TABLE IF NOT EXISTS kpi_indicators (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    unit TEXT,
+    default_weight FLOAT8 NOT NULL DEFAULT 0,
+    bands JSONB NOT NULL DEFAULT '[]',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+INSERT INTO kpi_indicators (code, name, description, unit, default_weight, bands) VALUES
+    ('on_time_rate', 'On-time completion rate', 'Task selesai sebelum atau pada due date', '%', 0.3,
+     '[{"label":"below","max":60,"score":40},{"label":"meets","min":60,"max":80,"score":70},{"label":"exceeds","min":80,"max":95,"score":90},{"label":"outstanding","min":95,"score":100}]'),
+    ('estimate_accuracy', 'Estimate accuracy', 'Kedekatan jam aktual dengan estimasi', '%', 0.2,
+     '[{"label":"below","max":50,"score":40},{"label":"meets","min":50,"max":75,"score":70},{"label":"exceeds","min":75,"max":90,"score":90},{"label":"outstanding","min":90,"score":100}]'),
+    ('throughput', 'Throughput', 'Jumlah task selesai per minggu kerja', 'tasks/week', 0.2,
+     '[{"label":"below","max":1,"score":40},{"label":"meets","min":1,"max":2,"score":70},{"label":"exceeds","min":2,"max":4,"score":90},{"label":"outstanding","min":4,"score":100}]'),
+    ('utilisation', 'Utilisation', 'Jam terpakai dibanding jam kerja tersedia', '%', 0.2,
+     '[{"label":"under","max":60,"score":40},{"label":"low","min":60,"max":80,"score":70},{"label":"optimal","min":80,"max":110,"score":100},{"label":"high","min":110,"max":130,"score":70},{"label":"over","min":130,"score":40}]'),
+    ('rework_rate', 'Rework rate', 'Task yang dibuka kembali setelah selesai', '%', 0.1,
+     '[{"label":"outstanding","max":5,"score":100},{"label":"exceeds","min":5,"max":10,"score":90},{"label":"meets","min":10,"max":20,"score":70},{"label":"below","min":20,"score":40}]')
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS kpi_role_weights (
+    role_id INT NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    indicator_code TEXT NOT NULL REFERENCES kpi_indicators(code) ON DELETE CASCADE,
+    weight FLOAT8 NOT NULL,
+    PRIMARY KEY (role_id, indicator_code)
+);
+
+CREATE TABLE IF NOT EXISTS kpi_scorecards (
+    id BIGSERIAL PRIMARY KEY,
+    user_clickup_id BIGINT NOT NULL REFERENCES users(clickup_id) ON DELETE CASCADE,
+    period_type TEXT NOT NULL,
+    period_label TEXT NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    total_score FLOAT8 NOT NULL DEFAULT 0,
+    computed_at TIMESTAMPTZ DEFAULT now(),
+    UNIQUE (user_clickup_id, period_type, period_label)
+);
+
+CREATE TABLE IF NOT EXISTS kpi_scorecard_items (
+    id BIGSERIAL PRIMARY KEY,
+    scorecard_id BIGINT NOT NULL REFERENCES kpi_scorecards(id) ON DELETE CASCADE,
+    indicator_code TEXT NOT NULL,
+    value FLOAT8,
+    score FLOAT8 NOT NULL DEFAULT 0,
+    weight FLOAT8 NOT NULL DEFAULT 0,
+    band TEXT,
+    task_count INT NOT NULL DEFAULT 0,
+    UNIQUE (scorecard_id, indicator_code)
+);
+
+CREATE TABLE IF NOT EXISTS kpi_scorecard_item_tasks (
+    item_id BIGINT NOT NULL REFERENCES kpi_scorecard_items(id) ON DELETE CASCADE,
+    task_id TEXT NOT NULL,
+    task_name TEXT,
+    counted BOOLEAN NOT NULL DEFAULT FALSE,
+    value FLOAT8,
+    note TEXT,
+    PRIMARY KEY (item_id, task_id)
+);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS appraisal_reviews;
+DROP TABLE IF EXISTS appraisal_audit_log;
+DROP TABLE IF EXISTS appraisal_task_snapshots;
+DROP TABLE IF EXISTS appraisal_scorecard_snapshots;
+DROP TABLE IF EXISTS appraisal_periods;
@@ -0,0 +1,67 @@
+CREATE TABLE IF NOT EXISTS appraisal_periods (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open',
+    computed_at TIMESTAMPTZ,
+    locked_at TIMESTAMPTZ,
+    locked_by TEXT,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS appraisal_scorecard_snapshots (
+    period_id BIGINT NOT NULL REFERENCES appraisal_periods(id) ON DELETE CASCADE,
+    user_clickup_id BIGINT NOT NULL,
+    name TEXT,
+    email TEXT,
+    role TEXT,
+    total_score FLOAT8 NOT NULL DEFAULT 0,
+    items JSONB NOT NULL DEFAULT '[]',
+    computed_at TIMESTAMPTZ,
+    snapshot_at TIMESTAMPTZ DEFAULT now(),
+    PRIMARY KEY (period_id, user_clickup_id)
+);
+
+CREATE TABLE IF NOT EXISTS appraisal_task_snapshots (
+    period_id BIGINT NOT NULL REFERENCES appraisal_periods(id) ON DELETE CASCADE,
+    user_clickup_id BIGINT NOT NULL,
+    task_id TEXT NOT NULL,
+    task_name TEXT,
+    project_name TEXT,
+    status_name TEXT,
+    status_type TEXT,
+    start_date TIMESTAMPTZ,
+    due_date TIMESTAMPTZ,
+    date_done TIMESTAMPTZ,
+    date_closed TIMESTAMPTZ,
+    time_estimate_hours FLOAT8,
+    time_spent_hours FLOAT8,
+    snapshot_at TIMESTAMPTZ DEFAULT now(),
+    PRIMARY KEY (period_id, user_clickup_id, task_id)
+);
+
+CREATE TABLE IF NOT EXISTS appraisal_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    period_id BIGINT NOT NULL REFERENCES appraisal_periods(id) ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS appraisal_reviews (
+    period_id BIGINT NOT NULL REFERENCES appraisal_periods(id) ON DELETE CASCADE,
+    user_clickup_id BIGINT NOT NULL REFERENCES users(clickup_id) ON DELETE CASCADE,
+    reviewer TEXT NOT NULL,
+    rating INT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    strengths TEXT,
+    improvements TEXT,
+    final_grade TEXT NOT NULL,
+    acknowledged_at TIMESTAMPTZ,
+    rebuttal TEXT,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    PRIMARY KEY (period_id, user_clickup_id)
+);
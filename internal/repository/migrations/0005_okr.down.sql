@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS okr_key_result_links;
+DROP TABLE IF EXISTS okr_key_results;
+DROP TABLE IF EXISTS okr_objectives;
+DROP TABLE IF EXISTS task_tags;
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    tag TEXT NOT NULL,
+    PRIMARY KEY (task_id, tag)
+);
+CREATE INDEX IF NOT EXISTS idx_task_tags_tag ON task_tags(tag);
+
+CREATE TABLE IF NOT EXISTS okr_objectives (
+    id BIGSERIAL PRIMARY KEY,
+    title TEXT NOT NULL,
+    description TEXT,
+    period_label TEXT NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    owner_type TEXT NOT NULL,
+    owner_user_id BIGINT REFERENCES users(clickup_id) ON DELETE CASCADE,
+    owner_role_id INT REFERENCES roles(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_okr_objectives_period ON okr_objectives(period_label);
+
+CREATE TABLE IF NOT EXISTS okr_key_results (
+    id BIGSERIAL PRIMARY KEY,
+    objective_id BIGINT NOT NULL REFERENCES okr_objectives(id) ON DELETE CASCADE,
+    title TEXT NOT NULL,
+    metric TEXT NOT NULL,
+    target_value FLOAT8,
+    weight FLOAT8 NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS okr_key_result_links (
+    id BIGSERIAL PRIMARY KEY,
+    key_result_id BIGINT NOT NULL REFERENCES okr_key_results(id) ON DELETE CASCADE,
+    link_type TEXT NOT NULL,
+    link_value TEXT NOT NULL,
+    UNIQUE (key_result_id, link_type, link_value)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS project_budget_alerts;
+DROP TABLE IF EXISTS project_budgets;
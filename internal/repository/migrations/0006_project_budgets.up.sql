@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS project_budgets (
+    id BIGSERIAL PRIMARY KEY,
+    level TEXT NOT NULL,
+    node_id TEXT NOT NULL,
+    budget_hours FLOAT8 NOT NULL,
+    start_date TIMESTAMPTZ,
+    end_date TIMESTAMPTZ,
+    thresholds JSONB NOT NULL DEFAULT '[75, 90, 100]',
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    UNIQUE (level, node_id)
+);
+
+CREATE TABLE IF NOT EXISTS project_budget_alerts (
+    id BIGSERIAL PRIMARY KEY,
+    budget_id BIGINT NOT NULL REFERENCES project_budgets(id) ON DELETE CASCADE,
+    threshold FLOAT8 NOT NULL,
+    burn_pct FLOAT8 NOT NULL,
+    spent_hours FLOAT8 NOT NULL,
+    fired_at TIMESTAMPTZ DEFAULT now(),
+    UNIQUE (budget_id, threshold)
+);
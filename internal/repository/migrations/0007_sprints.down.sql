@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS sprints;
+DROP TABLE IF EXISTS task_status_history;
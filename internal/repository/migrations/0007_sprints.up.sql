@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS task_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    status_id TEXT,
+    status_name TEXT,
+    status_type TEXT,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS idx_task_status_history_task ON task_status_history(task_id, changed_at);
+
+CREATE TABLE IF NOT EXISTS sprints (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    list_id TEXT NOT NULL UNIQUE,
+    start_date TIMESTAMPTZ NOT NULL,
+    end_date TIMESTAMPTZ NOT NULL,
+    source TEXT NOT NULL DEFAULT 'manual',
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS alert_rules (
+    code TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    description TEXT,
+    threshold FLOAT8 NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+INSERT INTO alert_rules (code, name, description, threshold) VALUES
+    ('overdue', 'Overdue task', 'Task belum selesai melewati due date (threshold: hari toleransi)', 0),
+    ('due_soon_no_time', 'Due soon without time logged', 'Task jatuh tempo dalam N hari tanpa jam tercatat (threshold: hari)', 3),
+    ('over_estimate', 'Spent over estimate', 'Jam terpakai melebihi estimasi sebesar X persen (threshold: persen)', 20),
+    ('member_overload', 'Member projected overloaded', 'Sisa jam task member melebihi kapasitas dalam N hari ke depan (threshold: hari)', 14)
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id BIGSERIAL PRIMARY KEY,
+    rule_code TEXT NOT NULL REFERENCES alert_rules(code) ON DELETE CASCADE,
+    subject_type TEXT NOT NULL,
+    subject_id TEXT NOT NULL,
+    subject_name TEXT,
+    user_clickup_id BIGINT,
+    message TEXT NOT NULL,
+    value FLOAT8,
+    status TEXT NOT NULL DEFAULT 'open',
+    created_at TIMESTAMPTZ DEFAULT now(),
+    last_seen_at TIMESTAMPTZ DEFAULT now(),
+    acknowledged_at TIMESTAMPTZ,
+    acknowledged_by TEXT,
+    resolved_at TIMESTAMPTZ
+);
+CREATE UNIQUE INDEX IF NOT EXISTS alerts_active_subject_idx
+    ON alerts (rule_code, subject_type, subject_id) WHERE status <> 'resolved';
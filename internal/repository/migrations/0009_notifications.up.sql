@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS notification_subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    subscriber_type TEXT NOT NULL,
+    user_clickup_id BIGINT REFERENCES users(clickup_id) ON DELETE CASCADE,
+    role_id INT REFERENCES roles(id) ON DELETE CASCADE,
+    channel TEXT NOT NULL,
+    target TEXT,
+    events JSONB NOT NULL DEFAULT '[]',
+    quiet_start TEXT,
+    quiet_end TEXT,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS notification_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT REFERENCES notification_subscriptions(id) ON DELETE SET NULL,
+    channel TEXT NOT NULL,
+    target TEXT NOT NULL,
+    event TEXT NOT NULL,
+    subject TEXT,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ DEFAULT now(),
+    created_at TIMESTAMPTZ DEFAULT now(),
+    sent_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS notification_deliveries_due_idx
+    ON notification_deliveries (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS report_definitions (
+    id BIGSERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    report_type TEXT NOT NULL,
+    period TEXT NOT NULL,
+    audience JSONB NOT NULL DEFAULT '{}',
+    filters JSONB NOT NULL DEFAULT '{}',
+    format TEXT NOT NULL DEFAULT 'html+csv',
+    language TEXT NOT NULL DEFAULT 'en',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    last_run_at TIMESTAMPTZ,
+    next_run_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS report_runs (
+    id BIGSERIAL PRIMARY KEY,
+    definition_id BIGINT NOT NULL REFERENCES report_definitions(id) ON DELETE CASCADE,
+    period_label TEXT NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    status TEXT NOT NULL,
+    deliveries INT NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+-- database lama dibuat sebelum kolom language ada
+ALTER TABLE report_definitions ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT 'en';
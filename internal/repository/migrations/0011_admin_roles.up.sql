@@ -0,0 +1,4 @@
+-- Role akun menentukan siapa boleh mengunci periode appraisal dan menulis
+-- review. Akun yang sudah ada tetap admin.
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'admin'
+    CHECK (role IN ('admin', 'manager', 'member'));
@@ -0,0 +1,4 @@
+-- Akun yang terhubung dengan anggota ClickUp boleh meng-acknowledge review
+-- appraisal anggota tersebut.
+ALTER TABLE admins ADD COLUMN IF NOT EXISTS user_clickup_id BIGINT
+    REFERENCES users(clickup_id) ON DELETE SET NULL;
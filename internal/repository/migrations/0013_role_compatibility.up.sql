@@ -0,0 +1,21 @@
+-- Role lain yang boleh mengambil alih task sebuah role saat rebalancing,
+-- selain role itu sendiri. Satu arah: baris (backend, backend-web) berarti
+-- task backend boleh dipindah ke anggota backend-web.
+CREATE TABLE IF NOT EXISTS role_compatibility (
+    role_id INT NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    compatible_role_id INT NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, compatible_role_id),
+    CHECK (role_id <> compatible_role_id)
+);
+
+INSERT INTO role_compatibility (role_id, compatible_role_id)
+SELECT f.id, t.id
+FROM (VALUES
+    ('backend', 'backend-web'),
+    ('web', 'backend-web'),
+    ('backend-web', 'backend'),
+    ('backend-web', 'web')
+) AS p(from_role, to_role)
+JOIN roles f ON f.name = p.from_role
+JOIN roles t ON t.name = p.to_role
+ON CONFLICT DO NOTHING;
@@ -1,14 +0,0 @@
-CREATE TABLE IF NOT EXISTS employees (
-    id TEXT PRIMARY KEY,
-    name TEXT NOT NULL,
-    email TEXT,
-    role TEXT,
-    team_id TEXT,
-    created_at TIMESTAMP DEFAULT NOW(),
-    updated_at TIMESTAMP DEFAULT NOW(),
-
-    CONSTRAINT fk_team
-        FOREIGN KEY (team_id)
-        REFERENCES teams(id)
-        ON DELETE SET NULL
-);
@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+const subscriptionSelect = `
+	SELECT s.id, s.subscriber_type, s.user_clickup_id, COALESCE(u.name, ''), COALESCE(ro.name, ''),
+		s.channel, COALESCE(s.target, ''), s.events, COALESCE(s.quiet_start, ''), COALESCE(s.quiet_end, ''),
+		s.active, s.created_at, s.updated_at
+	FROM notification_subscriptions s
+	LEFT JOIN users u ON u.clickup_id = s.user_clickup_id
+	LEFT JOIN roles ro ON ro.id = s.role_id
+`
+
+func scanSubscription(row interface{ Scan(...interface{}) error }) (model.NotificationSubscription, error) {
+	var sub model.NotificationSubscription
+	var userID sql.NullInt64
+	var events []byte
+	if err := row.Scan(&sub.ID, &sub.SubscriberType, &userID, &sub.UserName, &sub.Role,
+		&sub.Channel, &sub.Target, &events, &sub.QuietStart, &sub.QuietEnd,
+		&sub.Active, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+		return sub, err
+	}
+	if userID.Valid {
+		sub.UserID = &userID.Int64
+	}
+	if err := json.Unmarshal(events, &sub.Events); err != nil {
+		return sub, fmt.Errorf("invalid events for subscription %d: %w", sub.ID, err)
+	}
+	return sub, nil
+}
+
+// subscriptionRoleID resolves the role of a role subscription; sql.ErrNoRows
+// when the role does not exist.
+func (r *PostgresRepo) subscriptionRoleID(ctx context.Context, sub *model.NotificationSubscription) (*int, error) {
+	if sub.SubscriberType != model.SubscriberTypeRole {
+		return nil, nil
+	}
+	var id int
+	if err := r.DB.QueryRowContext(ctx, `SELECT id FROM roles WHERE lower(name) = lower($1) LIMIT 1`, sub.Role).Scan(&id); err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// CreateNotificationSubscription returns sql.ErrNoRows when the user or role
+// does not exist.
+func (r *PostgresRepo) CreateNotificationSubscription(ctx context.Context, sub *model.NotificationSubscription) error {
+	roleID, err := r.subscriptionRoleID(ctx, sub)
+	if err != nil {
+		return err
+	}
+	events, err := json.Marshal(sub.Events)
+	if err != nil {
+		return err
+	}
+	err = r.DB.QueryRowContext(ctx, `
+		INSERT INTO notification_subscriptions
+			(subscriber_type, user_clickup_id, role_id, channel, target, events, quiet_start, quiet_end, active)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, NULLIF($7, ''), NULLIF($8, ''), $9)
+		RETURNING id, created_at, updated_at
+	`, sub.SubscriberType, sub.UserID, roleID, sub.Channel, sub.Target, events, sub.QuietStart, sub.QuietEnd, sub.Active,
+	).Scan(&sub.ID, &sub.CreatedAt, &sub.UpdatedAt)
+	if isForeignKeyViolation(err) {
+		return sql.ErrNoRows
+	}
+	return err
+}
+
+// UpdateNotificationSubscription returns sql.ErrNoRows when the subscription,
+// its user or its role does not exist.
+func (r *PostgresRepo) UpdateNotificationSubscription(ctx context.Context, sub *model.NotificationSubscription) error {
+	roleID, err := r.subscriptionRoleID(ctx, sub)
+	if err != nil {
+		return err
+	}
+	events, err := json.Marshal(sub.Events)
+	if err != nil {
+		return err
+	}
+	err = r.DB.QueryRowContext(ctx, `
+		UPDATE notification_subscriptions SET
+			subscriber_type = $2, user_clickup_id = $3, role_id = $4, channel = $5, target = NULLIF($6, ''),
+			events = $7, quiet_start = NULLIF($8, ''), quiet_end = NULLIF($9, ''), active = $10, updated_at = now()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`, sub.ID, sub.SubscriberType, sub.UserID, roleID, sub.Channel, sub.Target, events, sub.QuietStart, sub.QuietEnd, sub.Active,
+	).Scan(&sub.CreatedAt, &sub.UpdatedAt)
+	if isForeignKeyViolation(err) {
+		return sql.ErrNoRows
+	}
+	return err
+}
+
+func (r *PostgresRepo) DeleteNotificationSubscription(ctx context.Context, id int64) (bool, error) {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM notification_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func (r *PostgresRepo) GetNotificationSubscriptions(ctx context.Context) ([]model.NotificationSubscription, error) {
+	rows, err := r.DB.QueryContext(ctx, subscriptionSelect+" ORDER BY s.id")
+	if err != nil {
+		return nil, fmt.Errorf("querying notification subscriptions failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.NotificationSubscription{}
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sub)
+	}
+	return out, rows.Err()
+}
+
+// GetNotificationSubscription returns nil, nil when the subscription does not exist.
+func (r *PostgresRepo) GetNotificationSubscription(ctx context.Context, id int64) (*model.NotificationSubscription, error) {
+	sub, err := scanSubscription(r.DB.QueryRowContext(ctx, subscriptionSelect+" WHERE s.id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// GetNotificationRecipients mengurai langganan aktif untuk sebuah event.
+// Langganan email role tanpa target dikirim ke email setiap anggota aktif role itu.
+// Audience membatasi ke langganan milik role atau user tertentu.
+func (r *PostgresRepo) GetNotificationRecipients(ctx context.Context, event string, aud model.NotificationAudience) ([]model.NotificationRecipient, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT s.id, s.channel, COALESCE(NULLIF(s.target, ''), u.email, ''),
+			COALESCE(s.quiet_start, ''), COALESCE(s.quiet_end, '')
+		FROM notification_subscriptions s
+		LEFT JOIN users u ON
+			(s.subscriber_type = 'user' AND u.clickup_id = s.user_clickup_id) OR
+			(s.subscriber_type = 'role' AND s.channel = 'email' AND COALESCE(s.target, '') = ''
+				AND u.role_id = s.role_id
+				AND u.status_id = (SELECT id FROM user_statuses WHERE name = 'aktif'))
+		LEFT JOIN roles ro ON ro.id = COALESCE(s.role_id, u.role_id)
+		WHERE s.active AND (s.events = '[]'::jsonb OR s.events ? $1)
+			AND ($2 = '' OR lower(ro.name) = lower($2))
+			AND ($3::bigint IS NULL OR (s.subscriber_type = 'user' AND s.user_clickup_id = $3))
+		ORDER BY s.id
+	`, event, aud.Role, aud.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("querying notification recipients failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.NotificationRecipient{}
+	for rows.Next() {
+		var rc model.NotificationRecipient
+		if err := rows.Scan(&rc.SubscriptionID, &rc.Channel, &rc.Target, &rc.QuietStart, &rc.QuietEnd); err != nil {
+			return nil, err
+		}
+		if rc.Target != "" {
+			out = append(out, rc)
+		}
+	}
+	return out, rows.Err()
+}
+
+const deliverySelect = `
+	SELECT id, subscription_id, channel, target, event, COALESCE(subject, ''), payload, status, attempts,
+		COALESCE(last_error, ''), next_attempt_at, created_at, sent_at
+	FROM notification_deliveries
+`
+
+func scanDelivery(row interface{ Scan(...interface{}) error }) (model.NotificationDelivery, error) {
+	var d model.NotificationDelivery
+	var subID sql.NullInt64
+	var next, sent sql.NullTime
+	var payload []byte
+	if err := row.Scan(&d.ID, &subID, &d.Channel, &d.Target, &d.Event, &d.Subject, &payload, &d.Status, &d.Attempts,
+		&d.LastError, &next, &d.CreatedAt, &sent); err != nil {
+		return d, err
+	}
+	d.Payload = payload
+	if subID.Valid {
+		d.SubscriptionID = &subID.Int64
+	}
+	if next.Valid {
+		d.NextAttemptAt = &next.Time
+	}
+	if sent.Valid {
+		d.SentAt = &sent.Time
+	}
+	return d, nil
+}
+
+func (r *PostgresRepo) CreateNotificationDelivery(ctx context.Context, d *model.NotificationDelivery) error {
+	return r.DB.QueryRowContext(ctx, `
+		INSERT INTO notification_deliveries (subscription_id, channel, target, event, subject, payload, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, status, created_at
+	`, d.SubscriptionID, d.Channel, d.Target, d.Event, d.Subject, []byte(d.Payload), d.NextAttemptAt,
+	).Scan(&d.ID, &d.Status, &d.CreatedAt)
+}
+
+// ClaimDueDeliveries mengambil pengiriman pending yang sudah jatuh tempo dan
+// menundanya selama lease, agar worker lain tidak mengirim dua kali.
+func (r *PostgresRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.NotificationDelivery, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		UPDATE notification_deliveries SET next_attempt_at = now() + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM notification_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= now()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, subscription_id, channel, target, event, COALESCE(subject, ''), payload, status, attempts,
+			COALESCE(last_error, ''), next_attempt_at, created_at, sent_at
+	`, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claiming notification deliveries failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.NotificationDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (r *PostgresRepo) MarkDeliverySent(ctx context.Context, id int64) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE notification_deliveries
+		SET status = 'sent', attempts = attempts + 1, last_error = NULL, sent_at = now(), next_attempt_at = NULL
+		WHERE id = $1
+	`, id)
+	return err
+}
+
+// MarkDeliveryFailed mencatat percobaan gagal. next nil berarti tidak dicoba lagi.
+func (r *PostgresRepo) MarkDeliveryFailed(ctx context.Context, id int64, errMsg string, next *time.Time) error {
+	status := model.DeliveryStatusPending
+	if next == nil {
+		status = model.DeliveryStatusFailed
+	}
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE notification_deliveries
+		SET status = $2, attempts = attempts + 1, last_error = $3, next_attempt_at = $4
+		WHERE id = $1
+	`, id, status, errMsg, next)
+	return err
+}
+
+// RequeueDelivery returns sql.ErrNoRows when the delivery does not exist or
+// was already sent.
+func (r *PostgresRepo) RequeueDelivery(ctx context.Context, id int64) error {
+	res, err := r.DB.ExecContext(ctx, `
+		UPDATE notification_deliveries SET status = 'pending', next_attempt_at = now()
+		WHERE id = $1 AND status <> 'sent'
+	`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *PostgresRepo) GetNotificationDeliveries(ctx context.Context, status string, limit int) ([]model.NotificationDelivery, error) {
+	query := deliverySelect
+	args := []interface{}{limit}
+	if status != "" {
+		query += " WHERE status = $2"
+		args = append(args, status)
+	}
+	query += " ORDER BY created_at DESC, id DESC LIMIT $1"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying notification deliveries failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.NotificationDelivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// GetNotificationDelivery returns nil, nil when the delivery does not exist.
+func (r *PostgresRepo) GetNotificationDelivery(ctx context.Context, id int64) (*model.NotificationDelivery, error) {
+	d, err := scanDelivery(r.DB.QueryRowContext(ctx, deliverySelect+" WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,395 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+const objectiveSelect = `
+	SELECT o.id, o.title, COALESCE(o.description, ''), o.period_label, o.owner_type,
+		o.owner_user_id, COALESCE(u.name, ''), COALESCE(r.name, ''), o.created_at, o.updated_at
+	FROM okr_objectives o
+	LEFT JOIN users u ON o.owner_user_id = u.clickup_id
+	LEFT JOIN roles r ON o.owner_role_id = r.id
+`
+
+func scanObjective(row interface{ Scan(...interface{}) error }) (model.Objective, error) {
+	var o model.Objective
+	var ownerUserID sql.NullInt64
+	err := row.Scan(&o.ID, &o.Title, &o.Description, &o.Period, &o.OwnerType,
+		&ownerUserID, &o.OwnerName, &o.OwnerRole, &o.CreatedAt, &o.UpdatedAt)
+	if ownerUserID.Valid {
+		o.OwnerUserID = &ownerUserID.Int64
+	}
+	return o, err
+}
+
+// objectiveRoleID returns nil for member objectives and sql.ErrNoRows for an
+// unknown role.
+func objectiveRoleID(ctx context.Context, tx *sql.Tx, o *model.Objective) (*int, error) {
+	if o.OwnerType != model.OKROwnerRole {
+		return nil, nil
+	}
+	var roleID int
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM roles WHERE lower(name) = lower($1) LIMIT 1`, o.OwnerRole).Scan(&roleID); err != nil {
+		return nil, err
+	}
+	return &roleID, nil
+}
+
+// CreateObjective returns sql.ErrNoRows when the owner member or role does not exist.
+func (r *PostgresRepo) CreateObjective(ctx context.Context, o *model.Objective, start, end time.Time) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	roleID, err := objectiveRoleID(ctx, tx, o)
+	if err != nil {
+		return err
+	}
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO okr_objectives (title, description, period_label, period_start, period_end, owner_type, owner_user_id, owner_role_id)
+		VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8)
+		RETURNING id, created_at, updated_at
+	`, o.Title, o.Description, o.Period, start, end, o.OwnerType, o.OwnerUserID, roleID).Scan(&o.ID, &o.CreatedAt, &o.UpdatedAt)
+	if isForeignKeyViolation(err) {
+		return sql.ErrNoRows
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateObjective returns sql.ErrNoRows when the objective, owner member or role does not exist.
+func (r *PostgresRepo) UpdateObjective(ctx context.Context, o *model.Objective, start, end time.Time) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	roleID, err := objectiveRoleID(ctx, tx, o)
+	if err != nil {
+		return err
+	}
+	err = tx.QueryRowContext(ctx, `
+		UPDATE okr_objectives SET
+			title = $2, description = NULLIF($3, ''), period_label = $4, period_start = $5, period_end = $6,
+			owner_type = $7, owner_user_id = $8, owner_role_id = $9, updated_at = now()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`, o.ID, o.Title, o.Description, o.Period, start, end, o.OwnerType, o.OwnerUserID, roleID).Scan(&o.CreatedAt, &o.UpdatedAt)
+	if isForeignKeyViolation(err) {
+		return sql.ErrNoRows
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRepo) DeleteObjective(ctx context.Context, id int64) (bool, error) {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM okr_objectives WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// GetObjectives returns objectives with their key results and links. Member
+// filtering also includes objectives owned by the member's role.
+func (r *PostgresRepo) GetObjectives(ctx context.Context, period string, userID *int64, role string) ([]model.Objective, error) {
+	query := objectiveSelect + " WHERE 1=1"
+	args := []interface{}{}
+	if period != "" {
+		args = append(args, period)
+		query += fmt.Sprintf(" AND o.period_label = $%d", len(args))
+	}
+	if userID != nil {
+		args = append(args, *userID)
+		query += fmt.Sprintf(` AND (o.owner_user_id = $%d OR o.owner_role_id = (SELECT role_id FROM users WHERE clickup_id = $%d))`, len(args), len(args))
+	}
+	if role != "" {
+		args = append(args, role)
+		query += fmt.Sprintf(" AND lower(r.name) = lower($%d)", len(args))
+	}
+	query += " ORDER BY o.period_start DESC, o.id"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying objectives failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.Objective{}
+	for rows.Next() {
+		o, err := scanObjective(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range out {
+		if out[i].KeyResults, err = r.getKeyResults(ctx, out[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// GetObjective returns nil, nil when the objective does not exist.
+func (r *PostgresRepo) GetObjective(ctx context.Context, id int64) (*model.Objective, error) {
+	o, err := scanObjective(r.DB.QueryRowContext(ctx, objectiveSelect+" WHERE o.id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.KeyResults, err = r.getKeyResults(ctx, id); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+// GetObjectiveRange returns the stored period range of an objective.
+func (r *PostgresRepo) GetObjectiveRange(ctx context.Context, id int64) (time.Time, time.Time, error) {
+	var start, end time.Time
+	err := r.DB.QueryRowContext(ctx, `SELECT period_start, period_end FROM okr_objectives WHERE id = $1`, id).Scan(&start, &end)
+	return start, end, err
+}
+
+func (r *PostgresRepo) getKeyResults(ctx context.Context, objectiveID int64) ([]model.KeyResult, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, objective_id, title, metric, target_value, weight, created_at, updated_at
+		FROM okr_key_results
+		WHERE objective_id = $1
+		ORDER BY id
+	`, objectiveID)
+	if err != nil {
+		return nil, fmt.Errorf("querying key results failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.KeyResult{}
+	for rows.Next() {
+		kr, err := scanKeyResult(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, kr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range out {
+		if out[i].Links, err = r.getKeyResultLinks(ctx, out[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func scanKeyResult(row interface{ Scan(...interface{}) error }) (model.KeyResult, error) {
+	var kr model.KeyResult
+	var target sql.NullFloat64
+	err := row.Scan(&kr.ID, &kr.ObjectiveID, &kr.Title, &kr.Metric, &target, &kr.Weight, &kr.CreatedAt, &kr.UpdatedAt)
+	if target.Valid {
+		kr.TargetValue = &target.Float64
+	}
+	return kr, err
+}
+
+// GetKeyResult returns nil, nil when the key result does not exist.
+func (r *PostgresRepo) GetKeyResult(ctx context.Context, id int64) (*model.KeyResult, error) {
+	kr, err := scanKeyResult(r.DB.QueryRowContext(ctx, `
+		SELECT id, objective_id, title, metric, target_value, weight, created_at, updated_at
+		FROM okr_key_results
+		WHERE id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if kr.Links, err = r.getKeyResultLinks(ctx, id); err != nil {
+		return nil, err
+	}
+	return &kr, nil
+}
+
+func (r *PostgresRepo) getKeyResultLinks(ctx context.Context, keyResultID int64) ([]model.KeyResultLink, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, key_result_id, link_type, link_value
+		FROM okr_key_result_links
+		WHERE key_result_id = $1
+		ORDER BY id
+	`, keyResultID)
+	if err != nil {
+		return nil, fmt.Errorf("querying key result links failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.KeyResultLink{}
+	for rows.Next() {
+		var l model.KeyResultLink
+		if err := rows.Scan(&l.ID, &l.KeyResultID, &l.LinkType, &l.LinkValue); err != nil {
+			return nil, err
+		}
+		out = append(out, l)
+	}
+	return out, rows.Err()
+}
+
+func insertKeyResultLinks(ctx context.Context, tx *sql.Tx, kr *model.KeyResult) error {
+	for i := range kr.Links {
+		l := &kr.Links[i]
+		l.KeyResultID = kr.ID
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO okr_key_result_links (key_result_id, link_type, link_value)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (key_result_id, link_type, link_value) DO UPDATE SET link_value = EXCLUDED.link_value
+			RETURNING id
+		`, kr.ID, l.LinkType, l.LinkValue).Scan(&l.ID)
+		if err != nil {
+			return fmt.Errorf("failed to link %s %s: %w", l.LinkType, l.LinkValue, err)
+		}
+	}
+	return nil
+}
+
+func (r *PostgresRepo) CreateKeyResult(ctx context.Context, kr *model.KeyResult) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO okr_key_results (objective_id, title, metric, target_value, weight)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`, kr.ObjectiveID, kr.Title, kr.Metric, kr.TargetValue, kr.Weight).Scan(&kr.ID, &kr.CreatedAt, &kr.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if err := insertKeyResultLinks(ctx, tx, kr); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UpdateKeyResult replaces the key result fields and its links. It returns
+// sql.ErrNoRows when the key result does not exist.
+func (r *PostgresRepo) UpdateKeyResult(ctx context.Context, kr *model.KeyResult) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		UPDATE okr_key_results SET title = $2, metric = $3, target_value = $4, weight = $5, updated_at = now()
+		WHERE id = $1
+		RETURNING objective_id, created_at, updated_at
+	`, kr.ID, kr.Title, kr.Metric, kr.TargetValue, kr.Weight).Scan(&kr.ObjectiveID, &kr.CreatedAt, &kr.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM okr_key_result_links WHERE key_result_id = $1`, kr.ID); err != nil {
+		return err
+	}
+	if err := insertKeyResultLinks(ctx, tx, kr); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresRepo) DeleteKeyResult(ctx context.Context, id int64) (bool, error) {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM okr_key_results WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// GetKeyResultTasks resolves the tasks behind the given key results in one
+// query, keyed by key result ID. Explicit task links always count; list,
+// folder and tag links only count tasks that overlap the objective period.
+func (r *PostgresRepo) GetKeyResultTasks(ctx context.Context, keyResultIDs []int64, start, end time.Time) (map[int64][]model.OKRTask, error) {
+	out := make(map[int64][]model.OKRTask, len(keyResultIDs))
+	for _, id := range keyResultIDs {
+		out[id] = []model.OKRTask{}
+	}
+	if len(keyResultIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT kr.id, t.id, COALESCE(t.name, ''), t.list_id, COALESCE(ts.name, ''), COALESCE(ts.type, ''),
+			t.due_date, COALESCE(t.date_done, t.date_closed),
+			COALESCE(t.time_estimate_hours, 0), COALESCE(t.time_spent_hours, 0)
+		FROM tasks t
+		LEFT JOIN lists l ON l.id = t.list_id
+		LEFT JOIN task_statuses ts ON ts.id = t.status_id
+		JOIN unnest($1::bigint[]) AS kr(id) ON EXISTS (
+			SELECT 1 FROM okr_key_result_links k
+			WHERE k.key_result_id = kr.id AND (
+				(k.link_type = 'task' AND k.link_value = t.id)
+				OR (
+					(
+						(k.link_type = 'list' AND k.link_value = t.list_id)
+						OR (k.link_type = 'folder' AND k.link_value = l.folder_id)
+						OR (k.link_type = 'tag' AND EXISTS (
+							SELECT 1 FROM task_tags tt WHERE tt.task_id = t.id AND tt.tag = k.link_value
+						))
+					)
+					AND `+taskOverlap("$2", "$3")+`
+				)
+			)
+		)
+		ORDER BY kr.id, t.due_date NULLS LAST, t.id
+	`, pq.Array(keyResultIDs), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("querying key result tasks failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var krID int64
+		var t model.OKRTask
+		var listID sql.NullString
+		var dueDate, dateDone sql.NullTime
+		if err := rows.Scan(&krID, &t.TaskID, &t.TaskName, &listID, &t.StatusName, &t.StatusType,
+			&dueDate, &dateDone, &t.EstimateHours, &t.SpentHours); err != nil {
+			return nil, err
+		}
+		if listID.Valid {
+			t.ListID = &listID.String
+		}
+		if dueDate.Valid {
+			t.DueDate = &dueDate.Time
+		}
+		if dateDone.Valid {
+			t.DateDone = &dateDone.Time
+		}
+		out[krID] = append(out[krID], t)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,55 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/repository/repotest"
+)
+
+// TestKeyResultTasksBatch butuh TEST_DATABASE_URL (lihat repotest).
+func TestKeyResultTasksBatch(t *testing.T) {
+	repo := repotest.New(t, "okr")
+	ctx := context.Background()
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0).Add(-time.Nanosecond)
+
+	seed := []string{
+		`INSERT INTO tasks (id, name, start_date, due_date) VALUES
+			('t1', 'in period', '2026-10-05', '2026-10-09'),
+			('t2', 'before period', '2026-09-01', '2026-09-03'),
+			('t3', 'in period, untagged', '2026-10-12', '2026-10-14')`,
+		`INSERT INTO task_tags (task_id, tag) VALUES ('t1', 'q4'), ('t2', 'q4')`,
+		`INSERT INTO okr_objectives (id, title, period_label, period_start, period_end, owner_type)
+			VALUES (1, 'Ship', '2026-10', '2026-10-01', '2026-10-31', 'team')`,
+		`INSERT INTO okr_key_results (id, objective_id, title, metric) VALUES
+			(1, 1, 'tagged', 'tasks_completed'), (2, 1, 'explicit', 'tasks_completed'), (3, 1, 'unlinked', 'tasks_completed')`,
+		// t1 cocok lewat tag dan link task sekaligus, tetap dihitung sekali
+		`INSERT INTO okr_key_result_links (key_result_id, link_type, link_value) VALUES
+			(1, 'tag', 'q4'), (1, 'task', 't1'), (2, 'task', 't2'), (2, 'task', 't3')`,
+	}
+	for _, q := range seed {
+		if _, err := repo.DB.ExecContext(ctx, q); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	got, err := repo.GetKeyResultTasks(ctx, []int64{1, 2, 3}, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[int64][]string{1: {"t1"}, 2: {"t2", "t3"}, 3: {}}
+	for kr, ids := range want {
+		tasks := got[kr]
+		if tasks == nil || len(tasks) != len(ids) {
+			t.Errorf("key result %d: %d tasks, want %v", kr, len(tasks), ids)
+			continue
+		}
+		for i, id := range ids {
+			if tasks[i].TaskID != id {
+				t.Errorf("key result %d: task %d is %s, want %s", kr, i, tasks[i].TaskID, id)
+			}
+		}
+	}
+}
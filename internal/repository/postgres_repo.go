@@ -10,6 +10,7 @@ import (
 	"time"
 
 	_ "github.com/lib/pq"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
 	"github.com/roksva123/go-kinerja-backend/internal/model"
 )
 
@@ -50,7 +51,6 @@ func NewPostgresRepoFromConfig(cfg *DBConfig) (*PostgresRepo, error) {
 
 func NewPostgresRepo() *PostgresRepo {
 	 dsn := os.Getenv("DATABASE_URL")
-	 fmt.Println("dsn", dsn)
 	 if dsn == "" {
 	 dsn = "host=db.fsufakerljrkzrlrjiwm.supabase.co port=5432 user=postgres password=aufa dbname=kinerja_db sslmode=disable"
 	 }
@@ -83,161 +83,39 @@ func (r *PostgresRepo) GetByUsername(ctx context.Context, username string) (*mod
 }
 
 
+// RunMigrations applies the pending migrations embedded from migrations/.
 func (r *PostgresRepo) RunMigrations(ctx context.Context) error {
-    queries := []string{
-		`CREATE TABLE IF NOT EXISTS admins (
-         id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-         username VARCHAR(100) UNIQUE NOT NULL,
-         password_hash TEXT NOT NULL,
-         created_at TIMESTAMP DEFAULT NOW()
-       );`,
-        `CREATE EXTENSION IF NOT EXISTS "pgcrypto";`,
-        `CREATE TABLE IF NOT EXISTS roles (
-            id SERIAL PRIMARY KEY,
-            name TEXT UNIQUE NOT NULL
-        );`,
-        `CREATE TABLE IF NOT EXISTS user_statuses (
-            id SERIAL PRIMARY KEY,
-            name TEXT UNIQUE NOT NULL
-        );`,
-        `INSERT INTO roles (name) VALUES
-            ('infra'),
-            ('mobile apps'),
-            ('web'),
-            ('backend'),
-            ('pm'),
-            ('backend-web'),
-            ('analis'),
-            ('UI-UX')
-        ON CONFLICT (name) DO NOTHING;`,
-        `INSERT INTO user_statuses (name) VALUES
-            ('aktif'),
-            ('nonaktif')
-        ON CONFLICT (name) DO NOTHING;`,
-        `CREATE TABLE IF NOT EXISTS teams (
-            team_id TEXT PRIMARY KEY,
-            name TEXT NOT NULL,
-            parent_id TEXT,
-            created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
-            updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
-        );`,
-        `CREATE TABLE IF NOT EXISTS users (
-         clickup_id BIGINT PRIMARY KEY,
-         name TEXT,
-         email TEXT,
-         password TEXT,
-         role_id INT REFERENCES roles(id) ON DELETE SET NULL,
-         status_id INT REFERENCES user_statuses(id) ON DELETE SET NULL,
-         created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
-         updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
-        );`,
-        `CREATE TABLE IF NOT EXISTS task_statuses (
-            id TEXT PRIMARY KEY,
-            name TEXT NOT NULL,
-            type TEXT,
-            color TEXT,
-            created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
-            updated_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
-            UNIQUE(id)
-        );`,
-        `CREATE TABLE IF NOT EXISTS tasks (
-        id TEXT PRIMARY KEY,
-        name TEXT,
-        text_content TEXT,
-        description TEXT,
-        status_id TEXT REFERENCES task_statuses(id) ON DELETE SET NULL,
-        date_done TIMESTAMPTZ,
-        date_closed TIMESTAMPTZ,
-        start_date TIMESTAMPTZ,
-        due_date TIMESTAMPTZ,
-        time_estimate_hours FLOAT,
-        time_spent_hours FLOAT,
-        list_id TEXT,
-        created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
-        updated_at TIMESTAMP WITH TIME ZONE DEFAULT now()
-    );`,
-    `CREATE TABLE IF NOT EXISTS task_assignees (
-        id BIGSERIAL PRIMARY KEY,
-        task_id TEXT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
-        user_clickup_id BIGINT NOT NULL REFERENCES users(clickup_id) ON DELETE CASCADE,
-        created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
-        UNIQUE(task_id, user_clickup_id)
-    );`,
-    `DO $$ BEGIN
-        ALTER TABLE tasks ADD COLUMN IF NOT EXISTS list_id TEXT;
-    END $$;`,
-    `DO $$ BEGIN
-        ALTER TABLE tasks ADD COLUMN IF NOT EXISTS time_estimate_hours FLOAT;
-        ALTER TABLE tasks ADD COLUMN IF NOT EXISTS time_spent_hours FLOAT;
-    END $$;`,
-    `DO $$ BEGIN
-        ALTER TABLE tasks ADD COLUMN IF NOT EXISTS remaining_time_hours FLOAT8;
-        ALTER TABLE tasks ADD COLUMN IF NOT EXISTS time_efficiency_percentage FLOAT8;
-    END $$;`,
-    `CREATE INDEX IF NOT EXISTS idx_tasks_start_date ON tasks(start_date);
-     CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks(due_date);
-     CREATE INDEX IF NOT EXISTS idx_tasks_status_id ON tasks(status_id);`,
-    `CREATE TABLE IF NOT EXISTS folders (
-        id TEXT PRIMARY KEY,
-        name TEXT NOT NULL,
-        archived BOOLEAN DEFAULT FALSE,
-        space_id TEXT,
-        created_at TIMESTAMPTZ DEFAULT now(),
-        updated_at TIMESTAMPTZ DEFAULT now()
-    );`,
-    `CREATE TABLE IF NOT EXISTS spaces (
-        id TEXT PRIMARY KEY,
-        name TEXT NOT NULL,
-        archived BOOLEAN DEFAULT FALSE,
-        created_at TIMESTAMPTZ DEFAULT now(),
-        updated_at TIMESTAMPTZ DEFAULT now()
-    );`,
-    `CREATE TABLE IF NOT EXISTS lists (
-        id TEXT PRIMARY KEY,
-        name TEXT NOT NULL,
-        archived BOOLEAN DEFAULT FALSE,
-        folder_id TEXT,
-        space_id TEXT,
-        created_at TIMESTAMPTZ DEFAULT now(),
-        updated_at TIMESTAMPTZ DEFAULT now()
-    );`,
-    `CREATE TABLE IF NOT EXISTS sync_history (
-        id SERIAL PRIMARY KEY,
-        sync_time TIMESTAMPTZ DEFAULT now(),
-        sync_type TEXT NOT NULL,
-        status TEXT NOT NULL,
-        duration_ms BIGINT,
-        details JSONB
-    );`,
-    }
-    for _, q := range queries {
-        if _, err := r.DB.ExecContext(ctx, q); err != nil {
-            return err
-        }
-    }
-    return nil
+	m, err := NewMigrator(r.DB)
+	if err != nil {
+		return err
+	}
+	applied, err := m.Up(ctx)
+	for _, mig := range applied {
+		log.Printf("migration %04d_%s applied", mig.Version, mig.Name)
+	}
+	return err
 }
 
 func (r *PostgresRepo) GetAdminByUsername(ctx context.Context, username string) (*model.Admin, error) {
     query := `
-        SELECT id, username, password_hash
+        SELECT id, username, password_hash, language, role, user_clickup_id
         FROM admins
         WHERE username = $1
         LIMIT 1
     `
 
     row := r.DB.QueryRowContext(ctx, query, username)
-    fmt.Println("DEBUG mencari username:", username)
-    
 
     var a model.Admin
     err := row.Scan(
         &a.ID,
         &a.Username,
         &a.PasswordHash,
+        &a.Language,
+        &a.Role,
+        &a.UserID,
     )
     if err != nil {
-        fmt.Println("SCAN ERROR:", err)
         return nil, err
     }
 
@@ -285,6 +163,37 @@ func (r *PostgresRepo) GetAllUsers(ctx context.Context) ([]model.User, error) {
     return users, nil
 }
 
+// UpdateAdminLanguage stores the admin's language preference and returns the
+// updated admin, or sql.ErrNoRows when id does not exist.
+func (r *PostgresRepo) UpdateAdminLanguage(ctx context.Context, id, lang string) (*model.Admin, error) {
+	var a model.Admin
+	err := r.DB.QueryRowContext(ctx, `
+		UPDATE admins SET language = $2 WHERE id = $1
+		RETURNING id, username, language, role, user_clickup_id
+	`, id, lang).Scan(&a.ID, &a.Username, &a.Language, &a.Role, &a.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// SaveAdmin creates or updates the account a.Username with its password
+// hash, role and linked member, then sets a.ID and a.Language.
+func (r *PostgresRepo) SaveAdmin(ctx context.Context, a *model.Admin) error {
+	err := r.DB.QueryRowContext(ctx, `
+		INSERT INTO admins (username, password_hash, role, user_clickup_id) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (username) DO UPDATE SET
+			password_hash = EXCLUDED.password_hash,
+			role = EXCLUDED.role,
+			user_clickup_id = EXCLUDED.user_clickup_id
+		RETURNING id, language
+	`, a.Username, a.PasswordHash, a.Role, a.UserID).Scan(&a.ID, &a.Language)
+	if isForeignKeyViolation(err) {
+		return sql.ErrNoRows
+	}
+	return err
+}
+
 func (r *PostgresRepo) UpsertAdmin(ctx context.Context, username, passwordHash string) error {
 	_, err := r.DB.ExecContext(ctx, `
 		INSERT INTO admins (username, password_hash) VALUES ($1,$2)
@@ -326,6 +235,25 @@ func (r *PostgresRepo) UpsertTaskAssignees(ctx context.Context, taskID string, a
 	return tx.Commit()
 }
 
+// ReplaceTaskTags mengganti seluruh tag sebuah task dengan hasil sync terbaru.
+func (r *PostgresRepo) ReplaceTaskTags(ctx context.Context, taskID string, tags []string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = $1", taskID); err != nil {
+		return fmt.Errorf("failed to delete old tags: %w", err)
+	}
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO task_tags (task_id, tag) VALUES ($1, $2) ON CONFLICT DO NOTHING", taskID, tag); err != nil {
+			return fmt.Errorf("failed to insert tag %s for task %s: %w", tag, taskID, err)
+		}
+	}
+	return tx.Commit()
+}
+
 // UpsertTeam
 func (r *PostgresRepo) UpsertTeam(ctx context.Context, teamID, name, parentID string) error {
 	log.Printf("UpsertTeam is deprecated, use UpsertSpace instead. Called with teamID: %s", teamID)
@@ -338,14 +266,14 @@ func (r *PostgresRepo) UpsertUser(ctx context.Context, u *model.User) error {
 		INSERT INTO users (clickup_id, name, email, role_id, status_id)
 		VALUES (
 			$1, $2, $3,
-			(SELECT id FROM roles WHERE name ILIKE $4 LIMIT 1),
-			(SELECT id FROM user_statuses WHERE name ILIKE $5 LIMIT 1)
+			(SELECT id FROM roles WHERE lower(name) = lower($4) LIMIT 1),
+			(SELECT id FROM user_statuses WHERE lower(name) = lower($5) LIMIT 1)
 		)
 		ON CONFLICT (clickup_id) DO UPDATE SET
 			name = EXCLUDED.name,
 			email = EXCLUDED.email,
-			role_id = COALESCE((SELECT id FROM roles WHERE name ILIKE $6 LIMIT 1), users.role_id),
-			status_id = (SELECT id FROM user_statuses WHERE name ILIKE $7 LIMIT 1),
+			role_id = COALESCE((SELECT id FROM roles WHERE lower(name) = lower($6) LIMIT 1), users.role_id),
+			status_id = (SELECT id FROM user_statuses WHERE lower(name) = lower($7) LIMIT 1),
 			updated_at = now()
 	`
 	_, err := r.DB.ExecContext(ctx, query, u.ClickUpID, u.Name, u.Email, u.Role, u.Status, u.Role, u.Status)
@@ -383,15 +311,17 @@ func (r *PostgresRepo) UpsertFolder(ctx context.Context, folder *model.Folder) e
 
 func (r *PostgresRepo) UpsertList(ctx context.Context, list *model.List) error {
 	query := `
-		INSERT INTO lists (id, name, folder_id, space_id)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO lists (id, name, folder_id, space_id, start_date, due_date)
+		VALUES ($1, $2, $3, $4, $5, $6)
 		ON CONFLICT (id) DO UPDATE SET
 			name = EXCLUDED.name,
 			folder_id = EXCLUDED.folder_id,
 			space_id = EXCLUDED.space_id,
+			start_date = EXCLUDED.start_date,
+			due_date = EXCLUDED.due_date,
 			updated_at = now();
 	`
-	_, err := r.DB.ExecContext(ctx, query, list.ID, list.Name, list.FolderID, list.SpaceID)
+	_, err := r.DB.ExecContext(ctx, query, list.ID, list.Name, list.FolderID, list.SpaceID, list.StartDate, list.DueDate)
 	return err
 }
 
@@ -421,9 +351,9 @@ func (r *PostgresRepo) UpsertTask(ctx context.Context, t *model.TaskResponse) er
             id, name, text_content, description,
             status_id, date_done, date_closed, start_date, due_date,
             time_estimate_hours, time_spent_hours, list_id,
-            remaining_time_hours, time_efficiency_percentage
+            remaining_time_hours, time_efficiency_percentage, date_created, estimate_source
         )
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, NULLIF($16, ''))
         ON CONFLICT (id)
         DO UPDATE SET
             name = EXCLUDED.name,
@@ -434,11 +364,18 @@ func (r *PostgresRepo) UpsertTask(ctx context.Context, t *model.TaskResponse) er
             date_closed = EXCLUDED.date_closed,
             start_date = EXCLUDED.start_date,
             due_date = EXCLUDED.due_date,
-            time_estimate_hours = EXCLUDED.time_estimate_hours,
+            -- estimasi manual tidak ditimpa oleh default 8 jam dari sync
+            time_estimate_hours = CASE
+                WHEN tasks.estimate_source = 'manual' AND EXCLUDED.estimate_source = 'default' THEN tasks.time_estimate_hours
+                ELSE EXCLUDED.time_estimate_hours END,
+            estimate_source = CASE
+                WHEN tasks.estimate_source = 'manual' AND EXCLUDED.estimate_source = 'default' THEN tasks.estimate_source
+                ELSE COALESCE(EXCLUDED.estimate_source, tasks.estimate_source) END,
             time_spent_hours = EXCLUDED.time_spent_hours,
             list_id = EXCLUDED.list_id,
             remaining_time_hours = EXCLUDED.remaining_time_hours,
             time_efficiency_percentage = EXCLUDED.time_efficiency_percentage,
+            date_created = COALESCE(EXCLUDED.date_created, tasks.date_created),
             updated_at = now()
     `
     _, err := r.DB.ExecContext(ctx, query,
@@ -456,6 +393,8 @@ func (r *PostgresRepo) UpsertTask(ctx context.Context, t *model.TaskResponse) er
         t.ListID,
         t.RemainingTimeHours,
         t.TimeEfficiencyPercentage,
+        t.DateCreated,
+        t.EstimateSource,
     )
 
     return err
@@ -489,83 +428,9 @@ func (r *PostgresRepo) GetUserByEmail(ctx context.Context, email string) (*model
 }
 
 
+// GetTasks returns every task with its assignees, most recently done first.
 func (r *PostgresRepo) GetTasks(ctx context.Context) ([]model.TaskResponse, error) {
-    q := `
-        SELECT 
-            COALESCE(task_id, id) AS id,
-            name,
-            description,
-            ts.id as status_id,
-            ts.name as status_name,
-            ts.type as status_type,
-            date_done,    -- Sekarang TIMESTAMPTZ
-            start_date,   -- Sekarang TIMESTAMPTZ
-            due_date,     -- Sekarang TIMESTAMPTZ
-            date_closed,  -- Sekarang TIMESTAMPTZ
-            '' as assignee_username, '' as assignee_email, '' as assignee_color
-        FROM tasks
-        ORDER BY COALESCE(date_done, 0) DESC
-    `
-
-
-    rows, err := r.DB.QueryContext(ctx, q)
-    if err != nil {
-        return nil, err
-    }
-    defer rows.Close()
-
-    out := []model.TaskResponse{}
-
-    for rows.Next() {
-
-        var t model.TaskResponse
-
-        var (
-            statusID, statusName, statusType sql.NullString
-            dateDone, dateClosed, startDate, dueDate       sql.NullTime 
-            uname, uemail, ucolor sql.NullString
-        )
-
-        if err := rows.Scan(
-            &t.ID,
-            &t.Name,
-            &t.Description,
-            &statusID,
-            &statusName,
-            &statusType,
-            &dateDone,
-            &dueDate,
-            &startDate,
-            &dateClosed,
-            &uname,
-            &uemail,
-            &ucolor,
-        ); err != nil {
-            return nil, err
-        }
-
-        t.Status.ID = statusID.String
-        t.Status.Name = statusName.String
-        t.Status.Type = statusType.String
-
-        if dateDone.Valid { t.DateDone = &dateDone.Time }
-        if dateClosed.Valid { t.DateClosed = &dateClosed.Time }
-        if dueDate.Valid { t.DueDate = &dueDate.Time }
-        if startDate.Valid { t.StartDate = &startDate.Time }
-        if uname.Valid {
-            t.Username = uname.String
-        }
-        if uemail.Valid {
-            t.Email = uemail.String
-        }
-        if ucolor.Valid {
-            t.Color = ucolor.String
-        }
-
-        out = append(out, t)
-    }
-
-    return out, nil
+	return r.queryTaskList(ctx, taskListSelect+" ORDER BY t.date_done DESC NULLS LAST, t.id")
 }
 
 
@@ -612,6 +477,26 @@ func (r *PostgresRepo) GetMembers(ctx context.Context) ([]model.User, error) {
     return out, nil
 }
 
+// GetMember returns nil, nil when the member does not exist.
+func (r *PostgresRepo) GetMember(ctx context.Context, id int64) (*model.User, error) {
+	var u model.User
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT u.clickup_id, COALESCE(u.name, ''), COALESCE(u.email, ''),
+			COALESCE(r.name, ''), COALESCE(us.name, ''), u.created_at, u.updated_at
+		FROM users u
+		LEFT JOIN roles r ON u.role_id = r.id
+		LEFT JOIN user_statuses us ON u.status_id = us.id
+		WHERE u.clickup_id = $1
+	`, id).Scan(&u.ClickUpID, &u.Name, &u.Email, &u.Role, &u.Status, &u.CreatedAt, &u.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
 // GetSpaces
 func (r *PostgresRepo) GetSpaces(ctx context.Context) ([]model.SpaceInfo, error) {
     q := `SELECT id, name FROM spaces ORDER BY name`
@@ -839,13 +724,7 @@ func (r *PostgresRepo) GetTasksByRange(
     if startMs != nil && endMs != nil {
         startTime := time.UnixMilli(*startMs)
         endTime := time.UnixMilli(*endMs)
-        query += fmt.Sprintf(`
-            AND (
-                (t.start_date IS NOT NULL AND t.due_date IS NOT NULL AND t.start_date <= $%d AND t.due_date >= $%d) OR
-                (t.date_done IS NOT NULL AND t.date_done BETWEEN $%d AND $%d) OR
-                (t.date_closed IS NOT NULL AND t.date_closed BETWEEN $%d AND $%d)
-            )
-        `, i+1, i, i, i+1)
+        query += " AND " + taskOverlap(fmt.Sprintf("$%d", i), fmt.Sprintf("$%d", i+1))
         args = append(args, startTime, endTime)
         i += 2
     }
@@ -990,8 +869,8 @@ func (r *PostgresRepo) GetTasksByRange(
             "TASK: %-40s | UID: %v | UN: %-20s | EMAIL: %-30s | START: %v | DUE: %v | SPENT: %v",
             tt.TaskName,
             tt.UserID,
-            tt.Username,
-            tt.Email,
+            stringValue(tt.Username),
+            stringValue(tt.Email),
             tt.StartDate,
             tt.DueDate,
             tt.TimeSpentHours,
@@ -1001,6 +880,14 @@ func (r *PostgresRepo) GetTasksByRange(
     return tasks, nil
 }
 
+// stringValue is *s, or "" for nil; for logging nullable columns.
+func stringValue(s *string) string {
+    if s == nil {
+        return ""
+    }
+    return *s
+}
+
 func (r *PostgresRepo) GetTasksFull(
     ctx context.Context,
     startMs *int64,
@@ -1232,18 +1119,7 @@ func (r *PostgresRepo) GetTasksFiltered(ctx context.Context, startDate, endDate
 
 
 func calculateWorkingDays(start, end time.Time) int {
-	if start.After(end) {
-		return 0
-	}
-
-	workingDays := 0
-	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
-		weekday := d.Weekday()
-		if weekday != time.Saturday && weekday != time.Sunday {
-			workingDays++
-		}
-	}
-	return workingDays
+	return dates.WorkingDays(start, end, dates.Default())
 }
 func (r *PostgresRepo) GetWorkload(ctx context.Context, start, end time.Time) ([]model.WorkloadUser, error) {
     query := `
@@ -1258,11 +1134,7 @@ func (r *PostgresRepo) GetWorkload(ctx context.Context, start, end time.Time) ([
         FROM users u
         LEFT JOIN roles r ON u.role_id = r.id
         LEFT JOIN task_assignees ta ON u.clickup_id = ta.user_clickup_id
-        LEFT JOIN tasks t ON ta.task_id = t.id AND (
-            (t.start_date IS NOT NULL AND t.due_date IS NOT NULL AND t.start_date <= $2 AND t.due_date >= $1) OR
-            (t.date_done IS NOT NULL AND t.date_done BETWEEN $1 AND $2) OR
-            (t.date_closed IS NOT NULL AND t.date_closed BETWEEN $1 AND $2)
-        )
+        LEFT JOIN tasks t ON ta.task_id = t.id AND `+taskOverlap("$1", "$2")+`
         WHERE u.status_id = (SELECT id FROM user_statuses WHERE name = 'aktif')
         GROUP BY u.clickup_id, u.name, u.email, r.name
         ORDER BY u.name ASC
@@ -1300,90 +1172,14 @@ func (r *PostgresRepo) GetWorkload(ctx context.Context, start, end time.Time) ([
 }
 
 func (r *PostgresRepo) GetTasksByUser(ctx context.Context, userID int64, start, end time.Time) ([]model.TaskItem, error) {
-    query := `
-        SELECT 
-            t.id,
-            t.name,
-            t.description, 
-            ts.id as status_id,
-            ts.name as status_name,
-            ts.type as status_type,
-            t.date_done,
-            t.date_closed,
-            t.start_date,
-            t.due_date,
-            t.time_estimate_hours,
-            t.time_spent_hours,
-            t.remaining_time_hours,
-            t.time_efficiency_percentage,
-            COALESCE(f.name, l.name) AS project_name,
-            '' AS category
-        FROM tasks t
-        INNER JOIN task_assignees ta ON t.id = ta.task_id
-        LEFT JOIN task_statuses ts ON t.status_id = ts.id
-        LEFT JOIN lists l ON t.list_id = l.id
-        LEFT JOIN folders f ON l.folder_id = f.id
-        WHERE ta.user_clickup_id = $1
-          AND (
-            (t.start_date IS NOT NULL AND t.due_date IS NOT NULL AND t.start_date <= $3 AND t.due_date >= $2) OR
-            (t.date_done IS NOT NULL AND t.date_done BETWEEN $2 AND $3)
-        )
+    tasks, err := r.queryTaskItems(ctx, taskItemSelect+`
+        WHERE ta.user_clickup_id = $1 AND `+taskOverlap("$2", "$3")+`
         ORDER BY t.start_date DESC, t.name ASC
-    `
-
-    rows, err := r.DB.QueryContext(ctx, query, userID, start, end)
+    `, userID, start, end)
     if err != nil {
         return nil, err
     }
-    defer rows.Close()
-
-    var tasks []model.TaskItem
-    for rows.Next() {
-        var t model.TaskItem
-        var timeEstimate, timeSpent, remainingHours, efficiencyPercentage sql.NullFloat64
-        var startDate, dueDate, dateDone, dateClosed sql.NullTime
-        var category sql.NullString
-        var description sql.NullString
-        var projectName sql.NullString
-
-        if err := rows.Scan(
-            &t.ID,
-            &t.Name,
-            &description,
-            &t.StatusID,
-            &t.StatusName,
-            &t.StatusType,
-            &dateDone,
-            &dateClosed,
-            &startDate,
-            &dueDate,
-            &timeEstimate,
-            &timeSpent,
-            &remainingHours,
-            &efficiencyPercentage,
-            &projectName,
-            &category,
-        ); err != nil {
-            return nil, err
-        }
-
-        if description.Valid { t.Description = description.String }
-        if dateDone.Valid { t.DateDone = &dateDone.Time }
-        if dateClosed.Valid { t.DateClosed = &dateClosed.Time }
-        if startDate.Valid { t.StartDate = &startDate.Time }
-        if dueDate.Valid { t.DueDate = &dueDate.Time }
-        if timeEstimate.Valid { t.TimeEstimateHours = timeEstimate.Float64 }
-        if timeSpent.Valid { t.TimeSpentHours = timeSpent.Float64 }
-        if remainingHours.Valid { t.RemainingTimeHours = &remainingHours.Float64 }
-        if efficiencyPercentage.Valid { t.TimeEfficiencyPercentage = &efficiencyPercentage.Float64 }
-        if projectName.Valid {
-            t.ProjectName = &projectName.String
-        }
-
-        tasks = append(tasks, t)
-    }
-
-    return tasks, nil
+    return tasks[userID], nil
 }
 
 func (r *PostgresRepo) GetTasksSummary(
@@ -1464,11 +1260,7 @@ func (r *PostgresRepo) GetTasksSummaryByDateRange(ctx context.Context, start, en
 			), 0) AS total_upcoming_hours
 		FROM users u
 		LEFT JOIN task_assignees ta ON u.clickup_id = ta.user_clickup_id
-		LEFT JOIN tasks t ON ta.task_id = t.id AND (
-			(t.start_date IS NOT NULL AND t.due_date IS NOT NULL AND t.start_date <= $2 AND t.due_date >= $1) OR
-            (t.date_done IS NOT NULL AND t.date_done BETWEEN $1 AND $2) OR
-            (t.date_closed IS NOT NULL AND t.date_closed BETWEEN $1 AND $2)
-		)
+		LEFT JOIN tasks t ON ta.task_id = t.id AND `+taskOverlap("$1", "$2")+`
 		LEFT JOIN task_statuses ts ON t.status_id = ts.id LEFT JOIN roles r ON u.role_id = r.id
 		WHERE u.status_id = (SELECT id FROM user_statuses WHERE name = 'aktif')
 		GROUP BY u.clickup_id, u.name, u.email, r.name
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// projectTables memetakan level project ke tabelnya.
+var projectTables = map[string]string{
+	model.ProjectLevelSpace:  "spaces",
+	model.ProjectLevelFolder: "folders",
+	model.ProjectLevelList:   "lists",
+}
+
+// projectFilters memetakan level project ke kolom filter pada query task.
+var projectFilters = map[string]string{
+	model.ProjectLevelSpace:  "COALESCE(l.space_id, f.space_id)",
+	model.ProjectLevelFolder: "l.folder_id",
+	model.ProjectLevelList:   "t.list_id",
+}
+
+func (r *PostgresRepo) GetProjectNodes(ctx context.Context, level string) ([]model.ProjectNode, error) {
+	table, ok := projectTables[level]
+	if !ok {
+		return nil, fmt.Errorf("unknown project level %q", level)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `SELECT id, name, COALESCE(archived, false) FROM `+table+` ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s failed: %w", table, err)
+	}
+	defer rows.Close()
+
+	out := []model.ProjectNode{}
+	for rows.Next() {
+		var n model.ProjectNode
+		if err := rows.Scan(&n.ID, &n.Name, &n.Archived); err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, rows.Err()
+}
+
+// GetProjectNode returns nil, nil when the space, folder or list does not exist.
+func (r *PostgresRepo) GetProjectNode(ctx context.Context, level, id string) (*model.ProjectNode, error) {
+	table, ok := projectTables[level]
+	if !ok {
+		return nil, fmt.Errorf("unknown project level %q", level)
+	}
+
+	var n model.ProjectNode
+	err := r.DB.QueryRowContext(ctx, `SELECT id, name, COALESCE(archived, false) FROM `+table+` WHERE id = $1`, id).
+		Scan(&n.ID, &n.Name, &n.Archived)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// GetProjectTasks returns tasks with their list, folder and space. An empty
+// level returns every task.
+func (r *PostgresRepo) GetProjectTasks(ctx context.Context, level, id string) ([]model.ProjectTask, error) {
+	query := `
+		SELECT t.id, COALESCE(t.name, ''),
+			COALESCE(t.list_id, ''), COALESCE(l.name, ''),
+			COALESCE(l.folder_id, ''), COALESCE(f.name, ''),
+			COALESCE(l.space_id, f.space_id, ''), COALESCE(sp.name, ''),
+			COALESCE(ts.name, ''), COALESCE(ts.type, ''),
+			t.due_date, COALESCE(t.date_done, t.date_closed),
+			COALESCE(t.time_estimate_hours, 0), COALESCE(t.time_spent_hours, 0),
+			ta.user_clickup_id, COALESCE(u.name, '')
+		FROM tasks t
+		LEFT JOIN lists l ON t.list_id = l.id
+		LEFT JOIN folders f ON l.folder_id = f.id
+		LEFT JOIN spaces sp ON sp.id = COALESCE(l.space_id, f.space_id)
+		LEFT JOIN task_statuses ts ON t.status_id = ts.id
+		LEFT JOIN task_assignees ta ON t.id = ta.task_id
+		LEFT JOIN users u ON ta.user_clickup_id = u.clickup_id
+	`
+	args := []interface{}{}
+	if level != "" {
+		filter, ok := projectFilters[level]
+		if !ok {
+			return nil, fmt.Errorf("unknown project level %q", level)
+		}
+		query += " WHERE " + filter + " = $1"
+		args = append(args, id)
+	}
+	query += " ORDER BY t.id, ta.user_clickup_id"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying project tasks failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.ProjectTask{}
+	for rows.Next() {
+		var t model.ProjectTask
+		var dueDate, dateDone sql.NullTime
+		var userID sql.NullInt64
+		var userName string
+		if err := rows.Scan(&t.TaskID, &t.TaskName, &t.ListID, &t.ListName, &t.FolderID, &t.FolderName,
+			&t.SpaceID, &t.SpaceName, &t.StatusName, &t.StatusType, &dueDate, &dateDone,
+			&t.EstimateHours, &t.SpentHours, &userID, &userName); err != nil {
+			return nil, err
+		}
+
+		// satu baris per assignee, gabungkan ke task sebelumnya
+		if n := len(out); n > 0 && out[n-1].TaskID == t.TaskID {
+			if userID.Valid {
+				out[n-1].Assignees = append(out[n-1].Assignees, model.ProjectAssignee{UserID: userID.Int64, Name: userName})
+			}
+			continue
+		}
+
+		if dueDate.Valid {
+			t.DueDate = &dueDate.Time
+		}
+		if dateDone.Valid {
+			t.DateDone = &dateDone.Time
+		}
+		t.Assignees = []model.ProjectAssignee{}
+		if userID.Valid {
+			t.Assignees = append(t.Assignees, model.ProjectAssignee{UserID: userID.Int64, Name: userName})
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// GetProjectTree returns spaces, folders and lists with Level and ParentID
+// set. An empty level returns every level; ids, when given, limits the result.
+func (r *PostgresRepo) GetProjectTree(ctx context.Context, level string, ids []string) ([]model.ProjectNode, error) {
+	query := `
+		SELECT level, id, name, archived, parent_id FROM (
+			SELECT 'space' AS level, id, COALESCE(name, '') AS name, COALESCE(archived, false) AS archived, '' AS parent_id
+			FROM spaces
+			UNION ALL
+			SELECT 'folder', id, COALESCE(name, ''), COALESCE(archived, false), COALESCE(space_id, '')
+			FROM folders
+			UNION ALL
+			SELECT 'list', id, COALESCE(name, ''), COALESCE(archived, false), COALESCE(folder_id, space_id, '')
+			FROM lists
+		) p WHERE TRUE`
+	args := []interface{}{}
+	if level != "" {
+		if _, ok := projectTables[level]; !ok {
+			return nil, fmt.Errorf("unknown project level %q", level)
+		}
+		args = append(args, level)
+		query += fmt.Sprintf(" AND level = $%d", len(args))
+	}
+	if ids != nil {
+		args = append(args, pq.Array(ids))
+		query += fmt.Sprintf(" AND id = ANY($%d)", len(args))
+	}
+	query += " ORDER BY LOWER(name), id"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying project tree failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.ProjectNode{}
+	for rows.Next() {
+		var n model.ProjectNode
+		if err := rows.Scan(&n.Level, &n.ID, &n.Name, &n.Archived, &n.ParentID); err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// GetOpenAssignments returns every assignment of active members on tasks that
+// are not finished yet, including overdue ones.
+func (r *PostgresRepo) GetOpenAssignments(ctx context.Context) ([]model.OpenTaskAssignment, error) {
+	query := `
+		SELECT
+			t.id,
+			COALESCE(t.name, ''),
+			COALESCE(ts.name, ''),
+			t.start_date,
+			t.due_date,
+			COALESCE(t.time_estimate_hours, 0),
+			COALESCE(t.time_spent_hours, 0),
+			u.clickup_id,
+			COALESCE(u.name, ''),
+			COALESCE(r.name, ''),
+			(SELECT COUNT(*) FROM task_assignees x WHERE x.task_id = t.id)
+		FROM tasks t
+		INNER JOIN task_assignees ta ON t.id = ta.task_id
+		INNER JOIN users u ON ta.user_clickup_id = u.clickup_id
+		LEFT JOIN roles r ON u.role_id = r.id
+		LEFT JOIN task_statuses ts ON t.status_id = ts.id
+		WHERE u.status_id = (SELECT id FROM user_statuses WHERE name = 'aktif')
+		  AND t.date_done IS NULL
+		  AND t.date_closed IS NULL
+		  AND COALESCE(ts.type, '') NOT IN ('done', 'closed')
+		ORDER BY u.clickup_id, t.due_date NULLS LAST
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("querying open assignments failed: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.OpenTaskAssignment
+	for rows.Next() {
+		var a model.OpenTaskAssignment
+		var startDate, dueDate sql.NullTime
+		if err := rows.Scan(
+			&a.TaskID,
+			&a.TaskName,
+			&a.StatusName,
+			&startDate,
+			&dueDate,
+			&a.EstimateHours,
+			&a.SpentHours,
+			&a.UserID,
+			&a.Name,
+			&a.Role,
+			&a.AssigneeCount,
+		); err != nil {
+			return nil, fmt.Errorf("scanning open assignment row failed: %w", err)
+		}
+		if startDate.Valid {
+			a.StartDate = &startDate.Time
+		}
+		if dueDate.Valid {
+			a.DueDate = &dueDate.Time
+		}
+		out = append(out, a)
+	}
+
+	return out, rows.Err()
+}
+
+// ReplacePendingSuggestions drops suggestions nobody acted on and stores the
+// new batch, filling in the generated IDs.
+func (r *PostgresRepo) ReplacePendingSuggestions(ctx context.Context, suggestions []model.RebalanceSuggestion) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM rebalance_suggestions WHERE status = 'pending'`); err != nil {
+		return fmt.Errorf("failed to delete pending suggestions: %w", err)
+	}
+
+	for i := range suggestions {
+		s := &suggestions[i]
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO rebalance_suggestions (task_id, from_user_id, to_user_id, remaining_hours, score, reason, status)
+			VALUES ($1, $2, $3, $4, $5, $6, 'pending')
+			RETURNING id, status, created_at
+		`, s.TaskID, s.FromUserID, s.ToUserID, s.RemainingHours, s.Score, s.Reason).Scan(&s.ID, &s.Status, &s.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert suggestion for task %s: %w", s.TaskID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+const rebalanceSuggestionSelect = `
+	SELECT
+		s.id, s.task_id, COALESCE(t.name, ''), t.due_date, COALESCE(s.remaining_hours, 0),
+		s.from_user_id, COALESCE(fu.name, ''), s.to_user_id, COALESCE(tu.name, ''),
+		COALESCE(r.name, ''), s.score, COALESCE(s.reason, ''), s.status, s.created_at, s.decided_at
+	FROM rebalance_suggestions s
+	LEFT JOIN tasks t ON s.task_id = t.id
+	LEFT JOIN users fu ON s.from_user_id = fu.clickup_id
+	LEFT JOIN users tu ON s.to_user_id = tu.clickup_id
+	LEFT JOIN roles r ON tu.role_id = r.id
+`
+
+func scanRebalanceSuggestion(row interface{ Scan(...interface{}) error }) (model.RebalanceSuggestion, error) {
+	var s model.RebalanceSuggestion
+	var dueDate, decidedAt sql.NullTime
+	err := row.Scan(
+		&s.ID, &s.TaskID, &s.TaskName, &dueDate, &s.RemainingHours,
+		&s.FromUserID, &s.FromName, &s.ToUserID, &s.ToName,
+		&s.Role, &s.Score, &s.Reason, &s.Status, &s.CreatedAt, &decidedAt,
+	)
+	if dueDate.Valid {
+		s.DueDate = &dueDate.Time
+	}
+	if decidedAt.Valid {
+		s.DecidedAt = &decidedAt.Time
+	}
+	return s, err
+}
+
+func (r *PostgresRepo) GetRebalanceSuggestions(ctx context.Context, status string) ([]model.RebalanceSuggestion, error) {
+	query := rebalanceSuggestionSelect
+	args := []interface{}{}
+	if status != "" {
+		query += " WHERE s.status = $1"
+		args = append(args, status)
+	}
+	query += " ORDER BY s.score DESC, s.id ASC"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying rebalance suggestions failed: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.RebalanceSuggestion
+	for rows.Next() {
+		s, err := scanRebalanceSuggestion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scanning rebalance suggestion failed: %w", err)
+		}
+		out = append(out, s)
+	}
+	return out, rows.Err()
+}
+
+// GetRebalanceSuggestion returns nil, nil when the suggestion does not exist.
+func (r *PostgresRepo) GetRebalanceSuggestion(ctx context.Context, id int64) (*model.RebalanceSuggestion, error) {
+	row := r.DB.QueryRowContext(ctx, rebalanceSuggestionSelect+" WHERE s.id = $1", id)
+	s, err := scanRebalanceSuggestion(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SetSuggestionStatus records a decision on a suggestion. Accepting one
+// supersedes the other pending suggestions for the same task.
+func (r *PostgresRepo) SetSuggestionStatus(ctx context.Context, id int64, status string) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var taskID string
+	err = tx.QueryRowContext(ctx, `
+		UPDATE rebalance_suggestions SET status = $2, decided_at = now()
+		WHERE id = $1
+		RETURNING task_id
+	`, id, status).Scan(&taskID)
+	if err != nil {
+		return err
+	}
+
+	if status == "accepted" {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE rebalance_suggestions SET status = 'superseded', decided_at = now()
+			WHERE task_id = $1 AND id <> $2 AND status = 'pending'
+		`, taskID, id); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ReassignTask moves a task from one assignee to another in the local copy,
+// mirroring what was sent to ClickUp.
+func (r *PostgresRepo) ReassignTask(ctx context.Context, taskID string, fromUserID, toUserID int64) error {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_assignees WHERE task_id = $1 AND user_clickup_id = $2`, taskID, fromUserID); err != nil {
+		return fmt.Errorf("failed to remove assignee %d from task %s: %w", fromUserID, taskID, err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO task_assignees (task_id, user_clickup_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, taskID, toUserID); err != nil {
+		return fmt.Errorf("failed to add assignee %d to task %s: %w", toUserID, taskID, err)
+	}
+
+	return tx.Commit()
+}
+
+// GetRoleCompatibility returns, per role name, the other roles that may take
+// over its tasks (table role_compatibility).
+func (r *PostgresRepo) GetRoleCompatibility(ctx context.Context) (map[string][]string, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT f.name, t.name
+		FROM role_compatibility c
+		JOIN roles f ON f.id = c.role_id
+		JOIN roles t ON t.id = c.compatible_role_id
+		ORDER BY f.name, t.name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("querying role compatibility failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[string][]string{}
+	for rows.Next() {
+		var from, to string
+		if err := rows.Scan(&from, &to); err != nil {
+			return nil, err
+		}
+		out[from] = append(out[from], to)
+	}
+	return out, rows.Err()
+}
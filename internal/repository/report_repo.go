@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+const reportDefinitionSelect = `
+	SELECT id, name, report_type, period, audience, filters, format, language, active,
+		last_run_at, next_run_at, created_at, updated_at
+	FROM report_definitions
+`
+
+func scanReportDefinition(row interface{ Scan(...interface{}) error }) (model.ReportDefinition, error) {
+	var d model.ReportDefinition
+	var audience, filters []byte
+	var lastRun, nextRun sql.NullTime
+	if err := row.Scan(&d.ID, &d.Name, &d.ReportType, &d.Period, &audience, &filters, &d.Format, &d.Language, &d.Active,
+		&lastRun, &nextRun, &d.CreatedAt, &d.UpdatedAt); err != nil {
+		return d, err
+	}
+	if err := json.Unmarshal(audience, &d.Audience); err != nil {
+		return d, fmt.Errorf("invalid audience for report %d: %w", d.ID, err)
+	}
+	if err := json.Unmarshal(filters, &d.Filters); err != nil {
+		return d, fmt.Errorf("invalid filters for report %d: %w", d.ID, err)
+	}
+	if lastRun.Valid {
+		d.LastRunAt = &lastRun.Time
+	}
+	if nextRun.Valid {
+		d.NextRunAt = &nextRun.Time
+	}
+	return d, nil
+}
+
+func (r *PostgresRepo) CreateReportDefinition(ctx context.Context, d *model.ReportDefinition) error {
+	audience, err := json.Marshal(d.Audience)
+	if err != nil {
+		return err
+	}
+	filters, err := json.Marshal(d.Filters)
+	if err != nil {
+		return err
+	}
+	return r.DB.QueryRowContext(ctx, `
+		INSERT INTO report_definitions (name, report_type, period, audience, filters, format, language, active, next_run_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id, created_at, updated_at
+	`, d.Name, d.ReportType, d.Period, audience, filters, d.Format, d.Language, d.Active, d.NextRunAt,
+	).Scan(&d.ID, &d.CreatedAt, &d.UpdatedAt)
+}
+
+// UpdateReportDefinition returns sql.ErrNoRows when the definition does not exist.
+func (r *PostgresRepo) UpdateReportDefinition(ctx context.Context, d *model.ReportDefinition) error {
+	audience, err := json.Marshal(d.Audience)
+	if err != nil {
+		return err
+	}
+	filters, err := json.Marshal(d.Filters)
+	if err != nil {
+		return err
+	}
+	return r.DB.QueryRowContext(ctx, `
+		UPDATE report_definitions SET
+			name = $2, report_type = $3, period = $4, audience = $5, filters = $6, format = $7,
+			language = $8, active = $9, next_run_at = $10, updated_at = now()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`, d.ID, d.Name, d.ReportType, d.Period, audience, filters, d.Format, d.Language, d.Active, d.NextRunAt,
+	).Scan(&d.CreatedAt, &d.UpdatedAt)
+}
+
+func (r *PostgresRepo) DeleteReportDefinition(ctx context.Context, id int64) (bool, error) {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM report_definitions WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+func (r *PostgresRepo) GetReportDefinitions(ctx context.Context) ([]model.ReportDefinition, error) {
+	rows, err := r.DB.QueryContext(ctx, reportDefinitionSelect+" ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("querying report definitions failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.ReportDefinition{}
+	for rows.Next() {
+		d, err := scanReportDefinition(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// GetReportDefinition returns nil, nil when the definition does not exist.
+func (r *PostgresRepo) GetReportDefinition(ctx context.Context, id int64) (*model.ReportDefinition, error) {
+	d, err := scanReportDefinition(r.DB.QueryRowContext(ctx, reportDefinitionSelect+" WHERE id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ClaimDueReports memajukan next_run_at laporan aktif yang sudah jatuh tempo
+// dan mengembalikannya beserta jadwal lamanya, agar satu jadwal hanya
+// dijalankan sekali walau ada beberapa instance.
+func (r *PostgresRepo) ClaimDueReports(ctx context.Context, now time.Time, next func(model.ReportDefinition) time.Time) ([]model.ReportDefinition, error) {
+	rows, err := r.DB.QueryContext(ctx, reportDefinitionSelect+" WHERE active AND next_run_at <= $1 ORDER BY next_run_at", now)
+	if err != nil {
+		return nil, fmt.Errorf("querying due reports failed: %w", err)
+	}
+	var due []model.ReportDefinition
+	for rows.Next() {
+		d, err := scanReportDefinition(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		due = append(due, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	claimed := []model.ReportDefinition{}
+	for _, d := range due {
+		res, err := r.DB.ExecContext(ctx, `
+			UPDATE report_definitions SET next_run_at = $3, last_run_at = now()
+			WHERE id = $1 AND next_run_at = $2
+		`, d.ID, d.NextRunAt, next(d))
+		if err != nil {
+			return nil, err
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			claimed = append(claimed, d)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *PostgresRepo) CreateReportRun(ctx context.Context, run *model.ReportRun) error {
+	return r.DB.QueryRowContext(ctx, `
+		INSERT INTO report_runs (definition_id, period_label, period_start, period_end, status, deliveries, error)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''))
+		RETURNING id, created_at
+	`, run.DefinitionID, run.PeriodLabel, run.PeriodStart, run.PeriodEnd, run.Status, run.Deliveries, run.Error,
+	).Scan(&run.ID, &run.CreatedAt)
+}
+
+func (r *PostgresRepo) GetReportRuns(ctx context.Context, definitionID int64) ([]model.ReportRun, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, definition_id, period_label, period_start, period_end, status, deliveries, COALESCE(error, ''), created_at
+		FROM report_runs
+		WHERE definition_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT 100
+	`, definitionID)
+	if err != nil {
+		return nil, fmt.Errorf("querying report runs failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.ReportRun{}
+	for rows.Next() {
+		var run model.ReportRun
+		if err := rows.Scan(&run.ID, &run.DefinitionID, &run.PeriodLabel, &run.PeriodStart, &run.PeriodEnd,
+			&run.Status, &run.Deliveries, &run.Error, &run.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, run)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+)
+
+// Query untuk API v2 (lihat package resource). Filternya sama dengan v1,
+// nama field sort dan tanggal mengikuti nama field resource.
+
+var taskResourceSpec = query.Spec{
+	Sort: map[string]string{
+		"name":           "LOWER(t.name)",
+		"status":         "LOWER(ts.name)",
+		"project":        "LOWER(COALESCE(f.name, l.name))",
+		"start_at":       "t.start_date",
+		"due_at":         "t.due_date",
+		"done_at":        "t.date_done",
+		"closed_at":      "t.date_closed",
+		"created_at":     "t.date_created",
+		"updated_at":     "t.updated_at",
+		"estimate_hours": "t.time_estimate_hours",
+		"spent_hours":    "t.time_spent_hours",
+	},
+	DefaultSort: []query.SortField{{Field: "updated_at", Desc: true}},
+	Tiebreak:    "t.id",
+	Filters:     taskListSpec.Filters,
+	Dates: map[string]string{
+		"start_at":   "t.start_date",
+		"due_at":     "t.due_date",
+		"done_at":    "t.date_done",
+		"closed_at":  "t.date_closed",
+		"created_at": "t.date_created",
+		"updated_at": "t.updated_at",
+	},
+	Search: taskListSpec.Search,
+}
+
+// GetTaskResourcesPage is GetTasksPage with the v2 sort and date fields.
+func (r *PostgresRepo) GetTaskResourcesPage(ctx context.Context, p query.Params) ([]model.TaskResponse, query.Page, error) {
+	c, err := taskResourceSpec.Build(p)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	total, err := r.countRows(ctx, taskListFrom, c)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	tasks, err := r.queryTaskList(ctx, taskListSelect+c.Where+c.Tail, c.Args...)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	return tasks, query.NewPage(p, c.Limit, total, len(tasks)), nil
+}
+
+// GetTask returns nil, nil when the task does not exist.
+func (r *PostgresRepo) GetTask(ctx context.Context, id string) (*model.TaskResponse, error) {
+	tasks, err := r.queryTaskList(ctx, taskListSelect+" WHERE t.id = $1", id)
+	if err != nil || len(tasks) == 0 {
+		return nil, err
+	}
+	return &tasks[0], nil
+}
+
+// GetMembersByID loads members in one query; unknown ids are skipped.
+func (r *PostgresRepo) GetMembersByID(ctx context.Context, ids []int64) ([]model.User, error) {
+	out := []model.User{}
+	if len(ids) == 0 {
+		return out, nil
+	}
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT u.clickup_id, COALESCE(u.name, ''), COALESCE(u.email, ''), COALESCE(r.name, ''),
+			COALESCE(us.name, ''), u.created_at, u.updated_at
+	`+memberListFrom+`
+		WHERE u.clickup_id = ANY($1)
+		ORDER BY LOWER(u.name), u.clickup_id
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("querying members failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u model.User
+		if err := rows.Scan(&u.ClickUpID, &u.Name, &u.Email, &u.Role, &u.Status, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
+
+// GetTasksForMembers returns the tasks of every given member in the period,
+// keyed by member, with two queries regardless of the number of members.
+func (r *PostgresRepo) GetTasksForMembers(ctx context.Context, ids []int64, start, end time.Time) (map[int64][]model.TaskResponse, error) {
+	out := make(map[int64][]model.TaskResponse, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	tasks, err := r.queryTaskList(ctx, taskListSelect+`
+		WHERE EXISTS (SELECT 1 FROM task_assignees ta WHERE ta.task_id = t.id AND ta.user_clickup_id = ANY($1))
+		AND `+taskOverlap("$2", "$3")+`
+		ORDER BY t.due_date NULLS LAST, t.id
+	`, pq.Array(ids), start, end)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	for _, t := range tasks {
+		for _, a := range t.Assignees {
+			if wanted[a.ID] {
+				out[a.ID] = append(out[a.ID], t)
+			}
+		}
+	}
+	return out, nil
+}
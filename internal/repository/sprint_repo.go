@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// RecordTaskStatus menambah riwayat status hanya bila status terakhir yang
+// tercatat berbeda.
+func (r *PostgresRepo) RecordTaskStatus(ctx context.Context, taskID, statusID, statusName, statusType string, changedAt time.Time) error {
+	_, err := r.DB.ExecContext(ctx, `
+		INSERT INTO task_status_history (task_id, status_id, status_name, status_type, changed_at)
+		SELECT $1, $2, $3, $4, $5
+		WHERE NOT EXISTS (
+			SELECT 1 FROM (
+				SELECT status_id FROM task_status_history
+				WHERE task_id = $1
+				ORDER BY changed_at DESC, id DESC
+				LIMIT 1
+			) last
+			WHERE last.status_id = $2
+		)
+	`, taskID, statusID, statusName, statusType, changedAt)
+	return err
+}
+
+const sprintSelect = `
+	SELECT s.id, s.name, s.list_id, COALESCE(l.name, ''), s.start_date, s.end_date, s.source, s.created_at, s.updated_at
+	FROM sprints s
+	LEFT JOIN lists l ON l.id = s.list_id
+`
+
+func scanSprint(row interface{ Scan(...interface{}) error }) (model.Sprint, error) {
+	var sp model.Sprint
+	err := row.Scan(&sp.ID, &sp.Name, &sp.ListID, &sp.ListName, &sp.StartDate, &sp.EndDate, &sp.Source, &sp.CreatedAt, &sp.UpdatedAt)
+	return sp, err
+}
+
+// SaveSprint membuat sprint manual; list yang sudah punya sprint diganti.
+func (r *PostgresRepo) SaveSprint(ctx context.Context, sp *model.Sprint) error {
+	return r.DB.QueryRowContext(ctx, `
+		INSERT INTO sprints (name, list_id, start_date, end_date, source)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (list_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			start_date = EXCLUDED.start_date,
+			end_date = EXCLUDED.end_date,
+			source = EXCLUDED.source,
+			updated_at = now()
+		RETURNING id, created_at, updated_at
+	`, sp.Name, sp.ListID, sp.StartDate, sp.EndDate, sp.Source).Scan(&sp.ID, &sp.CreatedAt, &sp.UpdatedAt)
+}
+
+// UpdateSprint returns sql.ErrNoRows when the sprint does not exist.
+func (r *PostgresRepo) UpdateSprint(ctx context.Context, sp *model.Sprint) error {
+	return r.DB.QueryRowContext(ctx, `
+		UPDATE sprints SET name = $2, list_id = $3, start_date = $4, end_date = $5, source = $6, updated_at = now()
+		WHERE id = $1
+		RETURNING created_at, updated_at
+	`, sp.ID, sp.Name, sp.ListID, sp.StartDate, sp.EndDate, sp.Source).Scan(&sp.CreatedAt, &sp.UpdatedAt)
+}
+
+func (r *PostgresRepo) DeleteSprint(ctx context.Context, id int64) (bool, error) {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM sprints WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// DetectSprints membuat sprint dari list bertanggal di folder bernama "sprint".
+// Sprint manual pada list yang sama tidak ditimpa.
+func (r *PostgresRepo) DetectSprints(ctx context.Context) (int64, error) {
+	res, err := r.DB.ExecContext(ctx, `
+		INSERT INTO sprints (name, list_id, start_date, end_date, source)
+		SELECT l.name, l.id, l.start_date, l.due_date, 'clickup'
+		FROM lists l
+		JOIN folders f ON f.id = l.folder_id
+		WHERE f.name ILIKE '%sprint%'
+			AND COALESCE(l.archived, false) = false
+			AND l.start_date IS NOT NULL AND l.due_date IS NOT NULL
+		ON CONFLICT (list_id) DO UPDATE SET
+			name = EXCLUDED.name,
+			start_date = EXCLUDED.start_date,
+			end_date = EXCLUDED.end_date,
+			updated_at = now()
+		WHERE sprints.source = 'clickup'
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("detecting sprints failed: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func (r *PostgresRepo) GetSprints(ctx context.Context) ([]model.Sprint, error) {
+	rows, err := r.DB.QueryContext(ctx, sprintSelect+" ORDER BY s.start_date DESC")
+	if err != nil {
+		return nil, fmt.Errorf("querying sprints failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.Sprint{}
+	for rows.Next() {
+		sp, err := scanSprint(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sp)
+	}
+	return out, rows.Err()
+}
+
+// GetSprint returns nil, nil when the sprint does not exist.
+func (r *PostgresRepo) GetSprint(ctx context.Context, id int64) (*model.Sprint, error) {
+	sp, err := scanSprint(r.DB.QueryRowContext(ctx, sprintSelect+" WHERE s.id = $1", id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sp, nil
+}
+
+func (r *PostgresRepo) GetSprintTasks(ctx context.Context, listID string) ([]model.SprintTask, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT t.id, COALESCE(t.name, ''), COALESCE(ts.name, ''), COALESCE(ts.type, ''),
+			COALESCE(t.time_estimate_hours, 0), t.date_created, COALESCE(t.date_done, t.date_closed)
+		FROM tasks t
+		LEFT JOIN task_statuses ts ON t.status_id = ts.id
+		WHERE t.list_id = $1
+		ORDER BY t.date_created NULLS FIRST, t.id
+	`, listID)
+	if err != nil {
+		return nil, fmt.Errorf("querying sprint tasks failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := []model.SprintTask{}
+	for rows.Next() {
+		var t model.SprintTask
+		var created, done sql.NullTime
+		if err := rows.Scan(&t.TaskID, &t.TaskName, &t.StatusName, &t.StatusType, &t.EstimateHours, &created, &done); err != nil {
+			return nil, err
+		}
+		if created.Valid {
+			t.DateCreated = &created.Time
+		}
+		if done.Valid {
+			t.DateDone = &done.Time
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// GetListStatusHistory returns the status history of every task in a list,
+// grouped by task and ordered by time.
+func (r *PostgresRepo) GetListStatusHistory(ctx context.Context, listID string) (map[string][]model.TaskStatusChange, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT h.task_id, COALESCE(h.status_id, ''), COALESCE(h.status_name, ''), COALESCE(h.status_type, ''), h.changed_at
+		FROM task_status_history h
+		JOIN tasks t ON t.id = h.task_id
+		WHERE t.list_id = $1
+		ORDER BY h.task_id, h.changed_at, h.id
+	`, listID)
+	if err != nil {
+		return nil, fmt.Errorf("querying status history failed: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[string][]model.TaskStatusChange{}
+	for rows.Next() {
+		var c model.TaskStatusChange
+		if err := rows.Scan(&c.TaskID, &c.StatusID, &c.StatusName, &c.StatusType, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		out[c.TaskID] = append(out[c.TaskID], c)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+)
+
+// Interface sempit per domain. Service dan handler bergantung pada interface
+// ini, bukan pada *PostgresRepo, supaya logikanya bisa dites dengan
+// implementasi in-memory (package memstore). PostgresRepo memenuhi semuanya.
+
+// TaskStore reads tasks and their per-period aggregates. A task belongs to
+// a period when it overlaps it (see taskOverlap).
+type TaskStore interface {
+	GetTasksSummaryByDateRange(ctx context.Context, start, end time.Time) ([]model.TaskSummary, error)
+	GetTasksByAssignee(ctx context.Context, start, end time.Time) ([]model.AssigneeWithTasks, error)
+	GetTasksByUser(ctx context.Context, userID int64, start, end time.Time) ([]model.TaskItem, error)
+	GetWorkload(ctx context.Context, start, end time.Time) ([]model.WorkloadUser, error)
+	GetMetricTasks(ctx context.Context, start, end time.Time) ([]model.KPITaskMetric, error)
+	GetTaskDetailsByRange(ctx context.Context, start, end time.Time, asc bool) ([]model.TaskDetail, error)
+	// GetOpenAssignments returns the assignments of active members on
+	// unfinished tasks, overdue ones included.
+	GetOpenAssignments(ctx context.Context) ([]model.OpenTaskAssignment, error)
+}
+
+// MemberStore reads members. GetMember returns nil, nil for an unknown id.
+type MemberStore interface {
+	GetMembers(ctx context.Context) ([]model.User, error)
+	GetMember(ctx context.Context, id int64) (*model.User, error)
+}
+
+// HierarchyStore reads the ClickUp hierarchy: spaces, folders, lists and the
+// tasks under them. GetProjectNode returns nil, nil for an unknown id.
+type HierarchyStore interface {
+	GetSpaces(ctx context.Context) ([]model.SpaceInfo, error)
+	GetFolders(ctx context.Context) ([]model.Folder, error)
+	GetLists(ctx context.Context) ([]model.List, error)
+	GetProjectNodes(ctx context.Context, level string) ([]model.ProjectNode, error)
+	GetProjectNode(ctx context.Context, level, id string) (*model.ProjectNode, error)
+	GetProjectTasks(ctx context.Context, level, id string) ([]model.ProjectTask, error)
+}
+
+// SyncHistoryStore records and lists sync runs.
+type SyncHistoryStore interface {
+	CreateSyncHistory(ctx context.Context, syncType, status string, durationMs int64, details []byte) (int64, error)
+	GetSyncHistoryPage(ctx context.Context, p query.Params) ([]model.SyncHistory, query.Page, error)
+}
+
+// AdminStore reads and writes admin accounts. The lookups return
+// sql.ErrNoRows for an unknown admin; SaveAdmin returns it when the account
+// links a member that was never synced.
+type AdminStore interface {
+	GetAdminByUsername(ctx context.Context, username string) (*model.Admin, error)
+	UpdateAdminLanguage(ctx context.Context, id, lang string) (*model.Admin, error)
+	SaveAdmin(ctx context.Context, a *model.Admin) error
+}
+
+// KPIStore holds the KPI configuration and computed scorecards.
+// UpdateKPIIndicator and SetKPIRoleWeights return sql.ErrNoRows for an
+// unknown indicator or role; GetScorecard returns nil, nil for an unknown id.
+type KPIStore interface {
+	GetKPIIndicators(ctx context.Context) ([]model.KPIIndicator, error)
+	UpdateKPIIndicator(ctx context.Context, ind *model.KPIIndicator) error
+	GetKPIRoleWeights(ctx context.Context) ([]model.KPIRoleWeight, error)
+	SetKPIRoleWeights(ctx context.Context, role string, weights map[string]float64) error
+	SaveScorecard(ctx context.Context, sc *model.KPIScorecard) error
+	GetScorecards(ctx context.Context, periodType, periodLabel string, userID *int64) ([]model.KPIScorecard, error)
+	GetScorecard(ctx context.Context, id int64) (*model.KPIScorecard, error)
+	GetScorecardItemTasks(ctx context.Context, scorecardID int64, indicatorCode string) ([]model.KPIItemTask, error)
+}
+
+// ClickUpSyncStore writes what the ClickUp sync fetches. Every method is an
+// upsert keyed by the ClickUp id; RecordTaskStatus only appends a change when
+// it differs from the last recorded status of the task.
+type ClickUpSyncStore interface {
+	UpsertTeam(ctx context.Context, teamID, name, parentID string) error
+	UpsertUser(ctx context.Context, u *model.User) error
+	UpsertSpace(ctx context.Context, space *model.SpaceInfo) error
+	UpsertFolder(ctx context.Context, folder *model.Folder) error
+	UpsertList(ctx context.Context, list *model.List) error
+	UpsertTaskStatus(ctx context.Context, status *model.TaskStatus) error
+	UpsertTask(ctx context.Context, t *model.TaskResponse) error
+	UpsertTaskAssignees(ctx context.Context, taskID string, assigneeIDs []int64) error
+	ReplaceTaskTags(ctx context.Context, taskID string, tags []string) error
+	RecordTaskStatus(ctx context.Context, taskID, statusID, statusName, statusType string, changedAt time.Time) error
+}
+
+// ListingStore serves the plain and paged lists of synced data (see package
+// query for the paging syntax).
+type ListingStore interface {
+	GetTasks(ctx context.Context) ([]model.TaskResponse, error)
+	GetTasksPage(ctx context.Context, p query.Params) ([]model.TaskResponse, query.Page, error)
+	GetMembersPage(ctx context.Context, p query.Params) ([]model.User, query.Page, error)
+	GetFoldersPage(ctx context.Context, p query.Params) ([]model.Folder, query.Page, error)
+	GetListsPage(ctx context.Context, p query.Params) ([]model.List, query.Page, error)
+	GetFullSyncFiltered(ctx context.Context, start, end *int64, role string) ([]model.TaskWithMember, error)
+	GetFullDataFiltered(ctx context.Context, startMs, endMs *int64, role, username string) ([]model.TaskWithMember, error)
+}
+
+// RebalanceStore holds rebalance suggestions. GetRebalanceSuggestion returns
+// nil, nil for an unknown id.
+type RebalanceStore interface {
+	GetRoleCompatibility(ctx context.Context) (map[string][]string, error)
+	ReplacePendingSuggestions(ctx context.Context, suggestions []model.RebalanceSuggestion) error
+	GetRebalanceSuggestions(ctx context.Context, status string) ([]model.RebalanceSuggestion, error)
+	GetRebalanceSuggestion(ctx context.Context, id int64) (*model.RebalanceSuggestion, error)
+	SetSuggestionStatus(ctx context.Context, id int64, status string) error
+	ReassignTask(ctx context.Context, taskID string, fromUserID, toUserID int64) error
+}
+
+// AppraisalStore holds appraisal periods, their snapshots, reviews and audit
+// log. GetAppraisalPeriod returns nil, nil for an unknown id; the state
+// changes return sql.ErrNoRows when the period is not in the state they
+// require (see the PostgresRepo methods).
+type AppraisalStore interface {
+	CreateAppraisalPeriod(ctx context.Context, p *model.AppraisalPeriod, actor string) error
+	GetAppraisalPeriods(ctx context.Context) ([]model.AppraisalPeriod, error)
+	GetAppraisalPeriod(ctx context.Context, id int64) (*model.AppraisalPeriod, error)
+	MarkAppraisalComputed(ctx context.Context, id int64, actor string) error
+	LockAppraisalPeriod(ctx context.Context, id int64, actor string, scorecards []model.KPIScorecard, tasks []model.KPITaskMetric) error
+	ReopenAppraisalPeriod(ctx context.Context, id int64, actor, reason string) error
+	GetAppraisalSnapshots(ctx context.Context, periodID int64, userID *int64) ([]model.KPIScorecard, error)
+	GetAppraisalTaskSnapshots(ctx context.Context, periodID int64, userID *int64) ([]model.AppraisalTaskSnapshot, error)
+	GetAppraisalAuditLog(ctx context.Context, periodID int64) ([]model.AppraisalAuditEntry, error)
+	UpsertAppraisalReview(ctx context.Context, rv *model.AppraisalReview) error
+	AcknowledgeAppraisalReview(ctx context.Context, periodID, userID int64, rebuttal string) (*model.AppraisalReview, error)
+	GetAppraisalReviews(ctx context.Context, periodID int64, userID *int64) ([]model.AppraisalReview, error)
+}
+
+// OKRStore holds objectives and key results with their links. The single
+// getters return nil, nil for an unknown id.
+type OKRStore interface {
+	CreateObjective(ctx context.Context, o *model.Objective, start, end time.Time) error
+	UpdateObjective(ctx context.Context, o *model.Objective, start, end time.Time) error
+	DeleteObjective(ctx context.Context, id int64) (bool, error)
+	GetObjectives(ctx context.Context, period string, userID *int64, role string) ([]model.Objective, error)
+	GetObjective(ctx context.Context, id int64) (*model.Objective, error)
+	GetObjectiveRange(ctx context.Context, id int64) (time.Time, time.Time, error)
+	CreateKeyResult(ctx context.Context, kr *model.KeyResult) error
+	UpdateKeyResult(ctx context.Context, kr *model.KeyResult) error
+	DeleteKeyResult(ctx context.Context, id int64) (bool, error)
+	GetKeyResult(ctx context.Context, id int64) (*model.KeyResult, error)
+	GetKeyResultTasks(ctx context.Context, keyResultIDs []int64, start, end time.Time) (map[int64][]model.OKRTask, error)
+}
+
+// BudgetStore holds project budgets and their threshold alerts. GetBudget
+// returns nil, nil for an unknown id.
+type BudgetStore interface {
+	UpsertBudget(ctx context.Context, b *model.ProjectBudget) error
+	UpdateBudget(ctx context.Context, b *model.ProjectBudget) error
+	DeleteBudget(ctx context.Context, id int64) (bool, error)
+	GetBudgets(ctx context.Context) ([]model.ProjectBudget, error)
+	GetBudget(ctx context.Context, id int64) (*model.ProjectBudget, error)
+	GetBudgetAlerts(ctx context.Context, budgetID int64) ([]model.BudgetAlert, error)
+	SyncBudgetAlerts(ctx context.Context, budgetID int64, burnPct, spentHours float64, crossed []float64) ([]model.BudgetAlert, error)
+}
+
+// SprintStore holds sprints and the task status history their burndown is
+// built from. GetSprint returns nil, nil for an unknown id.
+type SprintStore interface {
+	SaveSprint(ctx context.Context, sp *model.Sprint) error
+	UpdateSprint(ctx context.Context, sp *model.Sprint) error
+	DeleteSprint(ctx context.Context, id int64) (bool, error)
+	DetectSprints(ctx context.Context) (int64, error)
+	GetSprints(ctx context.Context) ([]model.Sprint, error)
+	GetSprint(ctx context.Context, id int64) (*model.Sprint, error)
+	GetSprintTasks(ctx context.Context, listID string) ([]model.SprintTask, error)
+	GetListStatusHistory(ctx context.Context, listID string) (map[string][]model.TaskStatusChange, error)
+}
+
+// EstimateStore reads finished work for estimate calibration and stores
+// manual estimates. SetManualEstimate returns sql.ErrNoRows for an unknown
+// task.
+type EstimateStore interface {
+	SetManualEstimate(ctx context.Context, taskID string, hours float64) error
+	GetEstimateSamples(ctx context.Context, start, end time.Time) ([]model.EstimateSample, error)
+}
+
+// AlertStore holds alert rules and the alerts they raised. GetAlert returns
+// nil, nil for an unknown id.
+type AlertStore interface {
+	GetAlertRules(ctx context.Context) ([]model.AlertRule, error)
+	UpdateAlertRule(ctx context.Context, ar *model.AlertRule) error
+	GetAlerts(ctx context.Context, f model.AlertFilter) ([]model.Alert, error)
+	GetAlert(ctx context.Context, id int64) (*model.Alert, error)
+	SyncAlerts(ctx context.Context, candidates []model.AlertCandidate) (model.AlertEvaluation, []model.Alert, error)
+	SetAlertStatus(ctx context.Context, id int64, from []string, to, actor string) error
+}
+
+// NotificationStore holds subscriptions and the delivery queue. The single
+// getters return nil, nil for an unknown id.
+type NotificationStore interface {
+	CreateNotificationSubscription(ctx context.Context, sub *model.NotificationSubscription) error
+	UpdateNotificationSubscription(ctx context.Context, sub *model.NotificationSubscription) error
+	DeleteNotificationSubscription(ctx context.Context, id int64) (bool, error)
+	GetNotificationSubscriptions(ctx context.Context) ([]model.NotificationSubscription, error)
+	GetNotificationSubscription(ctx context.Context, id int64) (*model.NotificationSubscription, error)
+	GetNotificationRecipients(ctx context.Context, event string, aud model.NotificationAudience) ([]model.NotificationRecipient, error)
+	CreateNotificationDelivery(ctx context.Context, d *model.NotificationDelivery) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.NotificationDelivery, error)
+	MarkDeliverySent(ctx context.Context, id int64) error
+	MarkDeliveryFailed(ctx context.Context, id int64, errMsg string, next *time.Time) error
+	RequeueDelivery(ctx context.Context, id int64) error
+	GetNotificationDeliveries(ctx context.Context, status string, limit int) ([]model.NotificationDelivery, error)
+	GetNotificationDelivery(ctx context.Context, id int64) (*model.NotificationDelivery, error)
+}
+
+// ReportStore holds scheduled report definitions and their runs.
+// GetReportDefinition returns nil, nil for an unknown id.
+type ReportStore interface {
+	CreateReportDefinition(ctx context.Context, d *model.ReportDefinition) error
+	UpdateReportDefinition(ctx context.Context, d *model.ReportDefinition) error
+	DeleteReportDefinition(ctx context.Context, id int64) (bool, error)
+	GetReportDefinitions(ctx context.Context) ([]model.ReportDefinition, error)
+	GetReportDefinition(ctx context.Context, id int64) (*model.ReportDefinition, error)
+	ClaimDueReports(ctx context.Context, now time.Time, next func(model.ReportDefinition) time.Time) ([]model.ReportDefinition, error)
+	CreateReportRun(ctx context.Context, run *model.ReportRun) error
+	GetReportRuns(ctx context.Context, definitionID int64) ([]model.ReportRun, error)
+}
+
+// ResourceStore serves the v2 resources. GetTask returns nil, nil for an
+// unknown id; GetMembersByID skips unknown ids.
+type ResourceStore interface {
+	GetTaskResourcesPage(ctx context.Context, p query.Params) ([]model.TaskResponse, query.Page, error)
+	GetTask(ctx context.Context, id string) (*model.TaskResponse, error)
+	GetMembersByID(ctx context.Context, ids []int64) ([]model.User, error)
+	GetTasksForMembers(ctx context.Context, ids []int64, start, end time.Time) (map[int64][]model.TaskResponse, error)
+	GetProjectTree(ctx context.Context, level string, ids []string) ([]model.ProjectNode, error)
+}
+
+var (
+	_ TaskStore         = (*PostgresRepo)(nil)
+	_ MemberStore       = (*PostgresRepo)(nil)
+	_ HierarchyStore    = (*PostgresRepo)(nil)
+	_ SyncHistoryStore  = (*PostgresRepo)(nil)
+	_ AdminStore        = (*PostgresRepo)(nil)
+	_ KPIStore          = (*PostgresRepo)(nil)
+	_ ClickUpSyncStore  = (*PostgresRepo)(nil)
+	_ ListingStore      = (*PostgresRepo)(nil)
+	_ RebalanceStore    = (*PostgresRepo)(nil)
+	_ AppraisalStore    = (*PostgresRepo)(nil)
+	_ OKRStore          = (*PostgresRepo)(nil)
+	_ BudgetStore       = (*PostgresRepo)(nil)
+	_ SprintStore       = (*PostgresRepo)(nil)
+	_ EstimateStore     = (*PostgresRepo)(nil)
+	_ AlertStore        = (*PostgresRepo)(nil)
+	_ NotificationStore = (*PostgresRepo)(nil)
+	_ ReportStore       = (*PostgresRepo)(nil)
+	_ ResourceStore     = (*PostgresRepo)(nil)
+)
@@ -1 +1,123 @@
 package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// GetTaskDetailsByRange returns the tasks of the period with their assignees,
+// ordered by start date. Dates are formatted as DD-MM-YYYY; remaining time
+// and efficiency are derived from the schedule of finished tasks.
+func (r *PostgresRepo) GetTaskDetailsByRange(ctx context.Context, start, end time.Time, asc bool) ([]model.TaskDetail, error) {
+	order := "DESC"
+	if asc {
+		order = "ASC"
+	}
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT
+			t.id,
+			COALESCE(t.name, ''),
+			COALESCE(t.description, ''),
+			COALESCE(t.text_content, ''),
+			COALESCE(ts.name, ''),
+			t.start_date,
+			t.due_date,
+			t.date_done,
+			t.time_spent_hours,
+			t.time_estimate_hours,
+			COALESCE(l.name, f.name)
+		FROM tasks t
+		LEFT JOIN task_statuses ts ON t.status_id = ts.id
+		LEFT JOIN lists l ON t.list_id = l.id
+		LEFT JOIN folders f ON l.folder_id = f.id
+		WHERE `+taskOverlap("$1", "$2")+`
+		ORDER BY t.start_date `+order+`, t.id
+	`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("querying tasks by range failed: %w", err)
+	}
+	defer rows.Close()
+
+	var out []model.TaskDetail
+	index := map[string]int{}
+	for rows.Next() {
+		var d model.TaskDetail
+		var startDate, dueDate, dateDone sql.NullTime
+		var spent, estimate sql.NullFloat64
+		var projectName sql.NullString
+		if err := rows.Scan(
+			&d.ID, &d.Name, &d.Description, &d.TextContent, &d.StatusName,
+			&startDate, &dueDate, &dateDone, &spent, &estimate, &projectName,
+		); err != nil {
+			return nil, fmt.Errorf("scanning task row failed: %w", err)
+		}
+		d.StartDate = formatDate(startDate)
+		d.DueDate = formatDate(dueDate)
+		d.DateDone = formatDate(dateDone)
+		d.TimeSpentHours = spent.Float64
+		d.TimeEstimateHours = estimate.Float64
+		d.Assignees = []model.AssigneeDetail{}
+		if projectName.Valid {
+			d.ProjectName = &projectName.String
+		}
+
+		// Sisa waktu = due - done; efisiensi = durasi alokasi / durasi aktual.
+		if startDate.Valid && dueDate.Valid && dateDone.Valid {
+			remaining := dueDate.Time.Sub(dateDone.Time).Hours()
+			d.RemainingTimeHours = &remaining
+			if actual := dateDone.Time.Sub(startDate.Time).Hours(); actual > 0 {
+				efficiency := dueDate.Time.Sub(startDate.Time).Hours() / actual * 100
+				d.TimeEfficiencyPercentage = &efficiency
+			}
+		}
+
+		index[d.ID] = len(out)
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+	if len(out) == 0 {
+		return out, nil
+	}
+
+	ids := make([]string, len(out))
+	for i, d := range out {
+		ids[i] = d.ID
+	}
+	arows, err := r.DB.QueryContext(ctx, `
+		SELECT ta.task_id, u.clickup_id, COALESCE(u.name, ''), COALESCE(u.email, '')
+		FROM task_assignees ta
+		JOIN users u ON ta.user_clickup_id = u.clickup_id
+		WHERE ta.task_id = ANY($1)
+		ORDER BY u.name
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("querying assignees failed: %w", err)
+	}
+	defer arows.Close()
+	for arows.Next() {
+		var taskID string
+		var a model.AssigneeDetail
+		if err := arows.Scan(&taskID, &a.ClickUpID, &a.Name, &a.Email); err != nil {
+			return nil, fmt.Errorf("scanning assignee row failed: %w", err)
+		}
+		a.Username = a.Name
+		out[index[taskID]].Assignees = append(out[index[taskID]].Assignees, a)
+	}
+	return out, arows.Err()
+}
+
+func formatDate(t sql.NullTime) *string {
+	if !t.Valid {
+		return nil
+	}
+	s := t.Time.Format("02-01-2006")
+	return &s
+}
@@ -0,0 +1,62 @@
+package repository_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/repository/repotest"
+)
+
+// TestGetTasksByRangeFilters memeriksa nomor placeholder filter tanggal dan
+// filter sesudahnya; dulu argumen Sprintf kurang dan SQL-nya berisi
+// "%!d(MISSING)". Butuh TEST_DATABASE_URL (lihat repotest).
+func TestGetTasksByRangeFilters(t *testing.T) {
+	repo := repotest.New(t, "range")
+	ctx := context.Background()
+
+	seed := []string{
+		`INSERT INTO task_statuses (id, name, type) VALUES ('s-open', 'to do', 'open'), ('s-done', 'done', 'closed')`,
+		`INSERT INTO users (clickup_id, name, email) VALUES (1, 'Ana', 'ana@example.com'), (2, 'Budi', 'budi@example.com')`,
+		`INSERT INTO tasks (id, name, status_id, start_date, due_date, date_done) VALUES
+			('t1', 'scheduled in October', 's-open', '2026-10-05', '2026-10-09', NULL),
+			('t2', 'September only', 's-open', '2026-09-01', '2026-09-03', NULL),
+			('t3', 'done in October', 's-done', '2026-09-20', '2026-09-25', '2026-10-02'),
+			('t4', 'Budi in October', 's-open', '2026-10-12', '2026-10-14', NULL)`,
+		`INSERT INTO task_assignees (task_id, user_clickup_id) VALUES ('t1', 1), ('t2', 1), ('t3', 1), ('t4', 2)`,
+	}
+	for _, q := range seed {
+		if _, err := repo.DB.ExecContext(ctx, q); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	end := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC).UnixMilli() - 1
+	tests := []struct {
+		name, username, status string
+		want                   []string
+	}{
+		{"range only", "", "", []string{"t1", "t3", "t4"}},
+		{"range and username", "ana", "", []string{"t1", "t3"}},
+		{"range, username and status", "ana", "done", []string{"t3"}},
+	}
+	for _, tt := range tests {
+		tasks, err := repo.GetTasksByRange(ctx, &start, &end, tt.username, tt.status, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := map[string]bool{}
+		for _, task := range tasks {
+			got[task.TaskID] = true
+		}
+		want := map[string]bool{}
+		for _, id := range tt.want {
+			want[id] = true
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: tasks %v, want %v", tt.name, got, want)
+		}
+	}
+}
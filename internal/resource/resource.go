@@ -0,0 +1,232 @@
+// Package resource holds the canonical resources served by API v2. Every
+// resource uses the same conventions:
+//
+//   - ids are strings, also for ClickUp member ids
+//   - field names are snake_case; timestamps end in _at, durations in _hours
+//   - timestamps are RFC 3339 in the request time zone (?tz=), null when unset
+//   - relations are referenced by id and expanded with ?include=
+//
+// v1 keeps its own response types until its clients have migrated.
+package resource
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+)
+
+// Relasi yang bisa di-expand lewat ?include=.
+const (
+	IncludeAssignees = "assignees"
+	IncludeProject   = "project"
+	IncludeMember    = "member"
+)
+
+type Status struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Color string `json:"color"`
+}
+
+type Task struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Status      Status   `json:"status"`
+	ProjectID   *string  `json:"project_id"`
+	AssigneeIDs []string `json:"assignee_ids"`
+
+	StartAt   *time.Time `json:"start_at"`
+	DueAt     *time.Time `json:"due_at"`
+	DoneAt    *time.Time `json:"done_at"`
+	ClosedAt  *time.Time `json:"closed_at"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+
+	EstimateHours        *float64 `json:"estimate_hours"`
+	EstimateSource       string   `json:"estimate_source"`
+	SpentHours           *float64 `json:"spent_hours"`
+	RemainingHours       *float64 `json:"remaining_hours"`
+	EfficiencyPercentage *float64 `json:"efficiency_percentage"`
+
+	// Diisi hanya dengan ?include=assignees / ?include=project.
+	Assignees []Member `json:"assignees,omitempty"`
+	Project   *Project `json:"project,omitempty"`
+}
+
+type Member struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	Status    string     `json:"status"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// Project is a ClickUp space, folder or list. ParentID is the folder of a
+// list (or its space when the list has no folder) and the space of a folder.
+type Project struct {
+	ID       string  `json:"id"`
+	Level    string  `json:"level"`
+	Name     string  `json:"name"`
+	Archived bool    `json:"archived"`
+	ParentID *string `json:"parent_id"`
+}
+
+// Workload is the logged time of one member over a period.
+type Workload struct {
+	MemberID              string    `json:"member_id"`
+	PeriodStartAt         time.Time `json:"period_start_at"`
+	PeriodEndAt           time.Time `json:"period_end_at"`
+	TaskCount             int       `json:"task_count"`
+	LoggedHours           float64   `json:"logged_hours"`
+	ExpectedHours         float64   `json:"expected_hours"`
+	UtilizationPercentage *float64  `json:"utilization_percentage"`
+
+	// Diisi hanya dengan ?include=member.
+	Member *Member `json:"member,omitempty"`
+}
+
+// Include is the set of relations requested with ?include=a,b.
+type Include map[string]bool
+
+// ParseInclude rejects relations the endpoint does not offer.
+func ParseInclude(raw string, allowed ...string) (Include, error) {
+	inc := Include{}
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !contains(allowed, name) {
+			if len(allowed) == 0 {
+				return nil, apperr.Validation("include is not supported here")
+			}
+			return nil, apperr.Validation("invalid include " + strconv.Quote(name) + ", use " + strings.Join(allowed, ", "))
+		}
+		inc[name] = true
+	}
+	return inc, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// View controls how resources are rendered for one request.
+type View struct {
+	Include  Include
+	Location *time.Location
+}
+
+func (v View) at(t time.Time) time.Time {
+	if v.Location != nil {
+		return t.In(v.Location)
+	}
+	return t
+}
+
+func (v View) time(t *time.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+	out := v.at(*t)
+	return &out
+}
+
+func MemberID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// ParseMemberID is the inverse of MemberID.
+func ParseMemberID(id string) (int64, error) {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, apperr.Validation("invalid member id")
+	}
+	return n, nil
+}
+
+func (v View) Task(t model.TaskResponse) Task {
+	out := Task{
+		ID:          t.ID,
+		Name:        t.Name,
+		Description: t.Description,
+		Status: Status{
+			ID:    t.Status.ID,
+			Name:  t.Status.Name,
+			Type:  t.Status.Type,
+			Color: t.Status.Color,
+		},
+		ProjectID:            t.ListID,
+		AssigneeIDs:          make([]string, 0, len(t.Assignees)),
+		StartAt:              v.time(t.StartDate),
+		DueAt:                v.time(t.DueDate),
+		DoneAt:               v.time(t.DateDone),
+		ClosedAt:             v.time(t.DateClosed),
+		CreatedAt:            v.time(t.DateCreated),
+		UpdatedAt:            v.time(t.DateUpdated),
+		EstimateHours:        t.TimeEstimateHours,
+		EstimateSource:       t.EstimateSource,
+		SpentHours:           t.TimeSpentHours,
+		RemainingHours:       t.RemainingTimeHours,
+		EfficiencyPercentage: t.TimeEfficiencyPercentage,
+	}
+	if out.Description == "" {
+		out.Description = t.TextContent
+	}
+	if out.ProjectID != nil && *out.ProjectID == "" {
+		out.ProjectID = nil
+	}
+	for _, a := range t.Assignees {
+		out.AssigneeIDs = append(out.AssigneeIDs, MemberID(a.ID))
+	}
+	return out
+}
+
+func (v View) Member(u model.User) Member {
+	return Member{
+		ID:        MemberID(u.ClickUpID),
+		Name:      u.Name,
+		Email:     u.Email,
+		Role:      u.Role,
+		Status:    u.Status,
+		CreatedAt: v.time(&u.CreatedAt),
+		UpdatedAt: v.time(&u.UpdatedAt),
+	}
+}
+
+func (v View) Project(n model.ProjectNode) Project {
+	out := Project{ID: n.ID, Level: n.Level, Name: n.Name, Archived: n.Archived}
+	if n.ParentID != "" {
+		parent := n.ParentID
+		out.ParentID = &parent
+	}
+	return out
+}
+
+func (v View) Workload(u model.WorkloadUser, start, end time.Time) Workload {
+	out := Workload{
+		MemberID:      MemberID(u.UserID),
+		PeriodStartAt: v.at(start),
+		PeriodEndAt:   v.at(end),
+		TaskCount:     u.TaskCount,
+		LoggedHours:   u.TotalHours,
+		ExpectedHours: u.ExpectedHours,
+	}
+	if u.ExpectedHours > 0 {
+		pct := u.TotalHours / u.ExpectedHours * 100
+		out.UtilizationPercentage = &pct
+	}
+	return out
+}
@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/notify"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+var (
+	ErrAlertNotFound     = apperr.New(apperr.CodeNotFound, "alert not found")
+	ErrAlertRuleNotFound = apperr.New(apperr.CodeNotFound, "alert rule not found")
+	ErrInvalidAlert      = apperr.New(apperr.CodeValidationFailed, "invalid alert request")
+	ErrAlertTransition   = apperr.New(apperr.CodeConflict, "alert status does not allow this action")
+)
+
+// AlertRepo is the storage AlertService reads open tasks from and keeps
+// alert rules and events in.
+type AlertRepo interface {
+	repository.AlertStore
+	repository.TaskStore
+}
+
+type AlertService struct {
+	repo           AlertRepo
+	notifySvc      *NotificationService
+	weeklyCapacity float64
+}
+
+// NewAlertService creates the service. weeklyCapacity is used by the
+// member_overload rule (WORKLOAD_NORMAL_MAX); newly opened alerts are sent
+// through notifySvc (may be nil).
+func NewAlertService(repo AlertRepo, notifySvc *NotificationService, weeklyCapacity float64) *AlertService {
+	return &AlertService{repo: repo, notifySvc: notifySvc, weeklyCapacity: weeklyCapacity}
+}
+
+func (s *AlertService) GetRules(ctx context.Context) ([]model.AlertRule, error) {
+	return s.repo.GetAlertRules(ctx)
+}
+
+// UpdateRule mengubah threshold dan/atau status aktif sebuah rule.
+func (s *AlertService) UpdateRule(ctx context.Context, code string, req model.AlertRuleRequest) (*model.AlertRule, error) {
+	rules, err := s.repo.GetAlertRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rule *model.AlertRule
+	for i := range rules {
+		if rules[i].Code == code {
+			rule = &rules[i]
+		}
+	}
+	if rule == nil {
+		return nil, ErrAlertRuleNotFound
+	}
+	if req.Threshold != nil {
+		if *req.Threshold < 0 {
+			return nil, fmt.Errorf("%w: threshold must not be negative", ErrInvalidAlert)
+		}
+		rule.Threshold = *req.Threshold
+	}
+	if req.Active != nil {
+		rule.Active = *req.Active
+	}
+	if err := s.repo.UpdateAlertRule(ctx, rule); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAlertRuleNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *AlertService) GetAlerts(ctx context.Context, f model.AlertFilter) ([]model.Alert, error) {
+	if f.Status != "" && f.Status != model.AlertStatusOpen && f.Status != model.AlertStatusAcknowledged && f.Status != model.AlertStatusResolved {
+		return nil, fmt.Errorf("%w: status must be open, acknowledged or resolved", ErrInvalidAlert)
+	}
+	return s.repo.GetAlerts(ctx, f)
+}
+
+func (s *AlertService) GetAlert(ctx context.Context, id int64) (*model.Alert, error) {
+	a, err := s.repo.GetAlert(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		return nil, ErrAlertNotFound
+	}
+	return a, nil
+}
+
+// Acknowledge menandai alert open sudah dilihat; alert tetap aktif sampai
+// kondisinya hilang atau di-resolve.
+func (s *AlertService) Acknowledge(ctx context.Context, id int64, actor string) (*model.Alert, error) {
+	return s.transition(ctx, id, []string{model.AlertStatusOpen}, model.AlertStatusAcknowledged, actor)
+}
+
+// Resolve menutup alert secara manual. Bila kondisinya masih terpenuhi,
+// evaluasi berikutnya membuka alert baru.
+func (s *AlertService) Resolve(ctx context.Context, id int64, actor string) (*model.Alert, error) {
+	return s.transition(ctx, id, []string{model.AlertStatusOpen, model.AlertStatusAcknowledged}, model.AlertStatusResolved, actor)
+}
+
+func (s *AlertService) transition(ctx context.Context, id int64, from []string, to, actor string) (*model.Alert, error) {
+	if err := s.repo.SetAlertStatus(ctx, id, from, to, actor); err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		if _, err := s.GetAlert(ctx, id); err != nil {
+			return nil, err
+		}
+		return nil, ErrAlertTransition
+	}
+	return s.GetAlert(ctx, id)
+}
+
+// Evaluate menjalankan semua rule aktif terhadap task terbuka dan menyimpan
+// hasilnya. Dipanggil setelah setiap sync.
+func (s *AlertService) Evaluate(ctx context.Context) (*model.AlertEvaluation, error) {
+	rules, err := s.repo.GetAlertRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := s.repo.GetOpenAssignments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidates := EvaluateAlertRules(rules, assignments, s.weeklyCapacity, time.Now())
+	res, opened, err := s.repo.SyncAlerts(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range opened {
+		log.Printf("ALERT [%s] %s\n", a.RuleCode, a.Message)
+		msg := notify.Message{
+			Event:   model.NotificationEventAlert,
+			Subject: "Alert: " + a.SubjectName,
+			Text:    a.Message,
+			Fields:  map[string]string{"rule": a.RuleCode, "alert_id": strconv.FormatInt(a.ID, 10)},
+		}
+		if err := s.notifySvc.Notify(ctx, msg); err != nil {
+			log.Printf("WARNING: failed to notify alert %d: %v\n", a.ID, err)
+		}
+	}
+	return &res, nil
+}
+
+// EvaluateAll adalah Evaluate dengan bentuk hook post-sync.
+func (s *AlertService) EvaluateAll(ctx context.Context) error {
+	_, err := s.Evaluate(ctx)
+	return err
+}
+
+// alertTask is an open task with all of its assignees.
+type alertTask struct {
+	model.OpenTaskAssignment
+	userIDs []int64
+	names   []string
+}
+
+func (t alertTask) candidate(rule string, message string, value float64) model.AlertCandidate {
+	c := model.AlertCandidate{
+		RuleCode:    rule,
+		SubjectType: model.AlertSubjectTask,
+		SubjectID:   t.TaskID,
+		SubjectName: t.TaskName,
+		Message:     message,
+	}
+	v := round2(value)
+	c.Value = &v
+	if len(t.userIDs) == 1 {
+		id := t.userIDs[0]
+		c.UserID = &id
+	}
+	return c
+}
+
+func (t alertTask) assignees() string {
+	return strings.Join(t.names, ", ")
+}
+
+// EvaluateAlertRules returns the violations of the active rules. Task rules
+// produce one candidate per task; member_overload compares the remaining
+// hours due within the horizon (undated and overdue work included) with the
+// member's capacity over the same working days.
+func EvaluateAlertRules(rules []model.AlertRule, assignments []model.OpenTaskAssignment, weeklyCapacity float64, now time.Time) []model.AlertCandidate {
+	active := map[string]float64{}
+	for _, r := range rules {
+		if r.Active {
+			active[r.Code] = r.Threshold
+		}
+	}
+
+	var tasks []*alertTask
+	byID := map[string]*alertTask{}
+	for _, a := range assignments {
+		t := byID[a.TaskID]
+		if t == nil {
+			t = &alertTask{OpenTaskAssignment: a}
+			byID[a.TaskID] = t
+			tasks = append(tasks, t)
+		}
+		t.userIDs = append(t.userIDs, a.UserID)
+		t.names = append(t.names, a.Name)
+	}
+
+	out := []model.AlertCandidate{}
+	for _, t := range tasks {
+		if days, ok := active[model.AlertRuleOverdue]; ok && t.DueDate != nil {
+			late := now.Sub(*t.DueDate).Hours() / 24
+			if late > days {
+				out = append(out, t.candidate(model.AlertRuleOverdue,
+					fmt.Sprintf("Task %q is %.0f day(s) overdue (assignees: %s)", t.TaskName, math.Floor(late), t.assignees()), late))
+			}
+		}
+		if days, ok := active[model.AlertRuleDueSoonNoTime]; ok && t.DueDate != nil && t.SpentHours <= 0 {
+			left := t.DueDate.Sub(now).Hours() / 24
+			if left >= 0 && left <= days {
+				out = append(out, t.candidate(model.AlertRuleDueSoonNoTime,
+					fmt.Sprintf("Task %q is due %s with no time logged (assignees: %s)", t.TaskName, t.DueDate.Format("2006-01-02"), t.assignees()), left))
+			}
+		}
+		if pct, ok := active[model.AlertRuleOverEstimate]; ok && t.EstimateHours > 0 {
+			over := (t.SpentHours/t.EstimateHours - 1) * 100
+			if over > pct {
+				out = append(out, t.candidate(model.AlertRuleOverEstimate,
+					fmt.Sprintf("Task %q spent %.2fh of %.2fh estimate (+%.0f%%)", t.TaskName, t.SpentHours, t.EstimateHours, over), over))
+			}
+		}
+	}
+
+	if days, ok := active[model.AlertRuleMemberOverload]; ok && weeklyCapacity > 0 {
+		horizon := int(days)
+		if horizon < 1 {
+			horizon = 1
+		}
+		end := now.AddDate(0, 0, horizon)
+		capacity := float64(WorkingDaysBetween(now, end)) * weeklyCapacity / 5
+
+		type load struct {
+			name  string
+			hours float64
+		}
+		loads := map[int64]*load{}
+		for _, a := range assignments {
+			if a.DueDate != nil && a.DueDate.After(end) {
+				continue
+			}
+			l := loads[a.UserID]
+			if l == nil {
+				l = &load{name: a.Name}
+				loads[a.UserID] = l
+			}
+			l.hours += a.RemainingHours()
+		}
+		ids := make([]int64, 0, len(loads))
+		for id := range loads {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+		for _, id := range ids {
+			l := loads[id]
+			if capacity <= 0 || l.hours <= capacity {
+				continue
+			}
+			userID := id
+			pct := round2(l.hours / capacity * 100)
+			out = append(out, model.AlertCandidate{
+				RuleCode:    model.AlertRuleMemberOverload,
+				SubjectType: model.AlertSubjectMember,
+				SubjectID:   strconv.FormatInt(id, 10),
+				SubjectName: l.name,
+				UserID:      &userID,
+				Message: fmt.Sprintf("%s has %.2fh of work due in the next %d day(s) against %.2fh capacity (%.0f%%)",
+					l.name, l.hours, horizon, capacity, pct),
+				Value: &pct,
+			})
+		}
+	}
+	return out
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+)
+
+// Alert dibuka sekali, status acknowledged bertahan di evaluasi berikutnya,
+// lalu otomatis resolved setelah task selesai.
+func TestEvaluateAlertLifecycle(t *testing.T) {
+	now := time.Now()
+	due := now.AddDate(0, 0, -3)
+
+	st := memstore.New()
+	st.AddMember(model.User{ClickUpID: 1, Name: "Ana", Role: "backend", Status: memstore.ActiveStatus})
+	st.AddAlertRule(model.AlertRule{Code: model.AlertRuleOverdue, Threshold: 1, Active: true})
+	st.AddAlertRule(model.AlertRule{Code: model.AlertRuleOverEstimate, Threshold: 0})
+	late := memstore.Task{ID: "t1", Name: "API", Status: statusOpen, DueDate: &due, EstimateHours: 2, SpentHours: 5, Assignees: []int64{1}}
+	st.AddTask(late)
+
+	svc := NewAlertService(st, nil, 40)
+	ctx := context.Background()
+
+	res, err := svc.Evaluate(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Opened != 1 {
+		t.Fatalf("first evaluation = %+v, want only the overdue alert opened", res)
+	}
+	alerts, _ := svc.GetAlerts(ctx, model.AlertFilter{Status: model.AlertStatusOpen})
+	if len(alerts) != 1 || alerts[0].RuleCode != model.AlertRuleOverdue || alerts[0].UserID == nil || *alerts[0].UserID != 1 {
+		t.Fatalf("open alerts = %+v", alerts)
+	}
+	id := alerts[0].ID
+
+	if a, err := svc.Acknowledge(ctx, id, "a1"); err != nil || a.Status != model.AlertStatusAcknowledged || a.AcknowledgedBy != "a1" {
+		t.Fatalf("Acknowledge = %+v, %v", a, err)
+	}
+	if _, err := svc.Acknowledge(ctx, id, "a1"); !errors.Is(err, ErrAlertTransition) {
+		t.Errorf("second Acknowledge = %v, want ErrAlertTransition", err)
+	}
+
+	if res, _ := svc.Evaluate(ctx); res.Opened != 0 || res.Updated != 1 {
+		t.Errorf("second evaluation = %+v, want the alert updated", res)
+	}
+	if a, _ := svc.GetAlert(ctx, id); a.Status != model.AlertStatusAcknowledged {
+		t.Errorf("status after re-evaluation = %s, want acknowledged kept", a.Status)
+	}
+
+	late.Status, late.DateDone = statusDone, &now
+	st.AddTask(late)
+	if res, _ := svc.Evaluate(ctx); res.Resolved != 1 {
+		t.Errorf("evaluation after done = %+v, want the alert resolved", res)
+	}
+	if a, _ := svc.GetAlert(ctx, id); a.Status != model.AlertStatusResolved || a.ResolvedAt == nil {
+		t.Errorf("alert after done = %+v", a)
+	}
+}
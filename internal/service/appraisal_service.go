@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+var (
+	ErrAppraisalNotFound    = apperr.New(apperr.CodeNotFound, "appraisal period not found")
+	ErrInvalidAppraisal     = apperr.New(apperr.CodeValidationFailed, "invalid appraisal period")
+	ErrAppraisalLocked      = apperr.New(apperr.CodeConflict, "appraisal period is locked")
+	ErrAppraisalNotLocked   = apperr.New(apperr.CodeConflict, "appraisal period is not locked")
+	ErrReopenReasonRequired = apperr.New(apperr.CodeValidationFailed, "a reason is required to reopen a locked period")
+	ErrInvalidReview        = apperr.New(apperr.CodeValidationFailed, "invalid appraisal review")
+	ErrReviewNotFound       = apperr.New(apperr.CodeNotFound, "appraisal review not found")
+	ErrMemberNotFound       = apperr.New(apperr.CodeNotFound, "member not found")
+	ErrReviewForbidden      = apperr.New(apperr.CodeForbidden, "only managers and admins may write reviews")
+	ErrAcknowledgeForbidden = apperr.New(apperr.CodeForbidden, "only the reviewed member may acknowledge a review")
+	ErrOtherMember          = apperr.New(apperr.CodeForbidden, "members may only read their own data")
+)
+
+// ScopeUserID narrows a user_id filter to what caller may read. Admins and
+// managers get userID back unchanged; anyone else only sees the member linked
+// to their account, so a missing filter becomes that member and a different
+// one is refused.
+func ScopeUserID(caller model.Caller, userID *int64) (*int64, error) {
+	if caller.Role == model.RoleAdmin || caller.Role == model.RoleManager {
+		return userID, nil
+	}
+	if caller.UserID == nil || (userID != nil && *userID != *caller.UserID) {
+		return nil, ErrOtherMember
+	}
+	return caller.UserID, nil
+}
+
+// appraisalPeriodType is the KPI period type used for appraisal scorecards.
+const appraisalPeriodType = "appraisal"
+
+// AppraisalRepo is the storage AppraisalService keeps periods, scorecards and
+// reviews in.
+type AppraisalRepo interface {
+	repository.AppraisalStore
+	repository.KPIStore
+	repository.TaskStore
+}
+
+type AppraisalService struct {
+	repo   AppraisalRepo
+	kpiSvc *KPIService
+}
+
+func NewAppraisalService(repo AppraisalRepo, kpiSvc *KPIService) *AppraisalService {
+	return &AppraisalService{repo: repo, kpiSvc: kpiSvc}
+}
+
+// CreatePeriod membuat periode penilaian baru dengan tanggal YYYY-MM-DD (inklusif).
+func (s *AppraisalService) CreatePeriod(ctx context.Context, req model.AppraisalPeriodRequest, actor string) (*model.AppraisalPeriod, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidAppraisal)
+	}
+	start, err := dates.ParseStart(req.PeriodStart, dates.Default())
+	if err != nil {
+		return nil, fmt.Errorf("%w: period_start: %v", ErrInvalidAppraisal, err)
+	}
+	end, err := dates.ParseEnd(req.PeriodEnd, dates.Default())
+	if err != nil {
+		return nil, fmt.Errorf("%w: period_end: %v", ErrInvalidAppraisal, err)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: period_end is before period_start", ErrInvalidAppraisal)
+	}
+
+	p := &model.AppraisalPeriod{Name: name, PeriodStart: start, PeriodEnd: end}
+	if err := s.repo.CreateAppraisalPeriod(ctx, p, actor); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *AppraisalService) GetPeriods(ctx context.Context) ([]model.AppraisalPeriod, error) {
+	return s.repo.GetAppraisalPeriods(ctx)
+}
+
+func (s *AppraisalService) GetPeriod(ctx context.Context, id int64) (*model.AppraisalPeriod, error) {
+	p, err := s.repo.GetAppraisalPeriod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, ErrAppraisalNotFound
+	}
+	return p, nil
+}
+
+// Compute menghitung ulang scorecard live periode. Ditolak bila periode terkunci.
+func (s *AppraisalService) Compute(ctx context.Context, id int64, actor string) (*model.AppraisalScorecards, error) {
+	p, err := s.GetPeriod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status == model.AppraisalLocked {
+		return nil, ErrAppraisalLocked
+	}
+
+	scorecards, err := s.kpiSvc.ComputeRange(ctx, appraisalPeriodType, p.ScorecardLabel(), p.PeriodStart, p.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.MarkAppraisalComputed(ctx, id, actor); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAppraisalLocked
+		}
+		return nil, err
+	}
+
+	if p, err = s.GetPeriod(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.withReviews(ctx, p, false, scorecards, nil)
+}
+
+// Lock menghitung ulang lalu membekukan scorecard beserta task pendukungnya.
+// Setelah terkunci, perubahan data ClickUp tidak lagi mempengaruhi hasil.
+func (s *AppraisalService) Lock(ctx context.Context, id int64, actor string) (*model.AppraisalPeriod, error) {
+	p, err := s.GetPeriod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status == model.AppraisalLocked {
+		return nil, ErrAppraisalLocked
+	}
+
+	// disimpan di transaksi lock, supaya lock yang gagal tidak mengubah scorecard
+	scorecards, err := s.kpiSvc.BuildScorecards(ctx, appraisalPeriodType, p.ScorecardLabel(), p.PeriodStart, p.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.repo.GetMetricTasks(ctx, p.PeriodStart, p.PeriodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.LockAppraisalPeriod(ctx, id, actor, scorecards, tasks); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAppraisalLocked
+		}
+		return nil, err
+	}
+	return s.GetPeriod(ctx, id)
+}
+
+// Reopen membuka kembali periode terkunci. Snapshot dihapus dan alasan dicatat di audit log.
+func (s *AppraisalService) Reopen(ctx context.Context, id int64, actor, reason string) (*model.AppraisalPeriod, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrReopenReasonRequired
+	}
+	p, err := s.GetPeriod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.Status != model.AppraisalLocked {
+		return nil, ErrAppraisalNotLocked
+	}
+
+	if err := s.repo.ReopenAppraisalPeriod(ctx, id, actor, reason); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAppraisalNotLocked
+		}
+		return nil, err
+	}
+	return s.GetPeriod(ctx, id)
+}
+
+// GetScorecards returns frozen snapshots for a locked period, otherwise the
+// last computed live scorecards.
+func (s *AppraisalService) GetScorecards(ctx context.Context, id int64, userID *int64) (*model.AppraisalScorecards, error) {
+	p, err := s.GetPeriod(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var scorecards []model.KPIScorecard
+	frozen := p.Status == model.AppraisalLocked
+	if frozen {
+		scorecards, err = s.repo.GetAppraisalSnapshots(ctx, id, userID)
+	} else {
+		scorecards, err = s.repo.GetScorecards(ctx, appraisalPeriodType, p.ScorecardLabel(), userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.withReviews(ctx, p, frozen, scorecards, userID)
+}
+
+// withReviews menggabungkan scorecard KPI dengan review kualitatif manajer.
+func (s *AppraisalService) withReviews(ctx context.Context, p *model.AppraisalPeriod, frozen bool, scorecards []model.KPIScorecard, userID *int64) (*model.AppraisalScorecards, error) {
+	reviews, err := s.repo.GetAppraisalReviews(ctx, p.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	byUser := make(map[int64]*model.AppraisalReview, len(reviews))
+	for i := range reviews {
+		byUser[reviews[i].UserID] = &reviews[i]
+	}
+
+	out := make([]model.AppraisalMemberScorecard, 0, len(scorecards))
+	for _, sc := range scorecards {
+		out = append(out, model.AppraisalMemberScorecard{KPIScorecard: sc, Review: byUser[sc.UserID]})
+	}
+	return &model.AppraisalScorecards{Period: *p, Frozen: frozen, Count: len(out), Scorecards: out}, nil
+}
+
+// GetTasks returns the task rows behind the period's scorecards: the frozen
+// copy when locked, live task data otherwise.
+func (s *AppraisalService) GetTasks(ctx context.Context, id int64, userID *int64) ([]model.AppraisalTaskSnapshot, bool, error) {
+	p, err := s.GetPeriod(ctx, id)
+	if err != nil {
+		return nil, false, err
+	}
+	if p.Status == model.AppraisalLocked {
+		tasks, err := s.repo.GetAppraisalTaskSnapshots(ctx, id, userID)
+		return tasks, true, err
+	}
+
+	live, err := s.repo.GetMetricTasks(ctx, p.PeriodStart, p.PeriodEnd)
+	if err != nil {
+		return nil, false, err
+	}
+	now := time.Now()
+	tasks := []model.AppraisalTaskSnapshot{}
+	for _, t := range live {
+		if userID != nil && t.UserID != *userID {
+			continue
+		}
+		tasks = append(tasks, model.AppraisalTaskSnapshot{KPITaskMetric: t, SnapshotAt: now})
+	}
+	return tasks, false, nil
+}
+
+func (s *AppraisalService) GetAuditLog(ctx context.Context, id int64) ([]model.AppraisalAuditEntry, error) {
+	if _, err := s.GetPeriod(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAppraisalAuditLog(ctx, id)
+}
+
+// SaveReview menyimpan review kualitatif manajer untuk satu anggota.
+// Mengubah review akan mereset acknowledgement anggota.
+func (s *AppraisalService) SaveReview(ctx context.Context, periodID, userID int64, req model.AppraisalReviewRequest, reviewer model.Caller) (*model.AppraisalReview, error) {
+	if reviewer.Role != model.RoleAdmin && reviewer.Role != model.RoleManager {
+		return nil, ErrReviewForbidden
+	}
+	if req.Rating < 1 || req.Rating > 5 {
+		return nil, fmt.Errorf("%w: rating must be between 1 and 5", ErrInvalidReview)
+	}
+	grade := strings.ToUpper(strings.TrimSpace(req.FinalGrade))
+	if !validGrade(grade) {
+		return nil, fmt.Errorf("%w: final_grade must be one of %s", ErrInvalidReview, strings.Join(model.AppraisalGrades, ", "))
+	}
+	if _, err := s.GetPeriod(ctx, periodID); err != nil {
+		return nil, err
+	}
+
+	rv := &model.AppraisalReview{
+		PeriodID:     periodID,
+		UserID:       userID,
+		Reviewer:     reviewer.Subject,
+		Rating:       req.Rating,
+		Strengths:    strings.TrimSpace(req.Strengths),
+		Improvements: strings.TrimSpace(req.Improvements),
+		FinalGrade:   grade,
+	}
+	if err := s.repo.UpsertAppraisalReview(ctx, rv); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, err
+	}
+	return rv, nil
+}
+
+// Acknowledge dipanggil anggota untuk menyetujui review, opsional dengan rebuttal.
+// Caller harus akun yang terhubung dengan anggota userID.
+func (s *AppraisalService) Acknowledge(ctx context.Context, periodID, userID int64, caller model.Caller, rebuttal string) (*model.AppraisalReview, error) {
+	if caller.UserID == nil || *caller.UserID != userID {
+		return nil, ErrAcknowledgeForbidden
+	}
+	if _, err := s.GetPeriod(ctx, periodID); err != nil {
+		return nil, err
+	}
+	rv, err := s.repo.AcknowledgeAppraisalReview(ctx, periodID, userID, strings.TrimSpace(rebuttal))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReviewNotFound
+	}
+	return rv, err
+}
+
+func (s *AppraisalService) GetReviews(ctx context.Context, periodID int64, userID *int64) ([]model.AppraisalReview, error) {
+	if _, err := s.GetPeriod(ctx, periodID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAppraisalReviews(ctx, periodID, userID)
+}
+
+func validGrade(grade string) bool {
+	for _, g := range model.AppraisalGrades {
+		if g == grade {
+			return true
+		}
+	}
+	return false
+}
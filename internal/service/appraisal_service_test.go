@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+)
+
+// Pengecekan caller terjadi sebelum repository disentuh.
+func TestReviewCallerChecks(t *testing.T) {
+	svc := &AppraisalService{}
+	ctx := context.Background()
+	member := int64(101)
+	req := model.AppraisalReviewRequest{Rating: 4, FinalGrade: "B"}
+
+	for _, role := range []string{model.RoleMember, ""} {
+		_, err := svc.SaveReview(ctx, 1, 101, req, model.Caller{Subject: "a1", Role: role, UserID: &member})
+		if !errors.Is(err, ErrReviewForbidden) {
+			t.Errorf("SaveReview as %q = %v, want ErrReviewForbidden", role, err)
+		}
+	}
+
+	other := int64(102)
+	callers := map[string]model.Caller{
+		"other member":   {Subject: "a2", Role: model.RoleMember, UserID: &other},
+		"unlinked admin": {Subject: "a3", Role: model.RoleAdmin},
+	}
+	for name, caller := range callers {
+		if _, err := svc.Acknowledge(ctx, 1, 101, caller, ""); !errors.Is(err, ErrAcknowledgeForbidden) {
+			t.Errorf("Acknowledge by %s = %v, want ErrAcknowledgeForbidden", name, err)
+		}
+	}
+}
+
+// Anggota hanya boleh membaca data miliknya; admin dan manager bebas.
+func TestScopeUserID(t *testing.T) {
+	own, other := int64(101), int64(102)
+	member := model.Caller{Subject: "a1", Role: model.RoleMember, UserID: &own}
+	tests := []struct {
+		name    string
+		caller  model.Caller
+		userID  *int64
+		want    *int64
+		wantErr error
+	}{
+		{"admin tanpa filter", model.Caller{Role: model.RoleAdmin}, nil, nil, nil},
+		{"manager anggota lain", model.Caller{Role: model.RoleManager}, &other, &other, nil},
+		{"anggota tanpa filter", member, nil, &own, nil},
+		{"anggota dirinya", member, &own, &own, nil},
+		{"anggota lain", member, &other, nil, ErrOtherMember},
+		{"akun tanpa anggota", model.Caller{Role: model.RoleMember}, nil, nil, ErrOtherMember},
+	}
+	for _, tt := range tests {
+		got, err := ScopeUserID(tt.caller, tt.userID)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+			t.Errorf("%s: user id = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// Scorecard periode terkunci tidak berubah walau data task berubah, sampai
+// periode dibuka kembali.
+func TestLockFreezesAppraisal(t *testing.T) {
+	ctx := context.Background()
+	st := kpiStore()
+	svc := NewAppraisalService(st, NewKPIService(st))
+
+	p, err := svc.CreatePeriod(ctx, model.AppraisalPeriodRequest{Name: "Q3", PeriodStart: "2026-09-01", PeriodEnd: "2026-09-30"}, "a1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, err = svc.Lock(ctx, p.ID, "a1"); err != nil || p.Status != model.AppraisalLocked {
+		t.Fatalf("Lock = %+v, %v", p, err)
+	}
+	if _, err := svc.Lock(ctx, p.ID, "a1"); !errors.Is(err, ErrAppraisalLocked) {
+		t.Errorf("second Lock = %v, want ErrAppraisalLocked", err)
+	}
+
+	ana := int64(1)
+	frozen, err := svc.GetScorecards(ctx, p.ID, &ana)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !frozen.Frozen || frozen.Count != 1 {
+		t.Fatalf("scorecards = %+v, want Ana's frozen scorecard", frozen)
+	}
+	score := frozen.Scorecards[0].TotalScore
+
+	st.AddTask(memstore.Task{ID: "a4", Name: "Delta", Status: statusDone, DueDate: day(9, 5, 17), DateDone: day(9, 28, 12), Assignees: []int64{1}})
+	if again, _ := svc.GetScorecards(ctx, p.ID, &ana); again.Scorecards[0].TotalScore != score {
+		t.Errorf("frozen score changed from %v to %v", score, again.Scorecards[0].TotalScore)
+	}
+	if tasks, frozen, _ := svc.GetTasks(ctx, p.ID, &ana); !frozen || len(tasks) != 3 {
+		t.Errorf("GetTasks = %d tasks (frozen %v), want the 3 snapshotted", len(tasks), frozen)
+	}
+
+	if _, err := svc.Reopen(ctx, p.ID, "a1", ""); !errors.Is(err, ErrReopenReasonRequired) {
+		t.Errorf("Reopen without reason = %v", err)
+	}
+	if p, err = svc.Reopen(ctx, p.ID, "a1", "salah data"); err != nil || p.Status != model.AppraisalOpen {
+		t.Fatalf("Reopen = %+v, %v", p, err)
+	}
+	if tasks, frozen, _ := svc.GetTasks(ctx, p.ID, &ana); frozen || len(tasks) != 4 {
+		t.Errorf("GetTasks after reopen = %d tasks (frozen %v), want 4 live", len(tasks), frozen)
+	}
+
+	log, _ := svc.GetAuditLog(ctx, p.ID)
+	var actions []string
+	for _, e := range log {
+		actions = append(actions, e.Action)
+	}
+	if strings.Join(actions, ",") != "create,lock,reopen" || log[2].Reason != "salah data" {
+		t.Errorf("audit log = %+v", log)
+	}
+}
+
+func TestReviewAcknowledgeFlow(t *testing.T) {
+	ctx := context.Background()
+	st := kpiStore()
+	svc := NewAppraisalService(st, NewKPIService(st))
+	p, _ := svc.CreatePeriod(ctx, model.AppraisalPeriodRequest{Name: "Q3", PeriodStart: "2026-09-01", PeriodEnd: "2026-09-30"}, "a1")
+
+	ana := int64(1)
+	member := model.Caller{Subject: "u1", Role: model.RoleMember, UserID: &ana}
+	manager := model.Caller{Subject: "m1", Role: model.RoleManager}
+
+	if _, err := svc.Acknowledge(ctx, p.ID, 1, member, ""); !errors.Is(err, ErrReviewNotFound) {
+		t.Errorf("Acknowledge before review = %v, want ErrReviewNotFound", err)
+	}
+	if _, err := svc.SaveReview(ctx, p.ID, 99, model.AppraisalReviewRequest{Rating: 3, FinalGrade: "C"}, manager); !errors.Is(err, ErrMemberNotFound) {
+		t.Errorf("review of unknown member = %v, want ErrMemberNotFound", err)
+	}
+
+	rv, err := svc.SaveReview(ctx, p.ID, 1, model.AppraisalReviewRequest{Rating: 4, FinalGrade: "b"}, manager)
+	if err != nil || rv.Reviewer != "m1" || rv.FinalGrade != "B" {
+		t.Fatalf("SaveReview = %+v, %v", rv, err)
+	}
+	if rv, err = svc.Acknowledge(ctx, p.ID, 1, member, " kurang adil "); err != nil || rv.AcknowledgedAt == nil || rv.Rebuttal != "kurang adil" {
+		t.Fatalf("Acknowledge = %+v, %v", rv, err)
+	}
+
+	// review diubah: acknowledgement harus diulang
+	if _, err := svc.SaveReview(ctx, p.ID, 1, model.AppraisalReviewRequest{Rating: 5, FinalGrade: "A"}, manager); err != nil {
+		t.Fatal(err)
+	}
+	reviews, _ := svc.GetReviews(ctx, p.ID, &ana)
+	if len(reviews) != 1 || reviews[0].AcknowledgedAt != nil || reviews[0].Rebuttal != "" || reviews[0].Rating != 5 {
+		t.Errorf("reviews after edit = %+v", reviews)
+	}
+}
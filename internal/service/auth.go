@@ -1,12 +1,12 @@
 package service
 
 import (
-	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
 	"github.com/roksva123/go-kinerja-backend/internal/model"
 	"github.com/roksva123/go-kinerja-backend/internal/repository"
 )
@@ -16,6 +16,8 @@ type AuthService struct {
     jwtKey []byte
 }
 
+var ErrInvalidCredentials = apperr.New(apperr.CodeUnauthorized, "invalid credentials")
+
 func NewAuthService(repo repository.UserRepo, jwtKey string) *AuthService {
     return &AuthService{repo, []byte(jwtKey)}
 }
@@ -23,11 +25,11 @@ func NewAuthService(repo repository.UserRepo, jwtKey string) *AuthService {
 func (s *AuthService) Login(username, password string) (string, *model.User, error) {
     user, err := s.repo.GetByUsername(username)
     if err != nil {
-        return "", nil, errors.New("invalid credentials")
+        return "", nil, ErrInvalidCredentials
     }
 
     if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
-        return "", nil, errors.New("invalid credentials")
+        return "", nil, ErrInvalidCredentials
     }
 
     token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
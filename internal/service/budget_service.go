@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/notify"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+var (
+	ErrBudgetNotFound = apperr.New(apperr.CodeNotFound, "budget not found")
+	ErrInvalidBudget  = apperr.New(apperr.CodeValidationFailed, "invalid budget")
+)
+
+const budgetWeek = 7 * 24 * time.Hour
+
+// BudgetRepo is the storage BudgetService reads budgets and the project tree
+// from.
+type BudgetRepo interface {
+	repository.BudgetStore
+	repository.HierarchyStore
+}
+
+type BudgetService struct {
+	repo      BudgetRepo
+	notifySvc *NotificationService
+}
+
+func NewBudgetService(repo BudgetRepo, notifySvc *NotificationService) *BudgetService {
+	return &BudgetService{repo: repo, notifySvc: notifySvc}
+}
+
+func (s *BudgetService) budgetFromRequest(ctx context.Context, req model.BudgetRequest) (*model.ProjectBudget, error) {
+	b := &model.ProjectBudget{
+		Level:       strings.ToLower(strings.TrimSpace(req.Level)),
+		NodeID:      strings.TrimSpace(req.NodeID),
+		BudgetHours: req.BudgetHours,
+	}
+	if b.Level != model.ProjectLevelFolder && b.Level != model.ProjectLevelList {
+		return nil, fmt.Errorf("%w: level must be folder or list", ErrInvalidBudget)
+	}
+	if b.BudgetHours <= 0 {
+		return nil, fmt.Errorf("%w: budget_hours must be positive", ErrInvalidBudget)
+	}
+	if req.StartDate != "" {
+		t, err := dates.ParseStart(req.StartDate, dates.Default())
+		if err != nil {
+			return nil, fmt.Errorf("%w: start_date: %v", ErrInvalidBudget, err)
+		}
+		b.StartDate = &t
+	}
+	if req.EndDate != "" {
+		t, err := dates.ParseEnd(req.EndDate, dates.Default())
+		if err != nil {
+			return nil, fmt.Errorf("%w: end_date: %v", ErrInvalidBudget, err)
+		}
+		b.EndDate = &t
+	}
+	if b.StartDate != nil && b.EndDate != nil && b.EndDate.Before(*b.StartDate) {
+		return nil, fmt.Errorf("%w: end_date is before start_date", ErrInvalidBudget)
+	}
+
+	thresholds, err := normalizeThresholds(req.Thresholds)
+	if err != nil {
+		return nil, err
+	}
+	b.Thresholds = thresholds
+
+	node, err := s.repo.GetProjectNode(ctx, b.Level, b.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, ErrProjectNotFound
+	}
+	b.NodeName = node.Name
+	return b, nil
+}
+
+// normalizeThresholds mengurutkan dan membuang duplikat; kosong berarti default.
+func normalizeThresholds(in []float64) ([]float64, error) {
+	if len(in) == 0 {
+		return append([]float64(nil), model.DefaultBudgetThresholds...), nil
+	}
+	seen := map[float64]bool{}
+	out := []float64{}
+	for _, t := range in {
+		if t <= 0 || t > 1000 {
+			return nil, fmt.Errorf("%w: thresholds must be between 0 and 1000 percent", ErrInvalidBudget)
+		}
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	sort.Float64s(out)
+	return out, nil
+}
+
+// SetBudget membuat atau mengganti budget sebuah folder/list.
+func (s *BudgetService) SetBudget(ctx context.Context, req model.BudgetRequest) (*model.BudgetStatus, error) {
+	b, err := s.budgetFromRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpsertBudget(ctx, b); err != nil {
+		return nil, err
+	}
+	return s.GetBudget(ctx, b.ID)
+}
+
+func (s *BudgetService) UpdateBudget(ctx context.Context, id int64, req model.BudgetRequest) (*model.BudgetStatus, error) {
+	b, err := s.budgetFromRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	b.ID = id
+	if err := s.repo.UpdateBudget(ctx, b); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrBudgetNotFound
+		}
+		return nil, err
+	}
+	return s.GetBudget(ctx, id)
+}
+
+func (s *BudgetService) DeleteBudget(ctx context.Context, id int64) error {
+	ok, err := s.repo.DeleteBudget(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrBudgetNotFound
+	}
+	return nil
+}
+
+// GetBudgets menghitung burn semua budget dan memicu alert yang baru terlewati.
+func (s *BudgetService) GetBudgets(ctx context.Context) ([]model.BudgetStatus, error) {
+	budgets, err := s.repo.GetBudgets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.BudgetStatus, 0, len(budgets))
+	for _, b := range budgets {
+		st, err := s.evaluate(ctx, b)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *st)
+	}
+	return out, nil
+}
+
+func (s *BudgetService) GetBudget(ctx context.Context, id int64) (*model.BudgetStatus, error) {
+	b, err := s.repo.GetBudget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, ErrBudgetNotFound
+	}
+	return s.evaluate(ctx, *b)
+}
+
+// EvaluateAll memeriksa semua budget dan memicu alert yang baru terlewati.
+func (s *BudgetService) EvaluateAll(ctx context.Context) error {
+	_, err := s.GetBudgets(ctx)
+	return err
+}
+
+func (s *BudgetService) evaluate(ctx context.Context, b model.ProjectBudget) (*model.BudgetStatus, error) {
+	tasks, err := s.repo.GetProjectTasks(ctx, b.Level, b.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	st := ComputeBudgetStatus(b, tasks, time.Now())
+
+	var crossed []float64
+	for _, t := range b.Thresholds {
+		if st.BurnPct >= t {
+			crossed = append(crossed, t)
+		}
+	}
+	fired, err := s.repo.SyncBudgetAlerts(ctx, b.ID, st.BurnPct, st.SpentHours, crossed)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range fired {
+		text := fmt.Sprintf("%s %s (%s) reached %.0f%% (%.2f of %.2f hours)",
+			b.Level, b.NodeID, b.NodeName, a.Threshold, st.SpentHours, b.BudgetHours)
+		log.Printf("BUDGET ALERT: %s\n", text)
+		msg := notify.Message{
+			Event:   model.NotificationEventBudget,
+			Subject: fmt.Sprintf("Budget %s at %.0f%%", b.NodeName, a.Threshold),
+			Text:    text,
+		}
+		if err := s.notifySvc.Notify(ctx, msg); err != nil {
+			log.Printf("WARNING: failed to notify budget alert %d: %v\n", a.ID, err)
+		}
+	}
+	st.NewAlerts = fired
+
+	if st.Alerts, err = s.repo.GetBudgetAlerts(ctx, b.ID); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// ComputeBudgetStatus menghitung burn dari jam terpakai task di folder/list.
+// Bila budget punya rentang tanggal, hanya task yang selesai (atau jatuh tempo)
+// di dalam rentang itu yang dihitung. Burn rate = jam terpakai / minggu berjalan.
+func ComputeBudgetStatus(b model.ProjectBudget, tasks []model.ProjectTask, now time.Time) model.BudgetStatus {
+	st := model.BudgetStatus{ProjectBudget: b, Alerts: []model.BudgetAlert{}}
+
+	var first *time.Time
+	for _, t := range tasks {
+		at := t.DateDone
+		if at == nil {
+			at = t.DueDate
+		}
+		if at != nil {
+			if b.StartDate != nil && at.Before(*b.StartDate) {
+				continue
+			}
+			if b.EndDate != nil && at.After(*b.EndDate) {
+				continue
+			}
+			if t.SpentHours > 0 && (first == nil || at.Before(*first)) {
+				first = at
+			}
+		}
+		st.TaskCount++
+		st.SpentHours += t.SpentHours
+	}
+
+	st.RemainingHours = b.BudgetHours - st.SpentHours
+	st.BurnPct = round2(st.SpentHours / b.BudgetHours * 100)
+
+	start := b.CreatedAt
+	if b.StartDate != nil {
+		start = *b.StartDate
+	} else if first != nil && first.Before(start) {
+		start = *first
+	}
+	until := now
+	if b.EndDate != nil && b.EndDate.Before(now) {
+		until = *b.EndDate
+	}
+	weeks := until.Sub(start).Hours() / budgetWeek.Hours()
+	if weeks < 1 {
+		weeks = 1
+	}
+	st.BurnRatePerWeek = round2(st.SpentHours / weeks)
+
+	if st.RemainingHours > 0 && st.BurnRatePerWeek > 0 {
+		left := time.Duration(st.RemainingHours / st.BurnRatePerWeek * float64(budgetWeek))
+		exhaustion := now.Add(left)
+		st.ProjectedExhaustion = &exhaustion
+	}
+	if b.EndDate != nil && now.Before(*b.EndDate) {
+		projected := round2(st.SpentHours + st.BurnRatePerWeek*b.EndDate.Sub(now).Hours()/budgetWeek.Hours())
+		st.ProjectedSpentAtEnd = &projected
+	}
+
+	st.SpentHours = round2(st.SpentHours)
+	st.RemainingHours = round2(st.RemainingHours)
+	return st
+}
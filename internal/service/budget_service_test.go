@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+)
+
+// Setiap threshold terpicu sekali, dan alert di atas burn saat ini hilang
+// ketika budget dinaikkan.
+func TestBudgetThresholdAlerts(t *testing.T) {
+	ctx := context.Background()
+	st := memstore.New()
+	st.AddFolder(model.Folder{ID: "f1", Name: "Kinerja"})
+	st.AddList(model.List{ID: "l1", Name: "Sprint 1", FolderID: "f1"})
+	st.AddList(model.List{ID: "l2", Name: "Lain"})
+	st.AddTask(memstore.Task{ID: "b1", Name: "API", ListID: "l1", DueDate: day(10, 5, 17), SpentHours: 6})
+	st.AddTask(memstore.Task{ID: "b2", Name: "Luar", ListID: "l2", DueDate: day(10, 5, 17), SpentHours: 40})
+	svc := NewBudgetService(st, nil)
+
+	if _, err := svc.SetBudget(ctx, model.BudgetRequest{Level: "folder", NodeID: "f9", BudgetHours: 10}); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("budget for unknown folder = %v, want ErrProjectNotFound", err)
+	}
+
+	req := model.BudgetRequest{Level: "Folder", NodeID: "f1", BudgetHours: 10, Thresholds: []float64{100, 50, 50}}
+	b, err := svc.SetBudget(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.NodeName != "Kinerja" || b.TaskCount != 1 || b.BurnPct != 60 {
+		t.Fatalf("budget = %+v, want only the folder's task at 60%%", b)
+	}
+	if len(b.NewAlerts) != 1 || b.NewAlerts[0].Threshold != 50 {
+		t.Errorf("new alerts = %+v, want the 50%% threshold", b.NewAlerts)
+	}
+	if again, _ := svc.GetBudget(ctx, b.ID); len(again.NewAlerts) != 0 || len(again.Alerts) != 1 {
+		t.Errorf("re-evaluation = %d new, %d total alerts; want 0 and 1", len(again.NewAlerts), len(again.Alerts))
+	}
+
+	st.AddTask(memstore.Task{ID: "b3", Name: "UI", ListID: "l1", DueDate: day(10, 8, 17), SpentHours: 5})
+	if again, _ := svc.GetBudget(ctx, b.ID); len(again.NewAlerts) != 1 || again.NewAlerts[0].Threshold != 100 || len(again.Alerts) != 2 {
+		t.Errorf("after overspending = %+v, want the 100%% alert fired", again.NewAlerts)
+	}
+
+	req.BudgetHours = 100
+	same, err := svc.SetBudget(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if same.ID != b.ID || same.BurnPct != 11 || len(same.Alerts) != 0 {
+		t.Errorf("raised budget = id %d, %v%%, %d alerts; want the same budget with no alerts", same.ID, same.BurnPct, len(same.Alerts))
+	}
+}
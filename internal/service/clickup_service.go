@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -13,21 +14,65 @@ import (
 	"strings"
 	"time"
 
-	"github.com/lib/pq"
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
 	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
 	"github.com/roksva123/go-kinerja-backend/internal/repository"
 )
 
+var (
+	ErrClickUpUnavailable = apperr.New(apperr.CodeClickUpUnavailable, "clickup api unavailable")
+	ErrClickUpRateLimited = apperr.New(apperr.CodeRateLimited, "clickup api rate limit reached")
+	ErrClickUpRejected    = apperr.New(apperr.CodeClickUpError, "clickup api error")
+)
+
+// DefaultClickUpBaseURL is the ClickUp API v2 root used when BaseURL is empty.
+const DefaultClickUpBaseURL = "https://api.clickup.com/api/v2"
+
+// ClickUpRepo is the storage ClickUpService syncs ClickUp data into and serves
+// listings from.
+type ClickUpRepo interface {
+	repository.TaskStore
+	repository.MemberStore
+	repository.HierarchyStore
+	repository.ClickUpSyncStore
+	repository.ListingStore
+}
+
 type ClickUpService struct {
-    Repo   *repository.PostgresRepo
+    Repo   ClickUpRepo
     APIKey string
     Token  string
     TeamID string
     Client *http.Client
+    // BaseURL menggantikan DefaultClickUpBaseURL, misalnya server palsu di test.
+    BaseURL string
+
+    postSyncHooks []postSyncHook
+}
+
+type postSyncHook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// OnSyncComplete mendaftarkan fungsi yang dijalankan setelah SyncTasks selesai
+// (misalnya evaluasi alert dan budget). Error hook hanya dicatat di log.
+func (s *ClickUpService) OnSyncComplete(name string, fn func(ctx context.Context) error) {
+	s.postSyncHooks = append(s.postSyncHooks, postSyncHook{name: name, fn: fn})
+}
+
+func (s *ClickUpService) runPostSyncHooks(ctx context.Context) {
+	for _, h := range s.postSyncHooks {
+		if err := h.fn(ctx); err != nil {
+			log.Printf("WARNING: post-sync hook %s failed: %v\n", h.name, err)
+		}
+	}
 }
 
 func NewClickUpService(
-    repo *repository.PostgresRepo,
+    repo ClickUpRepo,
     apiKey string,
     token string,
     teamID string,
@@ -39,31 +84,90 @@ func NewClickUpService(
         Token:  token,
         TeamID: teamID,
         Client: &http.Client{Timeout: 20 * time.Second},
+        BaseURL: DefaultClickUpBaseURL,
     }
 }
 
+// endpoint builds an API URL from a path relative to the API root.
+func (s *ClickUpService) endpoint(format string, args ...interface{}) string {
+	base := strings.TrimSuffix(s.BaseURL, "/")
+	if base == "" {
+		base = DefaultClickUpBaseURL
+	}
+	return base + fmt.Sprintf(format, args...)
+}
+
 
 func (s *ClickUpService) doRequest(ctx context.Context, method, url string) ([]byte, error) {
     req, _ := http.NewRequestWithContext(ctx, method, url, nil)
     req.Header.Set("Authorization", s.Token)
     res, err := s.Client.Do(req)
     if err != nil {
-        return nil, err
+        return nil, fmt.Errorf("%w: %v", ErrClickUpUnavailable, err)
     }
     defer res.Body.Close()
     body, _ := io.ReadAll(res.Body)
     if res.StatusCode >= 400 {
-        return nil, fmt.Errorf("clickup api error %d: %s", res.StatusCode, string(body))
+        return nil, clickUpStatusError(res.StatusCode, body)
     }
     return body, nil
 }
 
+func (s *ClickUpService) doJSONRequest(ctx context.Context, method, url string, payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", s.Token)
+	req.Header.Set("Content-Type", "application/json")
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrClickUpUnavailable, err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode >= 400 {
+		return nil, clickUpStatusError(res.StatusCode, body)
+	}
+	return body, nil
+}
+
+// clickUpStatusError classifies a failed ClickUp response so the API can
+// answer with rate_limited, clickup_unavailable or clickup_error.
+func clickUpStatusError(status int, body []byte) error {
+	sentinel := ErrClickUpRejected
+	switch {
+	case status == http.StatusTooManyRequests:
+		sentinel = ErrClickUpRateLimited
+	case status >= 500:
+		sentinel = ErrClickUpUnavailable
+	}
+	return fmt.Errorf("%w: status %d: %s", sentinel, status, string(body))
+}
+
+// UpdateTaskAssignees adds and removes assignees of a task in ClickUp.
+func (s *ClickUpService) UpdateTaskAssignees(ctx context.Context, taskID string, add, rem []int64) error {
+	url := s.endpoint("/task/%s", taskID)
+	payload := map[string]interface{}{
+		"assignees": map[string][]int64{
+			"add": add,
+			"rem": rem,
+		},
+	}
+	_, err := s.doJSONRequest(ctx, http.MethodPut, url, payload)
+	return err
+}
+
 // SyncTeam 
 func (s *ClickUpService) SyncTeam(ctx context.Context) error {
     if s.TeamID == "" {
         return errors.New("team id not configured")
     }
-    url := fmt.Sprintf("https://api.clickup.com/api/v2/team/%s/space", s.TeamID)
+    url := s.endpoint("/team/%s/space", s.TeamID)
     b, err := s.doRequest(ctx, "GET", url)
     if err != nil {
         return err
@@ -93,7 +197,7 @@ func (s *ClickUpService) SyncMembers(ctx context.Context) error {
 	if s.TeamID == "" {
 		return errors.New("team id not configured")
 	}
-	url := "https://api.clickup.com/api/v2/team"
+	url := s.endpoint("/team")
 
 	b, err := s.doRequest(ctx, "GET", url)
 	if err != nil {
@@ -200,12 +304,7 @@ func getTimePtr(v interface{}) *time.Time {
     }
 
     if s, ok := v.(string); ok && s != "" {
-        // Try "DD-MM-YYYY" format (from your example JSON)
-        if t, err := time.Parse("02-01-2006", s); err == nil {
-            return &t
-        }
-        // Try "YYYY-MM-DD" format (common in databases/other APIs)
-        if t, err := time.Parse("2006-01-02", s); err == nil {
+        if t, err := dates.ParseStart(s, dates.Default()); err == nil {
             return &t
         }
     }
@@ -247,7 +346,7 @@ func (s *ClickUpService) SyncTasks(ctx context.Context) (int, error) {
 	total := 0
 
 	for {
-		url := fmt.Sprintf("https://api.clickup.com/api/v2/team/%s/task?page=%d&subtasks=true&include_closed=true", s.TeamID, page)
+		url := s.endpoint("/team/%s/task?page=%d&subtasks=true&include_closed=true", s.TeamID, page)
 		log.Println("[REQUEST]", url)
 
 		b, err := s.doRequest(ctx, "GET", url)
@@ -366,9 +465,11 @@ func (s *ClickUpService) SyncTasks(ctx context.Context) (int, error) {
 			// Fallback for TimeEstimate
 			if timeEstimateHours := parseTimeValueToHoursPtr(raw["time_estimate"]); timeEstimateHours != nil {
 				t.TimeEstimateHours = timeEstimateHours
+				t.EstimateSource = model.EstimateSourceClickUp
 			} else if t.TimeEstimateHours == nil { // Only set default if not set
-				defaultHours := 8.0
+				defaultHours := model.DefaultEstimateHours
 				t.TimeEstimateHours = &defaultHours
+				t.EstimateSource = model.EstimateSourceDefault
 			}
 
 			// STEP 5: PROCESS AND SAVE TO DATABASE
@@ -406,6 +507,34 @@ func (s *ClickUpService) SyncTasks(ctx context.Context) (int, error) {
 				log.Printf("❌ FAILED TO UPSERT ASSIGNEES for task %s: %v\n", t.ID, err)
 				return total, err
 			}
+			// Catat perubahan status (dipakai burndown sprint)
+			if t.Status.ID != "" {
+				changedAt := time.Now()
+				if finishedStatusTypes[t.Status.Type] {
+					if t.DateDone != nil {
+						changedAt = *t.DateDone
+					} else if t.DateClosed != nil {
+						changedAt = *t.DateClosed
+					}
+				}
+				if err := s.Repo.RecordTaskStatus(ctx, t.ID, t.Status.ID, t.Status.Name, t.Status.Type, changedAt); err != nil {
+					log.Printf("WARNING: Failed to record status history for task %s: %v\n", t.ID, err)
+				}
+			}
+			// Upsert Tags (dipakai link OKR)
+			var tags []string
+			if tagsArr, ok := raw["tags"].([]interface{}); ok {
+				for _, tagData := range tagsArr {
+					if tg, ok := tagData.(map[string]interface{}); ok {
+						if name := safeString(tg["name"]); name != "" {
+							tags = append(tags, name)
+						}
+					}
+				}
+			}
+			if err := s.Repo.ReplaceTaskTags(ctx, t.ID, tags); err != nil {
+				log.Printf("WARNING: Failed to upsert tags for task %s: %v\n", t.ID, err)
+			}
 			log.Println("✔ UPSERT SUCCESS:", t.ID)
 			total++
 		}
@@ -413,6 +542,7 @@ func (s *ClickUpService) SyncTasks(ctx context.Context) (int, error) {
 	}
 
 	log.Println("=== SYNC COMPLETE — TOTAL:", total)
+	s.runPostSyncHooks(ctx)
 	return total, nil
 }
 
@@ -433,7 +563,7 @@ func (s *ClickUpService) FullSync(ctx context.Context) ([]model.FullSync, error)
         return nil, err
     }
 
-    var out []model.FullSync
+    out := []model.FullSync{}
 
     for _, t := range tasks {
         var matchedMember *model.User
@@ -477,6 +607,22 @@ func (s *ClickUpService) GetMembers(ctx context.Context) ([]model.User, error) {
     return s.Repo.GetMembers(ctx)
 }
 
+func (s *ClickUpService) GetTasksPage(ctx context.Context, p query.Params) ([]model.TaskResponse, query.Page, error) {
+	return s.Repo.GetTasksPage(ctx, p)
+}
+
+func (s *ClickUpService) GetMembersPage(ctx context.Context, p query.Params) ([]model.User, query.Page, error) {
+	return s.Repo.GetMembersPage(ctx, p)
+}
+
+func (s *ClickUpService) GetListsPage(ctx context.Context, p query.Params) ([]model.List, query.Page, error) {
+	return s.Repo.GetListsPage(ctx, p)
+}
+
+func (s *ClickUpService) GetFoldersPage(ctx context.Context, p query.Params) ([]model.Folder, query.Page, error) {
+	return s.Repo.GetFoldersPage(ctx, p)
+}
+
 func (s *ClickUpService) GetSpaces(ctx context.Context) ([]model.SpaceInfo, error) {
 	return s.Repo.GetSpaces(ctx)
 }
@@ -511,11 +657,7 @@ func (s *ClickUpService) FullSyncFiltered(ctx context.Context, filter model.Full
         return nil, err
     }
 
-    if len(data) == 0 {
-        return nil, errors.New("Tidak ada task pada rentang tanggal yang dipilih")
-    }
-
-    var out []model.FullSync
+    out := []model.FullSync{}
 
     for _, t := range data {
 
@@ -563,7 +705,7 @@ func (s *ClickUpService) PullTasks(ctx context.Context) (int, error) {
     total := 0
 
     for {
-        url := fmt.Sprintf("https://api.clickup.com/api/v2/team/%s/task?page=%d", s.TeamID, page)
+        url := s.endpoint("/team/%s/task?page=%d", s.TeamID, page)
         b, err := s.doRequest(ctx, "GET", url)
         if err != nil {
             return total, err
@@ -667,7 +809,7 @@ func toIntPtr(v interface{}) *int64 {
 }
 
 func (s *ClickUpService) SyncFolders(ctx context.Context, spaceID string) error {
-	foldersURL := fmt.Sprintf("https://api.clickup.com/api/v2/space/%s/folder", spaceID)
+	foldersURL := s.endpoint("/space/%s/folder", spaceID)
 	folderBytes, err := s.doRequest(ctx, "GET", foldersURL)
 	if err != nil {
 		return fmt.Errorf("could not fetch folders for space %s: %w", spaceID, err)
@@ -703,20 +845,23 @@ func toInt64Ptr(ni sql.NullInt64) *int64 {
 	return &ni.Int64
 }
 
-func normalizeStatus(status string) string {
+// NormalizeStatus groups a ClickUp status into "to do", "progres", "done" or
+// "canceled"; other statuses are returned lowercased.
+func NormalizeStatus(status string) string {
 	lowerStatus := strings.ToLower(status)
 	if strings.Contains(lowerStatus, "review") || strings.Contains(lowerStatus, "progress") {
 		return "progres"
 	}
-	if strings.Contains(lowerStatus, "do") {
-		return "to do"
-	}
+	// cek "done" sebelum "do", kalau tidak "done" ikut terbaca "to do"
 	if strings.Contains(lowerStatus, "done") || strings.Contains(lowerStatus, "complete") || strings.Contains(lowerStatus, "closed") {
 		return "done"
 	}
 	if strings.Contains(lowerStatus, "cancel") {
 		return "canceled"
 	}
+	if strings.Contains(lowerStatus, "do") {
+		return "to do"
+	}
 	return lowerStatus 
 }
 
@@ -786,7 +931,7 @@ func (s *ClickUpService) SyncSpacesAndFolders(ctx context.Context) error {
 // Helper functions to make SyncSpacesAndFolders cleaner
 
 func (s *ClickUpService) fetchSpaces(ctx context.Context) ([]model.SpaceInfo, error) {
-	url := fmt.Sprintf("https://api.clickup.com/api/v2/team/%s/space?archived=false", s.TeamID)
+	url := s.endpoint("/team/%s/space?archived=false", s.TeamID)
 	bytes, err := s.doRequest(ctx, "GET", url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch spaces: %w", err)
@@ -815,7 +960,7 @@ func msToDateString(ms *int64) *string {
 }
 
 func (s *ClickUpService) fetchFoldersForSpace(ctx context.Context, spaceID string) ([]model.Folder, error) {
-	url := fmt.Sprintf("https://api.clickup.com/api/v2/space/%s/folder?archived=false", spaceID)
+	url := s.endpoint("/space/%s/folder?archived=false", spaceID)
 	bytes, err := s.doRequest(ctx, "GET", url)
 	if err != nil {
 		return nil, err
@@ -831,7 +976,7 @@ func (s *ClickUpService) fetchFoldersForSpace(ctx context.Context, spaceID strin
 }
 
 func (s *ClickUpService) fetchListsForFolder(ctx context.Context, folderID string) ([]model.List, error) {
-	url := fmt.Sprintf("https://api.clickup.com/api/v2/folder/%s/list?archived=false", folderID)
+	url := s.endpoint("/folder/%s/list?archived=false", folderID)
 	bytes, err := s.doRequest(ctx, "GET", url)
 	if err != nil {
 		return nil, err
@@ -847,7 +992,7 @@ func (s *ClickUpService) fetchListsForFolder(ctx context.Context, folderID strin
 }
 
 func (s *ClickUpService) fetchFolderlessListsForSpace(ctx context.Context, spaceID string) ([]model.List, error) {
-	url := fmt.Sprintf("https://api.clickup.com/api/v2/space/%s/list?archived=false", spaceID)
+	url := s.endpoint("/space/%s/list?archived=false", spaceID)
 	bytes, err := s.doRequest(ctx, "GET", url)
 	if err != nil {
 		return nil, err
@@ -864,6 +1009,8 @@ func (s *ClickUpService) fetchFolderlessListsForSpace(ctx context.Context, space
 
 func (s *ClickUpService) upsertLists(ctx context.Context, lists []model.List) error {
 	for _, list := range lists {
+		list.StartDate = getTimePtr(list.RawStartDate)
+		list.DueDate = getTimePtr(list.RawDueDate)
 		if err := s.Repo.UpsertList(ctx, &list); err != nil {
 			log.Printf("ERROR: Failed to upsert list %s: %v", list.ID, err)
 		}
@@ -872,145 +1019,8 @@ func (s *ClickUpService) upsertLists(ctx context.Context, lists []model.List) er
 }
 
 func (s *ClickUpService) GetTasksByRange(ctx context.Context, startMs, endMs int64, sortOrder string) ([]model.TaskDetail, error) {
-	orderDirection := "DESC" 
-	if strings.ToLower(sortOrder) == "asc" {
-		orderDirection = "ASC" 
-	}
-
-	query := `
-		SELECT
-			t.id,
-			t.name,
-			t.description,
-			t.text_content,
-			t.status_name,
-			t.start_date,
-			t.due_date,
-			t.date_done,
-			t.time_spent_hours,
-			t.time_estimate_hours,
-			COALESCE(l.name, f.name) as project_name
-		FROM tasks t
-		LEFT JOIN lists l ON t.list_id = l.id
-		LEFT JOIN folders f ON l.folder_id = f.id
-		WHERE
-			(t.start_date <= to_timestamp($2 / 1000.0) AND t.due_date >= to_timestamp($1 / 1000.0))
-			OR (t.date_done >= to_timestamp($1 / 1000.0) AND t.date_done <= to_timestamp($2 / 1000.0))
-		ORDER BY t.start_date %s
-	`
-	rows, err := s.Repo.DB.QueryContext(ctx, fmt.Sprintf(query, orderDirection), startMs, endMs)
-
-	if err != nil {
-		log.Printf("Error querying tasks by range: %v", err)
-		return nil, err
-	}
-	defer rows.Close()
-
-	taskMap := make(map[string]*model.TaskDetail)
-	var taskOrder []string
-	log.Println("--- Scanning Tasks By Range ---")
-	for rows.Next() {
-		var taskID, taskName, statusName, description, textContent string
-		var startDate, dueDate, dateDone sql.NullTime
-
-		var timeSpentHours, timeEstimateHours sql.NullFloat64
-		var projectName sql.NullString
-
-		err := rows.Scan(
-			&taskID,
-			&taskName,
-			&description,
-			&textContent,
-			&statusName,
-			&startDate,
-			&dueDate,
-			&dateDone,
-			&timeSpentHours,
-			&timeEstimateHours,
-			&projectName,
-		)
-		if err != nil {
-			log.Printf("Error scanning task row: %v", err)
-			return nil, err
-		}
-
-		taskDetail := model.TaskDetail{ 
-			ID:          taskID,
-			Name:        taskName,
-			Description: description,
-			TextContent: textContent,
-			StatusName:  statusName,
-			StartDate:   nullTimeToDateString(startDate),
-			DueDate:     nullTimeToDateString(dueDate),
-			DateDone:    nullTimeToDateString(dateDone),
-			Assignees:   []model.AssigneeDetail{},
-		}
-
-		// --- START: Kalkulasi Efisiensi Waktu & Sisa Waktu ---
-		if startDate.Valid && dueDate.Valid && dateDone.Valid {
-			// 1. Hitung sisa waktu (due_date - date_done)
-			remainingDuration := dueDate.Time.Sub(dateDone.Time)
-			remainingHoursValue := remainingDuration.Hours()
-			taskDetail.RemainingTimeHours = &remainingHoursValue
-
-			// 2. Hitung persentase efisiensi
-			durasiAlokasi := dueDate.Time.Sub(startDate.Time)
-			durasiAktual := dateDone.Time.Sub(startDate.Time)
-
-			if durasiAktual.Hours() > 0 {
-				efficiency := (durasiAlokasi.Hours() / durasiAktual.Hours()) * 100
-				taskDetail.TimeEfficiencyPercentage = &efficiency
-			}
-		}
-		// --- END: Kalkulasi ---
-
-		if projectName.Valid {
-			taskDetail.ProjectName = &projectName.String
-		}
-
-		if timeSpentHours.Valid { taskDetail.TimeSpentHours = timeSpentHours.Float64 }
-		if timeEstimateHours.Valid { taskDetail.TimeEstimateHours = timeEstimateHours.Float64 }
-
-
-		if _, exists := taskMap[taskID]; !exists {
-			taskMap[taskID] = &taskDetail
-			taskOrder = append(taskOrder, taskID)
-		}
-	}
-	rows.Close()
-
-	assigneeQuery := `
-		SELECT ta.task_id, u.clickup_id, u.username, u.email, u.name
-		FROM task_assignees ta
-		JOIN users u ON ta.user_clickup_id = u.clickup_id
-		WHERE ta.task_id = ANY($1)
-	`
-	assigneeRows, err := s.Repo.DB.QueryContext(ctx, assigneeQuery, pq.Array(taskOrder))
-	if err != nil {
-		log.Printf("Error querying assignees: %v", err)
-		return nil, err
-	}
-	defer assigneeRows.Close()
-
-	for assigneeRows.Next() {
-		var taskID string
-		var assignee model.AssigneeDetail
-		if err := assigneeRows.Scan(&taskID, &assignee.ClickUpID, &assignee.Username, &assignee.Email, &assignee.Name); err != nil {
-			log.Printf("Error scanning assignee row: %v", err)
-			return nil, err
-		}
-
-		if task, ok := taskMap[taskID]; ok {
-			task.Assignees = append(task.Assignees, assignee)
-		}
-	}
-	var result []model.TaskDetail
-	for _, taskID := range taskOrder {
-		result = append(result, *taskMap[taskID])
-	}
-
-	log.Printf("--- Found a total of %d tasks in range ---", len(result))
-	return result, nil
+	asc := strings.ToLower(sortOrder) == "asc"
+	return s.Repo.GetTaskDetailsByRange(ctx, time.UnixMilli(startMs), time.UnixMilli(endMs), asc)
 }
 
 
@@ -1053,68 +1063,18 @@ func (s *ClickUpService) AllSyncWithProgress(ctx context.Context, progressChan c
 
 
 
+// WorkingDaysBetween counts weekdays in the workspace time zone.
 func WorkingDaysBetween(start, end time.Time) int {
-	if end.Before(start) {
-		return 0
-	}
-
-	start = start.Truncate(24 * time.Hour)
-	end = end.Truncate(24 * time.Hour)
-
-	days := 0
-	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
-		wd := d.Weekday()
-		if wd != time.Saturday && wd != time.Sunday {
-			days++
-		}
-	}
-	return days
+	return dates.WorkingDays(start, end, dates.Default())
 }
 
 func (s *ClickUpService) GetTasksByAssignee(ctx context.Context, startMs, endMs int64) (*model.TasksByAssigneeResponse, error) {
-	start := time.UnixMilli(startMs)
-	end := time.UnixMilli(endMs)
-
-	summaries, err := s.Repo.GetTasksSummaryByDateRange(ctx, start, end)
+	assignees, err := s.Repo.GetTasksByAssignee(ctx, time.UnixMilli(startMs), time.UnixMilli(endMs))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get task summaries: %w", err)
-	}
-
-	if len(summaries) == 0 {
-		return &model.TasksByAssigneeResponse{
-			Count:     0,
-			Assignees: []model.AssigneeWithTasks{},
-		}, nil
+		return nil, fmt.Errorf("failed to get tasks by assignee: %w", err)
 	}
-
-	var assignees []model.AssigneeWithTasks
-	for _, summary := range summaries {
-		tasks, err := s.Repo.GetTasksByUser(ctx, summary.UserID, start, end)
-		if err != nil {
-			log.Printf("WARNING: could not get tasks for user %d: %v", summary.UserID, err)
-			continue 
-		}
-
-		totalSpentHours := summary.TotalSpentHours
-
-		assignee := model.AssigneeWithTasks{
-			ClickUpID:          summary.UserID,
-			Username:           summary.Name,
-			Email:              summary.Email,
-			Name:               summary.Name,
-			TotalSpentHours:    totalSpentHours,
-			ExpectedHours:      summary.TotalWorkHours,
-			TotalTasks:         summary.TotalTasks,
-			TotalUpcomingHours: summary.TotalUpcomingHours,
-			Tasks:              tasks,
-		}
-		assignees = append(assignees, assignee)
-	}
-
-	response := &model.TasksByAssigneeResponse{
+	return &model.TasksByAssigneeResponse{
 		Count:     len(assignees),
 		Assignees: assignees,
-	}
-
-	return response, nil
+	}, nil
 }
\ No newline at end of file
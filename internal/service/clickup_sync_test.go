@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/clickupfake"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/repotest"
+)
+
+func fakeClickUp(t *testing.T, repo ClickUpRepo) (*ClickUpService, *clickupfake.Server) {
+	fake := clickupfake.New(t)
+	svc := NewClickUpService(repo, "", clickupfake.Token, clickupfake.TeamID)
+	svc.BaseURL = fake.URL
+	return svc, fake
+}
+
+func TestSyncTasksRateLimited(t *testing.T) {
+	svc, fake := fakeClickUp(t, nil)
+	fake.RateLimit(1)
+
+	n, err := svc.SyncTasks(context.Background())
+	if !errors.Is(err, ErrClickUpRateLimited) || n != 0 {
+		t.Fatalf("SyncTasks = %d, %v; want ErrClickUpRateLimited", n, err)
+	}
+}
+
+func TestSyncMembersRejectedToken(t *testing.T) {
+	svc, _ := fakeClickUp(t, nil)
+	svc.Token = "pk_revoked"
+
+	if err := svc.SyncMembers(context.Background()); !errors.Is(err, ErrClickUpRejected) {
+		t.Fatalf("SyncMembers = %v, want ErrClickUpRejected", err)
+	}
+}
+
+func TestUpdateTaskAssigneesPayload(t *testing.T) {
+	svc, fake := fakeClickUp(t, nil)
+	if err := svc.UpdateTaskAssignees(context.Background(), "t1", []int64{102}, []int64{101}); err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := fake.Requests()
+	last := reqs[len(reqs)-1]
+	var body struct {
+		Assignees struct{ Add, Rem []int64 } `json:"assignees"`
+	}
+	json.Unmarshal(last.Body, &body)
+	if last.Method != http.MethodPut || last.Path != "/api/v2/task/t1" ||
+		!reflect.DeepEqual(body.Assignees.Add, []int64{102}) || !reflect.DeepEqual(body.Assignees.Rem, []int64{101}) {
+		t.Errorf("request = %s %s %s", last.Method, last.Path, last.Body)
+	}
+}
+
+// TestSyncAgainstFakeClickUp menjalankan sync lengkap ke Postgres; butuh
+// TEST_DATABASE_URL (lihat repotest).
+func TestSyncAgainstFakeClickUp(t *testing.T) {
+	repo := repotest.New(t, "sync")
+	checkSync(t, repo, func(taskID string) []string {
+		rows, err := repo.DB.QueryContext(context.Background(), `SELECT tag FROM task_tags WHERE task_id = $1 ORDER BY tag`, taskID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+		var tags []string
+		for rows.Next() {
+			var tag string
+			rows.Scan(&tag)
+			tags = append(tags, tag)
+		}
+		return tags
+	})
+}
+
+// Sync yang sama ke memstore; role hasil migrasi didaftarkan lebih dulu.
+func TestSyncIntoMemstore(t *testing.T) {
+	st := memstore.New()
+	for _, role := range []string{"backend", "web", "UI-UX", "pm"} {
+		st.AddRole(role)
+	}
+	checkSync(t, st, st.TaskTags)
+}
+
+// checkSync menjalankan sync lengkap dari fake ClickUp ke repo lalu
+// memeriksa hasilnya; tags membaca tag sebuah task, terurut.
+func checkSync(t *testing.T, repo interface {
+	ClickUpRepo
+	repository.ResourceStore
+}, tags func(taskID string) []string) {
+	t.Helper()
+	svc, fake := fakeClickUp(t, repo)
+	fake.PageSize = 2
+	ctx := context.Background()
+
+	if err := svc.SyncMembers(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SyncSpacesAndFolders(ctx); err != nil {
+		t.Fatal(err)
+	}
+	n, err := svc.SyncTasks(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 5 {
+		t.Errorf("synced %d tasks over the pages, want 5 including the subtask and the closed task", n)
+	}
+
+	members, err := repo.GetMembers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roles := map[int64]string{}
+	for _, m := range members {
+		roles[m.ClickUpID] = m.Role
+	}
+	if want := map[int64]string{101: "backend", 102: "UI-UX", 103: "pm"}; !reflect.DeepEqual(roles, want) {
+		t.Errorf("member roles = %v, want %v (other teams skipped)", roles, want)
+	}
+
+	spaces, _ := repo.GetSpaces(ctx)
+	folders, _ := repo.GetFolders(ctx)
+	lists, _ := repo.GetLists(ctx)
+	if len(spaces) != 2 || len(folders) != 1 || len(lists) != 4 {
+		t.Errorf("synced %d spaces, %d folders, %d lists; want 2, 1, 4 without archived ones", len(spaces), len(folders), len(lists))
+	}
+	for _, l := range lists {
+		if l.ID == "l3" && (l.FolderID != "" || l.SpaceID != "s1") {
+			t.Errorf("folderless list = %+v", l)
+		}
+	}
+
+	at := func(month time.Month, d, h int) time.Time { return time.Date(2026, month, d, h, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		id             string
+		start, due     time.Time
+		estimate       float64
+		estimateSource string
+		assignees      int
+	}{
+		{"t1", at(10, 5, 2), at(10, 9, 10), 6, "clickup", 1},
+		// Tanggal Mulai/Akhir menimpa start/due; estimasi kosong jadi default
+		{"t2", at(10, 12, 2), at(10, 14, 10), 8, "default", 2},
+		// subtask tanpa tanggal: start dari date_created, due dari start
+		{"t4", at(10, 13, 2), at(10, 13, 2), 1, "clickup", 1},
+		{"t5", at(9, 1, 2), at(9, 3, 10), 2, "clickup", 1},
+	}
+	for _, tt := range tests {
+		task, err := repo.GetTask(ctx, tt.id)
+		if err != nil || task == nil {
+			t.Fatalf("%s: %v, %v", tt.id, task, err)
+		}
+		if !task.StartDate.Equal(tt.start) || !task.DueDate.Equal(tt.due) {
+			t.Errorf("%s: start %v, due %v; want %v, %v", tt.id, task.StartDate, task.DueDate, tt.start, tt.due)
+		}
+		if *task.TimeEstimateHours != tt.estimate || task.EstimateSource != tt.estimateSource {
+			t.Errorf("%s: estimate %v (%s), want %v (%s)", tt.id, *task.TimeEstimateHours, task.EstimateSource, tt.estimate, tt.estimateSource)
+		}
+		if len(task.Assignees) != tt.assignees {
+			t.Errorf("%s: %d assignees, want %d", tt.id, len(task.Assignees), tt.assignees)
+		}
+	}
+
+	t2, _ := repo.GetTask(ctx, "t2")
+	// alokasi 56 jam, selesai dalam 49 jam
+	if e := t2.TimeEfficiencyPercentage; e == nil || math.Abs(*e-800.0/7) > 0.01 {
+		t.Errorf("t2 efficiency = %v", e)
+	}
+
+	if got := tags("t1"); !reflect.DeepEqual(got, []string{"api", "okr-q4"}) {
+		t.Errorf("t1 tags = %v", got)
+	}
+
+	// sync ulang tidak menggandakan data
+	if _, err := svc.SyncTasks(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if all, _ := repo.GetTasks(ctx); len(all) != 5 {
+		t.Errorf("%d tasks after a second sync, want 5", len(all))
+	}
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+var (
+	ErrInvalidEstimate = apperr.New(apperr.CodeValidationFailed, "invalid estimate")
+	ErrTaskNotFound    = apperr.New(apperr.CodeNotFound, "task not found")
+)
+
+// estimateDefaultDays adalah rentang default laporan akurasi bila tanggal kosong.
+const estimateDefaultDays = 90
+
+// estimateTolerance: ratio dalam ±20% dianggap estimasi yang tepat.
+const estimateTolerance = 0.2
+
+type EstimateService struct {
+	repo repository.EstimateStore
+}
+
+func NewEstimateService(repo repository.EstimateStore) *EstimateService {
+	return &EstimateService{repo: repo}
+}
+
+// SetManualEstimate mengganti estimasi task secara manual.
+func (s *EstimateService) SetManualEstimate(ctx context.Context, taskID string, hours float64) error {
+	if hours <= 0 {
+		return fmt.Errorf("%w: hours must be positive", ErrInvalidEstimate)
+	}
+	if err := s.repo.SetManualEstimate(ctx, taskID, hours); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTaskNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// GetAccuracy membuat laporan estimasi vs aktual untuk task yang sudah ditutup.
+func (s *EstimateService) GetAccuracy(ctx context.Context, startDate, endDate, groupBy string) (*model.EstimateAccuracyReport, error) {
+	groupBy = strings.ToLower(strings.TrimSpace(groupBy))
+	if groupBy == "" {
+		groupBy = model.EstimateGroupMember
+	}
+	if groupBy != model.EstimateGroupMember && groupBy != model.EstimateGroupRole && groupBy != model.EstimateGroupProject {
+		return nil, fmt.Errorf("%w: group_by must be member, role or project", ErrInvalidEstimate)
+	}
+
+	end := time.Now()
+	if endDate != "" {
+		t, err := dates.ParseEnd(endDate, dates.Default())
+		if err != nil {
+			return nil, fmt.Errorf("%w: end_date: %v", ErrInvalidEstimate, err)
+		}
+		end = t
+	}
+	start := end.AddDate(0, 0, -estimateDefaultDays)
+	if startDate != "" {
+		t, err := dates.ParseStart(startDate, dates.Default())
+		if err != nil {
+			return nil, fmt.Errorf("%w: start_date: %v", ErrInvalidEstimate, err)
+		}
+		start = t
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: end_date is before start_date", ErrInvalidEstimate)
+	}
+
+	samples, err := s.repo.GetEstimateSamples(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	report := ComputeEstimateAccuracy(samples, groupBy)
+	report.StartDate, report.EndDate = start, end
+	return &report, nil
+}
+
+// ComputeEstimateAccuracy groups closed samples and computes actual/estimate
+// ratios. Defaulted estimates and rows without estimate or tracked time are
+// counted as excluded. Overall counts each task once.
+func ComputeEstimateAccuracy(samples []model.EstimateSample, groupBy string) model.EstimateAccuracyReport {
+	type bucket struct {
+		group  model.EstimateAccuracyGroup
+		ratios []float64
+	}
+	buckets := map[string]*bucket{}
+	overall := &bucket{group: model.EstimateAccuracyGroup{Key: "all", Name: "All"}}
+	seen := map[string]bool{}
+
+	for _, s := range samples {
+		key, name := estimateGroupKey(s, groupBy)
+		b := buckets[key]
+		if b == nil {
+			b = &bucket{group: model.EstimateAccuracyGroup{Key: key, Name: name}}
+			buckets[key] = b
+		}
+		first := !seen[s.TaskID]
+		seen[s.TaskID] = true
+
+		targets := []*bucket{b}
+		if first {
+			targets = append(targets, overall)
+		}
+		for _, t := range targets {
+			switch {
+			case s.EstimateSource == model.EstimateSourceDefault:
+				t.group.ExcludedDefaults++
+			case s.EstimateHours <= 0 || s.SpentHours <= 0:
+				t.group.ExcludedNoData++
+			default:
+				t.group.TaskCount++
+				t.group.EstimateHours += s.EstimateHours
+				t.group.SpentHours += s.SpentHours
+				t.ratios = append(t.ratios, s.SpentHours/s.EstimateHours)
+			}
+		}
+	}
+
+	finish := func(b *bucket) model.EstimateAccuracyGroup {
+		g := b.group
+		g.EstimateHours = round2(g.EstimateHours)
+		g.SpentHours = round2(g.SpentHours)
+		n := len(b.ratios)
+		if n == 0 {
+			return g
+		}
+		var sum float64
+		within := 0
+		for _, r := range b.ratios {
+			sum += r
+			if math.Abs(r-1) <= estimateTolerance {
+				within++
+			}
+		}
+		mean := sum / float64(n)
+		var sq float64
+		for _, r := range b.ratios {
+			sq += (r - mean) * (r - mean)
+		}
+		sorted := append([]float64(nil), b.ratios...)
+		sort.Float64s(sorted)
+		median := sorted[n/2]
+		if n%2 == 0 {
+			median = (sorted[n/2-1] + sorted[n/2]) / 2
+		}
+
+		g.MeanRatio = round2(mean)
+		g.MedianRatio = round2(median)
+		g.BiasPct = round2((mean - 1) * 100)
+		g.Spread = round2(math.Sqrt(sq / float64(n)))
+		g.WithinTargetPct = round2(float64(within) / float64(n) * 100)
+		return g
+	}
+
+	report := model.EstimateAccuracyReport{GroupBy: groupBy, Groups: []model.EstimateAccuracyGroup{}}
+	for _, b := range buckets {
+		report.Groups = append(report.Groups, finish(b))
+	}
+	sort.Slice(report.Groups, func(i, j int) bool { return report.Groups[i].Name < report.Groups[j].Name })
+	report.Overall = finish(overall)
+	return report
+}
+
+func estimateGroupKey(s model.EstimateSample, groupBy string) (string, string) {
+	switch groupBy {
+	case model.EstimateGroupRole:
+		if s.Role == "" {
+			return "", "(no role)"
+		}
+		return strings.ToLower(s.Role), s.Role
+	case model.EstimateGroupProject:
+		if s.ProjectName == "" {
+			return "", "(no project)"
+		}
+		return s.ProjectName, s.ProjectName
+	default:
+		name := s.UserName
+		if name == "" {
+			name = strconv.FormatInt(s.UserID, 10)
+		}
+		return strconv.FormatInt(s.UserID, 10), name
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+)
+
+// e1 dikerjakan berdua, e2 masih memakai estimasi default sampai diisi manual,
+// e3 belum selesai dan e4 selesai di luar rentang.
+func TestEstimateAccuracyByProject(t *testing.T) {
+	ctx := context.Background()
+	st := memstore.New()
+	st.AddMember(model.User{ClickUpID: 1, Name: "Ana", Role: "backend", Status: memstore.ActiveStatus})
+	st.AddMember(model.User{ClickUpID: 2, Name: "Budi", Role: "web", Status: memstore.ActiveStatus})
+	st.AddFolder(model.Folder{ID: "f1", Name: "Kinerja"})
+	st.AddList(model.List{ID: "l1", Name: "Sprint 1", FolderID: "f1"})
+	st.AddList(model.List{ID: "l2", Name: "Lepas"})
+
+	st.AddTask(memstore.Task{ID: "e1", Name: "API", Status: statusDone, ListID: "l1", DateDone: day(9, 5, 12),
+		EstimateHours: 4, SpentHours: 5, EstimateSource: model.EstimateSourceClickUp, Assignees: []int64{2, 1}})
+	st.AddTask(memstore.Task{ID: "e2", Name: "Ekspor", Status: statusDone, ListID: "l2", DateDone: day(9, 8, 12),
+		EstimateHours: model.DefaultEstimateHours, SpentHours: 2, EstimateSource: model.EstimateSourceDefault, Assignees: []int64{1}})
+	st.AddTask(memstore.Task{ID: "e3", Name: "UI", Status: statusOpen, ListID: "l1",
+		EstimateHours: 3, SpentHours: 1, EstimateSource: model.EstimateSourceClickUp, Assignees: []int64{1}})
+	st.AddTask(memstore.Task{ID: "e4", Name: "Lama", Status: statusDone, ListID: "l1", DateDone: day(6, 5, 12),
+		EstimateHours: 2, SpentHours: 2, EstimateSource: model.EstimateSourceClickUp, Assignees: []int64{1}})
+	svc := NewEstimateService(st)
+
+	if err := svc.SetManualEstimate(ctx, "e9", 2); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("estimate for unknown task = %v, want ErrTaskNotFound", err)
+	}
+	if err := svc.SetManualEstimate(ctx, "e2", 0); !errors.Is(err, ErrInvalidEstimate) {
+		t.Errorf("zero estimate = %v, want ErrInvalidEstimate", err)
+	}
+	if _, err := svc.GetAccuracy(ctx, "2026-09-01", "2026-09-30", "space"); !errors.Is(err, ErrInvalidEstimate) {
+		t.Errorf("group by space = %v, want ErrInvalidEstimate", err)
+	}
+
+	r, err := svc.GetAccuracy(ctx, "2026-09-01", "2026-09-30", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.GroupBy != model.EstimateGroupMember || len(r.Groups) != 2 || r.Groups[0].Name != "Ana" || r.Groups[0].ExcludedDefaults != 1 {
+		t.Fatalf("by member = %+v, want Ana with the defaulted task excluded", r.Groups)
+	}
+	if r.Overall.TaskCount != 1 || r.Overall.MeanRatio != 1.25 || r.Overall.ExcludedDefaults != 1 {
+		t.Errorf("overall = %+v, want e1 counted once", r.Overall)
+	}
+
+	if err := svc.SetManualEstimate(ctx, "e2", 2); err != nil {
+		t.Fatal(err)
+	}
+	r, err = svc.GetAccuracy(ctx, "2026-09-01", "2026-09-30", "Project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Groups) != 2 || r.Groups[0].Name != "Kinerja" || r.Groups[0].TaskCount != 2 || r.Groups[1].Name != "Lepas" || r.Groups[1].MeanRatio != 1 {
+		t.Errorf("by project = %+v, want the folder name and the list name without folder", r.Groups)
+	}
+	if r.Overall.TaskCount != 2 || r.Overall.ExcludedDefaults != 0 || r.Overall.WithinTargetPct != 50 {
+		t.Errorf("overall after manual estimate = %+v", r.Overall)
+	}
+}
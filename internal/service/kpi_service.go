@@ -0,0 +1,448 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+var (
+	ErrInvalidPeriod     = apperr.New(apperr.CodeValidationFailed, "invalid period, use YYYY-MM or YYYY-Qn")
+	ErrIndicatorNotFound = apperr.New(apperr.CodeNotFound, "kpi indicator not found")
+	ErrRoleNotFound      = apperr.New(apperr.CodeNotFound, "role not found")
+	ErrScorecardNotFound = apperr.New(apperr.CodeNotFound, "scorecard not found")
+	ErrInvalidKPIConfig  = apperr.New(apperr.CodeValidationFailed, "invalid kpi configuration")
+)
+
+var (
+	periodMonthPattern   = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+	periodQuarterPattern = regexp.MustCompile(`^(\d{4})-[Qq]([1-4])$`)
+	finishedStatusTypes  = map[string]bool{"done": true, "closed": true}
+)
+
+// kpiExpectedDailyHours is the working time per day used for utilisation.
+const kpiExpectedDailyHours = 8.0
+
+// ParsePeriod turns a period label into its type and time range.
+// "2026-09" is monthly, "2026-Q3" is quarterly; bounds are in the workspace zone.
+func ParsePeriod(label string) (periodType string, start, end time.Time, err error) {
+	if m := periodMonthPattern.FindStringSubmatch(label); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return "", time.Time{}, time.Time{}, ErrInvalidPeriod
+		}
+		start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, dates.Default())
+		return "monthly", start, start.AddDate(0, 1, 0).Add(-time.Second), nil
+	}
+	if m := periodQuarterPattern.FindStringSubmatch(label); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		quarter, _ := strconv.Atoi(m[2])
+		start = time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, dates.Default())
+		return "quarterly", start, start.AddDate(0, 3, 0).Add(-time.Second), nil
+	}
+	return "", time.Time{}, time.Time{}, ErrInvalidPeriod
+}
+
+// KPIRepo is the storage KPIService reads configuration, members and tasks
+// from and writes scorecards to.
+type KPIRepo interface {
+	repository.KPIStore
+	repository.MemberStore
+	repository.TaskStore
+}
+
+type KPIService struct {
+	repo KPIRepo
+}
+
+func NewKPIService(repo KPIRepo) *KPIService {
+	return &KPIService{repo: repo}
+}
+
+func (s *KPIService) GetIndicators(ctx context.Context) ([]model.KPIIndicator, error) {
+	return s.repo.GetKPIIndicators(ctx)
+}
+
+// UpdateIndicator mengubah field indikator yang dikirim; field lain tetap.
+func (s *KPIService) UpdateIndicator(ctx context.Context, code string, req model.KPIIndicatorRequest) (*model.KPIIndicator, error) {
+	indicators, err := s.repo.GetKPIIndicators(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var ind *model.KPIIndicator
+	for i := range indicators {
+		if indicators[i].Code == code {
+			ind = &indicators[i]
+		}
+	}
+	if ind == nil {
+		return nil, ErrIndicatorNotFound
+	}
+	if req.Name != nil {
+		ind.Name = *req.Name
+	}
+	if req.Description != nil {
+		ind.Description = *req.Description
+	}
+	if req.Unit != nil {
+		ind.Unit = *req.Unit
+	}
+	if req.DefaultWeight != nil {
+		ind.DefaultWeight = *req.DefaultWeight
+	}
+	if req.Bands != nil {
+		ind.Bands = req.Bands
+	}
+	if req.Active != nil {
+		ind.Active = *req.Active
+	}
+
+	if ind.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidKPIConfig)
+	}
+	if ind.DefaultWeight < 0 {
+		return nil, fmt.Errorf("%w: default_weight must not be negative", ErrInvalidKPIConfig)
+	}
+	if len(ind.Bands) == 0 {
+		return nil, fmt.Errorf("%w: at least one band is required", ErrInvalidKPIConfig)
+	}
+	for _, b := range ind.Bands {
+		if b.Min != nil && b.Max != nil && *b.Min >= *b.Max {
+			return nil, fmt.Errorf("%w: band %q has min >= max", ErrInvalidKPIConfig, b.Label)
+		}
+	}
+
+	err = s.repo.UpdateKPIIndicator(ctx, ind)
+	if err == sql.ErrNoRows {
+		return nil, ErrIndicatorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ind, nil
+}
+
+func (s *KPIService) GetRoleWeights(ctx context.Context) ([]model.KPIRoleWeight, error) {
+	return s.repo.GetKPIRoleWeights(ctx)
+}
+
+func (s *KPIService) SetRoleWeights(ctx context.Context, role string, weights map[string]float64) error {
+	indicators, err := s.repo.GetKPIIndicators(ctx)
+	if err != nil {
+		return err
+	}
+	known := map[string]bool{}
+	for _, ind := range indicators {
+		known[ind.Code] = true
+	}
+	for code, w := range weights {
+		if !known[code] {
+			return fmt.Errorf("%w: unknown indicator %q", ErrInvalidKPIConfig, code)
+		}
+		if w < 0 {
+			return fmt.Errorf("%w: weight of %q must not be negative", ErrInvalidKPIConfig, code)
+		}
+	}
+
+	err = s.repo.SetKPIRoleWeights(ctx, role, weights)
+	if err == sql.ErrNoRows {
+		return ErrRoleNotFound
+	}
+	return err
+}
+
+// ComputePeriod computes and stores the scorecards of every active member for
+// a monthly or quarterly period label.
+func (s *KPIService) ComputePeriod(ctx context.Context, label string) ([]model.KPIScorecard, error) {
+	periodType, start, end, err := ParsePeriod(label)
+	if err != nil {
+		return nil, err
+	}
+	return s.ComputeRange(ctx, periodType, label, start, end)
+}
+
+// ComputeRange computes and stores scorecards for an arbitrary range.
+func (s *KPIService) ComputeRange(ctx context.Context, periodType, label string, start, end time.Time) ([]model.KPIScorecard, error) {
+	scorecards, err := s.BuildScorecards(ctx, periodType, label, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for i := range scorecards {
+		if err := s.repo.SaveScorecard(ctx, &scorecards[i]); err != nil {
+			return nil, err
+		}
+	}
+	return scorecards, nil
+}
+
+// BuildScorecards computes scorecards without storing them.
+func (s *KPIService) BuildScorecards(ctx context.Context, periodType, label string, start, end time.Time) ([]model.KPIScorecard, error) {
+	indicators, err := s.repo.GetKPIIndicators(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roleWeights, err := s.repo.GetKPIRoleWeights(ctx)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.repo.GetMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := s.repo.GetMetricTasks(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	tasksByUser := map[int64][]model.KPITaskMetric{}
+	for _, m := range metrics {
+		tasksByUser[m.UserID] = append(tasksByUser[m.UserID], m)
+	}
+
+	weightsByRole := map[string]map[string]float64{}
+	for _, w := range roleWeights {
+		if weightsByRole[w.Role] == nil {
+			weightsByRole[w.Role] = map[string]float64{}
+		}
+		weightsByRole[w.Role][w.IndicatorCode] = w.Weight
+	}
+
+	var out []model.KPIScorecard
+	for _, u := range members {
+		if u.Status != "aktif" {
+			continue
+		}
+		sc := model.KPIScorecard{
+			UserID:      u.ClickUpID,
+			Name:        u.Name,
+			Email:       u.Email,
+			Role:        u.Role,
+			PeriodType:  periodType,
+			PeriodLabel: label,
+			PeriodStart: start,
+			PeriodEnd:   end,
+		}
+		results := ComputeIndicators(tasksByUser[u.ClickUpID], start, end)
+		sc.Items, sc.TotalScore = scoreIndicators(indicators, weightsByRole[u.Role], results)
+		out = append(out, sc)
+	}
+	return out, nil
+}
+
+// IndicatorResult is the raw value of one indicator with the tasks behind it.
+// Value is nil when the member had no tasks the indicator applies to.
+type IndicatorResult struct {
+	Value *float64
+	Tasks []model.KPIItemTask
+}
+
+// ComputeIndicators computes the raw indicator values of one member.
+func ComputeIndicators(tasks []model.KPITaskMetric, start, end time.Time) map[string]IndicatorResult {
+	seen := map[string]bool{}
+	var unique []model.KPITaskMetric
+	for _, t := range tasks {
+		if !seen[t.TaskID] {
+			seen[t.TaskID] = true
+			unique = append(unique, t)
+		}
+	}
+
+	inPeriod := func(t *time.Time) bool {
+		return t != nil && !t.Before(start) && !t.After(end)
+	}
+	doneAt := func(t model.KPITaskMetric) *time.Time {
+		if t.DateDone != nil {
+			return t.DateDone
+		}
+		return t.DateClosed
+	}
+
+	onTime := IndicatorResult{}
+	accuracy := IndicatorResult{}
+	throughput := IndicatorResult{}
+	utilisation := IndicatorResult{}
+	rework := IndicatorResult{}
+
+	var onTimeCount, withDue, completed, finishedInPeriod, reopened int
+	var accuracySum, spentSum float64
+	var accuracyCount int
+
+	for _, t := range unique {
+		spentSum += t.SpentHours
+		utilisation.Tasks = append(utilisation.Tasks, model.KPIItemTask{
+			TaskID: t.TaskID, TaskName: t.TaskName, Counted: t.SpentHours > 0, Value: t.SpentHours, Note: "hours spent",
+		})
+
+		done := doneAt(t)
+		if !inPeriod(done) {
+			continue
+		}
+		finishedInPeriod++
+
+		if !finishedStatusTypes[t.StatusType] {
+			reopened++
+			rework.Tasks = append(rework.Tasks, model.KPIItemTask{
+				TaskID: t.TaskID, TaskName: t.TaskName, Counted: true, Note: "reopened, now " + t.StatusName,
+			})
+			continue
+		}
+		rework.Tasks = append(rework.Tasks, model.KPIItemTask{TaskID: t.TaskID, TaskName: t.TaskName, Note: "finished"})
+
+		completed++
+		throughput.Tasks = append(throughput.Tasks, model.KPIItemTask{
+			TaskID: t.TaskID, TaskName: t.TaskName, Counted: true, Value: 1, Note: "completed " + done.Format("2006-01-02"),
+		})
+
+		if t.DueDate != nil {
+			withDue++
+			ok := !done.After(*t.DueDate)
+			note := "late"
+			if ok {
+				onTimeCount++
+				note = "on time"
+			}
+			onTime.Tasks = append(onTime.Tasks, model.KPIItemTask{
+				TaskID: t.TaskID, TaskName: t.TaskName, Counted: ok, Value: done.Sub(*t.DueDate).Hours(), Note: note,
+			})
+		}
+
+		if t.EstimateSource == model.EstimateSourceDefault {
+			accuracy.Tasks = append(accuracy.Tasks, model.KPIItemTask{
+				TaskID: t.TaskID, TaskName: t.TaskName, Note: "default estimate, excluded",
+			})
+		} else if t.EstimateHours > 0 && t.SpentHours > 0 {
+			acc := math.Max(0, 100-math.Abs(t.SpentHours-t.EstimateHours)/t.EstimateHours*100)
+			accuracySum += acc
+			accuracyCount++
+			accuracy.Tasks = append(accuracy.Tasks, model.KPIItemTask{
+				TaskID: t.TaskID, TaskName: t.TaskName, Counted: true, Value: round2(acc),
+				Note: fmt.Sprintf("estimate %.1fh, spent %.1fh", t.EstimateHours, t.SpentHours),
+			})
+		} else {
+			accuracy.Tasks = append(accuracy.Tasks, model.KPIItemTask{
+				TaskID: t.TaskID, TaskName: t.TaskName, Note: "no estimate or time tracked",
+			})
+		}
+	}
+
+	if withDue > 0 {
+		onTime.Value = floatPtr(round2(float64(onTimeCount) / float64(withDue) * 100))
+	}
+	if accuracyCount > 0 {
+		accuracy.Value = floatPtr(round2(accuracySum / float64(accuracyCount)))
+	}
+	workingDays := WorkingDaysBetween(start, end)
+	if workingDays > 0 {
+		throughput.Value = floatPtr(round2(float64(completed) / (float64(workingDays) / 5)))
+		utilisation.Value = floatPtr(round2(spentSum / (float64(workingDays) * kpiExpectedDailyHours) * 100))
+	}
+	if finishedInPeriod > 0 {
+		rework.Value = floatPtr(round2(float64(reopened) / float64(finishedInPeriod) * 100))
+	}
+
+	return map[string]IndicatorResult{
+		model.KPIOnTimeRate:       onTime,
+		model.KPIEstimateAccuracy: accuracy,
+		model.KPIThroughput:       throughput,
+		model.KPIUtilisation:      utilisation,
+		model.KPIReworkRate:       rework,
+	}
+}
+
+// scoreIndicators maps raw values onto the indicator bands and combines them
+// with the role weights (or the indicator defaults when the role has none).
+// Indicators without a value do not take part in the weighted average.
+func scoreIndicators(indicators []model.KPIIndicator, roleWeights map[string]float64, results map[string]IndicatorResult) ([]model.KPIScorecardItem, float64) {
+	items := []model.KPIScorecardItem{}
+	var weighted, totalWeight float64
+
+	for _, ind := range indicators {
+		if !ind.Active {
+			continue
+		}
+		res, ok := results[ind.Code]
+		if !ok {
+			continue
+		}
+
+		weight := ind.DefaultWeight
+		if roleWeights != nil {
+			weight = roleWeights[ind.Code]
+		}
+
+		item := model.KPIScorecardItem{
+			IndicatorCode: ind.Code,
+			IndicatorName: ind.Name,
+			Unit:          ind.Unit,
+			Value:         res.Value,
+			Weight:        weight,
+			TaskCount:     len(res.Tasks),
+			Tasks:         res.Tasks,
+		}
+		if res.Value != nil {
+			item.Score, item.Band = bandFor(ind.Bands, *res.Value)
+			item.WeightedScore = round2(item.Score * weight)
+			weighted += item.Score * weight
+			totalWeight += weight
+		}
+		items = append(items, item)
+	}
+
+	if totalWeight == 0 {
+		return items, 0
+	}
+	return items, round2(weighted / totalWeight)
+}
+
+func bandFor(bands []model.KPIBand, v float64) (float64, string) {
+	for _, b := range bands {
+		if b.Min != nil && v < *b.Min {
+			continue
+		}
+		if b.Max != nil && v >= *b.Max {
+			continue
+		}
+		return b.Score, b.Label
+	}
+	return 0, ""
+}
+
+func (s *KPIService) GetScorecards(ctx context.Context, label string, userID *int64) ([]model.KPIScorecard, error) {
+	periodType, _, _, err := ParsePeriod(label)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.GetScorecards(ctx, periodType, label, userID)
+}
+
+func (s *KPIService) GetScorecard(ctx context.Context, id int64) (*model.KPIScorecard, error) {
+	sc, err := s.repo.GetScorecard(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sc == nil {
+		return nil, ErrScorecardNotFound
+	}
+	return sc, nil
+}
+
+func (s *KPIService) GetIndicatorTasks(ctx context.Context, scorecardID int64, code string) ([]model.KPIItemTask, error) {
+	if _, err := s.GetScorecard(ctx, scorecardID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetScorecardItemTasks(ctx, scorecardID, code)
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+)
+
+func bound(v float64) *float64 { return &v }
+
+// kpiStore holds two indicators and, for September 2026, three finished
+// tasks of Ana (two on time, one late). Budi has no tasks.
+func kpiStore() *memstore.Store {
+	st := memstore.New()
+	st.AddMember(model.User{ClickUpID: 1, Name: "Ana", Role: "backend", Status: memstore.ActiveStatus})
+	st.AddMember(model.User{ClickUpID: 2, Name: "Budi", Role: "web", Status: memstore.ActiveStatus})
+	st.AddMember(model.User{ClickUpID: 3, Name: "Citra", Role: "web", Status: "nonaktif"})
+
+	st.AddIndicator(model.KPIIndicator{Code: model.KPIOnTimeRate, Name: "On-time rate", Unit: "%", DefaultWeight: 2, Active: true,
+		Bands: []model.KPIBand{
+			{Label: "good", Min: bound(80), Score: 100},
+			{Label: "fair", Min: bound(50), Max: bound(80), Score: 60},
+			{Label: "poor", Max: bound(50), Score: 20},
+		}})
+	st.AddIndicator(model.KPIIndicator{Code: model.KPIThroughput, Name: "Throughput", DefaultWeight: 1, Active: true,
+		Bands: []model.KPIBand{{Label: "any", Score: 50}}})
+	st.AddIndicator(model.KPIIndicator{Code: model.KPIReworkRate, Name: "Rework", DefaultWeight: 5, Active: false,
+		Bands: []model.KPIBand{{Label: "any", Score: 0}}})
+
+	for _, t := range []memstore.Task{
+		{ID: "a1", Name: "Alpha", DueDate: day(9, 12, 17), DateDone: day(9, 10, 12)},
+		{ID: "a2", Name: "Beta", DueDate: day(9, 15, 17), DateDone: day(9, 20, 12)},
+		{ID: "a3", Name: "Gamma", DueDate: day(9, 25, 17), DateDone: day(9, 22, 12)},
+	} {
+		t.Status = statusDone
+		t.Assignees = []int64{1}
+		st.AddTask(t)
+	}
+	return st
+}
+
+func TestComputePeriodStoresScorecards(t *testing.T) {
+	ctx := context.Background()
+	svc := NewKPIService(kpiStore())
+
+	if err := svc.SetRoleWeights(ctx, "Backend", map[string]float64{model.KPIOnTimeRate: 1, model.KPIThroughput: 1}); err != nil {
+		t.Fatal(err)
+	}
+	computed, err := svc.ComputePeriod(ctx, "2026-09")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(computed) != 2 {
+		t.Fatalf("computed %d scorecards, want one per active member", len(computed))
+	}
+
+	stored, err := svc.GetScorecards(ctx, "2026-09", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 2 || stored[0].Name != "Ana" || stored[1].Name != "Budi" {
+		t.Fatalf("stored scorecards = %+v", stored)
+	}
+
+	// Ana: on-time 2/3 = 66.67% -> fair (60), throughput 50; bobot role 1:1.
+	ana := stored[0]
+	if ana.TotalScore != 55 {
+		t.Errorf("Ana total = %v, want 55", ana.TotalScore)
+	}
+	if len(ana.Items) != 2 {
+		t.Fatalf("Ana items = %+v, inactive indicators must be skipped", ana.Items)
+	}
+	var onTime model.KPIScorecardItem
+	for _, it := range ana.Items {
+		if it.IndicatorCode == model.KPIOnTimeRate {
+			onTime = it
+		}
+	}
+	if onTime.Value == nil || *onTime.Value != 66.67 || onTime.Band != "fair" || onTime.IndicatorName != "On-time rate" {
+		t.Errorf("on-time item = %+v", onTime)
+	}
+
+	// Budi tanpa bobot role: on-time tanpa nilai tidak ikut dirata-rata.
+	if budi := stored[1]; budi.TotalScore != 50 {
+		t.Errorf("Budi total = %v, want 50", budi.TotalScore)
+	}
+
+	tasks, err := svc.GetIndicatorTasks(ctx, ana.ID, model.KPIOnTimeRate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 3 || !tasks[0].Counted || tasks[2].Counted || tasks[2].TaskID != "a2" {
+		t.Errorf("drill-down = %+v, want counted tasks first and the late one last", tasks)
+	}
+
+	again, err := svc.ComputePeriod(ctx, "2026-09")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again[0].ID != ana.ID {
+		t.Errorf("recomputing created scorecard %d instead of replacing %d", again[0].ID, ana.ID)
+	}
+}
+
+func TestKPIServiceErrors(t *testing.T) {
+	ctx := context.Background()
+	svc := NewKPIService(kpiStore())
+	bands := []model.KPIBand{{Label: "any", Score: 1}}
+
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"unknown role", svc.SetRoleWeights(ctx, "qa", map[string]float64{model.KPIThroughput: 1}), ErrRoleNotFound},
+		{"unknown indicator weight", svc.SetRoleWeights(ctx, "web", map[string]float64{"nope": 1}), ErrInvalidKPIConfig},
+		{"negative weight", svc.SetRoleWeights(ctx, "web", map[string]float64{model.KPIThroughput: -1}), ErrInvalidKPIConfig},
+		{"unknown indicator", func() error {
+			_, err := svc.UpdateIndicator(ctx, "nope", model.KPIIndicatorRequest{Bands: bands})
+			return err
+		}(), ErrIndicatorNotFound},
+		{"invalid period", func() error { _, err := svc.ComputePeriod(ctx, "2026-13"); return err }(), ErrInvalidPeriod},
+		{"missing scorecard", func() error { _, err := svc.GetScorecard(ctx, 99); return err }(), ErrScorecardNotFound},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+}
+
+func TestUpdateIndicatorKeepsOmittedFields(t *testing.T) {
+	ctx := context.Background()
+	st := kpiStore()
+	svc := NewKPIService(st)
+
+	weight := 3.0
+	ind, err := svc.UpdateIndicator(ctx, model.KPIOnTimeRate, model.KPIIndicatorRequest{DefaultWeight: &weight})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ind.Active || ind.Name != "On-time rate" || ind.Unit != "%" || len(ind.Bands) == 0 || ind.DefaultWeight != 3 {
+		t.Errorf("updated indicator = %+v, want only default_weight changed", ind)
+	}
+
+	inactive := false
+	if _, err := svc.UpdateIndicator(ctx, model.KPIOnTimeRate, model.KPIIndicatorRequest{Active: &inactive}); err != nil {
+		t.Fatal(err)
+	}
+	stored, _ := st.GetKPIIndicators(ctx)
+	for _, s := range stored {
+		if s.Code == model.KPIOnTimeRate && (s.Active || s.DefaultWeight != 3) {
+			t.Errorf("stored indicator = %+v, want inactive with weight 3", s)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/i18n"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/pdf"
+)
+
+// memberReportTopProjects adalah jumlah proyek di bagian "Top projects".
+const memberReportTopProjects = 5
+
+// BuildMemberReport mengumpulkan data laporan satu member untuk periode KPI
+// (mis. "2026-09" atau "2026-Q3"). Scorecard yang sudah disimpan dipakai
+// lebih dulu; kalau belum ada, dihitung tanpa disimpan.
+func (s *ReportService) BuildMemberReport(ctx context.Context, userID int64, label string) (*model.MemberReport, error) {
+	periodType, start, end, err := ParsePeriod(label)
+	if err != nil {
+		return nil, err
+	}
+	member, err := s.repo.GetMember(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil {
+		return nil, ErrMemberNotFound
+	}
+
+	rep := &model.MemberReport{
+		Member:      *member,
+		PeriodType:  periodType,
+		PeriodLabel: label,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		GeneratedAt: time.Now(),
+	}
+
+	stored, err := s.repo.GetScorecards(ctx, periodType, label, &userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(stored) > 0 {
+		rep.Scorecard = &stored[0]
+	} else {
+		built, err := s.kpiSvc.BuildScorecards(ctx, periodType, label, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for i := range built {
+			if built[i].UserID == userID {
+				rep.Scorecard = &built[i]
+				break
+			}
+		}
+	}
+
+	tasks, err := s.repo.GetTasksByUser(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if tasks == nil {
+		tasks = []model.TaskItem{}
+	}
+	rep.Tasks = tasks
+	rep.Workload, rep.OnTime, rep.TopProjects = SummarizeMemberTasks(tasks, float64(WorkingDaysBetween(start, end))*kpiExpectedDailyHours)
+	return rep, nil
+}
+
+// SummarizeMemberTasks computes the workload, on-time rate and top projects
+// of a member's tasks. A task counts as on time when it was done on or
+// before its due date.
+func SummarizeMemberTasks(tasks []model.TaskItem, expectedHours float64) (model.MemberWorkload, model.OnTimeRate, []model.ProjectHours) {
+	w := model.MemberWorkload{TaskCount: len(tasks), ExpectedHours: expectedHours, ByStatus: []model.LabeledValue{}}
+	var onTime model.OnTimeRate
+	byStatus := map[string]float64{}
+	var statusOrder []string
+	byProject := map[string]*model.ProjectHours{}
+
+	for _, t := range tasks {
+		w.HoursLogged += t.TimeSpentHours
+		w.HoursEstimate += t.TimeEstimateHours
+
+		status := t.StatusName
+		if status == "" {
+			status = "-"
+		}
+		if _, ok := byStatus[status]; !ok {
+			statusOrder = append(statusOrder, status)
+		}
+		byStatus[status]++
+
+		project := "-"
+		if t.ProjectName != nil && *t.ProjectName != "" {
+			project = *t.ProjectName
+		}
+		p := byProject[project]
+		if p == nil {
+			p = &model.ProjectHours{Project: project}
+			byProject[project] = p
+		}
+		p.Tasks++
+		p.Hours += t.TimeSpentHours
+
+		if t.DateDone != nil {
+			onTime.Completed++
+			if t.DueDate != nil && !t.DateDone.After(*t.DueDate) {
+				onTime.OnTime++
+			}
+		}
+	}
+
+	w.HoursLogged = round2(w.HoursLogged)
+	w.HoursEstimate = round2(w.HoursEstimate)
+	if expectedHours > 0 {
+		w.Utilisation = round2(w.HoursLogged / expectedHours * 100)
+	}
+	for _, st := range statusOrder {
+		w.ByStatus = append(w.ByStatus, model.LabeledValue{Label: st, Value: byStatus[st]})
+	}
+	if onTime.Completed > 0 {
+		rate := round2(float64(onTime.OnTime) / float64(onTime.Completed) * 100)
+		onTime.Rate = &rate
+	}
+
+	projects := make([]model.ProjectHours, 0, len(byProject))
+	for _, p := range byProject {
+		p.Hours = round2(p.Hours)
+		projects = append(projects, *p)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if projects[i].Hours != projects[j].Hours {
+			return projects[i].Hours > projects[j].Hours
+		}
+		return projects[i].Project < projects[j].Project
+	})
+	if len(projects) > memberReportTopProjects {
+		projects = projects[:memberReportTopProjects]
+	}
+	return w, onTime, projects
+}
+
+var (
+	pdfAccent    = pdf.Color{R: 37, G: 99, B: 235}
+	pdfMuted     = pdf.Color{R: 226, G: 232, B: 240}
+	pdfGood      = pdf.Color{R: 22, G: 163, B: 74}
+	pdfWarn      = pdf.Color{R: 234, G: 88, B: 12}
+	pdfRowStripe = pdf.Color{R: 248, G: 250, B: 252}
+)
+
+const (
+	pdfMargin  = 40.0
+	pdfContent = pdf.PageWidth - 2*pdfMargin
+	pdfBottom  = pdf.PageHeight - 50
+)
+
+// memberReportPage menyimpan posisi tulis dan menambah halaman bila perlu.
+type memberReportPage struct {
+	doc    *pdf.Document
+	y      float64
+	footer string
+	lang   string
+}
+
+func (p *memberReportPage) newPage() {
+	p.doc.AddPage()
+	p.doc.SetFont(false, 8)
+	p.doc.Text(pdfMargin, pdf.PageHeight-25, p.footer, pdf.Gray)
+	page := i18n.T(p.lang, "report.page", p.doc.PageCount())
+	p.doc.Text(pdf.PageWidth-pdfMargin-p.doc.TextWidth(page), pdf.PageHeight-25, page, pdf.Gray)
+	p.y = pdfMargin
+}
+
+// ensure pindah halaman kalau sisa ruang kurang dari h. Return true bila
+// halaman baru dibuat.
+func (p *memberReportPage) ensure(h float64) bool {
+	if p.y+h > pdfBottom {
+		p.newPage()
+		return true
+	}
+	return false
+}
+
+func (p *memberReportPage) heading(title string) {
+	p.ensure(60)
+	p.y += 14
+	p.doc.SetFont(true, 12)
+	p.doc.Text(pdfMargin, p.y, title, pdfAccent)
+	p.y += 5
+	p.doc.Line(pdfMargin, p.y, pdfMargin+pdfContent, p.y, 0.5, pdfMuted)
+	p.y += 14
+}
+
+func (p *memberReportPage) text(s string, bold bool, size float64) {
+	p.doc.SetFont(bold, size)
+	for _, line := range pdf.Wrap(s, bold, size, pdfContent) {
+		p.ensure(size + 4)
+		p.doc.Text(pdfMargin, p.y, line, pdf.Black)
+		p.y += size + 4
+	}
+}
+
+// bars menggambar bar chart horizontal dengan label di kiri dan nilai di kanan.
+func (p *memberReportPage) bars(items []model.LabeledValue, unit string, max float64, color pdf.Color) {
+	const labelW, valueW, barH = 150.0, 60.0, 10.0
+	for _, v := range items {
+		if max < v.Value {
+			max = v.Value
+		}
+	}
+	barW := pdfContent - labelW - valueW
+	for _, v := range items {
+		p.ensure(barH + 6)
+		p.doc.SetFont(false, 9)
+		p.doc.Text(pdfMargin, p.y+barH-2, pdf.Truncate(v.Label, false, 9, labelW-8), pdf.Black)
+		p.doc.Rect(pdfMargin+labelW, p.y, barW, barH, pdfMuted)
+		if max > 0 && v.Value > 0 {
+			p.doc.Rect(pdfMargin+labelW, p.y, barW*v.Value/max, barH, color)
+		}
+		p.doc.Text(pdfMargin+labelW+barW+6, p.y+barH-2, formatReportFloat(v.Value)+unit, pdf.Black)
+		p.y += barH + 6
+	}
+}
+
+// table menggambar tabel dengan header yang diulang di tiap halaman baru.
+func (p *memberReportPage) table(columns []string, widths []float64, rows [][]string) {
+	const rowH = 14.0
+	header := func() {
+		p.doc.Rect(pdfMargin, p.y, pdfContent, rowH, pdfMuted)
+		p.doc.SetFont(true, 8)
+		x := pdfMargin
+		for i, c := range columns {
+			p.doc.Text(x+3, p.y+rowH-4, pdf.Truncate(c, true, 8, widths[i]-6), pdf.Black)
+			x += widths[i]
+		}
+		p.y += rowH
+	}
+	p.ensure(rowH * 2)
+	header()
+	for n, row := range rows {
+		if p.ensure(rowH) {
+			header()
+		}
+		if n%2 == 1 {
+			p.doc.Rect(pdfMargin, p.y, pdfContent, rowH, pdfRowStripe)
+		}
+		p.doc.SetFont(false, 8)
+		x := pdfMargin
+		for i, cell := range row {
+			p.doc.Text(x+3, p.y+rowH-4, pdf.Truncate(cell, false, 8, widths[i]-6), pdf.Black)
+			x += widths[i]
+		}
+		p.y += rowH
+	}
+}
+
+// RenderMemberReportPDF renders the member report as an A4 PDF with labels
+// in lang (i18n.EN or i18n.ID).
+func RenderMemberReportPDF(r model.MemberReport, lang string) ([]byte, error) {
+	t := func(key string, args ...interface{}) string { return i18n.T(lang, key, args...) }
+	doc := pdf.New(t("report.performance_title", r.Member.Name, r.PeriodLabel))
+	p := &memberReportPage{
+		doc:    doc,
+		footer: t("report.footer", r.Member.Name, r.PeriodLabel, r.GeneratedAt.Format("2006-01-02 15:04")),
+		lang:   lang,
+	}
+	p.newPage()
+
+	// Profil
+	doc.SetFont(false, 9)
+	doc.Text(pdfMargin, p.y+8, strings.ToUpper(t("report.performance")), pdf.Gray)
+	p.y += 28
+	doc.SetFont(true, 20)
+	doc.Text(pdfMargin, p.y, r.Member.Name, pdf.Black)
+	p.y += 18
+	profile := r.Member.Role
+	if profile == "" {
+		profile = t("report.no_role")
+	}
+	if r.Member.Email != "" {
+		profile += " · " + r.Member.Email
+	}
+	if r.Member.Status != "" {
+		profile += " · " + r.Member.Status
+	}
+	p.text(profile, false, 10)
+	p.text(t("report.period", r.PeriodLabel, r.PeriodStart.Format("02 Jan 2006"), r.PeriodEnd.Format("02 Jan 2006")), false, 10)
+
+	// KPI
+	p.heading(t("report.kpi_scorecard"))
+	if r.Scorecard == nil {
+		p.text(t("report.no_scorecard"), false, 10)
+	} else {
+		p.text(t("report.total_score", formatReportFloat(r.Scorecard.TotalScore)), true, 14)
+		p.y += 4
+		rows := make([][]string, 0, len(r.Scorecard.Items))
+		for _, it := range r.Scorecard.Items {
+			value := "-"
+			if it.Value != nil {
+				value = formatReportFloat(*it.Value) + " " + it.Unit
+			}
+			name := it.IndicatorName
+			if name == "" {
+				name = it.IndicatorCode
+			}
+			rows = append(rows, []string{name, value, formatReportFloat(it.Score), it.Band,
+				formatReportFloat(it.Weight), formatReportFloat(it.WeightedScore)})
+		}
+		p.table(reportColumns(lang, "indicator", "value", "score", "band", "weight", "weighted"),
+			[]float64{175, 90, 55, 85, 55, pdfContent - 460}, rows)
+	}
+
+	// Workload
+	w := r.Workload
+	p.heading(t("report.workload"))
+	p.text(t("report.workload_line",
+		w.TaskCount, formatReportFloat(w.HoursLogged), formatReportFloat(w.HoursEstimate),
+		formatReportFloat(w.ExpectedHours), formatReportFloat(w.Utilisation)), false, 10)
+	p.y += 6
+	logged := pdfAccent
+	if w.ExpectedHours > 0 && w.HoursLogged > w.ExpectedHours {
+		logged = pdfWarn
+	}
+	p.bars([]model.LabeledValue{{Label: t("report.hours_logged"), Value: w.HoursLogged}}, " h", w.ExpectedHours, logged)
+	p.bars([]model.LabeledValue{
+		{Label: t("report.hours_estimated"), Value: w.HoursEstimate},
+		{Label: t("report.hours_expected"), Value: w.ExpectedHours},
+	}, " h", w.HoursLogged, pdf.Gray)
+	if len(w.ByStatus) > 0 {
+		p.y += 6
+		p.text(t("report.tasks_by_status"), true, 10)
+		p.bars(w.ByStatus, "", 0, pdfAccent)
+	}
+
+	// On-time
+	p.heading(t("report.on_time"))
+	if r.OnTime.Rate == nil {
+		p.text(t("report.no_completed"), false, 10)
+	} else {
+		p.text(t("report.on_time_line", r.OnTime.OnTime, r.OnTime.Completed), false, 10)
+		color := pdfGood
+		if *r.OnTime.Rate < 80 {
+			color = pdfWarn
+		}
+		p.bars([]model.LabeledValue{{Label: t("report.on_time_rate"), Value: *r.OnTime.Rate}}, "%", 100, color)
+	}
+
+	// Proyek
+	p.heading(t("report.top_projects"))
+	if len(r.TopProjects) == 0 {
+		p.text(t("report.no_projects"), false, 10)
+	} else {
+		items := make([]model.LabeledValue, len(r.TopProjects))
+		for i, pr := range r.TopProjects {
+			items[i] = model.LabeledValue{Label: fmt.Sprintf("%s (%d)", pr.Project, pr.Tasks), Value: pr.Hours}
+		}
+		p.bars(items, " h", 0, pdfAccent)
+	}
+
+	// Task
+	p.heading(t("report.task_list", len(r.Tasks)))
+	if len(r.Tasks) == 0 {
+		p.text(t("report.no_tasks"), false, 10)
+	} else {
+		date := func(t *time.Time) string {
+			if t == nil {
+				return "-"
+			}
+			return t.Format("02-01-2006")
+		}
+		rows := make([][]string, 0, len(r.Tasks))
+		for _, t := range r.Tasks {
+			project := "-"
+			if t.ProjectName != nil {
+				project = *t.ProjectName
+			}
+			rows = append(rows, []string{t.Name, project, t.StatusName, date(t.DueDate), date(t.DateDone),
+				formatReportFloat(t.TimeEstimateHours), formatReportFloat(t.TimeSpentHours)})
+		}
+		p.table(reportColumns(lang, "task", "project", "status", "due", "done", "est_h", "spent_h"),
+			[]float64{170, 95, 70, 55, 55, 35, pdfContent - 480}, rows)
+	}
+
+	var b bytes.Buffer
+	if _, err := doc.WriteTo(&b); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
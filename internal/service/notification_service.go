@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/notify"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+var (
+	ErrSubscriptionNotFound = apperr.New(apperr.CodeNotFound, "notification subscription not found")
+	ErrDeliveryNotFound     = apperr.New(apperr.CodeNotFound, "notification delivery not found")
+	ErrInvalidSubscription  = apperr.New(apperr.CodeValidationFailed, "invalid notification subscription")
+)
+
+// deliveryBackoff adalah jeda sebelum percobaan ulang ke-n; setelah habis
+// pengiriman ditandai failed.
+var deliveryBackoff = []time.Duration{time.Minute, 5 * time.Minute, 30 * time.Minute, 2 * time.Hour}
+
+const (
+	deliveryBatch   = 50
+	deliveryLease   = 2 * time.Minute
+	deliveryTimeout = 30 * time.Second
+)
+
+type NotificationService struct {
+	repo     repository.NotificationStore
+	registry *notify.Registry
+}
+
+func NewNotificationService(repo repository.NotificationStore, registry *notify.Registry) *NotificationService {
+	return &NotificationService{repo: repo, registry: registry}
+}
+
+func (s *NotificationService) subscriptionFromRequest(req model.NotificationSubscriptionRequest) (*model.NotificationSubscription, error) {
+	sub := &model.NotificationSubscription{
+		SubscriberType: strings.ToLower(strings.TrimSpace(req.SubscriberType)),
+		Channel:        strings.ToLower(strings.TrimSpace(req.Channel)),
+		Target:         strings.TrimSpace(req.Target),
+		Events:         []string{},
+		QuietStart:     strings.TrimSpace(req.QuietStart),
+		QuietEnd:       strings.TrimSpace(req.QuietEnd),
+		Active:         true,
+	}
+	if req.Active != nil {
+		sub.Active = *req.Active
+	}
+
+	switch sub.SubscriberType {
+	case model.SubscriberTypeUser:
+		if req.UserID == nil {
+			return nil, fmt.Errorf("%w: user_id is required for user subscriptions", ErrInvalidSubscription)
+		}
+		sub.UserID = req.UserID
+	case model.SubscriberTypeRole:
+		sub.Role = strings.TrimSpace(req.Role)
+		if sub.Role == "" {
+			return nil, fmt.Errorf("%w: role is required for role subscriptions", ErrInvalidSubscription)
+		}
+	default:
+		return nil, fmt.Errorf("%w: subscriber_type must be user or role", ErrInvalidSubscription)
+	}
+
+	switch sub.Channel {
+	case notify.ChannelEmail:
+		if sub.Target != "" {
+			if _, err := mail.ParseAddress(sub.Target); err != nil {
+				return nil, fmt.Errorf("%w: target is not a valid email address", ErrInvalidSubscription)
+			}
+		}
+	case notify.ChannelWebhook, notify.ChannelChat:
+		if err := notify.CheckTarget(sub.Target); err != nil {
+			return nil, fmt.Errorf("%w: target must be a public http(s) url for %s: %v", ErrInvalidSubscription, sub.Channel, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: channel must be email, webhook or chat", ErrInvalidSubscription)
+	}
+
+	seen := map[string]bool{}
+	for _, e := range req.Events {
+		e = strings.ToLower(strings.TrimSpace(e))
+		known := false
+		for _, k := range model.NotificationEvents {
+			known = known || k == e
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: unknown event %q", ErrInvalidSubscription, e)
+		}
+		if !seen[e] {
+			seen[e] = true
+			sub.Events = append(sub.Events, e)
+		}
+	}
+
+	if err := (notify.QuietHours{Start: sub.QuietStart, End: sub.QuietEnd}).Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSubscription, err)
+	}
+	return sub, nil
+}
+
+func (s *NotificationService) CreateSubscription(ctx context.Context, req model.NotificationSubscriptionRequest) (*model.NotificationSubscription, error) {
+	sub, err := s.subscriptionFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateNotificationSubscription(ctx, sub); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: user or role not found", ErrInvalidSubscription)
+		}
+		return nil, err
+	}
+	return s.GetSubscription(ctx, sub.ID)
+}
+
+func (s *NotificationService) UpdateSubscription(ctx context.Context, id int64, req model.NotificationSubscriptionRequest) (*model.NotificationSubscription, error) {
+	sub, err := s.subscriptionFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+	sub.ID = id
+	if err := s.repo.UpdateNotificationSubscription(ctx, sub); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: user or role not found", ErrInvalidSubscription)
+		}
+		return nil, err
+	}
+	return s.GetSubscription(ctx, id)
+}
+
+func (s *NotificationService) DeleteSubscription(ctx context.Context, id int64) error {
+	ok, err := s.repo.DeleteNotificationSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (s *NotificationService) GetSubscriptions(ctx context.Context) ([]model.NotificationSubscription, error) {
+	return s.repo.GetNotificationSubscriptions(ctx)
+}
+
+func (s *NotificationService) GetSubscription(ctx context.Context, id int64) (*model.NotificationSubscription, error) {
+	sub, err := s.repo.GetNotificationSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub == nil {
+		return nil, ErrSubscriptionNotFound
+	}
+	return sub, nil
+}
+
+// Notify mengirim pesan ke semua pelanggan event-nya. Aman dipanggil pada
+// service nil.
+func (s *NotificationService) Notify(ctx context.Context, msg notify.Message) error {
+	_, err := s.NotifyAudience(ctx, msg, model.NotificationAudience{})
+	return err
+}
+
+// NotifyAudience mencatat satu pengiriman per penerima lalu langsung mencoba
+// mengirimnya, dan mengembalikan jumlah pengiriman yang diantrekan. Penerima
+// yang sedang quiet hours dijadwalkan setelah jendelanya selesai.
+func (s *NotificationService) NotifyAudience(ctx context.Context, msg notify.Message, aud model.NotificationAudience) (int, error) {
+	if s == nil {
+		return 0, nil
+	}
+	recipients, err := s.repo.GetNotificationRecipients(ctx, msg.Event, aud)
+	if err != nil {
+		return 0, err
+	}
+	if len(recipients) == 0 {
+		return 0, nil
+	}
+	now := time.Now()
+	if msg.SentAt.IsZero() {
+		msg.SentAt = now
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return 0, err
+	}
+
+	seen := map[string]bool{}
+	for _, rc := range recipients {
+		key := rc.Channel + "|" + strings.ToLower(rc.Target)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		next := now
+		if until := (notify.QuietHours{Start: rc.QuietStart, End: rc.QuietEnd}).Until(now); !until.IsZero() {
+			next = until
+		}
+		subID := rc.SubscriptionID
+		d := model.NotificationDelivery{
+			SubscriptionID: &subID,
+			Channel:        rc.Channel,
+			Target:         rc.Target,
+			Event:          msg.Event,
+			Subject:        msg.Subject,
+			Payload:        payload,
+			NextAttemptAt:  &next,
+		}
+		if err := s.repo.CreateNotificationDelivery(ctx, &d); err != nil {
+			return len(seen) - 1, fmt.Errorf("failed to queue notification for %s: %w", rc.Target, err)
+		}
+	}
+
+	_, err = s.ProcessDue(ctx)
+	return len(seen), err
+}
+
+// ProcessDue mengirim pengiriman pending yang sudah jatuh tempo.
+func (s *NotificationService) ProcessDue(ctx context.Context) (int, error) {
+	sent := 0
+	for {
+		batch, err := s.repo.ClaimDueDeliveries(ctx, deliveryBatch, deliveryLease)
+		if err != nil {
+			return sent, err
+		}
+		for _, d := range batch {
+			if s.deliver(ctx, d) {
+				sent++
+			}
+		}
+		if len(batch) < deliveryBatch {
+			return sent, nil
+		}
+	}
+}
+
+func (s *NotificationService) deliver(ctx context.Context, d model.NotificationDelivery) bool {
+	var msg notify.Message
+	err := json.Unmarshal(d.Payload, &msg)
+	if err == nil {
+		sendCtx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+		err = s.registry.Send(sendCtx, d.Channel, d.Target, msg)
+		cancel()
+	}
+	if err == nil {
+		if err := s.repo.MarkDeliverySent(ctx, d.ID); err != nil {
+			log.Printf("WARNING: failed to mark delivery %d sent: %v\n", d.ID, err)
+		}
+		return true
+	}
+
+	var next *time.Time
+	if d.Attempts < len(deliveryBackoff) && !errors.Is(err, notify.ErrUnknownChannel) {
+		t := time.Now().Add(deliveryBackoff[d.Attempts])
+		next = &t
+	}
+	log.Printf("NOTIFY: delivery %d via %s to %s failed (attempt %d): %v\n", d.ID, d.Channel, d.Target, d.Attempts+1, err)
+	if err := s.repo.MarkDeliveryFailed(ctx, d.ID, err.Error(), next); err != nil {
+		log.Printf("WARNING: failed to record delivery %d failure: %v\n", d.ID, err)
+	}
+	return false
+}
+
+// Run memproses antrean pengiriman secara berkala sampai ctx selesai.
+func (s *NotificationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.ProcessDue(ctx); err != nil {
+				log.Printf("WARNING: processing notification queue failed: %v\n", err)
+			}
+		}
+	}
+}
+
+func (s *NotificationService) GetDeliveries(ctx context.Context, status string, limit int) ([]model.NotificationDelivery, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return s.repo.GetNotificationDeliveries(ctx, status, limit)
+}
+
+// RetryDelivery menjadwalkan ulang pengiriman yang belum terkirim dan
+// langsung mencobanya.
+func (s *NotificationService) RetryDelivery(ctx context.Context, id int64) (*model.NotificationDelivery, error) {
+	if err := s.repo.RequeueDelivery(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	if _, err := s.ProcessDue(ctx); err != nil {
+		return nil, err
+	}
+	d, err := s.repo.GetNotificationDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrDeliveryNotFound
+	}
+	return d, nil
+}
+
+// SendTest mengirim pesan uji langsung tanpa antrean.
+func (s *NotificationService) SendTest(ctx context.Context, channel, target string) error {
+	if !s.registry.Has(channel) {
+		return fmt.Errorf("%w: channel must be email, webhook or chat", ErrInvalidSubscription)
+	}
+	return s.registry.Send(ctx, channel, target, notify.Message{
+		Event:   "test",
+		Subject: "Kinerja test notification",
+		Text:    "This is a test notification from the Kinerja backend.",
+	})
+}
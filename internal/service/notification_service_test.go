@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/notify"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+)
+
+// recordingNotifier mencatat target yang dikirimi, atau gagal bila fail diset.
+type recordingNotifier struct {
+	channel string
+	fail    bool
+	sent    []string
+}
+
+func (n *recordingNotifier) Channel() string { return n.channel }
+
+func (n *recordingNotifier) Send(ctx context.Context, target string, msg notify.Message) error {
+	if n.fail {
+		return errors.New("unreachable")
+	}
+	n.sent = append(n.sent, target)
+	return nil
+}
+
+// Langganan email role web tanpa target dikirim ke anggota aktifnya; webhook
+// Ana gagal lalu dikirim ulang.
+func TestNotifyFanOutAndRetry(t *testing.T) {
+	ctx := context.Background()
+	st := memstore.New()
+	st.AddMember(model.User{ClickUpID: 1, Name: "Ana", Email: "ana@example.com", Role: "backend", Status: memstore.ActiveStatus})
+	st.AddMember(model.User{ClickUpID: 2, Name: "Budi", Email: "budi@example.com", Role: "web", Status: memstore.ActiveStatus})
+	st.AddMember(model.User{ClickUpID: 3, Name: "Citra", Email: "citra@example.com", Role: "web", Status: "nonaktif"})
+	email := &recordingNotifier{channel: notify.ChannelEmail}
+	hook := &recordingNotifier{channel: notify.ChannelWebhook, fail: true}
+	svc := NewNotificationService(st, notify.NewRegistry(email, hook))
+
+	if _, err := svc.CreateSubscription(ctx, model.NotificationSubscriptionRequest{SubscriberType: "role", Role: "qa", Channel: "email"}); !errors.Is(err, ErrInvalidSubscription) {
+		t.Errorf("subscription for unknown role = %v, want ErrInvalidSubscription", err)
+	}
+	web, err := svc.CreateSubscription(ctx, model.NotificationSubscriptionRequest{SubscriberType: "role", Role: "Web", Channel: "email", Events: []string{"alert", "ALERT"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if web.Role != "web" || len(web.Events) != 1 {
+		t.Errorf("role subscription = %+v, want the stored role and one event", web)
+	}
+	ana := int64(1)
+	if _, err := svc.CreateSubscription(ctx, model.NotificationSubscriptionRequest{SubscriberType: "user", UserID: &ana, Channel: "webhook", Target: "http://169.254.169.254/latest"}); !errors.Is(err, ErrInvalidSubscription) {
+		t.Errorf("webhook to a link-local address = %v, want ErrInvalidSubscription", err)
+	}
+	if _, err := svc.CreateSubscription(ctx, model.NotificationSubscriptionRequest{SubscriberType: "user", UserID: &ana, Channel: "webhook", Target: "https://hooks.example.com/ana"}); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := svc.NotifyAudience(ctx, notify.Message{Event: model.NotificationEventAlert, Subject: "Overdue"}, model.NotificationAudience{})
+	if err != nil || n != 2 {
+		t.Fatalf("NotifyAudience = %d, %v; want Budi's address and Ana's webhook", n, err)
+	}
+	if len(email.sent) != 1 || email.sent[0] != "budi@example.com" {
+		t.Errorf("emails = %v, want only the active web member", email.sent)
+	}
+	if n, _ := svc.NotifyAudience(ctx, notify.Message{Event: model.NotificationEventBudget}, model.NotificationAudience{Role: "web"}); n != 0 {
+		t.Errorf("budget notification for web = %d, want none", n)
+	}
+
+	pending, _ := svc.GetDeliveries(ctx, model.DeliveryStatusPending, 0)
+	if len(pending) != 1 || pending[0].Attempts != 1 || pending[0].LastError == "" {
+		t.Fatalf("pending deliveries = %+v, want the failed webhook awaiting retry", pending)
+	}
+	hook.fail = false
+	d, err := svc.RetryDelivery(ctx, pending[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Status != model.DeliveryStatusSent || d.Attempts != 2 || len(hook.sent) != 1 {
+		t.Errorf("retried delivery = %+v, want it sent on the second attempt", d)
+	}
+	if _, err := svc.RetryDelivery(ctx, d.ID); !errors.Is(err, ErrDeliveryNotFound) {
+		t.Errorf("retrying a sent delivery = %v, want ErrDeliveryNotFound", err)
+	}
+}
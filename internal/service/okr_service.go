@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+var (
+	ErrObjectiveNotFound = apperr.New(apperr.CodeNotFound, "objective not found")
+	ErrKeyResultNotFound = apperr.New(apperr.CodeNotFound, "key result not found")
+	ErrOKROwnerNotFound  = apperr.New(apperr.CodeNotFound, "objective owner not found")
+	ErrInvalidOKR        = apperr.New(apperr.CodeValidationFailed, "invalid okr")
+)
+
+type OKRService struct {
+	repo repository.OKRStore
+}
+
+func NewOKRService(repo repository.OKRStore) *OKRService {
+	return &OKRService{repo: repo}
+}
+
+// objectiveFromRequest memvalidasi request dan menormalkan nilai-nilainya.
+func objectiveFromRequest(req model.ObjectiveRequest) (*model.Objective, error) {
+	o := &model.Objective{
+		Title:       strings.TrimSpace(req.Title),
+		Description: strings.TrimSpace(req.Description),
+		Period:      strings.ToUpper(strings.TrimSpace(req.Period)),
+		OwnerType:   strings.ToLower(strings.TrimSpace(req.OwnerType)),
+	}
+	if o.Title == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidOKR)
+	}
+	switch o.OwnerType {
+	case model.OKROwnerMember:
+		if req.OwnerUserID == nil {
+			return nil, fmt.Errorf("%w: owner_user_id is required for member objectives", ErrInvalidOKR)
+		}
+		o.OwnerUserID = req.OwnerUserID
+	case model.OKROwnerRole:
+		o.OwnerRole = strings.TrimSpace(req.OwnerRole)
+		if o.OwnerRole == "" {
+			return nil, fmt.Errorf("%w: owner_role is required for role objectives", ErrInvalidOKR)
+		}
+	default:
+		return nil, fmt.Errorf("%w: owner_type must be member or role", ErrInvalidOKR)
+	}
+	return o, nil
+}
+
+func (s *OKRService) CreateObjective(ctx context.Context, req model.ObjectiveRequest) (*model.Objective, error) {
+	o, err := objectiveFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	_, start, end, err := ParsePeriod(o.Period)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateObjective(ctx, o, start, end); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOKROwnerNotFound
+		}
+		return nil, err
+	}
+	return s.GetObjective(ctx, o.ID)
+}
+
+func (s *OKRService) UpdateObjective(ctx context.Context, id int64, req model.ObjectiveRequest) (*model.Objective, error) {
+	if _, err := s.GetObjective(ctx, id); err != nil {
+		return nil, err
+	}
+	o, err := objectiveFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	o.ID = id
+	_, start, end, err := ParsePeriod(o.Period)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateObjective(ctx, o, start, end); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrOKROwnerNotFound
+		}
+		return nil, err
+	}
+	return s.GetObjective(ctx, id)
+}
+
+func (s *OKRService) DeleteObjective(ctx context.Context, id int64) error {
+	ok, err := s.repo.DeleteObjective(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrObjectiveNotFound
+	}
+	return nil
+}
+
+// GetObjectives mengembalikan objective beserta progress terkini.
+func (s *OKRService) GetObjectives(ctx context.Context, period string, userID *int64, role string) ([]model.Objective, error) {
+	period = strings.ToUpper(strings.TrimSpace(period))
+	if period != "" {
+		if _, _, _, err := ParsePeriod(period); err != nil {
+			return nil, err
+		}
+	}
+	objectives, err := s.repo.GetObjectives(ctx, period, userID, role)
+	if err != nil {
+		return nil, err
+	}
+	for i := range objectives {
+		if err := s.computeProgress(ctx, &objectives[i]); err != nil {
+			return nil, err
+		}
+	}
+	return objectives, nil
+}
+
+func (s *OKRService) GetObjective(ctx context.Context, id int64) (*model.Objective, error) {
+	o, err := s.repo.GetObjective(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, ErrObjectiveNotFound
+	}
+	if err := s.computeProgress(ctx, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// computeProgress mengisi progress setiap key result dari task ter-link, lalu
+// progress objective sebagai rata-rata berbobot key result.
+func (s *OKRService) computeProgress(ctx context.Context, o *model.Objective) error {
+	_, start, end, err := ParsePeriod(o.Period)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int64, len(o.KeyResults))
+	for i, kr := range o.KeyResults {
+		ids[i] = kr.ID
+	}
+	tasks, err := s.repo.GetKeyResultTasks(ctx, ids, start, end)
+	if err != nil {
+		return err
+	}
+
+	var weighted, totalWeight float64
+	for i := range o.KeyResults {
+		kr := &o.KeyResults[i]
+		ApplyKeyResultProgress(kr, tasks[kr.ID])
+		weighted += kr.Progress * kr.Weight
+		totalWeight += kr.Weight
+	}
+	o.Progress = 0
+	if totalWeight > 0 {
+		o.Progress = round2(weighted / totalWeight)
+	}
+	return nil
+}
+
+// ApplyKeyResultProgress computes the current value, target and progress (0-100)
+// of a key result from its linked tasks. Without an explicit target the
+// target is the number of linked tasks (tasks_completed) or their total
+// estimate (hours_spent).
+func ApplyKeyResultProgress(kr *model.KeyResult, tasks []model.OKRTask) {
+	kr.TaskCount = len(tasks)
+	kr.DoneCount, kr.EstimateHrs, kr.SpentHrs = 0, 0, 0
+	for i := range tasks {
+		t := &tasks[i]
+		t.Done = finishedStatusTypes[t.StatusType] || t.DateDone != nil
+		if t.Done {
+			kr.DoneCount++
+		}
+		kr.EstimateHrs += t.EstimateHours
+		kr.SpentHrs += t.SpentHours
+	}
+
+	switch kr.Metric {
+	case model.OKRMetricHoursSpent:
+		kr.CurrentValue = kr.SpentHrs
+		kr.Target = kr.EstimateHrs
+	default:
+		kr.CurrentValue = float64(kr.DoneCount)
+		kr.Target = float64(kr.TaskCount)
+	}
+	if kr.TargetValue != nil {
+		kr.Target = *kr.TargetValue
+	}
+
+	kr.Progress = 0
+	if kr.Target > 0 {
+		kr.Progress = round2(math.Min(kr.CurrentValue/kr.Target, 1) * 100)
+	}
+	kr.CurrentValue = round2(kr.CurrentValue)
+	kr.EstimateHrs = round2(kr.EstimateHrs)
+	kr.SpentHrs = round2(kr.SpentHrs)
+}
+
+func keyResultFromRequest(req model.KeyResultRequest) (*model.KeyResult, error) {
+	kr := &model.KeyResult{
+		Title:       strings.TrimSpace(req.Title),
+		Metric:      strings.ToLower(strings.TrimSpace(req.Metric)),
+		TargetValue: req.TargetValue,
+		Weight:      1,
+	}
+	if kr.Title == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidOKR)
+	}
+	if kr.Metric != model.OKRMetricTasksCompleted && kr.Metric != model.OKRMetricHoursSpent {
+		return nil, fmt.Errorf("%w: metric must be %s or %s", ErrInvalidOKR, model.OKRMetricTasksCompleted, model.OKRMetricHoursSpent)
+	}
+	if kr.TargetValue != nil && *kr.TargetValue <= 0 {
+		return nil, fmt.Errorf("%w: target_value must be positive", ErrInvalidOKR)
+	}
+	if req.Weight != nil {
+		if *req.Weight <= 0 {
+			return nil, fmt.Errorf("%w: weight must be positive", ErrInvalidOKR)
+		}
+		kr.Weight = *req.Weight
+	}
+	for _, l := range req.Links {
+		link, err := normalizeLink(l)
+		if err != nil {
+			return nil, err
+		}
+		kr.Links = append(kr.Links, link)
+	}
+	return kr, nil
+}
+
+func normalizeLink(l model.KeyResultLink) (model.KeyResultLink, error) {
+	l.LinkType = strings.ToLower(strings.TrimSpace(l.LinkType))
+	l.LinkValue = strings.TrimSpace(l.LinkValue)
+	switch l.LinkType {
+	case model.OKRLinkList, model.OKRLinkFolder, model.OKRLinkTag, model.OKRLinkTask:
+	default:
+		return l, fmt.Errorf("%w: link_type must be list, folder, tag or task", ErrInvalidOKR)
+	}
+	if l.LinkValue == "" {
+		return l, fmt.Errorf("%w: link_value is required", ErrInvalidOKR)
+	}
+	return l, nil
+}
+
+func (s *OKRService) CreateKeyResult(ctx context.Context, objectiveID int64, req model.KeyResultRequest) (*model.KeyResult, error) {
+	if _, err := s.GetObjective(ctx, objectiveID); err != nil {
+		return nil, err
+	}
+	kr, err := keyResultFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	kr.ObjectiveID = objectiveID
+	if err := s.repo.CreateKeyResult(ctx, kr); err != nil {
+		return nil, err
+	}
+	return s.GetKeyResult(ctx, kr.ID)
+}
+
+// UpdateKeyResult mengganti isi key result termasuk seluruh link-nya.
+func (s *OKRService) UpdateKeyResult(ctx context.Context, id int64, req model.KeyResultRequest) (*model.KeyResult, error) {
+	kr, err := keyResultFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	kr.ID = id
+	if err := s.repo.UpdateKeyResult(ctx, kr); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrKeyResultNotFound
+		}
+		return nil, err
+	}
+	return s.GetKeyResult(ctx, id)
+}
+
+func (s *OKRService) DeleteKeyResult(ctx context.Context, id int64) error {
+	ok, err := s.repo.DeleteKeyResult(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrKeyResultNotFound
+	}
+	return nil
+}
+
+func (s *OKRService) GetKeyResult(ctx context.Context, id int64) (*model.KeyResult, error) {
+	kr, _, err := s.keyResultWithTasks(ctx, id)
+	return kr, err
+}
+
+// GetKeyResultTasks menampilkan task yang dihitung untuk sebuah key result.
+func (s *OKRService) GetKeyResultTasks(ctx context.Context, id int64) ([]model.OKRTask, error) {
+	_, tasks, err := s.keyResultWithTasks(ctx, id)
+	return tasks, err
+}
+
+func (s *OKRService) keyResultWithTasks(ctx context.Context, id int64) (*model.KeyResult, []model.OKRTask, error) {
+	kr, err := s.repo.GetKeyResult(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if kr == nil {
+		return nil, nil, ErrKeyResultNotFound
+	}
+	start, end, err := s.repo.GetObjectiveRange(ctx, kr.ObjectiveID)
+	if err != nil {
+		return nil, nil, err
+	}
+	tasks, err := s.repo.GetKeyResultTasks(ctx, []int64{id}, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	ApplyKeyResultProgress(kr, tasks[id])
+	return kr, tasks[id], nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+)
+
+// okrStore: folder f1 berisi list l1, list l2 tanpa folder. x4 dan x5 di luar Q3.
+func okrStore() *memstore.Store {
+	st := memstore.New()
+	st.AddMember(model.User{ClickUpID: 1, Name: "Ana", Role: "backend", Status: memstore.ActiveStatus})
+	st.AddMember(model.User{ClickUpID: 2, Name: "Budi", Role: "web", Status: memstore.ActiveStatus})
+	st.AddFolder(model.Folder{ID: "f1", Name: "Kinerja"})
+	st.AddList(model.List{ID: "l1", Name: "Sprint 1", FolderID: "f1"})
+	st.AddList(model.List{ID: "l2", Name: "Lepas"})
+
+	st.AddTask(memstore.Task{ID: "x1", Name: "Login", Status: statusDone, ListID: "l1",
+		StartDate: day(7, 1, 9), DueDate: day(7, 10, 17), DateDone: day(7, 9, 12), EstimateHours: 4, SpentHours: 3})
+	st.AddTask(memstore.Task{ID: "x2", Name: "Profil", Status: statusOpen, ListID: "l1",
+		StartDate: day(8, 1, 9), DueDate: day(8, 20, 17), EstimateHours: 6})
+	st.AddTask(memstore.Task{ID: "x3", Name: "Ekspor", Status: statusDone, ListID: "l2", Tags: []string{"okr"},
+		StartDate: day(9, 1, 9), DueDate: day(9, 5, 17), DateDone: day(9, 4, 12)})
+	st.AddTask(memstore.Task{ID: "x4", Name: "Lama", Status: statusDone, ListID: "l1",
+		StartDate: day(2, 1, 9), DueDate: day(2, 5, 17), DateDone: day(2, 5, 12)})
+	st.AddTask(memstore.Task{ID: "x5", Name: "Riset", Status: statusOpen, ListID: "l2",
+		StartDate: day(11, 1, 9), DueDate: day(11, 5, 17)})
+	return st
+}
+
+func TestObjectiveProgressFromLinks(t *testing.T) {
+	ctx := context.Background()
+	svc := NewOKRService(okrStore())
+
+	if _, err := svc.CreateObjective(ctx, model.ObjectiveRequest{Title: "X", Period: "2026-Q3", OwnerType: "role", OwnerRole: "qa"}); !errors.Is(err, ErrOKROwnerNotFound) {
+		t.Errorf("objective for unknown role = %v, want ErrOKROwnerNotFound", err)
+	}
+
+	o, err := svc.CreateObjective(ctx, model.ObjectiveRequest{Title: "Rilis web", Period: "2026-q3", OwnerType: "Role", OwnerRole: "Web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.OwnerRole != "web" || o.Period != "2026-Q3" {
+		t.Errorf("objective = %+v, want the stored role name and upper-case period", o)
+	}
+
+	weight, one := 3.0, 1.0
+	folder, err := svc.CreateKeyResult(ctx, o.ID, model.KeyResultRequest{Title: "Fitur", Metric: model.OKRMetricTasksCompleted,
+		Links: []model.KeyResultLink{{LinkType: "folder", LinkValue: "f1"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if folder.TaskCount != 2 || folder.DoneCount != 1 || folder.Progress != 50 {
+		t.Errorf("folder key result = %d done of %d (%v%%), want 1 of 2 inside the period", folder.DoneCount, folder.TaskCount, folder.Progress)
+	}
+
+	tagged, err := svc.CreateKeyResult(ctx, o.ID, model.KeyResultRequest{Title: "Ekspor", Metric: model.OKRMetricTasksCompleted,
+		TargetValue: &one, Weight: &weight,
+		Links: []model.KeyResultLink{{LinkType: "tag", LinkValue: "okr"}, {LinkType: "TASK", LinkValue: " x5 "}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks, _ := svc.GetKeyResultTasks(ctx, tagged.ID)
+	if len(tasks) != 2 || tasks[0].TaskID != "x3" || tasks[1].TaskID != "x5" || !tasks[0].Done || tasks[1].Done {
+		t.Fatalf("tag/task key result tasks = %+v, want x3 (done) then x5 linked directly", tasks)
+	}
+
+	if o, err = svc.GetObjective(ctx, o.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(o.KeyResults) != 2 || o.Progress != 87.5 {
+		t.Errorf("objective progress = %v over %d key results, want (50*1 + 100*3) / 4", o.Progress, len(o.KeyResults))
+	}
+
+	budi, ana := int64(2), int64(1)
+	if list, _ := svc.GetObjectives(ctx, "2026-Q3", &budi, ""); len(list) != 1 {
+		t.Errorf("objectives of Budi = %d, want the web objective", len(list))
+	}
+	if list, _ := svc.GetObjectives(ctx, "", &ana, ""); len(list) != 0 {
+		t.Errorf("objectives of Ana = %d, want none", len(list))
+	}
+
+	if err := svc.DeleteObjective(ctx, o.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.GetKeyResult(ctx, folder.ID); !errors.Is(err, ErrKeyResultNotFound) {
+		t.Errorf("key result after deleting its objective = %v, want ErrKeyResultNotFound", err)
+	}
+}
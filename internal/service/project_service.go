@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+var (
+	ErrInvalidProjectLevel = apperr.New(apperr.CodeValidationFailed, "invalid project level, use space, folder or list")
+	ErrProjectNotFound     = apperr.New(apperr.CodeNotFound, "project not found")
+)
+
+// Status kesehatan project.
+const (
+	ProjectHealthy  = "healthy"
+	ProjectAtRisk   = "at_risk"
+	ProjectCritical = "critical"
+	ProjectNoData   = "no_data"
+)
+
+const (
+	defaultTrendWeeks = 12
+	maxTrendWeeks     = 52
+)
+
+type ProjectService struct {
+	repo repository.HierarchyStore
+}
+
+func NewProjectService(repo repository.HierarchyStore) *ProjectService {
+	return &ProjectService{repo: repo}
+}
+
+func validProjectLevel(level string) bool {
+	return level == model.ProjectLevelSpace || level == model.ProjectLevelFolder || level == model.ProjectLevelList
+}
+
+// GetProjects mengembalikan ringkasan semua space, folder atau list.
+func (s *ProjectService) GetProjects(ctx context.Context, level string, includeArchived bool) ([]model.ProjectSummary, error) {
+	if !validProjectLevel(level) {
+		return nil, ErrInvalidProjectLevel
+	}
+	nodes, err := s.repo.GetProjectNodes(ctx, level)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.repo.GetProjectTasks(ctx, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	byNode := make(map[string][]model.ProjectTask)
+	for _, t := range tasks {
+		key := projectKey(level, t)
+		if key != "" {
+			byNode[key] = append(byNode[key], t)
+		}
+	}
+
+	now := time.Now().In(dates.Default())
+	out := []model.ProjectSummary{}
+	for _, n := range nodes {
+		if n.Archived && !includeArchived {
+			continue
+		}
+		out = append(out, SummarizeProject(level, n, byNode[n.ID], now))
+	}
+	return out, nil
+}
+
+// GetProject mengembalikan health view satu space, folder atau list.
+func (s *ProjectService) GetProject(ctx context.Context, level, id string, weeks int) (*model.ProjectDetail, error) {
+	if !validProjectLevel(level) {
+		return nil, ErrInvalidProjectLevel
+	}
+	node, err := s.repo.GetProjectNode(ctx, level, id)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, ErrProjectNotFound
+	}
+	tasks, err := s.repo.GetProjectTasks(ctx, level, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if weeks <= 0 {
+		weeks = defaultTrendWeeks
+	}
+	if weeks > maxTrendWeeks {
+		weeks = maxTrendWeeks
+	}
+
+	now := time.Now().In(dates.Default())
+	detail := &model.ProjectDetail{
+		ProjectSummary: SummarizeProject(level, *node, tasks, now),
+		Contributors:   ProjectContributors(tasks),
+		OverdueTasks:   []model.ProjectTask{},
+		Trend:          ProjectTrend(tasks, weeks, now),
+	}
+	for _, t := range tasks {
+		if isProjectTaskOverdue(t, now) {
+			detail.OverdueTasks = append(detail.OverdueTasks, t)
+		}
+	}
+	return detail, nil
+}
+
+func projectKey(level string, t model.ProjectTask) string {
+	switch level {
+	case model.ProjectLevelSpace:
+		return t.SpaceID
+	case model.ProjectLevelFolder:
+		return t.FolderID
+	default:
+		return t.ListID
+	}
+}
+
+// projectTaskStatus returns the normalized status of a task; a done/closed
+// status type always counts as done.
+func projectTaskStatus(t model.ProjectTask) string {
+	if finishedStatusTypes[t.StatusType] {
+		return "done"
+	}
+	if t.StatusName == "" {
+		return "unknown"
+	}
+	return NormalizeStatus(t.StatusName)
+}
+
+func isProjectTaskOverdue(t model.ProjectTask, now time.Time) bool {
+	status := projectTaskStatus(t)
+	return status != "done" && status != "canceled" && t.DateDone == nil && t.DueDate != nil && t.DueDate.Before(now)
+}
+
+// SummarizeProject menghitung status, jam, overdue dan health sebuah node.
+func SummarizeProject(level string, node model.ProjectNode, tasks []model.ProjectTask, now time.Time) model.ProjectSummary {
+	sum := model.ProjectSummary{
+		Level:        level,
+		ID:           node.ID,
+		Name:         node.Name,
+		Archived:     node.Archived,
+		TaskCount:    len(tasks),
+		StatusCounts: map[string]int{},
+	}
+
+	var done, canceled int
+	for _, t := range tasks {
+		status := projectTaskStatus(t)
+		sum.StatusCounts[status]++
+		switch status {
+		case "done":
+			done++
+		case "canceled":
+			canceled++
+		}
+		sum.EstimateHours += t.EstimateHours
+		sum.SpentHours += t.SpentHours
+		if isProjectTaskOverdue(t, now) {
+			sum.OverdueCount++
+		}
+	}
+
+	active := len(tasks) - canceled
+	if active > 0 {
+		sum.CompletionRate = round2(float64(done) / float64(active) * 100)
+	}
+	sum.Health = projectHealth(active-done, sum.OverdueCount, sum.EstimateHours, sum.SpentHours)
+	sum.EstimateHours = round2(sum.EstimateHours)
+	sum.SpentHours = round2(sum.SpentHours)
+	return sum
+}
+
+// projectHealth: critical bila >=25% task terbuka overdue atau jam terpakai
+// >130% estimasi, at_risk bila >=10% overdue atau >110% estimasi.
+func projectHealth(open, overdue int, estimate, spent float64) string {
+	if open == 0 && estimate == 0 && spent == 0 {
+		return ProjectNoData
+	}
+	var overdueRatio, burn float64
+	if open > 0 {
+		overdueRatio = float64(overdue) / float64(open)
+	}
+	if estimate > 0 {
+		burn = spent / estimate
+	}
+	switch {
+	case overdueRatio >= 0.25 || burn > 1.3:
+		return ProjectCritical
+	case overdueRatio >= 0.1 || burn > 1.1:
+		return ProjectAtRisk
+	default:
+		return ProjectHealthy
+	}
+}
+
+// ProjectContributors returns every assignee with their share of the spent
+// hours. A task's hours are split evenly between its assignees.
+func ProjectContributors(tasks []model.ProjectTask) []model.ProjectContributor {
+	byUser := map[int64]*model.ProjectContributor{}
+	var total float64
+	for _, t := range tasks {
+		if len(t.Assignees) == 0 {
+			continue
+		}
+		share := t.SpentHours / float64(len(t.Assignees))
+		for _, a := range t.Assignees {
+			c, ok := byUser[a.UserID]
+			if !ok {
+				c = &model.ProjectContributor{UserID: a.UserID, Name: a.Name}
+				byUser[a.UserID] = c
+			}
+			c.TaskCount++
+			c.SpentHours += share
+		}
+		total += t.SpentHours
+	}
+
+	out := make([]model.ProjectContributor, 0, len(byUser))
+	for _, c := range byUser {
+		if total > 0 {
+			c.Share = round2(c.SpentHours / total * 100)
+		}
+		c.SpentHours = round2(c.SpentHours)
+		out = append(out, *c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].SpentHours != out[j].SpentHours {
+			return out[i].SpentHours > out[j].SpentHours
+		}
+		return out[i].UserID < out[j].UserID
+	})
+	return out
+}
+
+// ProjectTrend builds a weekly (Monday-based) completion trend covering the
+// last weeks up to now.
+func ProjectTrend(tasks []model.ProjectTask, weeks int, now time.Time) []model.ProjectTrendPoint {
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+	offset := (int(today.Weekday()) + 6) % 7
+	first := today.AddDate(0, 0, -offset-7*(weeks-1))
+
+	out := make([]model.ProjectTrendPoint, weeks)
+	for i := range out {
+		ws := first.AddDate(0, 0, 7*i)
+		we := ws.AddDate(0, 0, 7)
+		p := model.ProjectTrendPoint{WeekStart: ws}
+		for _, t := range tasks {
+			status := projectTaskStatus(t)
+			if status == "canceled" {
+				continue
+			}
+			if t.DueDate != nil && t.DueDate.Before(we) {
+				p.Scheduled++
+			}
+			if t.DateDone == nil {
+				continue
+			}
+			if t.DateDone.Before(we) {
+				p.CumulativeCompleted++
+				if !t.DateDone.Before(ws) {
+					p.Completed++
+				}
+			}
+		}
+		out[i] = p
+	}
+	return out
+}
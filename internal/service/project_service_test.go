@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+)
+
+// projectStore: list l1 berisi satu task selesai, satu overdue, satu masih
+// berjalan dan satu dibatalkan; list l2 (arsip) punya satu task overdue lagi.
+// Tanggal relatif terhadap sekarang karena ProjectService memakai time.Now.
+func projectStore() *memstore.Store {
+	now := time.Now().In(dates.Default())
+	ago := func(days int) *time.Time {
+		t := now.AddDate(0, 0, -days)
+		return &t
+	}
+	st := memstore.New()
+	st.AddMember(model.User{ClickUpID: 1, Name: "Ana", Status: memstore.ActiveStatus})
+	st.AddMember(model.User{ClickUpID: 2, Name: "Budi", Status: memstore.ActiveStatus})
+	st.AddSpace(model.ProjectNode{ID: "sp1", Name: "Produk"})
+	st.AddFolder(model.Folder{ID: "f1", Name: "Kinerja", Space: model.SpaceInfo{ID: "sp1"}})
+	st.AddList(model.List{ID: "l1", Name: "Backlog", FolderID: "f1"})
+	st.AddList(model.List{ID: "l2", Name: "Lama", FolderID: "f1", Archived: true})
+
+	st.AddTask(memstore.Task{ID: "a1", Name: "API", Status: statusDone, ListID: "l1",
+		DueDate: ago(2), DateDone: ago(3), EstimateHours: 4, SpentHours: 5, Assignees: []int64{1, 2}})
+	st.AddTask(memstore.Task{ID: "a2", Name: "Login", Status: statusOpen, ListID: "l1",
+		DueDate: ago(5), EstimateHours: 6, SpentHours: 2, Assignees: []int64{1}})
+	st.AddTask(memstore.Task{ID: "a3", Name: "UI", Status: model.TaskStatus{ID: "s3", Name: "in progress", Type: "custom"}, ListID: "l1",
+		DueDate: ago(-10), EstimateHours: 5, SpentHours: 3, Assignees: []int64{2}})
+	// dibatalkan: tidak overdue dan tidak masuk completion rate
+	st.AddTask(memstore.Task{ID: "a4", Name: "Export", Status: model.TaskStatus{ID: "s4", Name: "cancelled", Type: "custom"}, ListID: "l1",
+		DueDate: ago(20)})
+	st.AddTask(memstore.Task{ID: "a5", Name: "Migrasi", Status: statusOpen, ListID: "l2", DueDate: ago(1), EstimateHours: 2})
+	return st
+}
+
+func TestProjectDetail(t *testing.T) {
+	ctx := context.Background()
+	svc := NewProjectService(projectStore())
+
+	d, err := svc.GetProject(ctx, model.ProjectLevelList, "l1", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"done": 1, "to do": 1, "progres": 1, "canceled": 1}
+	if len(d.StatusCounts) != len(want) {
+		t.Errorf("status counts = %v, want %v", d.StatusCounts, want)
+	}
+	for status, n := range want {
+		if d.StatusCounts[status] != n {
+			t.Errorf("status counts = %v, want %v", d.StatusCounts, want)
+			break
+		}
+	}
+	if d.TaskCount != 4 || d.EstimateHours != 15 || d.SpentHours != 10 {
+		t.Errorf("tasks %d, estimate %v, spent %v; want 4, 15, 10", d.TaskCount, d.EstimateHours, d.SpentHours)
+	}
+	// 1 dari 3 task aktif selesai; 1 dari 2 task terbuka overdue
+	if d.CompletionRate != 33.33 || d.OverdueCount != 1 || d.Health != ProjectCritical {
+		t.Errorf("completion %v, overdue %d, health %s", d.CompletionRate, d.OverdueCount, d.Health)
+	}
+	if len(d.OverdueTasks) != 1 || d.OverdueTasks[0].TaskID != "a2" {
+		t.Errorf("overdue tasks = %+v, want a2", d.OverdueTasks)
+	}
+
+	// jam a1 dibagi rata: Budi 2.5+3, Ana 2.5+2
+	if len(d.Contributors) != 2 {
+		t.Fatalf("contributors = %+v", d.Contributors)
+	}
+	if c := d.Contributors[0]; c.UserID != 2 || c.Name != "Budi" || c.SpentHours != 5.5 || c.Share != 55 || c.TaskCount != 2 {
+		t.Errorf("first contributor = %+v, want Budi with 55%%", c)
+	}
+	if c := d.Contributors[1]; c.UserID != 1 || c.SpentHours != 4.5 || c.Share != 45 {
+		t.Errorf("second contributor = %+v, want Ana with 45%%", c)
+	}
+
+	if len(d.Trend) != 2 {
+		t.Fatalf("trend = %+v, want 2 weeks", d.Trend)
+	}
+	last := d.Trend[1]
+	if last.CumulativeCompleted != 1 || d.Trend[0].Completed+last.Completed != 1 {
+		t.Errorf("trend = %+v, want a1 completed once", d.Trend)
+	}
+	// a3 jatuh tempo setelah minggu ini, a4 dibatalkan
+	if last.Scheduled != 2 {
+		t.Errorf("scheduled = %d, want a1 and a2", last.Scheduled)
+	}
+
+	folder, err := svc.GetProject(ctx, model.ProjectLevelFolder, "f1", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if folder.TaskCount != 5 || folder.OverdueCount != 2 || len(folder.Trend) != defaultTrendWeeks {
+		t.Errorf("folder = %d tasks, %d overdue, %d weeks", folder.TaskCount, folder.OverdueCount, len(folder.Trend))
+	}
+}
+
+func TestProjectList(t *testing.T) {
+	ctx := context.Background()
+	svc := NewProjectService(projectStore())
+
+	lists, err := svc.GetProjects(ctx, model.ProjectLevelList, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists) != 1 || lists[0].ID != "l1" || lists[0].TaskCount != 4 {
+		t.Errorf("lists = %+v, want only the active list", lists)
+	}
+	if all, _ := svc.GetProjects(ctx, model.ProjectLevelList, true); len(all) != 2 || all[1].Health != ProjectCritical {
+		t.Errorf("lists with archived = %+v", all)
+	}
+	spaces, err := svc.GetProjects(ctx, model.ProjectLevelSpace, false)
+	if err != nil || len(spaces) != 1 || spaces[0].TaskCount != 5 {
+		t.Errorf("spaces = %+v, %v", spaces, err)
+	}
+
+	if _, err := svc.GetProjects(ctx, "team", false); !errors.Is(err, ErrInvalidProjectLevel) {
+		t.Errorf("bad level err = %v", err)
+	}
+	if _, err := svc.GetProject(ctx, model.ProjectLevelList, "nope", 0); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("missing project err = %v", err)
+	}
+}
+
+// Minggu dimulai Senin; task yang selesai sebelum jendela tetap masuk
+// CumulativeCompleted.
+func TestProjectTrendWeeks(t *testing.T) {
+	now := time.Date(2026, 10, 21, 15, 0, 0, 0, time.UTC) // Rabu
+	tasks := []model.ProjectTask{
+		{TaskID: "old", StatusType: "done", DateDone: day(9, 30, 9), DueDate: day(9, 30, 9)},
+		{TaskID: "w1", StatusType: "done", DateDone: day(10, 12, 9), DueDate: day(10, 18, 23)},
+		{TaskID: "w2", StatusType: "done", DateDone: day(10, 19, 0), DueDate: day(10, 20, 9)},
+		{TaskID: "open", StatusName: "to do", DueDate: day(10, 25, 23)},
+		{TaskID: "x", StatusName: "canceled", DueDate: day(10, 13, 9), DateDone: day(10, 13, 9)},
+	}
+	got := ProjectTrend(tasks, 2, now)
+	want := []model.ProjectTrendPoint{
+		{WeekStart: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Completed: 1, CumulativeCompleted: 2, Scheduled: 2},
+		{WeekStart: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Completed: 1, CumulativeCompleted: 3, Scheduled: 4},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("trend = %+v", got)
+	}
+	for i := range want {
+		if !got[i].WeekStart.Equal(want[i].WeekStart) || got[i].Completed != want[i].Completed ||
+			got[i].CumulativeCompleted != want[i].CumulativeCompleted || got[i].Scheduled != want[i].Scheduled {
+			t.Errorf("week %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
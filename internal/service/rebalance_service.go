@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+var (
+	ErrSuggestionNotFound = apperr.New(apperr.CodeNotFound, "rebalance suggestion not found")
+	ErrSuggestionDecided  = apperr.New(apperr.CodeConflict, "rebalance suggestion is no longer pending")
+)
+
+// roleCompatibility lists the roles that may take over work from a role
+// besides the role itself, keyed by role name (users.role_id -> roles.name).
+// It is loaded from the role_compatibility table.
+type roleCompatibility map[string][]string
+
+func (rc roleCompatibility) compatible(from, to string) bool {
+	from = strings.ToLower(from)
+	to = strings.ToLower(to)
+	if from == "" || to == "" {
+		return false
+	}
+	if from == to {
+		return true
+	}
+	for _, r := range rc[from] {
+		if strings.ToLower(r) == to {
+			return true
+		}
+	}
+	return false
+}
+
+// RebalanceRepo is the storage RebalanceService reads workload from and keeps
+// suggestions in.
+type RebalanceRepo interface {
+	repository.RebalanceStore
+	repository.MemberStore
+	repository.TaskStore
+}
+
+type RebalanceService struct {
+	repo           RebalanceRepo
+	clickupSvc     *ClickUpService
+	weeklyCapacity float64
+	defaultHorizon int
+}
+
+// NewRebalanceService creates the service. weeklyCapacity is the number of
+// hours a member can take per week before being considered overloaded
+// (WORKLOAD_NORMAL_MAX).
+func NewRebalanceService(repo RebalanceRepo, clickupSvc *ClickUpService, weeklyCapacity float64) *RebalanceService {
+	return &RebalanceService{
+		repo:           repo,
+		clickupSvc:     clickupSvc,
+		weeklyCapacity: weeklyCapacity,
+		defaultHorizon: 14,
+	}
+}
+
+// memberLoad keeps the open work of one member while suggestions are being
+// simulated.
+type memberLoad struct {
+	userID int64
+	name   string
+	role   string
+	tasks  []model.OpenTaskAssignment
+}
+
+// capacityUntil is how many hours the member can work from now until t.
+func (s *RebalanceService) capacityUntil(now, t time.Time) float64 {
+	if !t.After(now) {
+		return 0
+	}
+	return float64(WorkingDaysBetween(now, t)) * s.weeklyCapacity / 5
+}
+
+// committedUntil sums the remaining hours of tasks due on or before t.
+// Tasks without a due date are counted as due now.
+func (m *memberLoad) committedUntil(t time.Time) float64 {
+	total := 0.0
+	for _, a := range m.tasks {
+		if a.DueDate == nil || !a.DueDate.After(t) {
+			total += a.RemainingHours()
+		}
+	}
+	return total
+}
+
+func (m *memberLoad) remove(taskID string) {
+	for i, a := range m.tasks {
+		if a.TaskID == taskID {
+			m.tasks = append(m.tasks[:i], m.tasks[i+1:]...)
+			return
+		}
+	}
+}
+
+// Suggest computes reassignment proposals for members whose open work until
+// the end of the horizon exceeds their capacity, and stores them as pending.
+func (s *RebalanceService) Suggest(ctx context.Context, horizonDays int) (*model.RebalanceResponse, error) {
+	if horizonDays <= 0 {
+		horizonDays = s.defaultHorizon
+	}
+	now := time.Now()
+	horizonEnd := now.AddDate(0, 0, horizonDays)
+
+	assignments, err := s.repo.GetOpenAssignments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.repo.GetMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	compat, err := s.repo.GetRoleCompatibility(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roles := roleCompatibility{}
+	for from, to := range compat {
+		roles[strings.ToLower(from)] = to
+	}
+
+	// Members without open work are the best targets, so seed everyone active.
+	members := map[int64]*memberLoad{}
+	var order []int64
+	for _, u := range users {
+		if u.Status != "aktif" {
+			continue
+		}
+		members[u.ClickUpID] = &memberLoad{userID: u.ClickUpID, name: u.Name, role: u.Role}
+		order = append(order, u.ClickUpID)
+	}
+	for _, a := range assignments {
+		m, ok := members[a.UserID]
+		if !ok {
+			m = &memberLoad{userID: a.UserID, name: a.Name, role: a.Role}
+			members[a.UserID] = m
+			order = append(order, a.UserID)
+		}
+		m.tasks = append(m.tasks, a)
+	}
+
+	horizonCapacity := s.capacityUntil(now, horizonEnd)
+	suggestions := []model.RebalanceSuggestion{}
+
+	for _, fromID := range order {
+		from := members[fromID]
+		excess := from.committedUntil(horizonEnd) - horizonCapacity
+		if excess <= 0 {
+			continue
+		}
+
+		// Move the tasks with the latest due dates first: they leave the most
+		// room for someone else to pick them up.
+		candidates := make([]model.OpenTaskAssignment, 0, len(from.tasks))
+		for _, a := range from.tasks {
+			if a.DueDate == nil || !a.DueDate.After(now) || a.DueDate.After(horizonEnd) {
+				continue
+			}
+			if a.RemainingHours() <= 0 || a.AssigneeCount > 1 {
+				continue
+			}
+			candidates = append(candidates, a)
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].DueDate.After(*candidates[j].DueDate)
+		})
+
+		for _, task := range candidates {
+			if excess <= 0 {
+				break
+			}
+			best, score, reason := s.pickTarget(now, horizonDays, task, from, excess, members, order, roles)
+			if best == nil {
+				continue
+			}
+
+			suggestions = append(suggestions, model.RebalanceSuggestion{
+				TaskID:         task.TaskID,
+				TaskName:       task.TaskName,
+				DueDate:        task.DueDate,
+				RemainingHours: round2(task.RemainingHours()),
+				FromUserID:     from.userID,
+				FromName:       from.name,
+				ToUserID:       best.userID,
+				ToName:         best.name,
+				Role:           best.role,
+				Score:          score,
+				Reason:         reason,
+			})
+
+			// Simulate the move so the next proposal sees the new load.
+			from.remove(task.TaskID)
+			moved := task
+			moved.UserID, moved.Name, moved.Role = best.userID, best.name, best.role
+			best.tasks = append(best.tasks, moved)
+			excess -= task.RemainingHours()
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+
+	if err := s.repo.ReplacePendingSuggestions(ctx, suggestions); err != nil {
+		return nil, err
+	}
+
+	return &model.RebalanceResponse{
+		HorizonStart: now,
+		HorizonEnd:   horizonEnd,
+		Count:        len(suggestions),
+		Suggestions:  suggestions,
+	}, nil
+}
+
+// pickTarget returns the compatible member with the best score who can finish
+// the task before its due date on top of their own work.
+func (s *RebalanceService) pickTarget(
+	now time.Time,
+	horizonDays int,
+	task model.OpenTaskAssignment,
+	from *memberLoad,
+	excess float64,
+	members map[int64]*memberLoad,
+	order []int64,
+	roles roleCompatibility,
+) (*memberLoad, float64, string) {
+	remaining := task.RemainingHours()
+	due := *task.DueDate
+	capacity := s.capacityUntil(now, due)
+	if capacity <= 0 {
+		return nil, 0, ""
+	}
+
+	var best *memberLoad
+	bestScore := -1.0
+	bestReason := ""
+
+	for _, id := range order {
+		to := members[id]
+		if to.userID == from.userID || !roles.compatible(from.role, to.role) {
+			continue
+		}
+		free := capacity - to.committedUntil(due)
+		if free < remaining {
+			continue
+		}
+
+		// headroom: how much of the target's capacity is still free after the move
+		headroom := math.Min((free-remaining)/capacity, 1)
+		// relief: how much of the source's overload this move removes
+		relief := math.Min(remaining/excess, 1)
+		// slack: more time until the due date makes a handover safer
+		slack := math.Min(due.Sub(now).Hours()/24/float64(horizonDays), 1)
+
+		score := 50*headroom + 30*relief + 20*slack
+		if !strings.EqualFold(from.role, to.role) {
+			score *= 0.9
+		}
+		score = round2(score)
+
+		if score > bestScore {
+			best = to
+			bestScore = score
+			bestReason = fmt.Sprintf(
+				"%s is %.1fh over capacity; %s has %.1fh free until %s",
+				from.name, excess, to.name, free, due.Format("2006-01-02"),
+			)
+		}
+	}
+
+	return best, bestScore, bestReason
+}
+
+func (s *RebalanceService) GetSuggestions(ctx context.Context, status string) ([]model.RebalanceSuggestion, error) {
+	return s.repo.GetRebalanceSuggestions(ctx, status)
+}
+
+// Accept applies the suggestion in ClickUp first and only then in the local
+// database, so a failed API call leaves everything untouched.
+func (s *RebalanceService) Accept(ctx context.Context, id int64) (*model.RebalanceSuggestion, error) {
+	sug, err := s.pendingSuggestion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.clickupSvc.UpdateTaskAssignees(ctx, sug.TaskID, []int64{sug.ToUserID}, []int64{sug.FromUserID}); err != nil {
+		return nil, fmt.Errorf("failed to reassign task %s in clickup: %w", sug.TaskID, err)
+	}
+	if err := s.repo.ReassignTask(ctx, sug.TaskID, sug.FromUserID, sug.ToUserID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetSuggestionStatus(ctx, id, "accepted"); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetRebalanceSuggestion(ctx, id)
+}
+
+func (s *RebalanceService) Dismiss(ctx context.Context, id int64) (*model.RebalanceSuggestion, error) {
+	if _, err := s.pendingSuggestion(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetSuggestionStatus(ctx, id, "dismissed"); err != nil {
+		return nil, err
+	}
+	return s.repo.GetRebalanceSuggestion(ctx, id)
+}
+
+func (s *RebalanceService) pendingSuggestion(ctx context.Context, id int64) (*model.RebalanceSuggestion, error) {
+	sug, err := s.repo.GetRebalanceSuggestion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sug == nil {
+		return nil, ErrSuggestionNotFound
+	}
+	if sug.Status != "pending" {
+		return nil, ErrSuggestionDecided
+	}
+	return sug, nil
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+)
+
+// Ana (backend) memegang 100 jam dalam horizon 14 hari. Budi (backend-web)
+// boleh mengambil alih, Citra (web) tidak.
+func TestSuggestAndAcceptRebalance(t *testing.T) {
+	now := time.Now()
+	soon, later := now.AddDate(0, 0, 3), now.AddDate(0, 0, 10)
+
+	st := memstore.New()
+	st.AddMember(model.User{ClickUpID: 1, Name: "Ana", Role: "backend", Status: memstore.ActiveStatus})
+	st.AddMember(model.User{ClickUpID: 2, Name: "Budi", Role: "backend-web", Status: memstore.ActiveStatus})
+	st.AddMember(model.User{ClickUpID: 3, Name: "Citra", Role: "web", Status: memstore.ActiveStatus})
+	st.AddRoleCompatibility("backend", "backend-web")
+	st.AddTask(memstore.Task{ID: "t0", Name: "Migrasi", Status: statusOpen, DueDate: &soon, EstimateHours: 60, Assignees: []int64{1}})
+	st.AddTask(memstore.Task{ID: "t1", Name: "Laporan", Status: statusOpen, DueDate: &later, EstimateHours: 40, Assignees: []int64{1}})
+
+	clickup, fake := fakeClickUp(t, nil)
+	svc := NewRebalanceService(st, clickup, 40)
+	ctx := context.Background()
+
+	resp, err := svc.Suggest(ctx, 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Count != 1 {
+		t.Fatalf("suggestions = %+v, want only the latest task moved", resp.Suggestions)
+	}
+	sug := resp.Suggestions[0]
+	if sug.TaskID != "t1" || sug.FromUserID != 1 || sug.ToUserID != 2 || sug.Status != "pending" {
+		t.Fatalf("suggestion = %+v, want t1 from Ana to Budi", sug)
+	}
+
+	accepted, err := svc.Accept(ctx, sug.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if accepted.Status != "accepted" || accepted.ToName != "Budi" || accepted.DecidedAt == nil {
+		t.Errorf("accepted = %+v", accepted)
+	}
+	if reqs := fake.Requests(); reqs[len(reqs)-1].Path != "/api/v2/task/t1" {
+		t.Errorf("last clickup request = %+v, want the t1 update", reqs[len(reqs)-1])
+	}
+
+	open, _ := st.GetOpenAssignments(ctx)
+	for _, a := range open {
+		if a.TaskID == "t1" && a.UserID != 2 {
+			t.Errorf("t1 still assigned to %d locally", a.UserID)
+		}
+	}
+
+	if _, err := svc.Dismiss(ctx, sug.ID); !errors.Is(err, ErrSuggestionDecided) {
+		t.Errorf("Dismiss after accept = %v, want ErrSuggestionDecided", err)
+	}
+}
@@ -0,0 +1,495 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/i18n"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/notify"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+var (
+	ErrReportNotFound = apperr.New(apperr.CodeNotFound, "report definition not found")
+	ErrInvalidReport  = apperr.New(apperr.CodeValidationFailed, "invalid report definition")
+)
+
+// reportRunHour adalah jam (waktu server) laporan terjadwal dikirim.
+const reportRunHour = 8
+
+// ReportRepo is the storage ReportService reads report data, members and
+// scorecards from and keeps schedules in.
+type ReportRepo interface {
+	repository.ReportStore
+	repository.KPIStore
+	repository.MemberStore
+	repository.TaskStore
+}
+
+type ReportService struct {
+	repo      ReportRepo
+	kpiSvc    *KPIService
+	notifySvc *NotificationService
+}
+
+func NewReportService(repo ReportRepo, kpiSvc *KPIService, notifySvc *NotificationService) *ReportService {
+	return &ReportService{repo: repo, kpiSvc: kpiSvc, notifySvc: notifySvc}
+}
+
+// NextReportRun returns the first scheduled run strictly after t: Monday
+// 08:00 for weekly reports, the 1st at 08:00 for monthly ones.
+func NextReportRun(period string, t time.Time) time.Time {
+	y, m, d := t.Date()
+	if period == model.ReportPeriodMonthly {
+		next := time.Date(y, m, 1, reportRunHour, 0, 0, 0, t.Location())
+		if !next.After(t) {
+			next = next.AddDate(0, 1, 0)
+		}
+		return next
+	}
+	offset := (int(time.Monday) - int(t.Weekday()) + 7) % 7
+	next := time.Date(y, m, d+offset, reportRunHour, 0, 0, 0, t.Location())
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next
+}
+
+// ReportPeriodRange returns the last complete week (Monday-Sunday) or month
+// before t, with a label such as 2026-W42 or 2026-09.
+func ReportPeriodRange(period string, t time.Time) (label string, start, end time.Time) {
+	y, m, d := t.Date()
+	if period == model.ReportPeriodMonthly {
+		first := time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+		start = first.AddDate(0, -1, 0)
+		return start.Format("2006-01"), start, first.Add(-time.Second)
+	}
+	monday := time.Date(y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	start = monday.AddDate(0, 0, -7)
+	year, week := start.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week), start, monday.Add(-time.Second)
+}
+
+func (s *ReportService) definitionFromRequest(req model.ReportDefinitionRequest) (*model.ReportDefinition, error) {
+	d := &model.ReportDefinition{
+		Name:       strings.TrimSpace(req.Name),
+		ReportType: strings.ToLower(strings.TrimSpace(req.ReportType)),
+		Period:     strings.ToLower(strings.TrimSpace(req.Period)),
+		Audience:   req.Audience,
+		Filters:    req.Filters,
+		Format:     strings.ToLower(strings.TrimSpace(req.Format)),
+		Language:   i18n.Normalize(req.Language),
+		Active:     true,
+	}
+	if req.Active != nil {
+		d.Active = *req.Active
+	}
+	d.Audience.Role = strings.TrimSpace(d.Audience.Role)
+	d.Filters.Role = strings.TrimSpace(d.Filters.Role)
+
+	if d.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidReport)
+	}
+	if d.ReportType != model.ReportTypeWorkload && d.ReportType != model.ReportTypeKPI {
+		return nil, fmt.Errorf("%w: report_type must be workload or kpi", ErrInvalidReport)
+	}
+	if d.Period != model.ReportPeriodWeekly && d.Period != model.ReportPeriodMonthly {
+		return nil, fmt.Errorf("%w: period must be weekly or monthly", ErrInvalidReport)
+	}
+	switch d.Format {
+	case "":
+		d.Format = model.ReportFormatHTMLCSV
+	case model.ReportFormatHTML, model.ReportFormatCSV, model.ReportFormatHTMLCSV:
+	default:
+		return nil, fmt.Errorf("%w: format must be html, csv or html+csv", ErrInvalidReport)
+	}
+	if d.Language == "" {
+		if strings.TrimSpace(req.Language) != "" {
+			return nil, fmt.Errorf("%w: language must be en or id", ErrInvalidReport)
+		}
+		d.Language = i18n.Default
+	}
+	if d.Audience.Role != "" && d.Audience.UserID != nil {
+		return nil, fmt.Errorf("%w: audience is either a role or a user", ErrInvalidReport)
+	}
+	next := NextReportRun(d.Period, time.Now().In(dates.Default()))
+	d.NextRunAt = &next
+	return d, nil
+}
+
+func (s *ReportService) CreateDefinition(ctx context.Context, req model.ReportDefinitionRequest) (*model.ReportDefinition, error) {
+	d, err := s.definitionFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateReportDefinition(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *ReportService) UpdateDefinition(ctx context.Context, id int64, req model.ReportDefinitionRequest) (*model.ReportDefinition, error) {
+	d, err := s.definitionFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	d.ID = id
+	if err := s.repo.UpdateReportDefinition(ctx, d); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrReportNotFound
+		}
+		return nil, err
+	}
+	return s.GetDefinition(ctx, id)
+}
+
+func (s *ReportService) DeleteDefinition(ctx context.Context, id int64) error {
+	ok, err := s.repo.DeleteReportDefinition(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrReportNotFound
+	}
+	return nil
+}
+
+func (s *ReportService) GetDefinitions(ctx context.Context) ([]model.ReportDefinition, error) {
+	return s.repo.GetReportDefinitions(ctx)
+}
+
+func (s *ReportService) GetDefinition(ctx context.Context, id int64) (*model.ReportDefinition, error) {
+	d, err := s.repo.GetReportDefinition(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, ErrReportNotFound
+	}
+	return d, nil
+}
+
+func (s *ReportService) GetRuns(ctx context.Context, id int64) ([]model.ReportRun, error) {
+	if _, err := s.GetDefinition(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.GetReportRuns(ctx, id)
+}
+
+// Preview membangun laporan periode terakhir tanpa mengirimnya.
+func (s *ReportService) Preview(ctx context.Context, id int64) (*model.ReportDefinition, *model.ReportDocument, error) {
+	d, err := s.GetDefinition(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := s.Build(ctx, *d, time.Now().In(dates.Default()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return d, doc, nil
+}
+
+// RunNow merender dan mengirim laporan periode terakhir di luar jadwal.
+func (s *ReportService) RunNow(ctx context.Context, id int64) (*model.ReportRun, error) {
+	d, err := s.GetDefinition(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.run(ctx, *d, time.Now().In(dates.Default()))
+}
+
+// RunDue menjalankan semua laporan yang jadwalnya sudah lewat.
+func (s *ReportService) RunDue(ctx context.Context) error {
+	now := time.Now().In(dates.Default())
+	due, err := s.repo.ClaimDueReports(ctx, now, func(d model.ReportDefinition) time.Time {
+		return NextReportRun(d.Period, now)
+	})
+	if err != nil {
+		return err
+	}
+	for _, d := range due {
+		if _, err := s.run(ctx, d, *d.NextRunAt); err != nil {
+			log.Printf("WARNING: report %d (%s) failed: %v\n", d.ID, d.Name, err)
+		}
+	}
+	return nil
+}
+
+// Run menjalankan scheduler laporan sampai ctx selesai.
+func (s *ReportService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RunDue(ctx); err != nil {
+				log.Printf("WARNING: report scheduler failed: %v\n", err)
+			}
+		}
+	}
+}
+
+func (s *ReportService) run(ctx context.Context, d model.ReportDefinition, at time.Time) (*model.ReportRun, error) {
+	run := &model.ReportRun{DefinitionID: d.ID, Status: model.ReportRunSent}
+	run.PeriodLabel, run.PeriodStart, run.PeriodEnd = ReportPeriodRange(d.Period, at)
+
+	doc, err := s.Build(ctx, d, at)
+	if err == nil {
+		var msg notify.Message
+		msg, err = ReportMessage(d, *doc)
+		if err == nil {
+			run.Deliveries, err = s.notifySvc.NotifyAudience(ctx, msg, d.Audience)
+		}
+	}
+	if err != nil {
+		run.Status = model.ReportRunFailed
+		run.Error = err.Error()
+	}
+	if err := s.repo.CreateReportRun(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Build mengumpulkan data laporan untuk periode terakhir sebelum at.
+func (s *ReportService) Build(ctx context.Context, d model.ReportDefinition, at time.Time) (*model.ReportDocument, error) {
+	label, start, end := ReportPeriodRange(d.Period, at)
+	doc := &model.ReportDocument{
+		Title:       d.Name,
+		PeriodLabel: label,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Summary:     []string{},
+		GeneratedAt: time.Now().In(dates.Default()),
+		Language:    d.Language,
+	}
+
+	switch d.ReportType {
+	case model.ReportTypeWorkload:
+		rows, err := s.repo.GetWorkload(ctx, start, end)
+		if err != nil {
+			return nil, err
+		}
+		var filtered []model.WorkloadUser
+		for _, u := range rows {
+			if reportFilterMatch(d.Filters, u.UserID, u.Role) {
+				filtered = append(filtered, u)
+			}
+		}
+		doc.Summary, doc.Table = WorkloadReportTable(filtered, d.Language)
+	case model.ReportTypeKPI:
+		scorecards, err := s.kpiSvc.BuildScorecards(ctx, d.Period, label, start, end)
+		if err != nil {
+			return nil, err
+		}
+		var filtered []model.KPIScorecard
+		for _, sc := range scorecards {
+			if reportFilterMatch(d.Filters, sc.UserID, sc.Role) {
+				filtered = append(filtered, sc)
+			}
+		}
+		doc.Summary, doc.Table = KPIReportTable(filtered, d.Language)
+	default:
+		return nil, fmt.Errorf("%w: unknown report_type %q", ErrInvalidReport, d.ReportType)
+	}
+	return doc, nil
+}
+
+func reportFilterMatch(f model.ReportFilters, userID int64, role string) bool {
+	if f.Role != "" && !strings.EqualFold(f.Role, role) {
+		return false
+	}
+	return f.UserID == nil || *f.UserID == userID
+}
+
+// WorkloadReportTable summarizes logged hours against expected hours.
+func WorkloadReportTable(rows []model.WorkloadUser, lang string) ([]string, model.ReportTable) {
+	table := model.ReportTable{
+		Columns: reportColumns(lang, "member", "role", "tasks", "hours_logged", "expected_hours", "utilisation"),
+		Rows:    [][]string{},
+	}
+	var total, utilSum float64
+	over := 0
+	for _, u := range rows {
+		util := 0.0
+		if u.ExpectedHours > 0 {
+			util = round2(u.TotalHours / u.ExpectedHours * 100)
+		}
+		if util > 100 {
+			over++
+		}
+		total += u.TotalHours
+		utilSum += util
+		table.Rows = append(table.Rows, []string{
+			u.Name, u.Role, strconv.Itoa(u.TaskCount),
+			formatReportFloat(u.TotalHours), formatReportFloat(u.ExpectedHours), formatReportFloat(util),
+		})
+	}
+	summary := []string{
+		i18n.T(lang, "report.members_count", len(rows)),
+		i18n.T(lang, "report.total_logged", formatReportFloat(total)),
+	}
+	if len(rows) > 0 {
+		summary = append(summary, i18n.T(lang, "report.avg_utilisation", formatReportFloat(utilSum/float64(len(rows)))))
+	}
+	summary = append(summary, i18n.T(lang, "report.over_capacity", over))
+	return summary, table
+}
+
+// KPIReportTable lists total score and indicator values per member.
+func KPIReportTable(scorecards []model.KPIScorecard, lang string) ([]string, model.ReportTable) {
+	var codes, names []string
+	seen := map[string]bool{}
+	for _, sc := range scorecards {
+		for _, it := range sc.Items {
+			if !seen[it.IndicatorCode] {
+				seen[it.IndicatorCode] = true
+				codes = append(codes, it.IndicatorCode)
+				name := it.IndicatorName
+				if name == "" {
+					name = it.IndicatorCode
+				}
+				names = append(names, name)
+			}
+		}
+	}
+
+	table := model.ReportTable{
+		Columns: append(reportColumns(lang, "member", "role", "score"), names...),
+		Rows:    [][]string{},
+	}
+	var sum float64
+	for _, sc := range scorecards {
+		values := map[string]string{}
+		for _, it := range sc.Items {
+			if it.Value != nil {
+				values[it.IndicatorCode] = formatReportFloat(*it.Value)
+			}
+		}
+		row := []string{sc.Name, sc.Role, formatReportFloat(sc.TotalScore)}
+		for _, c := range codes {
+			row = append(row, values[c])
+		}
+		table.Rows = append(table.Rows, row)
+		sum += sc.TotalScore
+	}
+
+	summary := []string{i18n.T(lang, "report.members_count", len(scorecards))}
+	if len(scorecards) > 0 {
+		summary = append(summary, i18n.T(lang, "report.avg_score", formatReportFloat(sum/float64(len(scorecards)))))
+	}
+	return summary, table
+}
+
+// reportColumns translates "report.<key>" headers.
+func reportColumns(lang string, keys ...string) []string {
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = i18n.T(lang, "report."+k)
+	}
+	return out
+}
+
+func formatReportFloat(v float64) string {
+	return strconv.FormatFloat(round2(v), 'f', -1, 64)
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Funcs(template.FuncMap{"t": i18n.T}).Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>{{.Title}}</title></head>
+<body style="font-family:Arial,Helvetica,sans-serif;color:#222">
+<h2 style="margin-bottom:4px">{{.Title}}</h2>
+<p style="margin-top:0;color:#666">{{.PeriodLabel}} ({{.PeriodStart.Format "2006-01-02"}} – {{.PeriodEnd.Format "2006-01-02"}})</p>
+{{if .Summary}}<ul>{{range .Summary}}<li>{{.}}</li>{{end}}</ul>{{end}}
+<table style="border-collapse:collapse;font-size:13px">
+<thead><tr>{{range .Table.Columns}}<th style="border:1px solid #ccc;padding:4px 8px;background:#f3f3f3;text-align:left">{{.}}</th>{{end}}</tr></thead>
+<tbody>{{range .Table.Rows}}<tr>{{range .}}<td style="border:1px solid #ccc;padding:4px 8px">{{.}}</td>{{end}}</tr>{{else}}<tr><td colspan="{{len .Table.Columns}}" style="padding:4px 8px">{{t .Language "report.no_data"}}</td></tr>{{end}}</tbody>
+</table>
+<p style="color:#999;font-size:11px">{{t .Language "report.generated"}} {{.GeneratedAt.Format "2006-01-02 15:04"}}</p>
+</body></html>`))
+
+// RenderReportHTML renders a report document as a standalone HTML page.
+func RenderReportHTML(doc model.ReportDocument) (string, error) {
+	var b bytes.Buffer
+	if err := reportHTMLTemplate.Execute(&b, doc); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// RenderReportCSV renders the report table as CSV with a header row.
+func RenderReportCSV(doc model.ReportDocument) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.Write(doc.Table.Columns); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(doc.Table.Rows); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// ReportFileName returns e.g. "weekly-team-workload-2026-W42".
+func ReportFileName(d model.ReportDefinition, doc model.ReportDocument) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, d.Name)
+	for strings.Contains(slug, "--") {
+		slug = strings.ReplaceAll(slug, "--", "-")
+	}
+	slug = strings.Trim(slug, "-")
+	if slug == "" {
+		slug = "report"
+	}
+	return slug + "-" + doc.PeriodLabel
+}
+
+// ReportMessage builds the notification: HTML body plus a CSV attachment,
+// depending on the definition format.
+func ReportMessage(d model.ReportDefinition, doc model.ReportDocument) (notify.Message, error) {
+	msg := notify.Message{
+		Event:   model.NotificationEventReport,
+		Subject: fmt.Sprintf("%s (%s)", doc.Title, doc.PeriodLabel),
+		Text:    strings.Join(doc.Summary, "\n"),
+		Fields:  map[string]string{"period": doc.PeriodLabel, "report_id": strconv.FormatInt(d.ID, 10)},
+	}
+	if d.Format != model.ReportFormatCSV {
+		html, err := RenderReportHTML(doc)
+		if err != nil {
+			return msg, err
+		}
+		msg.HTML = html
+	}
+	if d.Format != model.ReportFormatHTML {
+		data, err := RenderReportCSV(doc)
+		if err != nil {
+			return msg, err
+		}
+		msg.Attachments = append(msg.Attachments, notify.Attachment{
+			Filename:    ReportFileName(d, doc) + ".csv",
+			ContentType: "text/csv",
+			Data:        data,
+		})
+	}
+	return msg, nil
+}
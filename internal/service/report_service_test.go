@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/notify"
+)
+
+func TestBuildWorkloadReportFiltersRole(t *testing.T) {
+	svc := NewReportService(workloadStore(), nil, nil)
+	d := model.ReportDefinition{Name: "Beban web", ReportType: model.ReportTypeWorkload, Period: model.ReportPeriodMonthly,
+		Filters: model.ReportFilters{Role: "Web"}, Language: "en"}
+
+	doc, err := svc.Build(context.Background(), d, *day(11, 2, 9))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.PeriodLabel != "2026-10" || len(doc.Table.Rows) != 1 || doc.Table.Rows[0][0] != "Budi" {
+		t.Errorf("report %s = %v, want only Budi in October", doc.PeriodLabel, doc.Table.Rows)
+	}
+}
+
+// Jadwal yang sudah lewat dijalankan sekali lalu dimajukan ke jadwal berikutnya.
+func TestRunDueSendsOncePerSchedule(t *testing.T) {
+	ctx := context.Background()
+	st := workloadStore()
+	email := &recordingNotifier{channel: notify.ChannelEmail}
+	notifySvc := NewNotificationService(st, notify.NewRegistry(email))
+	if _, err := notifySvc.CreateSubscription(ctx, model.NotificationSubscriptionRequest{SubscriberType: "role", Role: "web", Channel: "email"}); err != nil {
+		t.Fatal(err)
+	}
+	svc := NewReportService(st, nil, notifySvc)
+
+	due := time.Now().Add(-time.Hour)
+	d := model.ReportDefinition{Name: "Beban mingguan", ReportType: model.ReportTypeWorkload, Period: model.ReportPeriodWeekly,
+		Audience: model.NotificationAudience{Role: "web"}, Format: model.ReportFormatCSV, Language: "id", Active: true, NextRunAt: &due}
+	if err := st.CreateReportDefinition(ctx, &d); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if err := svc.RunDue(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	runs, err := svc.GetRuns(ctx, d.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	label, _, _ := ReportPeriodRange(d.Period, due)
+	if len(runs) != 1 || runs[0].Status != model.ReportRunSent || runs[0].Deliveries != 1 || runs[0].PeriodLabel != label {
+		t.Fatalf("runs = %+v, want one sent run for %s", runs, label)
+	}
+	if len(email.sent) != 1 || email.sent[0] != "budi@example.com" {
+		t.Errorf("emails = %v, want the web member once", email.sent)
+	}
+	if again, _ := svc.GetDefinition(ctx, d.ID); again.LastRunAt == nil || !again.NextRunAt.After(time.Now()) {
+		t.Errorf("definition after run = %+v, want the next schedule in the future", again)
+	}
+}
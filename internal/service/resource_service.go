@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+	"github.com/roksva123/go-kinerja-backend/internal/resource"
+)
+
+// ResourceRepo is the storage ResourceService reads tasks, members and the
+// project tree from.
+type ResourceRepo interface {
+	repository.ResourceStore
+	repository.MemberStore
+	repository.ListingStore
+	repository.TaskStore
+}
+
+// ResourceService builds the API v2 resources. Included relations are loaded
+// with one query per relation for the whole page, never per row.
+type ResourceService struct {
+	repo ResourceRepo
+}
+
+func NewResourceService(repo ResourceRepo) *ResourceService {
+	return &ResourceService{repo: repo}
+}
+
+func (s *ResourceService) ListTasks(ctx context.Context, p query.Params, v resource.View) ([]resource.Task, query.Page, error) {
+	rows, page, err := s.repo.GetTaskResourcesPage(ctx, p)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	tasks, err := s.tasks(ctx, rows, v)
+	return tasks, page, err
+}
+
+func (s *ResourceService) GetTask(ctx context.Context, id string, v resource.View) (*resource.Task, error) {
+	row, err := s.repo.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if row == nil {
+		return nil, ErrTaskNotFound
+	}
+	tasks, err := s.tasks(ctx, []model.TaskResponse{*row}, v)
+	if err != nil {
+		return nil, err
+	}
+	return &tasks[0], nil
+}
+
+// tasks mengubah baris task ke resource lalu mengisi relasi yang diminta.
+func (s *ResourceService) tasks(ctx context.Context, rows []model.TaskResponse, v resource.View) ([]resource.Task, error) {
+	out := make([]resource.Task, len(rows))
+	for i, t := range rows {
+		out[i] = v.Task(t)
+	}
+
+	if v.Include[resource.IncludeAssignees] {
+		var ids []int64
+		seen := map[int64]bool{}
+		for _, t := range rows {
+			for _, a := range t.Assignees {
+				if !seen[a.ID] {
+					seen[a.ID] = true
+					ids = append(ids, a.ID)
+				}
+			}
+		}
+		members, err := s.members(ctx, ids, v)
+		if err != nil {
+			return nil, err
+		}
+		for i := range out {
+			out[i].Assignees = []resource.Member{}
+			for _, id := range out[i].AssigneeIDs {
+				if m, ok := members[id]; ok {
+					out[i].Assignees = append(out[i].Assignees, m)
+				}
+			}
+		}
+	}
+
+	if v.Include[resource.IncludeProject] {
+		var ids []string
+		seen := map[string]bool{}
+		for _, t := range out {
+			if t.ProjectID != nil && !seen[*t.ProjectID] {
+				seen[*t.ProjectID] = true
+				ids = append(ids, *t.ProjectID)
+			}
+		}
+		projects := map[string]resource.Project{}
+		if len(ids) > 0 {
+			nodes, err := s.repo.GetProjectTree(ctx, model.ProjectLevelList, ids)
+			if err != nil {
+				return nil, err
+			}
+			for _, n := range nodes {
+				projects[n.ID] = v.Project(n)
+			}
+		}
+		for i := range out {
+			if out[i].ProjectID == nil {
+				continue
+			}
+			if p, ok := projects[*out[i].ProjectID]; ok {
+				out[i].Project = &p
+			}
+		}
+	}
+	return out, nil
+}
+
+// MembersByID loads members by resource id in one query. Unknown or
+// malformed ids are left out of the map.
+func (s *ResourceService) MembersByID(ctx context.Context, ids []string, v resource.View) (map[string]resource.Member, error) {
+	return s.members(ctx, memberIDs(ids), v)
+}
+
+func memberIDs(ids []string) []int64 {
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if n, err := resource.ParseMemberID(id); err == nil {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// ProjectsByID loads spaces, folders and lists by id in one query.
+func (s *ResourceService) ProjectsByID(ctx context.Context, ids []string, v resource.View) (map[string]resource.Project, error) {
+	out := make(map[string]resource.Project, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	nodes, err := s.repo.GetProjectTree(ctx, "", ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		out[n.ID] = v.Project(n)
+	}
+	return out, nil
+}
+
+// TasksForMembers returns the tasks of each member in [start, end], keyed by
+// member resource id.
+func (s *ResourceService) TasksForMembers(ctx context.Context, ids []string, start, end time.Time, v resource.View) (map[string][]resource.Task, error) {
+	byMember, err := s.repo.GetTasksForMembers(ctx, memberIDs(ids), start, end)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]resource.Task, len(byMember))
+	for id, rows := range byMember {
+		tasks, err := s.tasks(ctx, rows, v)
+		if err != nil {
+			return nil, err
+		}
+		out[resource.MemberID(id)] = tasks
+	}
+	return out, nil
+}
+
+// members returns the members keyed by their resource id.
+func (s *ResourceService) members(ctx context.Context, ids []int64, v resource.View) (map[string]resource.Member, error) {
+	users, err := s.repo.GetMembersByID(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]resource.Member, len(users))
+	for _, u := range users {
+		m := v.Member(u)
+		out[m.ID] = m
+	}
+	return out, nil
+}
+
+func (s *ResourceService) ListMembers(ctx context.Context, p query.Params, v resource.View) ([]resource.Member, query.Page, error) {
+	users, page, err := s.repo.GetMembersPage(ctx, p)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	out := make([]resource.Member, len(users))
+	for i, u := range users {
+		out[i] = v.Member(u)
+	}
+	return out, page, nil
+}
+
+func (s *ResourceService) GetMember(ctx context.Context, id string, v resource.View) (*resource.Member, error) {
+	clickupID, err := resource.ParseMemberID(id)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.repo.GetMember(ctx, clickupID)
+	if err != nil {
+		return nil, err
+	}
+	if u == nil {
+		return nil, ErrMemberNotFound
+	}
+	m := v.Member(*u)
+	return &m, nil
+}
+
+var projectResourceSpec = query.MemorySpec[resource.Project]{
+	Sort: map[string]func(a, b resource.Project) int{
+		"name":  func(a, b resource.Project) int { return query.CompareString(a.Name, b.Name) },
+		"level": func(a, b resource.Project) int { return query.CompareString(a.Level, b.Level) },
+	},
+	DefaultSort: []query.SortField{{Field: "name"}},
+	Filters: map[string]func(resource.Project) []string{
+		query.FilterProject: func(p resource.Project) []string {
+			if p.ParentID == nil {
+				return []string{p.ID, strings.ToLower(p.Name)}
+			}
+			return []string{p.ID, strings.ToLower(p.Name), *p.ParentID}
+		},
+	},
+	Search: func(p resource.Project) []string { return []string{p.Name} },
+}
+
+// ListProjects returns spaces, folders and lists; an empty level returns all.
+func (s *ResourceService) ListProjects(ctx context.Context, level string, includeArchived bool, p query.Params, v resource.View) ([]resource.Project, query.Page, error) {
+	if level != "" && !validProjectLevel(level) {
+		return nil, query.Page{}, ErrInvalidProjectLevel
+	}
+	nodes, err := s.repo.GetProjectTree(ctx, level, nil)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	out := make([]resource.Project, 0, len(nodes))
+	for _, n := range nodes {
+		if n.Archived && !includeArchived {
+			continue
+		}
+		out = append(out, v.Project(n))
+	}
+	return query.Apply(out, p, projectResourceSpec)
+}
+
+func (s *ResourceService) GetProject(ctx context.Context, id string, v resource.View) (*resource.Project, error) {
+	nodes, err := s.repo.GetProjectTree(ctx, "", []string{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, ErrProjectNotFound
+	}
+	p := v.Project(nodes[0])
+	return &p, nil
+}
+
+var workloadResourceSpec = query.MemorySpec[resource.Workload]{
+	Sort: map[string]func(a, b resource.Workload) int{
+		"task_count":     func(a, b resource.Workload) int { return query.CompareInt(a.TaskCount, b.TaskCount) },
+		"logged_hours":   func(a, b resource.Workload) int { return query.CompareFloat(a.LoggedHours, b.LoggedHours) },
+		"expected_hours": func(a, b resource.Workload) int { return query.CompareFloat(a.ExpectedHours, b.ExpectedHours) },
+	},
+	DefaultSort: []query.SortField{{Field: "logged_hours", Desc: true}},
+	Filters: map[string]func(resource.Workload) []string{
+		query.FilterAssignee: func(w resource.Workload) []string { return []string{w.MemberID} },
+	},
+}
+
+// GetWorkload returns the workload of every active member over [start, end].
+func (s *ResourceService) GetWorkload(ctx context.Context, start, end time.Time, p query.Params, v resource.View) ([]resource.Workload, query.Page, error) {
+	users, err := s.repo.GetWorkload(ctx, start, end)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	out := make([]resource.Workload, len(users))
+	for i, u := range users {
+		out[i] = v.Workload(u, start, end)
+	}
+	out, page, err := query.Apply(out, p, workloadResourceSpec)
+	if err != nil || !v.Include[resource.IncludeMember] {
+		return out, page, err
+	}
+
+	ids := make([]string, len(out))
+	for i, w := range out {
+		ids[i] = w.MemberID
+	}
+	members, err := s.MembersByID(ctx, ids, v)
+	if err != nil {
+		return nil, query.Page{}, err
+	}
+	for i := range out {
+		if m, ok := members[out[i].MemberID]; ok {
+			out[i].Member = &m
+		}
+	}
+	return out, page, nil
+}
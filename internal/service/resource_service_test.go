@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/roksva123/go-kinerja-backend/internal/query"
+	"github.com/roksva123/go-kinerja-backend/internal/resource"
+)
+
+func TestListTaskResourcesWithIncludes(t *testing.T) {
+	ctx := context.Background()
+	svc := NewResourceService(workloadStore())
+	v := resource.View{Include: resource.Include{resource.IncludeAssignees: true, resource.IncludeProject: true}}
+
+	// Citra sudah nonaktif tetapi role-nya tetap web.
+	p := query.Params{Filters: map[string][]string{query.FilterRole: {"web"}}, Sort: []query.SortField{{Field: "due_at"}}}
+	tasks, page, err := svc.ListTasks(ctx, p, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 3 || len(tasks) != 3 || tasks[0].ID != "t5" || tasks[1].ID != "t2" || tasks[2].ID != "t4" {
+		t.Fatalf("web tasks = %+v, want t5, t2 then the undated t4", tasks)
+	}
+	login := tasks[1]
+	if len(login.Assignees) != 2 || login.Assignees[0].Name != "Ana" || login.Project == nil || login.Project.Name != "Sprint 1" || *login.Project.ParentID != "f1" {
+		t.Errorf("t2 = %+v, want both assignees and its list", login)
+	}
+	if _, _, err := svc.ListTasks(ctx, query.Params{Sort: []query.SortField{{Field: "due_date"}}}, v); !errors.Is(err, query.ErrInvalidQuery) {
+		t.Errorf("sort by the v1 field = %v, want ErrInvalidQuery", err)
+	}
+	if _, err := svc.GetTask(ctx, "t9", v); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("unknown task = %v, want ErrTaskNotFound", err)
+	}
+
+	byMember, err := svc.TasksForMembers(ctx, []string{"2", "x"}, october[0], october[1], resource.View{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := byMember["2"]; len(byMember) != 1 || len(got) != 2 || got[0].ID != "t2" || got[1].ID != "t4" {
+		t.Errorf("tasks of Budi = %+v", byMember)
+	}
+
+	projects, _, err := svc.ListProjects(ctx, "", false, query.Params{}, resource.View{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 2 || projects[0].Level != "folder" || projects[1].ID != "l1" {
+		t.Errorf("projects = %+v, want the folder then its list", projects)
+	}
+}
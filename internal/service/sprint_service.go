@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/apperr"
+	"github.com/roksva123/go-kinerja-backend/internal/dates"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
+)
+
+var (
+	ErrSprintNotFound = apperr.New(apperr.CodeNotFound, "sprint not found")
+	ErrInvalidSprint  = apperr.New(apperr.CodeValidationFailed, "invalid sprint")
+)
+
+// velocityWindow adalah jumlah sprint selesai terakhir untuk rata-rata velocity.
+const velocityWindow = 3
+
+// SprintRepo is the storage SprintService keeps sprints in and resolves lists
+// from.
+type SprintRepo interface {
+	repository.SprintStore
+	repository.HierarchyStore
+}
+
+type SprintService struct {
+	repo SprintRepo
+}
+
+func NewSprintService(repo SprintRepo) *SprintService {
+	return &SprintService{repo: repo}
+}
+
+func (s *SprintService) sprintFromRequest(ctx context.Context, req model.SprintRequest) (*model.Sprint, error) {
+	sp := &model.Sprint{
+		Name:   strings.TrimSpace(req.Name),
+		ListID: strings.TrimSpace(req.ListID),
+		Source: model.SprintSourceManual,
+	}
+	if sp.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidSprint)
+	}
+	start, err := dates.ParseStart(req.StartDate, dates.Default())
+	if err != nil {
+		return nil, fmt.Errorf("%w: start_date: %v", ErrInvalidSprint, err)
+	}
+	end, err := dates.ParseEnd(req.EndDate, dates.Default())
+	if err != nil {
+		return nil, fmt.Errorf("%w: end_date: %v", ErrInvalidSprint, err)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: end_date is before start_date", ErrInvalidSprint)
+	}
+	sp.StartDate, sp.EndDate = start, end
+
+	node, err := s.repo.GetProjectNode(ctx, model.ProjectLevelList, sp.ListID)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, ErrProjectNotFound
+	}
+	return sp, nil
+}
+
+// CreateSprint mendefinisikan sprint manual untuk sebuah list.
+func (s *SprintService) CreateSprint(ctx context.Context, req model.SprintRequest) (*model.Sprint, error) {
+	sp, err := s.sprintFromRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveSprint(ctx, sp); err != nil {
+		return nil, err
+	}
+	return s.GetSprint(ctx, sp.ID)
+}
+
+func (s *SprintService) UpdateSprint(ctx context.Context, id int64, req model.SprintRequest) (*model.Sprint, error) {
+	sp, err := s.sprintFromRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	sp.ID = id
+	if err := s.repo.UpdateSprint(ctx, sp); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSprintNotFound
+		}
+		return nil, err
+	}
+	return s.GetSprint(ctx, id)
+}
+
+func (s *SprintService) DeleteSprint(ctx context.Context, id int64) error {
+	ok, err := s.repo.DeleteSprint(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrSprintNotFound
+	}
+	return nil
+}
+
+// DetectSprints membuat/memperbarui sprint dari list di folder sprint ClickUp.
+func (s *SprintService) DetectSprints(ctx context.Context) (int64, error) {
+	return s.repo.DetectSprints(ctx)
+}
+
+func (s *SprintService) GetSprints(ctx context.Context) ([]model.Sprint, error) {
+	return s.repo.GetSprints(ctx)
+}
+
+func (s *SprintService) GetSprint(ctx context.Context, id int64) (*model.Sprint, error) {
+	sp, err := s.repo.GetSprint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sp == nil {
+		return nil, ErrSprintNotFound
+	}
+	return sp, nil
+}
+
+// GetReport menghitung burndown, burnup dan perubahan scope satu sprint.
+func (s *SprintService) GetReport(ctx context.Context, id int64) (*model.SprintReport, error) {
+	sp, err := s.GetSprint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tasks, history, err := s.sprintData(ctx, sp.ListID)
+	if err != nil {
+		return nil, err
+	}
+	report := ComputeSprintReport(*sp, tasks, history, time.Now())
+	return &report, nil
+}
+
+// GetVelocity mengembalikan velocity (jam estimasi selesai) semua sprint.
+func (s *SprintService) GetVelocity(ctx context.Context) (*model.VelocityHistory, error) {
+	sprints, err := s.repo.GetSprints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sprints, func(i, j int) bool { return sprints[i].StartDate.Before(sprints[j].StartDate) })
+
+	now := time.Now()
+	out := &model.VelocityHistory{Sprints: []model.SprintVelocity{}}
+	for _, sp := range sprints {
+		tasks, history, err := s.sprintData(ctx, sp.ListID)
+		if err != nil {
+			return nil, err
+		}
+		r := ComputeSprintReport(sp, tasks, history, now)
+		out.Sprints = append(out.Sprints, model.SprintVelocity{
+			SprintID:       sp.ID,
+			Name:           sp.Name,
+			StartDate:      sp.StartDate,
+			EndDate:        sp.EndDate,
+			CommittedHours: r.CommittedHours,
+			CompletedHours: r.CompletedHours,
+			CompletedTasks: r.DoneCount,
+			Finished:       now.After(sp.EndDate),
+		})
+	}
+
+	var total float64
+	var n int
+	for i := len(out.Sprints) - 1; i >= 0 && n < velocityWindow; i-- {
+		if out.Sprints[i].Finished {
+			total += out.Sprints[i].CompletedHours
+			n++
+		}
+	}
+	if n > 0 {
+		out.AverageVelocity = round2(total / float64(n))
+	}
+	return out, nil
+}
+
+func (s *SprintService) sprintData(ctx context.Context, listID string) ([]model.SprintTask, map[string][]model.TaskStatusChange, error) {
+	tasks, err := s.repo.GetSprintTasks(ctx, listID)
+	if err != nil {
+		return nil, nil, err
+	}
+	history, err := s.repo.GetListStatusHistory(ctx, listID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tasks, history, nil
+}
+
+// sprintTaskDoneAt reports whether a task was finished at the given moment.
+// Status history wins when it covers that moment; otherwise date_done is used.
+func sprintTaskDoneAt(t model.SprintTask, history []model.TaskStatusChange, at time.Time) bool {
+	var last *model.TaskStatusChange
+	for i := range history {
+		if history[i].ChangedAt.After(at) {
+			break
+		}
+		last = &history[i]
+	}
+	if last != nil {
+		return finishedStatusTypes[last.StatusType]
+	}
+	return t.DateDone != nil && !t.DateDone.After(at)
+}
+
+// ComputeSprintReport builds the daily burndown/burnup of a sprint in estimate
+// hours. Tasks created after the first sprint day count as scope added; days
+// after now are left empty except for the ideal line.
+func ComputeSprintReport(sp model.Sprint, tasks []model.SprintTask, history map[string][]model.TaskStatusChange, now time.Time) model.SprintReport {
+	report := model.SprintReport{
+		Sprint:       sp,
+		TaskCount:    len(tasks),
+		UsesHistory:  len(history) > 0,
+		Days:         []model.SprintDay{},
+		ScopeChanges: []model.SprintScopeChange{},
+	}
+
+	loc := sp.StartDate.Location()
+	y, m, d := sp.StartDate.Date()
+	firstDay := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	startEOD := firstDay.Add(24*time.Hour - time.Nanosecond)
+
+	inScope := func(t model.SprintTask, at time.Time) bool {
+		return t.DateCreated == nil || !t.DateCreated.After(at)
+	}
+
+	for _, t := range tasks {
+		if inScope(t, startEOD) {
+			report.CommittedHours += t.EstimateHours
+			continue
+		}
+		report.AddedHours += t.EstimateHours
+		report.ScopeChanges = append(report.ScopeChanges, model.SprintScopeChange{
+			TaskID:        t.TaskID,
+			TaskName:      t.TaskName,
+			AddedAt:       *t.DateCreated,
+			EstimateHours: t.EstimateHours,
+		})
+	}
+
+	var days []time.Time
+	for day := firstDay; !day.After(sp.EndDate); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+
+	for i, day := range days {
+		eod := day.Add(24*time.Hour - time.Nanosecond)
+		point := model.SprintDay{Date: day, Ideal: report.CommittedHours}
+		if len(days) > 1 {
+			point.Ideal = round2(report.CommittedHours * (1 - float64(i)/float64(len(days)-1)))
+		}
+		if !day.After(now) {
+			var scope, completed float64
+			for _, t := range tasks {
+				if !inScope(t, eod) {
+					continue
+				}
+				scope += t.EstimateHours
+				if sprintTaskDoneAt(t, history[t.TaskID], eod) {
+					completed += t.EstimateHours
+				}
+			}
+			remaining := round2(scope - completed)
+			scope, completed = round2(scope), round2(completed)
+			point.Scope, point.Completed, point.Remaining = &scope, &completed, &remaining
+		}
+		report.Days = append(report.Days, point)
+	}
+
+	until := sp.EndDate
+	if now.Before(until) {
+		until = now
+	}
+	for _, t := range tasks {
+		if sprintTaskDoneAt(t, history[t.TaskID], until) {
+			report.DoneCount++
+			report.CompletedHours += t.EstimateHours
+		}
+	}
+
+	report.CommittedHours = round2(report.CommittedHours)
+	report.CompletedHours = round2(report.CompletedHours)
+	report.AddedHours = round2(report.AddedHours)
+	return report
+}
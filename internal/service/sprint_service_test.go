@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+)
+
+// Sprint 1 terdeteksi dari folder sprint; list s2 sudah punya sprint manual
+// yang tidak boleh ditimpa. k2 sempat selesai lalu dibuka lagi (riwayat status),
+// k3 ditambahkan setelah hari pertama.
+func TestDetectedSprintReportAndVelocity(t *testing.T) {
+	ctx := context.Background()
+	st := memstore.New()
+	st.AddFolder(model.Folder{ID: "fs", Name: "Sprint Board"})
+	st.AddList(model.List{ID: "s1", Name: "Sprint 1", FolderID: "fs", StartDate: day(9, 1, 0), DueDate: day(9, 14, 23)})
+	st.AddList(model.List{ID: "s2", Name: "Sprint 2", FolderID: "fs", StartDate: day(9, 15, 0), DueDate: day(9, 28, 23)})
+
+	st.AddTask(memstore.Task{ID: "k1", Name: "API", Status: statusDone, ListID: "s1", EstimateHours: 5,
+		DateCreated: day(8, 30, 9), DateDone: day(9, 5, 12)})
+	st.AddTask(memstore.Task{ID: "k2", Name: "UI", Status: statusOpen, ListID: "s1", EstimateHours: 3, DateCreated: day(8, 30, 9)})
+	st.AddTask(memstore.Task{ID: "k3", Name: "Bug", Status: statusDone, ListID: "s1", EstimateHours: 2,
+		DateCreated: day(9, 3, 9), DateDone: day(9, 8, 12)})
+	for _, c := range []struct {
+		status model.TaskStatus
+		at     int
+	}{{statusOpen, 1}, {statusOpen, 2}, {statusDone, 10}, {statusOpen, 12}} {
+		st.RecordTaskStatus(ctx, "k2", c.status.ID, c.status.Name, c.status.Type, *day(9, c.at, 9))
+	}
+	if h, _ := st.GetListStatusHistory(ctx, "s1"); len(h["k2"]) != 3 {
+		t.Fatalf("k2 history = %+v, want the repeated open status recorded once", h["k2"])
+	}
+
+	svc := NewSprintService(st)
+	manual, err := svc.CreateSprint(ctx, model.SprintRequest{Name: "Manual", ListID: "s2", StartDate: "2026-09-15", EndDate: "2026-09-30"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := svc.DetectSprints(ctx); err != nil || n != 1 {
+		t.Fatalf("DetectSprints = %d, %v; want only Sprint 1", n, err)
+	}
+	if again, _ := svc.GetSprint(ctx, manual.ID); again.Name != "Manual" || again.Source != model.SprintSourceManual {
+		t.Errorf("manual sprint = %+v, want it untouched", again)
+	}
+
+	sprints, _ := svc.GetSprints(ctx)
+	if len(sprints) != 2 || sprints[1].ListID != "s1" || sprints[1].ListName != "Sprint 1" {
+		t.Fatalf("sprints = %+v, want Sprint 2 first", sprints)
+	}
+	report, err := svc.GetReport(ctx, sprints[1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.CommittedHours != 8 || report.AddedHours != 2 || len(report.ScopeChanges) != 1 || report.ScopeChanges[0].TaskID != "k3" {
+		t.Errorf("scope = %v committed, %v added (%+v)", report.CommittedHours, report.AddedHours, report.ScopeChanges)
+	}
+	if !report.UsesHistory || report.DoneCount != 2 || report.CompletedHours != 7 {
+		t.Errorf("done = %d tasks, %v hours; want k1 and k3 since k2 was reopened", report.DoneCount, report.CompletedHours)
+	}
+
+	v, err := svc.GetVelocity(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v.Sprints) != 2 || v.Sprints[0].CompletedHours != 7 || v.AverageVelocity != 3.5 {
+		t.Errorf("velocity = %+v", v)
+	}
+}
@@ -13,11 +13,11 @@ import (
 )
 
 type WorkloadService struct {
-	repo       *repository.PostgresRepo
+	repo       repository.TaskStore
 	clickupSvc *ClickUpService
 }
 
-func NewWorkloadService(repo *repository.PostgresRepo, clickupSvc *ClickUpService) *WorkloadService {
+func NewWorkloadService(repo repository.TaskStore, clickupSvc *ClickUpService) *WorkloadService {
 	return &WorkloadService{
 		repo:       repo,
 		clickupSvc: clickupSvc,
@@ -57,9 +57,10 @@ func (s *WorkloadService) GetWorkload(ctx context.Context, start, end time.Time,
 		return workloads, nil
 	}
 
+	// Username tidak diisi repository; filter dicocokkan ke nama member.
 	var filteredWorkloads []model.WorkloadUser
 	for _, workload := range workloads {
-		if strings.Contains(strings.ToLower(workload.Username), strings.ToLower(username)) {
+		if strings.Contains(strings.ToLower(workload.Name), strings.ToLower(username)) {
 			filteredWorkloads = append(filteredWorkloads, workload)
 		}
 	}
@@ -80,43 +81,12 @@ func nullTimeToDateStringPointer(t *time.Time) *string {
 }
 
 func (s *WorkloadService) GetTasksByAssignee(ctx context.Context, start, end time.Time) (*model.TasksByAssigneeResponse, error) {
-	summaries, err := s.repo.GetTasksSummaryByDateRange(ctx, start, end)
+	assignees, err := s.repo.GetTasksByAssignee(ctx, start, end)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get task summaries: %w", err)
+		return nil, fmt.Errorf("failed to get tasks by assignee: %w", err)
 	}
-
-	if len(summaries) == 0 {
-		return &model.TasksByAssigneeResponse{
-			Count:     0,
-			Assignees: []model.AssigneeWithTasks{},
-		}, nil
-	}
-
-	var assignees []model.AssigneeWithTasks
-	for _, summary := range summaries {
-		tasks, err := s.repo.GetTasksByUser(ctx, summary.UserID, start, end)
-		if err != nil {
-			fmt.Printf("WARNING: could not get tasks for user %d: %v\n", summary.UserID, err)
-		}
-
-		assignee := model.AssigneeWithTasks{
-			ClickUpID:          summary.UserID,
-			Username:           summary.Name,
-			Email:              summary.Email,
-			Role:               summary.Role,
-			Name:               summary.Name,
-			TotalSpentHours:    summary.TotalSpentHours,
-			ExpectedHours:      summary.TotalWorkHours,
-			TotalTasks:         summary.TotalTasks,
-			TotalUpcomingHours: summary.TotalUpcomingHours,
-			Tasks:              tasks,
-		}
-		assignees = append(assignees, assignee)
-	}
-	response := &model.TasksByAssigneeResponse{
+	return &model.TasksByAssigneeResponse{
 		Count:     len(assignees),
 		Assignees: assignees,
-	}
-
-	return response, nil
-}
\ No newline at end of file
+	}, nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository/memstore"
+)
+
+func day(month time.Month, d, hour int) *time.Time {
+	t := time.Date(2026, month, d, hour, 0, 0, 0, time.UTC)
+	return &t
+}
+
+var (
+	statusOpen = model.TaskStatus{ID: "s1", Name: "to do", Type: "open"}
+	statusDone = model.TaskStatus{ID: "s2", Name: "complete", Type: "done"}
+)
+
+// workloadStore holds two active members and one inactive, with tasks in
+// and around October 2026.
+func workloadStore() *memstore.Store {
+	st := memstore.New()
+	st.AddMember(model.User{ClickUpID: 1, Name: "Ana", Email: "ana@example.com", Role: "backend", Status: memstore.ActiveStatus})
+	st.AddMember(model.User{ClickUpID: 2, Name: "Budi", Email: "budi@example.com", Role: "web", Status: memstore.ActiveStatus})
+	st.AddMember(model.User{ClickUpID: 3, Name: "Citra", Email: "citra@example.com", Role: "web", Status: "nonaktif"})
+	st.AddFolder(model.Folder{ID: "f1", Name: "Kinerja"})
+	st.AddList(model.List{ID: "l1", Name: "Sprint 1", FolderID: "f1"})
+
+	st.AddTask(memstore.Task{ID: "t1", Name: "API", Status: statusOpen, ListID: "l1",
+		StartDate: day(10, 5, 9), DueDate: day(10, 9, 17), EstimateHours: 6, SpentHours: 2, Assignees: []int64{1}})
+	st.AddTask(memstore.Task{ID: "t2", Name: "Login", Status: statusDone, ListID: "l1",
+		StartDate: day(10, 12, 9), DueDate: day(10, 14, 17), DateDone: day(10, 14, 10), EstimateHours: 4, SpentHours: 5, Assignees: []int64{1, 2}})
+	// selesai sebelum periode: tidak dihitung
+	st.AddTask(memstore.Task{ID: "t3", Name: "Old", Status: statusDone,
+		StartDate: day(9, 1, 9), DueDate: day(9, 3, 17), DateDone: day(9, 3, 10), SpentHours: 9, Assignees: []int64{1}})
+	// hanya date_closed yang masuk periode
+	st.AddTask(memstore.Task{ID: "t4", Name: "Closed", Status: statusDone, DateClosed: day(10, 20, 12), SpentHours: 1, Assignees: []int64{2}})
+	st.AddTask(memstore.Task{ID: "t5", Name: "Inactive", Status: statusOpen,
+		StartDate: day(10, 1, 9), DueDate: day(10, 2, 9), SpentHours: 3, Assignees: []int64{3}})
+	return st
+}
+
+var october = [2]time.Time{*day(10, 1, 0), time.Date(2026, 10, 31, 23, 59, 59, 0, time.UTC)}
+
+func TestGetTasksByAssignee(t *testing.T) {
+	svc := NewWorkloadService(workloadStore(), nil)
+	resp, err := svc.GetTasksByAssignee(context.Background(), october[0], october[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Count != 2 {
+		t.Fatalf("count = %d, want the 2 active members", resp.Count)
+	}
+
+	ana, budi := resp.Assignees[0], resp.Assignees[1]
+	if ana.Name != "Ana" || budi.Name != "Budi" {
+		t.Fatalf("assignees not ordered by name: %s, %s", ana.Name, budi.Name)
+	}
+	// Oktober 2026: 22 hari kerja.
+	if ana.ExpectedHours != 22*8 {
+		t.Errorf("expected hours = %v", ana.ExpectedHours)
+	}
+	if ana.TotalTasks != 2 || ana.TotalSpentHours != 7 || ana.TotalUpcomingHours != 6 {
+		t.Errorf("Ana summary = %d tasks, %v spent, %v upcoming", ana.TotalTasks, ana.TotalSpentHours, ana.TotalUpcomingHours)
+	}
+	if len(ana.Tasks) != 2 || ana.Tasks[0].ID != "t2" || ana.Tasks[1].ID != "t1" {
+		t.Errorf("Ana tasks should be t2, t1 (latest start first), got %+v", ana.Tasks)
+	}
+	if p := ana.Tasks[0].ProjectName; p == nil || *p != "Kinerja" {
+		t.Errorf("project name = %v, want the folder name", p)
+	}
+	if budi.TotalTasks != len(budi.Tasks) || budi.TotalTasks != 2 {
+		t.Errorf("Budi has %d tasks but total_tasks %d", len(budi.Tasks), budi.TotalTasks)
+	}
+}
+
+func TestGetWorkloadFiltersByUsername(t *testing.T) {
+	svc := NewWorkloadService(workloadStore(), nil)
+	got, err := svc.GetWorkload(context.Background(), october[0], october[1], "bud")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].UserID != 2 {
+		t.Fatalf("got %+v, want only Budi", got)
+	}
+	if got[0].TaskCount != 2 || got[0].TotalHours != 6 {
+		t.Errorf("Budi workload = %d tasks, %v hours", got[0].TaskCount, got[0].TotalHours)
+	}
+}
+
+func TestGetTasksSummaryFilters(t *testing.T) {
+	svc := NewWorkloadService(workloadStore(), nil)
+	tests := []struct {
+		name, email string
+		want        []int64
+	}{
+		{"", "", []int64{1, 2}},
+		{"ana", "", []int64{1}},
+		{"", "BUDI@", []int64{2}},
+		{"ana", "budi", nil},
+	}
+	for _, tt := range tests {
+		got, err := svc.GetTasksSummary(context.Background(), october[0], october[1], tt.name, tt.email)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []int64
+		for _, s := range got {
+			ids = append(ids, s.UserID)
+		}
+		if len(ids) != len(tt.want) || (len(ids) > 0 && ids[0] != tt.want[0]) {
+			t.Errorf("name=%q email=%q: got %v, want %v", tt.name, tt.email, ids, tt.want)
+		}
+	}
+}
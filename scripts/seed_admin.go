@@ -1,12 +1,16 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 
 	_ "github.com/lib/pq"
+	"github.com/roksva123/go-kinerja-backend/internal/model"
+	"github.com/roksva123/go-kinerja-backend/internal/repository"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -33,43 +37,49 @@ func main() {
 		log.Fatal("DB unreachable:", err)
 	}
 
-	// Ensure table "admins" exist
-	_, err = db.Exec(`
-		CREATE TABLE IF NOT EXISTS admins (
-			id SERIAL PRIMARY KEY,
-			username TEXT UNIQUE NOT NULL,
-			password_hash TEXT NOT NULL,
-			created_at TIMESTAMPTZ DEFAULT now()
-		)
-	`)
-	if err != nil {
-		log.Fatal("Failed ensure admins table:", err)
+	// Ensure schema (termasuk tabel admins) sudah dimigrasi
+	repo := &repository.PostgresRepo{DB: db}
+	if err := repo.RunMigrations(context.Background()); err != nil {
+		log.Fatal("Failed run migrations:", err)
 	}
 
 	// Read env (fallback if not provided)
 	username := getEnv("ADMIN_USERNAME", "admin")
 	password := getEnv("ADMIN_PASSWORD", "dnakinerja-2025")
+	// ADMIN_ROLE dan ADMIN_USER_ID membuat akun manager/member yang terhubung
+	// dengan anggota ClickUp (anggota harus sudah di-sync).
+	role := getEnv("ADMIN_ROLE", model.RoleAdmin)
+	if !model.ValidRole(role) {
+		log.Fatalf("ADMIN_ROLE must be admin, manager or member, got %q", role)
+	}
+	var userID *int64
+	if v := os.Getenv("ADMIN_USER_ID"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			log.Fatal("Invalid ADMIN_USER_ID:", err)
+		}
+		userID = &id
+	}
 
 	// Hash password
-	hash, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-
-	// Clear old admin (optional)
-	_, err = db.Exec("DELETE FROM admins")
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		log.Fatal("Failed delete old admins:", err)
+		log.Fatal("Failed hash password:", err)
 	}
 
-	// Insert new admin
-	_, err = db.Exec(
-		"INSERT INTO admins (username, password_hash) VALUES ($1, $2)",
-		username, string(hash),
-	)
+	// Upsert: akun lain tidak disentuh, akun dengan username sama diperbarui
+	admin := &model.Admin{Username: username, PasswordHash: string(hash), Role: role, UserID: userID}
+	err = repo.SaveAdmin(context.Background(), admin)
+	if err == sql.ErrNoRows {
+		log.Fatalf("ADMIN_USER_ID %d is not a synced ClickUp member", *userID)
+	}
 	if err != nil {
-		log.Fatal("Failed insert admin:", err)
+		log.Fatal("Failed save admin:", err)
 	}
 
-	fmt.Println("Admin created successfully!")
+	fmt.Println("Admin saved successfully!")
 	fmt.Println("Username:", username)
+	fmt.Println("Role:", role)
 }
 
 func getEnv(key, defaultValue string) string {